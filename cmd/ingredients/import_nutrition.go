@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/service"
+)
+
+// runImportNutrition implements the import-nutrition subcommand:
+//
+//	ingredients import-nutrition -source usda-fdc [-dry-run] foods.csv
+//
+// It prints the row mapping and returns the process exit code.
+func runImportNutrition(args []string) int {
+	fs := flag.NewFlagSet("import-nutrition", flag.ContinueOnError)
+	source := fs.String("source", "", "name of the dataset the rows come from (required)")
+	dryRun := fs.Bool("dry-run", false, "report the mapping without writing")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: ingredients import-nutrition -source NAME [-dry-run] FILE.csv")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 || *source == "" {
+		fs.Usage()
+		return 2
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		slog.Error("failed to open nutrition file", "error", err)
+		return 1
+	}
+	defer f.Close()
+
+	threshold, err := resolveThreshold()
+	if err != nil {
+		slog.Error("invalid RESOLVE_THRESHOLD", "error", err)
+		return 1
+	}
+	sqlDB, err := openDB()
+	if err != nil {
+		slog.Error("database setup failed", "error", err)
+		return 1
+	}
+	defer sqlDB.Close()
+
+	svc := service.New(db.New(sqlDB), sqlDB, threshold)
+	report, err := svc.ImportNutrition(context.Background(), f, service.NutritionImportOptions{
+		Source: *source,
+		DryRun: *dryRun,
+	})
+	if err != nil {
+		slog.Error("nutrition import failed", "error", err)
+		return 1
+	}
+	printNutritionReport(os.Stdout, report)
+	return 0
+}
+
+func printNutritionReport(out io.Writer, report service.NutritionImportReport) {
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINE\tSTATUS\tDATASET NAME\tINGREDIENT\tCONFIDENCE\tERROR")
+	for _, row := range report.Rows {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%.2f\t%s\n",
+			row.Line, row.Status, row.Name, row.Ingredient.Name, row.Confidence, row.Error)
+	}
+	tw.Flush()
+
+	verb := "imported"
+	if report.DryRun {
+		verb = "would import"
+	}
+	matched := 0
+	for _, row := range report.Rows {
+		if row.Status == service.NutritionRowMatched {
+			matched++
+		}
+	}
+	if !report.DryRun {
+		matched = report.Imported
+	}
+	fmt.Fprintf(out, "\n%d rows read, %d unmatched; %s %d ingredients from %s\n",
+		report.RowsRead, report.Unmatched, verb, matched, report.Source)
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -21,48 +23,46 @@ import (
 func main() {
 	logging.Setup()
 
+	if len(os.Args) > 1 && os.Args[1] == "import-nutrition" {
+		os.Exit(runImportNutrition(os.Args[2:]))
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
 
-	dbURL := os.Getenv("DB_URL")
-	if dbURL == "" {
-		slog.Error("DB_URL is required")
+	threshold, err := resolveThreshold()
+	if err != nil {
+		slog.Error("invalid RESOLVE_THRESHOLD", "error", err)
 		os.Exit(1)
 	}
 
-	threshold := 0.8
-	if t := os.Getenv("RESOLVE_THRESHOLD"); t != "" {
-		v, err := strconv.ParseFloat(t, 64)
+	var checkInterval time.Duration
+	if v := os.Getenv("CONVERSION_CHECK_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
 		if err != nil {
-			slog.Error("invalid RESOLVE_THRESHOLD", "error", err)
+			slog.Error("invalid CONVERSION_CHECK_INTERVAL", "error", err)
 			os.Exit(1)
 		}
-		threshold = v
+		checkInterval = d
 	}
 
-	sqlDB, err := sql.Open("postgres", dbURL)
+	sqlDB, err := openDB()
 	if err != nil {
-		slog.Error("failed to open database", "error", err)
+		slog.Error("database setup failed", "error", err)
 		os.Exit(1)
 	}
 	defer sqlDB.Close()
 
-	if err := sqlDB.Ping(); err != nil {
-		slog.Error("failed to connect to database", "error", err)
-		os.Exit(1)
-	}
-
-	if err := runMigrations(sqlDB); err != nil {
-		slog.Error("migrations failed", "error", err)
-		os.Exit(1)
-	}
-
 	queries := db.New(sqlDB)
 	svc := service.New(queries, sqlDB, threshold)
 	handler := api.NewRouter(svc)
 
+	if checkInterval > 0 {
+		go svc.RunConversionChecks(context.Background(), checkInterval)
+	}
+
 	addr := fmt.Sprintf(":%s", port)
 	slog.Info("ingredients service listening", "addr", addr)
 	if err := http.ListenAndServe(addr, handler); err != nil {
@@ -71,6 +71,36 @@ func main() {
 	}
 }
 
+// resolveThreshold reads RESOLVE_THRESHOLD, defaulting to 0.8.
+func resolveThreshold() (float64, error) {
+	t := os.Getenv("RESOLVE_THRESHOLD")
+	if t == "" {
+		return 0.8, nil
+	}
+	return strconv.ParseFloat(t, 64)
+}
+
+// openDB connects to DB_URL and applies pending migrations.
+func openDB() (*sql.DB, error) {
+	dbURL := os.Getenv("DB_URL")
+	if dbURL == "" {
+		return nil, fmt.Errorf("DB_URL is required")
+	}
+	sqlDB, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	if err := sqlDB.Ping(); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	if err := runMigrations(sqlDB); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+	return sqlDB, nil
+}
+
 func runMigrations(sqlDB *sql.DB) error {
 	srcDriver, err := iofs.New(db.MigrationsFS, "migrations")
 	if err != nil {
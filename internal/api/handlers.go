@@ -1,11 +1,18 @@
 package api
 
 import (
+	"crypto/sha256"
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -20,19 +27,81 @@ func NewRouter(svc *service.Service) http.Handler {
 	r := chi.NewRouter()
 	r.Use(logging.Middleware)
 	r.Use(middleware.Recoverer)
+	r.Use(withChange)
 
 	r.Get("/healthz", handleHealth)
 
 	r.Get("/ingredients", handleListIngredients(svc))
 	r.Post("/ingredients", handleCreateIngredient(svc))
 	r.Post("/ingredients/resolve", handleResolve(svc))
+	r.Post("/ingredients/translate", handleTranslateIDs(svc))
 	r.Post("/ingredients/merge", handleMerge(svc))
+	r.Get("/ingredients/duplicates", handleFindDuplicates(svc))
+	r.Post("/ingredients/scale", handleScale(svc))
+
+	r.Post("/nutrition/import", handleImportNutrition(svc))
+	r.Post("/storage/import", handleImportStorage(svc))
+
+	r.Get("/categories", handleListCategories(svc))
+	r.Post("/categories", handleCreateCategory(svc))
+	r.Get("/categories/{id}", handleGetCategory(svc))
+	r.Put("/categories/{id}", handleUpdateCategory(svc))
+	r.Delete("/categories/{id}", handleDeleteCategory(svc))
+	r.Get("/categories/{id}/storage", handleGetCategoryStorage(svc))
+	r.Put("/categories/{id}/storage", handleSetCategoryStorage(svc))
+
+	r.Get("/conversions/validate", handleValidateConversions(svc))
+	r.Post("/conversions/repair", handleRepairConversions(svc))
+
+	r.Delete("/admin/ingredients/{id}", handleHardDeleteIngredient(svc))
+
 	r.Get("/ingredients/{id}", handleGetIngredient(svc))
 	r.Put("/ingredients/{id}", handleUpdateIngredient(svc))
+	r.Patch("/ingredients/{id}", handlePatchIngredient(svc))
+	r.Post("/ingredients/{id}/rename", handleRenameIngredient(svc))
+	r.Delete("/ingredients/{id}", handleArchiveIngredient(svc))
+	r.Post("/ingredients/{id}/restore", handleRestoreIngredient(svc))
+	r.Get("/ingredients/{id}/aliases", handleListAliases(svc))
+	r.Post("/ingredients/{id}/aliases/{alias}", handleAddAlias(svc))
+	r.Delete("/ingredients/{id}/aliases/{alias}", handleRemoveAlias(svc))
+	r.Post("/ingredients/{id}/split", handleSplit(svc))
+	r.Get("/ingredients/{id}/merges", handleListMerges(svc))
+	r.Get("/ingredients/{id}/history", handleIngredientHistory(svc))
+	r.Put("/ingredients/{id}/parent", handleSetParent(svc))
+	r.Get("/ingredients/{id}/nutrition", handleGetNutrition(svc))
+	r.Get("/ingredients/{id}/storage", handleGetStorage(svc))
+	r.Put("/ingredients/{id}/storage", handleSetStorage(svc))
+	r.Get("/ingredients/{id}/expiry", handleEstimateExpiry(svc))
+	r.Get("/ingredients/{id}/dietary", handleGetDietary(svc))
+	r.Put("/ingredients/{id}/dietary", handleSetDietary(svc))
+	r.Get("/ingredients/{id}/ancestors", handleListAncestors(svc))
+	r.Get("/ingredients/{id}/descendants", handleListDescendants(svc))
+	r.Get("/ingredients/{id}/substitutes", handleListSubstitutes(svc))
+	r.Post("/ingredients/{id}/substitutes", handleAddSubstitute(svc))
+	r.Post("/ingredients/{id}/substitutes/search", handleSearchSubstitutes(svc))
+	r.Get("/ingredients/{id}/composite-substitutes", handleListCompositeSubstitutes(svc))
+	r.Post("/ingredients/{id}/composite-substitutes", handleAddCompositeSubstitute(svc))
+	r.Delete("/ingredients/{id}/composite-substitutes/{compositeID}", handleRemoveCompositeSubstitute(svc))
+	r.Delete("/ingredients/{id}/substitutes/{substituteID}", handleRemoveSubstitute(svc))
 
 	return r
 }
 
+// withChange hands the X-Actor header, naming the user or service behind a
+// request, and the X-Change-Reason header to the service layer.
+func withChange(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if actor := strings.TrimSpace(r.Header.Get("X-Actor")); actor != "" {
+			ctx = service.WithActor(ctx, actor)
+		}
+		if reason := strings.TrimSpace(r.Header.Get("X-Change-Reason")); reason != "" {
+			ctx = service.WithReason(ctx, reason)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("ok")) //nolint:errcheck
 }
@@ -41,16 +110,72 @@ func handleHealth(w http.ResponseWriter, r *http.Request) {
 
 func handleListIngredients(svc *service.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		items, err := svc.Queries().ListIngredients(r.Context())
+		includeArchived, _ := strconv.ParseBool(r.URL.Query().Get("include_archived"))
+		asOf, err := queryAsOf(r)
+		if err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		items, err := svc.ListIngredients(r.Context(), service.IngredientFilter{
+			FreeOf:          queryList(r, "free_of"),
+			Diets:           queryList(r, "diet"),
+			IncludeArchived: includeArchived,
+			AsOf:            asOf,
+		})
 		if err != nil {
+			if errors.Is(err, service.ErrInvalidAttribute) {
+				jsonError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
 			jsonError(w, "failed to list ingredients", http.StatusInternalServerError, err)
 			return
 		}
 		if items == nil {
 			items = []db.Ingredient{}
 		}
-		jsonOK(w, items)
+		// The list has no version of its own; tag it by content instead.
+		body, err := json.Marshal(items)
+		if err != nil {
+			jsonError(w, "failed to list ingredients", http.StatusInternalServerError, err)
+			return
+		}
+		tag := fmt.Sprintf(`W/"%x"`, sha256.Sum256(body))
+		w.Header().Set("ETag", tag)
+		if notModified(r, tag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(append(body, '\n')) //nolint:errcheck
+	}
+}
+
+// queryAsOf parses the as_of query parameter, an RFC 3339 timestamp. It
+// returns the zero time when the parameter is absent.
+func queryAsOf(r *http.Request) (time.Time, error) {
+	raw := r.URL.Query().Get("as_of")
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	asOf, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid as_of %q: want an RFC 3339 timestamp", raw)
+	}
+	return asOf, nil
+}
+
+// queryList collects a query parameter given either repeated or as a
+// comma-separated list.
+func queryList(r *http.Request, key string) []string {
+	var values []string
+	for _, raw := range r.URL.Query()[key] {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
 	}
+	return values
 }
 
 // --- create ---
@@ -73,18 +198,27 @@ func handleCreateIngredient(svc *service.Service) http.HandlerFunc {
 			jsonError(w, "name is required", http.StatusBadRequest)
 			return
 		}
-		aliases := req.Aliases
-		if aliases == nil {
-			aliases = []string{}
+		categoryID, err := svc.CategoryID(r.Context(), req.Category)
+		if err != nil {
+			categoryError(w, err)
+			return
 		}
-		ing, err := svc.Queries().CreateIngredient(r.Context(), db.CreateIngredientParams{
-			Name:        service.Normalize(req.Name),
-			Aliases:     aliases,
-			Category:    nullString(req.Category),
+		ing, err := svc.CreateIngredient(r.Context(), service.IngredientInput{
+			Name:        req.Name,
+			Aliases:     req.Aliases,
+			CategoryID:  categoryID,
 			DefaultUnit: nullString(req.DefaultUnit),
 		})
 		if err != nil {
-			jsonError(w, "failed to create ingredient", http.StatusInternalServerError, err)
+			var conflict *service.AliasConflictError
+			switch {
+			case errors.Is(err, service.ErrInvalidAlias):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			case errors.As(err, &conflict):
+				jsonError(w, err.Error(), http.StatusConflict)
+			default:
+				jsonError(w, "failed to create ingredient", http.StatusInternalServerError, err)
+			}
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -102,19 +236,111 @@ func handleGetIngredient(svc *service.Service) http.HandlerFunc {
 			jsonError(w, "invalid id", http.StatusBadRequest)
 			return
 		}
-		ing, err := svc.Queries().GetIngredient(r.Context(), id)
+		asOf, err := queryAsOf(r)
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var ing db.Ingredient
+		if asOf.IsZero() {
+			ing, err = svc.GetIngredient(r.Context(), id)
+		} else {
+			ing, err = svc.GetIngredientAsOf(r.Context(), id, asOf)
+		}
+		if err != nil {
+			var merged *service.MergedError
+			switch {
+			case errors.As(err, &merged):
+				writeMergedRedirect(w, merged)
+			case errors.Is(err, sql.ErrNoRows):
 				jsonError(w, "ingredient not found", http.StatusNotFound)
-				return
+			default:
+				jsonError(w, "failed to get ingredient", http.StatusInternalServerError, err)
 			}
-			jsonError(w, "failed to get ingredient", http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("ETag", etag(ing.Version))
+		if notModified(r, etag(ing.Version)) {
+			w.WriteHeader(http.StatusNotModified)
 			return
 		}
 		jsonOK(w, ing)
 	}
 }
 
+type mergedResponse struct {
+	Error      string    `json:"error"`
+	MergedInto uuid.UUID `json:"merged_into"`
+}
+
+// writeMergedRedirect answers a lookup of a merged-away ID with a permanent
+// redirect to the winner. The body carries merged_into for clients that
+// don't follow redirects.
+func writeMergedRedirect(w http.ResponseWriter, merged *service.MergedError) {
+	w.Header().Set("Location", "/ingredients/"+merged.MergedInto.String())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusMovedPermanently)
+	json.NewEncoder(w).Encode(mergedResponse{ //nolint:errcheck
+		Error:      merged.Error(),
+		MergedInto: merged.MergedInto,
+	})
+}
+
+// --- translate ---
+
+type translateRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type idTranslationResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	CurrentID *uuid.UUID `json:"current_id"`
+	Status    string     `json:"status"`
+}
+
+type translateResponse struct {
+	Translations []idTranslationResponse `json:"translations"`
+}
+
+// handleTranslateIDs maps old ingredient IDs to current ones so other
+// services can repair references to merged-away ingredients.
+func handleTranslateIDs(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req translateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		ids := make([]uuid.UUID, 0, len(req.IDs))
+		for _, raw := range req.IDs {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				jsonError(w, fmt.Sprintf("invalid id %q", raw), http.StatusBadRequest)
+				return
+			}
+			ids = append(ids, id)
+		}
+		translations, err := svc.TranslateIDs(r.Context(), ids)
+		if err != nil {
+			if errors.Is(err, service.ErrTooManyIDs) {
+				jsonError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			jsonError(w, "failed to translate ids", http.StatusInternalServerError, err)
+			return
+		}
+		resp := translateResponse{Translations: make([]idTranslationResponse, 0, len(translations))}
+		for _, t := range translations {
+			tr := idTranslationResponse{ID: t.ID, Status: string(t.Status)}
+			if t.Status != service.IDUnknown {
+				tr.CurrentID = &t.CurrentID
+			}
+			resp.Translations = append(resp.Translations, tr)
+		}
+		jsonOK(w, resp)
+	}
+}
+
 // --- update ---
 
 type updateIngredientRequest struct {
@@ -135,121 +361,2307 @@ func handleUpdateIngredient(svc *service.Service) http.HandlerFunc {
 			jsonError(w, "invalid request body", http.StatusBadRequest)
 			return
 		}
-		aliases := req.Aliases
-		if aliases == nil {
-			aliases = []string{}
+		version, ok := ifMatchVersion(w, r)
+		if !ok {
+			return
+		}
+		categoryID, err := svc.CategoryID(r.Context(), req.Category)
+		if err != nil {
+			categoryError(w, err)
+			return
 		}
-		ing, err := svc.Queries().UpdateIngredient(r.Context(), db.UpdateIngredientParams{
-			ID:          id,
-			Aliases:     aliases,
-			Category:    nullString(req.Category),
+		ing, err := svc.UpdateIngredient(r.Context(), id, service.IngredientInput{
+			Aliases:     req.Aliases,
+			CategoryID:  categoryID,
 			DefaultUnit: nullString(req.DefaultUnit),
+			Version:     version,
 		})
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
+			var conflict *service.AliasConflictError
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
 				jsonError(w, "ingredient not found", http.StatusNotFound)
-				return
+			case errors.Is(err, service.ErrVersionMismatch):
+				jsonError(w, err.Error(), http.StatusPreconditionFailed)
+			case errors.Is(err, service.ErrInvalidAlias):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			case errors.As(err, &conflict):
+				jsonError(w, err.Error(), http.StatusConflict)
+			default:
+				jsonError(w, "failed to update ingredient", http.StatusInternalServerError, err)
 			}
-			jsonError(w, "failed to update ingredient", http.StatusInternalServerError, err)
 			return
 		}
+		w.Header().Set("ETag", etag(ing.Version))
 		jsonOK(w, ing)
 	}
 }
 
-// --- resolve ---
+// handlePatchIngredient applies a JSON Merge Patch (RFC 7396): omitted
+// fields are left alone and null clears one. "name" renames the ingredient
+// and cannot be null.
+func handlePatchIngredient(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		var req map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req == nil {
+			jsonError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		version, ok := ifMatchVersion(w, r)
+		if !ok {
+			return
+		}
+		patch, err := decodeIngredientPatch(r, svc, req)
+		if err != nil {
+			if errors.Is(err, errInvalidPatch) {
+				jsonError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			categoryError(w, err)
+			return
+		}
+		patch.Version = version
+		ing, err := svc.PatchIngredient(r.Context(), id, patch)
+		if err != nil {
+			var conflict *service.AliasConflictError
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrVersionMismatch):
+				jsonError(w, err.Error(), http.StatusPreconditionFailed)
+			case errors.Is(err, service.ErrInvalidName),
+				errors.Is(err, service.ErrInvalidAlias):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			case errors.As(err, &conflict):
+				jsonError(w, err.Error(), http.StatusConflict)
+			default:
+				jsonError(w, "failed to update ingredient", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		w.Header().Set("ETag", etag(ing.Version))
+		jsonOK(w, ing)
+	}
+}
 
-type resolveRequest struct {
-	Name string `json:"name"`
+type renameRequest struct {
+	Name       string `json:"name"`
+	OnConflict string `json:"on_conflict"`
 }
 
-type resolveResponse struct {
+type renameResponse struct {
 	Ingredient db.Ingredient `json:"ingredient"`
-	Confidence float64       `json:"confidence"`
-	Created    bool          `json:"created"`
+	MergedInto *uuid.UUID    `json:"merged_into"`
+	MergeID    *uuid.UUID    `json:"merge_id"`
 }
 
-func handleResolve(svc *service.Service) http.HandlerFunc {
+// handleRenameIngredient renames {id}, keeping the old name as an alias.
+// With on_conflict "merge", a name held by another ingredient merges {id}
+// into that one instead of failing with 409.
+func handleRenameIngredient(svc *service.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req resolveRequest
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		var req renameRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			jsonError(w, "invalid request body", http.StatusBadRequest)
 			return
 		}
-		if req.Name == "" {
-			jsonError(w, "name is required", http.StatusBadRequest)
+		version, ok := ifMatchVersion(w, r)
+		if !ok {
 			return
 		}
-		result, err := svc.Resolve(r.Context(), req.Name)
+		result, err := svc.RenameIngredient(r.Context(), id, req.Name, service.RenameOptions{
+			OnConflict: service.RenameConflictPolicy(req.OnConflict),
+			Version:    version,
+		})
 		if err != nil {
-			jsonError(w, "resolve failed", http.StatusInternalServerError, err)
+			var conflict *service.AliasConflictError
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrVersionMismatch):
+				jsonError(w, err.Error(), http.StatusPreconditionFailed)
+			case errors.Is(err, service.ErrInvalidName),
+				errors.Is(err, service.ErrInvalidRenamePolicy):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			case errors.As(err, &conflict),
+				errors.Is(err, service.ErrConversionConflict),
+				errors.Is(err, service.ErrComponentUnitMismatch):
+				jsonError(w, err.Error(), http.StatusConflict)
+			default:
+				jsonError(w, "rename failed", http.StatusInternalServerError, err)
+			}
 			return
 		}
-		status := http.StatusOK
-		if result.Created {
-			status = http.StatusCreated
+		resp := renameResponse{Ingredient: result.Ingredient}
+		if result.MergedInto.Valid {
+			resp.MergedInto = &result.MergedInto.UUID
+			resp.MergeID = &result.MergeID.UUID
 		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(status)
-		json.NewEncoder(w).Encode(resolveResponse{ //nolint:errcheck
-			Ingredient: result.Ingredient,
-			Confidence: result.Confidence,
-			Created:    result.Created,
-		})
+		w.Header().Set("ETag", etag(result.Ingredient.Version))
+		jsonOK(w, resp)
 	}
 }
 
-// --- merge ---
+// errInvalidPatch marks a merge patch member of the wrong type or name.
+var errInvalidPatch = errors.New("invalid patch")
 
-type mergeRequest struct {
-	WinnerID string `json:"winner_id"`
-	LoserID  string `json:"loser_id"`
+// decodeIngredientPatch turns the members of a merge patch into a
+// service.IngredientPatch, looking up the category slug if one is given.
+func decodeIngredientPatch(r *http.Request, svc *service.Service, req map[string]json.RawMessage) (service.IngredientPatch, error) {
+	var patch service.IngredientPatch
+	for field, raw := range req {
+		isNull := string(raw) == "null"
+		switch field {
+		case "name":
+			var name string
+			if isNull || json.Unmarshal(raw, &name) != nil {
+				return patch, fmt.Errorf("%w: name must be a string", errInvalidPatch)
+			}
+			patch.Name = &name
+		case "aliases":
+			aliases := []string{}
+			if !isNull && json.Unmarshal(raw, &aliases) != nil {
+				return patch, fmt.Errorf("%w: aliases must be an array of strings or null", errInvalidPatch)
+			}
+			patch.Aliases = &aliases
+		case "category":
+			var slug string
+			if !isNull && json.Unmarshal(raw, &slug) != nil {
+				return patch, fmt.Errorf("%w: category must be a string or null", errInvalidPatch)
+			}
+			categoryID, err := svc.CategoryID(r.Context(), slug)
+			if err != nil {
+				return patch, err
+			}
+			patch.CategoryID = &categoryID
+		case "default_unit":
+			var unit string
+			if !isNull && json.Unmarshal(raw, &unit) != nil {
+				return patch, fmt.Errorf("%w: default_unit must be a string or null", errInvalidPatch)
+			}
+			defaultUnit := nullString(unit)
+			patch.DefaultUnit = &defaultUnit
+		default:
+			return patch, fmt.Errorf("%w: unknown field %q", errInvalidPatch, field)
+		}
+	}
+	return patch, nil
 }
 
-func handleMerge(svc *service.Service) http.HandlerFunc {
+// --- history ---
+
+type historyEntryResponse struct {
+	Version    int64         `json:"version"`
+	Operation  string        `json:"operation"`
+	Actor      *string       `json:"actor"`
+	Reason     *string       `json:"reason"`
+	Deleted    bool          `json:"deleted"`
+	RecordedAt time.Time     `json:"recorded_at"`
+	Ingredient db.Ingredient `json:"ingredient"`
+}
+
+// handleIngredientHistory lists every version of {id}, newest first. Merged
+// and deleted ingredients keep their history.
+func handleIngredientHistory(svc *service.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req mergeRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		entries, err := svc.IngredientHistory(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "failed to get history", http.StatusInternalServerError, err)
+			return
+		}
+		resp := make([]historyEntryResponse, 0, len(entries))
+		for _, e := range entries {
+			entry := historyEntryResponse{
+				Version:    e.Version,
+				Operation:  string(e.Operation),
+				Deleted:    e.Deleted,
+				RecordedAt: e.RecordedAt,
+				Ingredient: e.Ingredient,
+			}
+			if e.Actor.Valid {
+				entry.Actor = &e.Actor.String
+			}
+			if e.Reason.Valid {
+				entry.Reason = &e.Reason.String
+			}
+			resp = append(resp, entry)
+		}
+		jsonOK(w, resp)
+	}
+}
+
+// --- aliases ---
+
+type aliasRequest struct {
+	Source string `json:"source"`
+	Locale string `json:"locale"`
+}
+
+type aliasResponse struct {
+	Alias     string    `json:"alias"`
+	Source    string    `json:"source"`
+	CreatedBy *string   `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	Locale    *string   `json:"locale"`
+}
+
+func toAliasResponse(a db.IngredientAlias) aliasResponse {
+	resp := aliasResponse{Alias: a.Alias, Source: a.Source, CreatedAt: a.CreatedAt}
+	if a.CreatedBy.Valid {
+		resp.CreatedBy = &a.CreatedBy.String
+	}
+	if a.Locale.Valid {
+		resp.Locale = &a.Locale.String
+	}
+	return resp
+}
+
+// aliasError writes the response for errors returned by the alias service
+// methods.
+func aliasError(w http.ResponseWriter, err error) {
+	var conflict *service.AliasConflictError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		jsonError(w, "ingredient not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrAliasNotFound):
+		jsonError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidAlias):
+		jsonError(w, err.Error(), http.StatusBadRequest)
+	case errors.As(err, &conflict):
+		jsonError(w, err.Error(), http.StatusConflict)
+	default:
+		jsonError(w, "alias request failed", http.StatusInternalServerError, err)
+	}
+}
+
+// aliasParam returns the {alias} path segment, unescaped. chi matches
+// against r.URL.RawPath when it is set, leaving the segment escaped;
+// otherwise the segment comes from the already-decoded r.URL.Path.
+func aliasParam(r *http.Request) (string, error) {
+	alias := chi.URLParam(r, "alias")
+	if r.URL.RawPath == "" {
+		return alias, nil
+	}
+	return url.PathUnescape(alias)
+}
+
+func handleListAliases(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		aliases, err := svc.ListAliases(r.Context(), id)
+		if err != nil {
+			aliasError(w, err)
+			return
+		}
+		resp := make([]aliasResponse, 0, len(aliases))
+		for _, a := range aliases {
+			resp = append(resp, toAliasResponse(a))
+		}
+		jsonOK(w, resp)
+	}
+}
+
+// handleAddAlias adds one alias without touching the others. The body is
+// optional. Adding an alias the ingredient already has returns it with 200.
+func handleAddAlias(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		alias, err := aliasParam(r)
+		if err != nil {
+			jsonError(w, "invalid alias", http.StatusBadRequest)
+			return
+		}
+		var req aliasRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
 			jsonError(w, "invalid request body", http.StatusBadRequest)
 			return
 		}
-		winnerID, err := uuid.Parse(req.WinnerID)
+		row, created, err := svc.AddAlias(r.Context(), id, service.AliasInput{
+			Alias:  alias,
+			Source: service.AliasSource(req.Source),
+			Locale: req.Locale,
+		})
 		if err != nil {
-			jsonError(w, "invalid winner_id", http.StatusBadRequest)
+			aliasError(w, err)
+			return
+		}
+		if !created {
+			jsonOK(w, toAliasResponse(row))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(toAliasResponse(row)) //nolint:errcheck
+	}
+}
+
+func handleRemoveAlias(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
 			return
 		}
-		loserID, err := uuid.Parse(req.LoserID)
+		alias, err := aliasParam(r)
+		if err != nil {
+			jsonError(w, "invalid alias", http.StatusBadRequest)
+			return
+		}
+		if err := svc.RemoveAlias(r.Context(), id, alias); err != nil {
+			aliasError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// --- archive ---
+
+// handleArchiveIngredient soft-deletes the ingredient. Its ID stays valid for
+// other services; it just stops being resolved or listed by default.
+func handleArchiveIngredient(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
 		if err != nil {
-			jsonError(w, "invalid loser_id", http.StatusBadRequest)
+			jsonError(w, "invalid id", http.StatusBadRequest)
 			return
 		}
-		winner, err := svc.Merge(r.Context(), winnerID, loserID)
+		ing, err := svc.ArchiveIngredient(r.Context(), id)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				jsonError(w, "ingredient not found", http.StatusNotFound)
 				return
 			}
-			jsonError(w, "merge failed", http.StatusInternalServerError, err)
+			jsonError(w, "failed to archive ingredient", http.StatusInternalServerError, err)
 			return
 		}
-		jsonOK(w, winner)
+		jsonOK(w, ing)
 	}
 }
 
-// --- helpers ---
-
-func jsonOK(w http.ResponseWriter, v any) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(v) //nolint:errcheck
+func handleRestoreIngredient(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		ing, err := svc.RestoreIngredient(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "failed to restore ingredient", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, ing)
+	}
 }
 
-func jsonError(w http.ResponseWriter, msg string, status int, errs ...error) {
-	if status >= 500 && len(errs) > 0 {
-		slog.Error(msg, "status", status, "error", errs[0])
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]string{"error": msg}) //nolint:errcheck
+// handleHardDeleteIngredient permanently deletes an archived ingredient.
+func handleHardDeleteIngredient(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		if err := svc.DeleteIngredient(r.Context(), id); err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrNotArchived):
+				jsonError(w, err.Error(), http.StatusConflict)
+			default:
+				jsonError(w, "failed to delete ingredient", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// --- dietary attributes ---
+
+type dietaryRequest struct {
+	Allergens   []string `json:"allergens"`
+	DietaryTags []string `json:"dietary_tags"`
+	FreeOf      []string `json:"free_of"`
+}
+
+type dietaryResponse struct {
+	IngredientID   uuid.UUID `json:"ingredient_id"`
+	Allergens      []string  `json:"allergens"`
+	DietaryTags    []string  `json:"dietary_tags"`
+	OwnAllergens   []string  `json:"own_allergens"`
+	OwnDietaryTags []string  `json:"own_dietary_tags"`
+	OwnFreeOf      []string  `json:"own_free_of"`
+}
+
+func toDietaryResponse(id uuid.UUID, own, effective service.DietaryAttributes) dietaryResponse {
+	return dietaryResponse{
+		IngredientID:   id,
+		Allergens:      nonNilStrings(effective.Allergens),
+		DietaryTags:    nonNilStrings(effective.DietaryTags),
+		OwnAllergens:   nonNilStrings(own.Allergens),
+		OwnDietaryTags: nonNilStrings(own.DietaryTags),
+		OwnFreeOf:      nonNilStrings(own.FreeOf),
+	}
+}
+
+func handleGetDietary(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		own, effective, err := svc.DietaryAttributes(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "failed to get dietary attributes", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, toDietaryResponse(id, own, effective))
+	}
+}
+
+func handleSetDietary(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		var req dietaryRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		own, effective, err := svc.SetDietaryAttributes(r.Context(), id, service.DietaryAttributes{
+			Allergens:   req.Allergens,
+			DietaryTags: req.DietaryTags,
+			FreeOf:      req.FreeOf,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrInvalidAttribute):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			default:
+				jsonError(w, "failed to set dietary attributes", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		jsonOK(w, toDietaryResponse(id, own, effective))
+	}
+}
+
+// --- nutrition ---
+
+type nutritionResponse struct {
+	IngredientID  uuid.UUID `json:"ingredient_id"`
+	Source        string    `json:"source"`
+	SourceID      string    `json:"source_id,omitempty"`
+	SourceName    string    `json:"source_name"`
+	EnergyKcal    *float64  `json:"energy_kcal"`
+	ProteinG      *float64  `json:"protein_g"`
+	FatG          *float64  `json:"fat_g"`
+	SaturatedFatG *float64  `json:"saturated_fat_g"`
+	CarbohydrateG *float64  `json:"carbohydrate_g"`
+	SugarsG       *float64  `json:"sugars_g"`
+	FiberG        *float64  `json:"fiber_g"`
+	SodiumMg      *float64  `json:"sodium_mg"`
+	PotassiumMg   *float64  `json:"potassium_mg"`
+	CalciumMg     *float64  `json:"calcium_mg"`
+	IronMg        *float64  `json:"iron_mg"`
+	VitaminCMg    *float64  `json:"vitamin_c_mg"`
+}
+
+func nullFloat(v sql.NullFloat64) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
+
+func handleGetNutrition(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		n, err := svc.GetNutrition(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrNutritionNotFound):
+				jsonError(w, err.Error(), http.StatusNotFound)
+			default:
+				jsonError(w, "failed to get nutrition", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		jsonOK(w, nutritionResponse{
+			IngredientID:  n.IngredientID,
+			Source:        n.Source,
+			SourceID:      n.SourceID.String,
+			SourceName:    n.SourceName,
+			EnergyKcal:    nullFloat(n.EnergyKcal),
+			ProteinG:      nullFloat(n.ProteinG),
+			FatG:          nullFloat(n.FatG),
+			SaturatedFatG: nullFloat(n.SaturatedFatG),
+			CarbohydrateG: nullFloat(n.CarbohydrateG),
+			SugarsG:       nullFloat(n.SugarsG),
+			FiberG:        nullFloat(n.FiberG),
+			SodiumMg:      nullFloat(n.SodiumMg),
+			PotassiumMg:   nullFloat(n.PotassiumMg),
+			CalciumMg:     nullFloat(n.CalciumMg),
+			IronMg:        nullFloat(n.IronMg),
+			VitaminCMg:    nullFloat(n.VitaminCMg),
+		})
+	}
+}
+
+type nutritionImportRowResponse struct {
+	Line         int        `json:"line"`
+	Name         string     `json:"name"`
+	SourceID     string     `json:"source_id,omitempty"`
+	Status       string     `json:"status"`
+	IngredientID *uuid.UUID `json:"ingredient_id,omitempty"`
+	Ingredient   string     `json:"ingredient,omitempty"`
+	Confidence   float64    `json:"confidence,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+type nutritionImportResponse struct {
+	Source    string                       `json:"source"`
+	DryRun    bool                         `json:"dry_run"`
+	RowsRead  int                          `json:"rows_read"`
+	Unmatched int                          `json:"unmatched"`
+	Imported  int                          `json:"imported"`
+	Rows      []nutritionImportRowResponse `json:"rows"`
+}
+
+// handleImportNutrition takes the CSV as the request body. source names the
+// dataset and dry_run=true reports the mapping without writing.
+func handleImportNutrition(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+		report, err := svc.ImportNutrition(r.Context(), r.Body, service.NutritionImportOptions{
+			Source: r.URL.Query().Get("source"),
+			DryRun: dryRun,
+		})
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidNutritionCSV) {
+				jsonError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			jsonError(w, "nutrition import failed", http.StatusInternalServerError, err)
+			return
+		}
+		resp := nutritionImportResponse{
+			Source:    report.Source,
+			DryRun:    report.DryRun,
+			RowsRead:  report.RowsRead,
+			Unmatched: report.Unmatched,
+			Imported:  report.Imported,
+			Rows:      make([]nutritionImportRowResponse, 0, len(report.Rows)),
+		}
+		for _, row := range report.Rows {
+			rr := nutritionImportRowResponse{
+				Line:       row.Line,
+				Name:       row.Name,
+				SourceID:   row.SourceID,
+				Status:     string(row.Status),
+				Ingredient: row.Ingredient.Name,
+				Confidence: row.Confidence,
+				Error:      row.Error,
+			}
+			if row.Status != service.NutritionRowInvalid {
+				id := row.Ingredient.ID
+				rr.IngredientID = &id
+			}
+			resp.Rows = append(resp.Rows, rr)
+		}
+		jsonOK(w, resp)
+	}
+}
+
+// --- storage ---
+
+type storageGuidelineRequest struct {
+	Location     string `json:"location"`
+	UnopenedDays *int   `json:"unopened_days"`
+	OpenedDays   *int   `json:"opened_days"`
+	Notes        string `json:"notes"`
+}
+
+type storageRequest struct {
+	Guidelines []storageGuidelineRequest `json:"guidelines"`
+}
+
+type storageGuidelineResponse struct {
+	Location     string `json:"location"`
+	UnopenedDays *int   `json:"unopened_days"`
+	OpenedDays   *int   `json:"opened_days"`
+	Notes        string `json:"notes,omitempty"`
+}
+
+type storageResponse struct {
+	IngredientID uuid.UUID                  `json:"ingredient_id"`
+	Source       string                     `json:"source"`
+	CategoryID   *uuid.UUID                 `json:"category_id,omitempty"`
+	Guidelines   []storageGuidelineResponse `json:"guidelines"`
+}
+
+type categoryStorageResponse struct {
+	CategoryID uuid.UUID                  `json:"category_id"`
+	Guidelines []storageGuidelineResponse `json:"guidelines"`
+}
+
+func nullInt(v sql.NullInt32) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int32)
+	return &n
+}
+
+func toStorageGuidelines(rows []db.StorageGuideline) []storageGuidelineResponse {
+	out := make([]storageGuidelineResponse, 0, len(rows))
+	for _, g := range rows {
+		out = append(out, storageGuidelineResponse{
+			Location:     g.Location,
+			UnopenedDays: nullInt(g.UnopenedDays),
+			OpenedDays:   nullInt(g.OpenedDays),
+			Notes:        g.Notes.String,
+		})
+	}
+	return out
+}
+
+func toStorageResponse(id uuid.UUID, g service.StorageGuidance) storageResponse {
+	resp := storageResponse{
+		IngredientID: id,
+		Source:       string(g.Source),
+		Guidelines:   toStorageGuidelines(g.Guidelines),
+	}
+	if g.CategoryID.Valid {
+		resp.CategoryID = &g.CategoryID.UUID
+	}
+	return resp
+}
+
+func decodeStorageRequest(w http.ResponseWriter, r *http.Request) ([]service.StorageGuideline, bool) {
+	var req storageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return nil, false
+	}
+	guidelines := make([]service.StorageGuideline, 0, len(req.Guidelines))
+	for _, g := range req.Guidelines {
+		guidelines = append(guidelines, service.StorageGuideline{
+			Location:     g.Location,
+			UnopenedDays: g.UnopenedDays,
+			OpenedDays:   g.OpenedDays,
+			Notes:        g.Notes,
+		})
+	}
+	return guidelines, true
+}
+
+func handleGetStorage(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		guidance, err := svc.StorageGuidance(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "failed to get storage guidance", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, toStorageResponse(id, guidance))
+	}
+}
+
+// handleSetStorage replaces the ingredient's own guidelines; an empty list
+// makes it inherit from its category again.
+func handleSetStorage(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		guidelines, ok := decodeStorageRequest(w, r)
+		if !ok {
+			return
+		}
+		guidance, err := svc.SetStorageGuidelines(r.Context(), id, guidelines)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidStorage):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			default:
+				jsonError(w, "failed to set storage guidance", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		jsonOK(w, toStorageResponse(id, guidance))
+	}
+}
+
+func handleGetCategoryStorage(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		rows, err := svc.CategoryStorageGuidelines(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "category not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "failed to get storage guidance", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, categoryStorageResponse{CategoryID: id, Guidelines: toStorageGuidelines(rows)})
+	}
+}
+
+func handleSetCategoryStorage(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		guidelines, ok := decodeStorageRequest(w, r)
+		if !ok {
+			return
+		}
+		rows, err := svc.SetCategoryStorageGuidelines(r.Context(), id, guidelines)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidStorage):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "category not found", http.StatusNotFound)
+			default:
+				jsonError(w, "failed to set storage guidance", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		jsonOK(w, categoryStorageResponse{CategoryID: id, Guidelines: toStorageGuidelines(rows)})
+	}
+}
+
+const dateLayout = "2006-01-02"
+
+type expiryEstimateResponse struct {
+	Location  string `json:"location"`
+	ExpiresOn string `json:"expires_on"`
+	Days      int    `json:"days"`
+	Basis     string `json:"basis"`
+}
+
+type expiryResponse struct {
+	IngredientID uuid.UUID                `json:"ingredient_id"`
+	PurchasedOn  string                   `json:"purchased_on"`
+	OpenedOn     string                   `json:"opened_on,omitempty"`
+	Source       string                   `json:"source"`
+	CategoryID   *uuid.UUID               `json:"category_id,omitempty"`
+	Estimates    []expiryEstimateResponse `json:"estimates"`
+}
+
+// handleEstimateExpiry takes purchased (YYYY-MM-DD), an optional opened date
+// and an optional location; without a location every location with guidance
+// is estimated.
+func handleEstimateExpiry(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		q := r.URL.Query()
+		req := service.ExpiryRequest{Location: q.Get("location")}
+		if req.PurchasedOn, err = time.Parse(dateLayout, q.Get("purchased")); err != nil {
+			jsonError(w, "purchased must be a YYYY-MM-DD date", http.StatusBadRequest)
+			return
+		}
+		if raw := q.Get("opened"); raw != "" {
+			if req.OpenedOn, err = time.Parse(dateLayout, raw); err != nil {
+				jsonError(w, "opened must be a YYYY-MM-DD date", http.StatusBadRequest)
+				return
+			}
+		}
+		estimates, guidance, err := svc.EstimateExpiry(r.Context(), id, req)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidStorage):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrNoStorageGuidance):
+				jsonError(w, err.Error(), http.StatusNotFound)
+			default:
+				jsonError(w, "failed to estimate expiry", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		resp := expiryResponse{
+			IngredientID: id,
+			PurchasedOn:  req.PurchasedOn.Format(dateLayout),
+			Source:       string(guidance.Source),
+			Estimates:    make([]expiryEstimateResponse, 0, len(estimates)),
+		}
+		if !req.OpenedOn.IsZero() {
+			resp.OpenedOn = req.OpenedOn.Format(dateLayout)
+		}
+		if guidance.CategoryID.Valid {
+			resp.CategoryID = &guidance.CategoryID.UUID
+		}
+		for _, e := range estimates {
+			resp.Estimates = append(resp.Estimates, expiryEstimateResponse{
+				Location:  e.Location,
+				ExpiresOn: e.ExpiresOn.Format(dateLayout),
+				Days:      e.Days,
+				Basis:     e.Basis,
+			})
+		}
+		jsonOK(w, resp)
+	}
+}
+
+type storageImportRowResponse struct {
+	Line         int        `json:"line"`
+	Target       string     `json:"target"`
+	Location     string     `json:"location,omitempty"`
+	Status       string     `json:"status"`
+	IngredientID *uuid.UUID `json:"ingredient_id,omitempty"`
+	CategoryID   *uuid.UUID `json:"category_id,omitempty"`
+	Confidence   float64    `json:"confidence,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+type storageImportResponse struct {
+	DryRun   bool                       `json:"dry_run"`
+	RowsRead int                        `json:"rows_read"`
+	Imported int                        `json:"imported"`
+	Rows     []storageImportRowResponse `json:"rows"`
+}
+
+// handleImportStorage takes the CSV as the request body; dry_run=true reports
+// the mapping without writing.
+func handleImportStorage(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+		report, err := svc.ImportStorage(r.Context(), r.Body, service.StorageImportOptions{DryRun: dryRun})
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidStorageCSV) {
+				jsonError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			jsonError(w, "storage import failed", http.StatusInternalServerError, err)
+			return
+		}
+		resp := storageImportResponse{
+			DryRun:   report.DryRun,
+			RowsRead: report.RowsRead,
+			Imported: report.Imported,
+			Rows:     make([]storageImportRowResponse, 0, len(report.Rows)),
+		}
+		for _, row := range report.Rows {
+			rr := storageImportRowResponse{
+				Line:       row.Line,
+				Target:     row.Target,
+				Location:   row.Location,
+				Status:     string(row.Status),
+				Confidence: row.Confidence,
+				Error:      row.Error,
+			}
+			if row.Ingredient != nil {
+				rr.IngredientID = &row.Ingredient.ID
+			}
+			if row.Category != nil {
+				rr.CategoryID = &row.Category.ID
+			}
+			resp.Rows = append(resp.Rows, rr)
+		}
+		jsonOK(w, resp)
+	}
+}
+
+// --- categories ---
+
+type categoryRequest struct {
+	Slug        string  `json:"slug"`
+	DisplayName string  `json:"display_name"`
+	ParentID    *string `json:"parent_id"`
+}
+
+// categoryError writes the response for errors returned by the category
+// service methods.
+func categoryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		jsonError(w, "category not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidCategory),
+		errors.Is(err, service.ErrUnknownCategory):
+		jsonError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrDuplicateCategory),
+		errors.Is(err, service.ErrCategoryCycle),
+		errors.Is(err, service.ErrCategoryInUse):
+		jsonError(w, err.Error(), http.StatusConflict)
+	default:
+		jsonError(w, "category request failed", http.StatusInternalServerError, err)
+	}
+}
+
+func decodeCategoryRequest(w http.ResponseWriter, r *http.Request) (service.CategoryInput, bool) {
+	var req categoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return service.CategoryInput{}, false
+	}
+	in := service.CategoryInput{Slug: req.Slug, DisplayName: req.DisplayName}
+	if req.ParentID != nil {
+		pid, err := uuid.Parse(*req.ParentID)
+		if err != nil {
+			jsonError(w, "invalid parent_id", http.StatusBadRequest)
+			return service.CategoryInput{}, false
+		}
+		in.ParentID = uuid.NullUUID{UUID: pid, Valid: true}
+	}
+	return in, true
+}
+
+func handleListCategories(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cats, err := svc.Queries().ListCategories(r.Context())
+		if err != nil {
+			jsonError(w, "failed to list categories", http.StatusInternalServerError, err)
+			return
+		}
+		if cats == nil {
+			cats = []db.Category{}
+		}
+		jsonOK(w, cats)
+	}
+}
+
+func handleCreateCategory(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		in, ok := decodeCategoryRequest(w, r)
+		if !ok {
+			return
+		}
+		cat, err := svc.CreateCategory(r.Context(), in)
+		if err != nil {
+			categoryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(cat) //nolint:errcheck
+	}
+}
+
+func handleGetCategory(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		cat, err := svc.Queries().GetCategory(r.Context(), id)
+		if err != nil {
+			categoryError(w, err)
+			return
+		}
+		jsonOK(w, cat)
+	}
+}
+
+func handleUpdateCategory(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		in, ok := decodeCategoryRequest(w, r)
+		if !ok {
+			return
+		}
+		cat, err := svc.UpdateCategory(r.Context(), id, in)
+		if err != nil {
+			categoryError(w, err)
+			return
+		}
+		jsonOK(w, cat)
+	}
+}
+
+func handleDeleteCategory(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		if err := svc.DeleteCategory(r.Context(), id); err != nil {
+			categoryError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// --- hierarchy ---
+
+type setParentRequest struct {
+	ParentID *string `json:"parent_id"`
+}
+
+type relatedIngredientResponse struct {
+	Ingredient db.Ingredient `json:"ingredient"`
+	Depth      int           `json:"depth"`
+}
+
+func toRelatedIngredientResponses(related []service.RelatedIngredient) []relatedIngredientResponse {
+	resp := make([]relatedIngredientResponse, 0, len(related))
+	for _, r := range related {
+		resp = append(resp, relatedIngredientResponse{Ingredient: r.Ingredient, Depth: r.Depth})
+	}
+	return resp
+}
+
+func handleSetParent(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		var req setParentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		var parentID uuid.NullUUID
+		if req.ParentID != nil {
+			pid, err := uuid.Parse(*req.ParentID)
+			if err != nil {
+				jsonError(w, "invalid parent_id", http.StatusBadRequest)
+				return
+			}
+			parentID = uuid.NullUUID{UUID: pid, Valid: true}
+		}
+		ing, err := svc.SetParent(r.Context(), id, parentID)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrParentNotFound):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, service.ErrHierarchyCycle):
+				jsonError(w, err.Error(), http.StatusConflict)
+			default:
+				jsonError(w, "failed to set parent", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		jsonOK(w, ing)
+	}
+}
+
+func handleListAncestors(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		ancestors, err := svc.Ancestors(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "failed to list ancestors", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, toRelatedIngredientResponses(ancestors))
+	}
+}
+
+func handleListDescendants(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		maxDepth := 0
+		if raw := r.URL.Query().Get("max_depth"); raw != "" {
+			maxDepth, err = strconv.Atoi(raw)
+			if err != nil || maxDepth < 1 {
+				jsonError(w, "max_depth must be a positive integer", http.StatusBadRequest)
+				return
+			}
+		}
+		descendants, err := svc.Descendants(r.Context(), id, maxDepth)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "failed to list descendants", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, toRelatedIngredientResponses(descendants))
+	}
+}
+
+// --- substitutes ---
+
+type addSubstituteRequest struct {
+	SubstituteID string   `json:"substitute_id"`
+	Ratio        *float64 `json:"ratio"`
+	Notes        string   `json:"notes"`
+	Contexts     []string `json:"contexts"`
+	Reasons      []string `json:"reasons"`
+}
+
+type substituteResponse struct {
+	ID           uuid.UUID     `json:"id"`
+	IngredientID uuid.UUID     `json:"ingredient_id"`
+	Substitute   db.Ingredient `json:"substitute"`
+	Ratio        float64       `json:"ratio"`
+	Notes        string        `json:"notes,omitempty"`
+	Contexts     []string      `json:"contexts"`
+	Reasons      []string      `json:"reasons"`
+}
+
+func toSubstituteResponse(sub service.Substitute) substituteResponse {
+	return substituteResponse{
+		ID:           sub.IngredientSubstitute.ID,
+		IngredientID: sub.IngredientSubstitute.IngredientID,
+		Substitute:   sub.Ingredient,
+		Ratio:        sub.IngredientSubstitute.Ratio,
+		Notes:        sub.IngredientSubstitute.Notes.String,
+		Contexts:     nonNilStrings(sub.IngredientSubstitute.Contexts),
+		Reasons:      nonNilStrings(sub.IngredientSubstitute.Reasons),
+	}
+}
+
+// substituteFilter reads the context and reason query parameters shared by
+// the substitute listing endpoints.
+func substituteFilter(r *http.Request) service.SubstituteFilter {
+	return service.SubstituteFilter{
+		Context: r.URL.Query().Get("context"),
+		Reason:  r.URL.Query().Get("reason"),
+	}
+}
+
+// nonNilStrings returns s, or an empty slice if s is nil, so that tag lists
+// encode as [] rather than null.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func handleListSubstitutes(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		subs, err := svc.ListSubstitutes(r.Context(), id, substituteFilter(r))
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidTag) {
+				jsonError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "failed to list substitutes", http.StatusInternalServerError, err)
+			return
+		}
+		resp := make([]substituteResponse, 0, len(subs))
+		for _, sub := range subs {
+			resp = append(resp, toSubstituteResponse(sub))
+		}
+		jsonOK(w, resp)
+	}
+}
+
+func handleAddSubstitute(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		var req addSubstituteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		subID, err := uuid.Parse(req.SubstituteID)
+		if err != nil {
+			jsonError(w, "invalid substitute_id", http.StatusBadRequest)
+			return
+		}
+		ratio := 1.0
+		if req.Ratio != nil {
+			ratio = *req.Ratio
+		}
+		sub, err := svc.AddSubstitute(r.Context(), db.CreateSubstituteParams{
+			IngredientID: id,
+			SubstituteID: subID,
+			Ratio:        ratio,
+			Notes:        nullString(req.Notes),
+			Contexts:     req.Contexts,
+			Reasons:      req.Reasons,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrSelfSubstitute),
+				errors.Is(err, service.ErrInvalidRatio),
+				errors.Is(err, service.ErrInvalidTag),
+				errors.Is(err, service.ErrSubstituteNotFound):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, service.ErrDuplicateSubstitute):
+				jsonError(w, err.Error(), http.StatusConflict)
+			default:
+				jsonError(w, "failed to add substitute", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(toSubstituteResponse(sub)) //nolint:errcheck
+	}
+}
+
+func handleRemoveSubstitute(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		subID, err := uuid.Parse(chi.URLParam(r, "substituteID"))
+		if err != nil {
+			jsonError(w, "invalid substitute id", http.StatusBadRequest)
+			return
+		}
+		if err := svc.RemoveSubstitute(r.Context(), id, subID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "substitute not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "failed to remove substitute", http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+type searchSubstitutesRequest struct {
+	MaxDepth     *int     `json:"max_depth"`
+	AvailableIDs []string `json:"available_ids"`
+	Context      string   `json:"context"`
+	Reason       string   `json:"reason"`
+}
+
+type substituteStepResponse struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Ratio float64   `json:"ratio"`
+}
+
+type substitutePathResponse struct {
+	Substitute db.Ingredient            `json:"substitute"`
+	Path       []substituteStepResponse `json:"path"`
+	Ratio      float64                  `json:"ratio"`
+	Confidence float64                  `json:"confidence"`
+}
+
+func handleSearchSubstitutes(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		var req searchSubstitutesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		depth := service.DefaultSubstituteDepth
+		if req.MaxDepth != nil {
+			depth = *req.MaxDepth
+		}
+		if depth < 1 || depth > service.MaxSubstituteDepth {
+			jsonError(w, fmt.Sprintf("max_depth must be between 1 and %d", service.MaxSubstituteDepth), http.StatusBadRequest)
+			return
+		}
+		available := make([]uuid.UUID, 0, len(req.AvailableIDs))
+		for _, raw := range req.AvailableIDs {
+			aid, err := uuid.Parse(raw)
+			if err != nil {
+				jsonError(w, "invalid available_ids", http.StatusBadRequest)
+				return
+			}
+			available = append(available, aid)
+		}
+		paths, err := svc.SearchSubstitutes(r.Context(), id, service.SubstituteSearch{
+			MaxDepth:  depth,
+			Available: available,
+			Filter:    service.SubstituteFilter{Context: req.Context, Reason: req.Reason},
+		})
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidTag) {
+				jsonError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "substitute search failed", http.StatusInternalServerError, err)
+			return
+		}
+		resp := make([]substitutePathResponse, 0, len(paths))
+		for _, p := range paths {
+			steps := make([]substituteStepResponse, 0, len(p.Steps))
+			for _, st := range p.Steps {
+				steps = append(steps, substituteStepResponse{ID: st.Ingredient.ID, Name: st.Ingredient.Name, Ratio: st.Ratio})
+			}
+			resp = append(resp, substitutePathResponse{
+				Substitute: p.Substitute(),
+				Path:       steps,
+				Ratio:      p.Ratio,
+				Confidence: p.Confidence,
+			})
+		}
+		jsonOK(w, resp)
+	}
+}
+
+// --- composite substitutes ---
+
+type compositeComponentRequest struct {
+	ComponentID string  `json:"component_id"`
+	Quantity    float64 `json:"quantity"`
+	Unit        string  `json:"unit"`
+}
+
+type addCompositeSubstituteRequest struct {
+	Quantity   *float64                    `json:"quantity"`
+	Unit       string                      `json:"unit"`
+	Notes      string                      `json:"notes"`
+	Contexts   []string                    `json:"contexts"`
+	Reasons    []string                    `json:"reasons"`
+	Components []compositeComponentRequest `json:"components"`
+}
+
+type compositeComponentResponse struct {
+	Component db.Ingredient `json:"component"`
+	Quantity  float64       `json:"quantity"`
+	Unit      string        `json:"unit,omitempty"`
+}
+
+type compositeSubstituteResponse struct {
+	ID           uuid.UUID                    `json:"id"`
+	IngredientID uuid.UUID                    `json:"ingredient_id"`
+	Quantity     float64                      `json:"quantity"`
+	Unit         string                       `json:"unit,omitempty"`
+	Notes        string                       `json:"notes,omitempty"`
+	Contexts     []string                     `json:"contexts"`
+	Reasons      []string                     `json:"reasons"`
+	Components   []compositeComponentResponse `json:"components"`
+}
+
+func toCompositeSubstituteResponse(cs service.CompositeSubstitute) compositeSubstituteResponse {
+	resp := compositeSubstituteResponse{
+		ID:           cs.ID,
+		IngredientID: cs.IngredientID,
+		Quantity:     cs.Quantity,
+		Unit:         cs.Unit.String,
+		Notes:        cs.Notes.String,
+		Contexts:     nonNilStrings(cs.Contexts),
+		Reasons:      nonNilStrings(cs.Reasons),
+		Components:   make([]compositeComponentResponse, 0, len(cs.Components)),
+	}
+	for _, c := range cs.Components {
+		resp.Components = append(resp.Components, compositeComponentResponse{
+			Component: c.Ingredient,
+			Quantity:  c.CompositeSubstituteComponent.Quantity,
+			Unit:      c.CompositeSubstituteComponent.Unit.String,
+		})
+	}
+	return resp
+}
+
+func handleListCompositeSubstitutes(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		composites, err := svc.ListCompositeSubstitutes(r.Context(), id, substituteFilter(r))
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidTag) {
+				jsonError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "failed to list composite substitutes", http.StatusInternalServerError, err)
+			return
+		}
+		resp := make([]compositeSubstituteResponse, 0, len(composites))
+		for _, cs := range composites {
+			resp = append(resp, toCompositeSubstituteResponse(cs))
+		}
+		jsonOK(w, resp)
+	}
+}
+
+func handleAddCompositeSubstitute(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		var req addCompositeSubstituteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		in := service.CompositeSubstituteInput{
+			IngredientID: id,
+			Quantity:     1,
+			Unit:         req.Unit,
+			Notes:        req.Notes,
+			Contexts:     req.Contexts,
+			Reasons:      req.Reasons,
+		}
+		if req.Quantity != nil {
+			in.Quantity = *req.Quantity
+		}
+		for _, c := range req.Components {
+			cid, err := uuid.Parse(c.ComponentID)
+			if err != nil {
+				jsonError(w, "invalid component_id", http.StatusBadRequest)
+				return
+			}
+			in.Components = append(in.Components, service.CompositeComponentInput{
+				ComponentID: cid,
+				Quantity:    c.Quantity,
+				Unit:        c.Unit,
+			})
+		}
+		cs, err := svc.AddCompositeSubstitute(r.Context(), in)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrInvalidQuantity),
+				errors.Is(err, service.ErrTooFewComponents),
+				errors.Is(err, service.ErrInvalidComponent),
+				errors.Is(err, service.ErrInvalidTag),
+				errors.Is(err, service.ErrComponentNotFound):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			default:
+				jsonError(w, "failed to add composite substitute", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(toCompositeSubstituteResponse(cs)) //nolint:errcheck
+	}
+}
+
+func handleRemoveCompositeSubstitute(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		compositeID, err := uuid.Parse(chi.URLParam(r, "compositeID"))
+		if err != nil {
+			jsonError(w, "invalid composite id", http.StatusBadRequest)
+			return
+		}
+		if err := svc.RemoveCompositeSubstitute(r.Context(), id, compositeID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "composite substitute not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "failed to remove composite substitute", http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// --- resolve ---
+
+type resolveRequest struct {
+	Name            string `json:"name"`
+	RollupDepth     *int   `json:"rollup_depth"`
+	RestoreArchived bool   `json:"restore_archived"`
+}
+
+type resolveResponse struct {
+	Ingredient db.Ingredient  `json:"ingredient"`
+	Confidence float64        `json:"confidence"`
+	Created    bool           `json:"created"`
+	RolledUp   *db.Ingredient `json:"rolled_up,omitempty"`
+}
+
+func handleResolve(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req resolveRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Name == "" {
+			jsonError(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		if req.RollupDepth != nil && *req.RollupDepth < 0 {
+			jsonError(w, "rollup_depth must not be negative", http.StatusBadRequest)
+			return
+		}
+		result, err := svc.Resolve(r.Context(), req.Name, service.ResolveOptions{
+			RestoreArchived: req.RestoreArchived,
+		})
+		if err != nil {
+			var archived *service.ArchivedError
+			var conflict *service.AliasConflictError
+			if errors.As(err, &archived) || errors.As(err, &conflict) {
+				jsonError(w, err.Error(), http.StatusConflict)
+				return
+			}
+			jsonError(w, "resolve failed", http.StatusInternalServerError, err)
+			return
+		}
+		var rolledUp *db.Ingredient
+		if req.RollupDepth != nil {
+			ing, err := svc.RollUp(r.Context(), result.Ingredient, *req.RollupDepth)
+			if err != nil {
+				jsonError(w, "resolve failed", http.StatusInternalServerError, err)
+				return
+			}
+			rolledUp = &ing
+		}
+		status := http.StatusOK
+		if result.Created {
+			status = http.StatusCreated
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(resolveResponse{ //nolint:errcheck
+			Ingredient: result.Ingredient,
+			Confidence: result.Confidence,
+			Created:    result.Created,
+			RolledUp:   rolledUp,
+		})
+	}
+}
+
+// --- merge ---
+
+type mergeFieldRule struct {
+	Strategy string `json:"strategy"`
+	Value    string `json:"value"`
+}
+
+type mergeRequest struct {
+	WinnerID         string                    `json:"winner_id"`
+	LoserID          string                    `json:"loser_id"`
+	LoserIDs         []string                  `json:"loser_ids"`
+	ConversionPolicy string                    `json:"conversion_policy"`
+	Fields           map[string]mergeFieldRule `json:"fields"`
+}
+
+type droppedConversionResponse struct {
+	ID           uuid.UUID `json:"id"`
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	FromUnit     string    `json:"from_unit"`
+	ToUnit       string    `json:"to_unit"`
+	Factor       float64   `json:"factor"`
+}
+
+type droppedComponentResponse struct {
+	ID          uuid.UUID `json:"id"`
+	CompositeID uuid.UUID `json:"composite_id"`
+	ComponentID uuid.UUID `json:"component_id"`
+	Quantity    float64   `json:"quantity"`
+	Unit        string    `json:"unit,omitempty"`
+}
+
+type droppedCompositeResponse struct {
+	ID           uuid.UUID `json:"id"`
+	IngredientID uuid.UUID `json:"ingredient_id"`
+}
+
+type mergeDifferenceResponse struct {
+	LoserID uuid.UUID `json:"loser_id"`
+	Field   string    `json:"field"`
+	Winner  *string   `json:"winner"`
+	Loser   *string   `json:"loser"`
+	Result  *string   `json:"result"`
+}
+
+type conversionConflictResponse struct {
+	LoserID      uuid.UUID `json:"loser_id"`
+	FromUnit     string    `json:"from_unit"`
+	ToUnit       string    `json:"to_unit"`
+	WinnerFactor float64   `json:"winner_factor"`
+	LoserFactor  float64   `json:"loser_factor"`
+}
+
+type mergePreviewResponse struct {
+	Aliases             []string                     `json:"aliases"`
+	Differences         []mergeDifferenceResponse    `json:"differences"`
+	ChildrenMoved       int                          `json:"children_moved"`
+	SubstitutesMoved    int                          `json:"substitutes_moved"`
+	SubstitutesDropped  int                          `json:"substitutes_dropped"`
+	CompositesMoved     int                          `json:"composites_moved"`
+	CompositesDropped   int                          `json:"composites_dropped"`
+	ConversionsMoved    int                          `json:"conversions_moved"`
+	ConversionsUpdated  int                          `json:"conversions_updated"`
+	ConversionConflicts []conversionConflictResponse `json:"conversion_conflicts"`
+	NutritionMoved      bool                         `json:"nutrition_moved"`
+	StorageMoved        int                          `json:"storage_moved"`
+	StorageDropped      int                          `json:"storage_dropped"`
+	RedirectsMoved      int                          `json:"redirects_moved"`
+}
+
+// mergeResponse is the winner with the merge report alongside its fields.
+type mergeResponse struct {
+	db.Ingredient
+	LoserIDs           []uuid.UUID                 `json:"loser_ids"`
+	MergeIDs           []uuid.UUID                 `json:"merge_ids,omitempty"`
+	DryRun             bool                        `json:"dry_run"`
+	ConversionPolicy   string                      `json:"conversion_policy"`
+	DroppedConversions []droppedConversionResponse `json:"dropped_conversions"`
+	DroppedComponents  []droppedComponentResponse  `json:"dropped_components"`
+	DroppedComposites  []droppedCompositeResponse  `json:"dropped_composites"`
+	ConversionIssues   []conversionIssueResponse   `json:"conversion_issues"`
+	Preview            *mergePreviewResponse       `json:"preview,omitempty"`
+}
+
+func toMergePreviewResponse(p service.MergePreview) *mergePreviewResponse {
+	optional := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+	diffs := make([]mergeDifferenceResponse, 0, len(p.Differences))
+	for _, d := range p.Differences {
+		diffs = append(diffs, mergeDifferenceResponse{
+			LoserID: d.LoserID,
+			Field:   d.Field,
+			Winner:  optional(d.Winner),
+			Loser:   optional(d.Loser),
+			Result:  optional(d.Result),
+		})
+	}
+	conflicts := make([]conversionConflictResponse, 0, len(p.ConversionConflicts))
+	for _, c := range p.ConversionConflicts {
+		conflicts = append(conflicts, conversionConflictResponse{
+			LoserID:      c.LoserID,
+			FromUnit:     c.FromUnit,
+			ToUnit:       c.ToUnit,
+			WinnerFactor: c.WinnerFactor,
+			LoserFactor:  c.LoserFactor,
+		})
+	}
+	return &mergePreviewResponse{
+		Aliases:             nonNilStrings(p.Aliases),
+		Differences:         diffs,
+		ChildrenMoved:       p.ChildrenMoved,
+		SubstitutesMoved:    p.SubstitutesMoved,
+		SubstitutesDropped:  p.SubstitutesDropped,
+		CompositesMoved:     p.CompositesMoved,
+		CompositesDropped:   p.CompositesDropped,
+		ConversionsMoved:    p.ConversionsMoved,
+		ConversionsUpdated:  p.ConversionsUpdated,
+		ConversionConflicts: conflicts,
+		NutritionMoved:      p.NutritionMoved,
+		StorageMoved:        p.StorageMoved,
+		StorageDropped:      p.StorageDropped,
+		RedirectsMoved:      p.RedirectsMoved,
+	}
+}
+
+// handleMerge merges loser_id and/or every loser_ids entry into winner_id in
+// one transaction. With dry_run=true the merge is rolled back and the
+// response includes a preview of what it would do. If-Match applies to the
+// winner.
+func handleMerge(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req mergeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		winnerID, err := uuid.Parse(req.WinnerID)
+		if err != nil {
+			jsonError(w, "invalid winner_id", http.StatusBadRequest)
+			return
+		}
+		var loserIDs []uuid.UUID
+		if req.LoserID != "" {
+			loserID, err := uuid.Parse(req.LoserID)
+			if err != nil {
+				jsonError(w, "invalid loser_id", http.StatusBadRequest)
+				return
+			}
+			loserIDs = append(loserIDs, loserID)
+		}
+		for _, raw := range req.LoserIDs {
+			loserID, err := uuid.Parse(raw)
+			if err != nil {
+				jsonError(w, fmt.Sprintf("invalid loser_ids entry %q", raw), http.StatusBadRequest)
+				return
+			}
+			loserIDs = append(loserIDs, loserID)
+		}
+		var fields map[string]service.FieldRule
+		if len(req.Fields) > 0 {
+			fields = make(map[string]service.FieldRule, len(req.Fields))
+			for name, rule := range req.Fields {
+				fields[name] = service.FieldRule{Strategy: service.FieldStrategy(rule.Strategy), Value: rule.Value}
+			}
+		}
+		version, ok := ifMatchVersion(w, r)
+		if !ok {
+			return
+		}
+		dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+		result, err := svc.MergeMany(r.Context(), winnerID, loserIDs, service.MergeOptions{
+			ConversionPolicy: service.ConversionPolicy(req.ConversionPolicy),
+			Fields:           fields,
+			DryRun:           dryRun,
+			WinnerVersion:    version,
+		})
+		if err != nil {
+			var conflict *service.AliasConflictError
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrVersionMismatch):
+				jsonError(w, err.Error(), http.StatusPreconditionFailed)
+			case errors.As(err, &conflict):
+				jsonError(w, err.Error(), http.StatusConflict)
+			case errors.Is(err, service.ErrInvalidConversionPolicy),
+				errors.Is(err, service.ErrInvalidMerge),
+				errors.Is(err, service.ErrInvalidFieldRule),
+				errors.Is(err, service.ErrUnknownCategory):
+				jsonError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, service.ErrConversionConflict),
+				errors.Is(err, service.ErrComponentUnitMismatch):
+				jsonError(w, err.Error(), http.StatusConflict)
+			default:
+				jsonError(w, "merge failed", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		dropped := make([]droppedConversionResponse, 0, len(result.DroppedConversions))
+		for _, c := range result.DroppedConversions {
+			dropped = append(dropped, droppedConversionResponse{
+				ID:           c.ID,
+				IngredientID: c.IngredientID,
+				FromUnit:     c.FromUnit,
+				ToUnit:       c.ToUnit,
+				Factor:       c.Factor,
+			})
+		}
+		droppedComponents := make([]droppedComponentResponse, 0, len(result.DroppedComponents))
+		for _, c := range result.DroppedComponents {
+			droppedComponents = append(droppedComponents, droppedComponentResponse{
+				ID:          c.ID,
+				CompositeID: c.CompositeID,
+				ComponentID: c.ComponentID,
+				Quantity:    c.Quantity,
+				Unit:        c.Unit.String,
+			})
+		}
+		droppedComposites := make([]droppedCompositeResponse, 0, len(result.DroppedComposites))
+		for _, c := range result.DroppedComposites {
+			droppedComposites = append(droppedComposites, droppedCompositeResponse{ID: c.ID, IngredientID: c.IngredientID})
+		}
+		resp := mergeResponse{
+			Ingredient:         result.Ingredient,
+			LoserIDs:           result.LoserIDs,
+			MergeIDs:           result.MergeIDs,
+			DryRun:             result.DryRun,
+			ConversionPolicy:   string(result.ConversionPolicy),
+			DroppedConversions: dropped,
+			DroppedComponents:  droppedComponents,
+			DroppedComposites:  droppedComposites,
+			ConversionIssues:   toConversionIssueResponses(result.ConversionIssues),
+		}
+		if result.Preview != nil {
+			resp.Preview = toMergePreviewResponse(*result.Preview)
+		}
+		jsonOK(w, resp)
+	}
+}
+
+// --- duplicates ---
+
+type duplicatePairResponse struct {
+	A     uuid.UUID `json:"a"`
+	B     uuid.UUID `json:"b"`
+	ATerm string    `json:"a_term"`
+	BTerm string    `json:"b_term"`
+	Score float64   `json:"score"`
+}
+
+type mergeSuggestionResponse struct {
+	WinnerID uuid.UUID   `json:"winner_id"`
+	LoserIDs []uuid.UUID `json:"loser_ids"`
+}
+
+type duplicateClusterResponse struct {
+	Score       float64                 `json:"score"`
+	Ingredients []db.Ingredient         `json:"ingredients"`
+	Pairs       []duplicatePairResponse `json:"pairs"`
+	Suggestion  mergeSuggestionResponse `json:"suggestion"`
+}
+
+type duplicatesResponse struct {
+	Clusters []duplicateClusterResponse `json:"clusters"`
+}
+
+// handleFindDuplicates reports suspected duplicate clusters. Each cluster's
+// suggestion can be posted as-is to /ingredients/merge.
+func handleFindDuplicates(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var opts service.DuplicateOptions
+		if v := r.URL.Query().Get("min_score"); v != "" {
+			score, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				jsonError(w, "invalid min_score", http.StatusBadRequest)
+				return
+			}
+			opts.MinScore = score
+		}
+		if v := r.URL.Query().Get("limit"); v != "" {
+			limit, err := strconv.Atoi(v)
+			if err != nil {
+				jsonError(w, "invalid limit", http.StatusBadRequest)
+				return
+			}
+			opts.Limit = limit
+		}
+		clusters, err := svc.FindDuplicates(r.Context(), opts)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidDuplicateOptions) {
+				jsonError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			jsonError(w, "failed to find duplicates", http.StatusInternalServerError, err)
+			return
+		}
+		resp := make([]duplicateClusterResponse, 0, len(clusters))
+		for _, c := range clusters {
+			pairs := make([]duplicatePairResponse, 0, len(c.Pairs))
+			for _, p := range c.Pairs {
+				pairs = append(pairs, duplicatePairResponse{A: p.A, B: p.B, ATerm: p.ATerm, BTerm: p.BTerm, Score: p.Score})
+			}
+			resp = append(resp, duplicateClusterResponse{
+				Score:       c.Score,
+				Ingredients: c.Ingredients,
+				Pairs:       pairs,
+				Suggestion: mergeSuggestionResponse{
+					WinnerID: c.Suggestion.WinnerID,
+					LoserIDs: c.Suggestion.LoserIDs,
+				},
+			})
+		}
+		jsonOK(w, duplicatesResponse{Clusters: resp})
+	}
+}
+
+// --- split ---
+
+type splitRequest struct {
+	LoserID string `json:"loser_id"`
+}
+
+type splitIssueResponse struct {
+	Kind   string    `json:"kind"`
+	ID     uuid.UUID `json:"id"`
+	Detail string    `json:"detail"`
+}
+
+type splitResponse struct {
+	MergeID  uuid.UUID            `json:"merge_id"`
+	Winner   db.Ingredient        `json:"winner"`
+	Restored db.Ingredient        `json:"restored"`
+	Issues   []splitIssueResponse `json:"issues"`
+}
+
+type mergeRecordResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	WinnerID  uuid.UUID  `json:"winner_id"`
+	LoserID   uuid.UUID  `json:"loser_id"`
+	LoserName string     `json:"loser_name"`
+	MergedAt  time.Time  `json:"merged_at"`
+	SplitAt   *time.Time `json:"split_at"`
+}
+
+// handleSplit undoes a merge into {id}. The body is optional; without a
+// loser_id the most recent unsplit merge is undone.
+func handleSplit(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		var req splitRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			jsonError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		var loserID uuid.NullUUID
+		if req.LoserID != "" {
+			if loserID.UUID, err = uuid.Parse(req.LoserID); err != nil {
+				jsonError(w, "invalid loser_id", http.StatusBadRequest)
+				return
+			}
+			loserID.Valid = true
+		}
+		result, err := svc.Split(r.Context(), id, loserID)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrMergeNotFound):
+				jsonError(w, err.Error(), http.StatusNotFound)
+			case errors.Is(err, service.ErrSplitConflict):
+				jsonError(w, err.Error(), http.StatusConflict)
+			default:
+				jsonError(w, "split failed", http.StatusInternalServerError, err)
+			}
+			return
+		}
+		issues := make([]splitIssueResponse, 0, len(result.Issues))
+		for _, issue := range result.Issues {
+			issues = append(issues, splitIssueResponse{Kind: issue.Kind, ID: issue.ID, Detail: issue.Detail})
+		}
+		jsonOK(w, splitResponse{
+			MergeID:  result.MergeID,
+			Winner:   result.Winner,
+			Restored: result.Restored,
+			Issues:   issues,
+		})
+	}
+}
+
+func handleListMerges(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		merges, err := svc.ListMerges(r.Context(), id)
+		if err != nil {
+			jsonError(w, "failed to list merges", http.StatusInternalServerError, err)
+			return
+		}
+		resp := make([]mergeRecordResponse, 0, len(merges))
+		for _, m := range merges {
+			rec := mergeRecordResponse{
+				ID:        m.ID,
+				WinnerID:  m.WinnerID,
+				LoserID:   m.LoserID,
+				LoserName: m.LoserName,
+				MergedAt:  m.MergedAt,
+			}
+			if m.SplitAt.Valid {
+				rec.SplitAt = &m.SplitAt.Time
+			}
+			resp = append(resp, rec)
+		}
+		jsonOK(w, resp)
+	}
+}
+
+// --- scale ---
+
+type scaleItemRequest struct {
+	IngredientID string  `json:"ingredient_id"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"`
+}
+
+type scaleRequest struct {
+	Items  []scaleItemRequest `json:"items"`
+	Factor *float64           `json:"factor"`
+	System string             `json:"system"`
+}
+
+type scaledItemResponse struct {
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	Quantity     float64   `json:"quantity"`
+	Unit         string    `json:"unit"`
+	Display      string    `json:"display"`
+}
+
+type scaleResponse struct {
+	Items []scaledItemResponse `json:"items"`
+}
+
+func handleScale(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req scaleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		factor := 1.0
+		if req.Factor != nil {
+			factor = *req.Factor
+		}
+		if factor <= 0 {
+			jsonError(w, "factor must be positive", http.StatusBadRequest)
+			return
+		}
+		system := service.UnitSystem(req.System)
+		switch system {
+		case "", service.UnitSystemMetric, service.UnitSystemUS:
+		default:
+			jsonError(w, "system must be \"metric\" or \"us\"", http.StatusBadRequest)
+			return
+		}
+		items := make([]service.ScaleItem, 0, len(req.Items))
+		for _, it := range req.Items {
+			id, err := uuid.Parse(it.IngredientID)
+			if err != nil {
+				jsonError(w, "invalid ingredient_id", http.StatusBadRequest)
+				return
+			}
+			if it.Quantity < 0 {
+				jsonError(w, "quantity must not be negative", http.StatusBadRequest)
+				return
+			}
+			items = append(items, service.ScaleItem{IngredientID: id, Quantity: it.Quantity, Unit: it.Unit})
+		}
+		scaled, err := svc.Scale(r.Context(), items, factor, system)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(w, "ingredient not found", http.StatusNotFound)
+				return
+			}
+			jsonError(w, "scale failed", http.StatusInternalServerError, err)
+			return
+		}
+		resp := scaleResponse{Items: make([]scaledItemResponse, 0, len(scaled))}
+		for _, it := range scaled {
+			resp.Items = append(resp.Items, scaledItemResponse{
+				IngredientID: it.IngredientID,
+				Quantity:     it.Quantity,
+				Unit:         it.Unit,
+				Display:      it.Display,
+			})
+		}
+		jsonOK(w, resp)
+	}
+}
+
+// --- conversions ---
+
+type conversionIssueResponse struct {
+	IngredientID  uuid.UUID   `json:"ingredient_id"`
+	Kind          string      `json:"kind"`
+	ConversionIDs []uuid.UUID `json:"conversion_ids"`
+	Detail        string      `json:"detail"`
+	Repaired      bool        `json:"repaired"`
+}
+
+type conversionReportResponse struct {
+	IngredientsChecked int                       `json:"ingredients_checked"`
+	ConversionsChecked int                       `json:"conversions_checked"`
+	Issues             []conversionIssueResponse `json:"issues"`
+}
+
+func handleValidateConversions(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := svc.CheckConversions(r.Context(), false)
+		if err != nil {
+			jsonError(w, "conversion check failed", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, toConversionReportResponse(report))
+	}
+}
+
+func handleRepairConversions(svc *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := svc.CheckConversions(r.Context(), true)
+		if err != nil {
+			jsonError(w, "conversion repair failed", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, toConversionReportResponse(report))
+	}
+}
+
+func toConversionReportResponse(report service.ConversionReport) conversionReportResponse {
+	return conversionReportResponse{
+		IngredientsChecked: report.IngredientsChecked,
+		ConversionsChecked: report.ConversionsChecked,
+		Issues:             toConversionIssueResponses(report.Issues),
+	}
+}
+
+func toConversionIssueResponses(issues []service.ConversionIssue) []conversionIssueResponse {
+	resp := make([]conversionIssueResponse, 0, len(issues))
+	for _, issue := range issues {
+		ids := issue.ConversionIDs
+		if ids == nil {
+			ids = []uuid.UUID{}
+		}
+		resp = append(resp, conversionIssueResponse{
+			IngredientID:  issue.IngredientID,
+			Kind:          string(issue.Kind),
+			ConversionIDs: ids,
+			Detail:        issue.Detail,
+			Repaired:      issue.Repaired,
+		})
+	}
+	return resp
+}
+
+// --- helpers ---
+
+func jsonOK(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
+
+func jsonError(w http.ResponseWriter, msg string, status int, errs ...error) {
+	if status >= 500 && len(errs) > 0 {
+		slog.Error(msg, "status", status, "error", errs[0])
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg}) //nolint:errcheck
+}
+
+// etag is the ETag of an ingredient at version.
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion returns the ingredient version named by If-Match, or 0 when
+// the header is absent or "*". A header naming anything but a single
+// version can never match, so it writes 412 and returns false.
+func ifMatchVersion(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	h := strings.TrimSpace(r.Header.Get("If-Match"))
+	if h == "" || h == "*" {
+		return 0, true
+	}
+	if len(h) > 2 && h[0] == '"' && h[len(h)-1] == '"' {
+		if v, err := strconv.ParseInt(h[1:len(h)-1], 10, 64); err == nil && v > 0 {
+			return v, true
+		}
+	}
+	jsonError(w, service.ErrVersionMismatch.Error(), http.StatusPreconditionFailed)
+	return 0, false
+}
+
+// notModified reports whether If-None-Match lists tag, using the weak
+// comparison RFC 9110 prescribes for it.
+func notModified(r *http.Request, tag string) bool {
+	h := r.Header.Get("If-None-Match")
+	if h == "" {
+		return false
+	}
+	tag = strings.TrimPrefix(tag, "W/")
+	for _, t := range strings.Split(h, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == tag {
+			return true
+		}
+	}
+	return false
 }
 
 func nullString(s string) sql.NullString {
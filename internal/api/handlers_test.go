@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -26,7 +27,7 @@ func newTestIngredient(name string) db.Ingredient {
 		ID:          uuid.New(),
 		Name:        name,
 		Aliases:     []string{},
-		Category:    sql.NullString{},
+		CategoryID:  uuid.NullUUID{},
 		DefaultUnit: sql.NullString{},
 		CreatedAt:   time.Now(),
 	}
@@ -85,6 +86,27 @@ func TestListIngredients_Empty(t *testing.T) {
 	assert.Empty(t, items)
 }
 
+func TestListIngredients_NotModified(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	items := []db.Ingredient{newTestIngredient("garlic")}
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return(items, nil).Twice()
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	tag := rec.Header().Get("ETag")
+	require.NotEmpty(t, tag)
+
+	req = httptest.NewRequest(http.MethodGet, "/ingredients", nil)
+	req.Header.Set("If-None-Match", tag)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+}
+
 func TestListIngredients_WithItems(t *testing.T) {
 	t.Parallel()
 	mockQ, router := setupRouter(t)
@@ -104,6 +126,41 @@ func TestListIngredients_WithItems(t *testing.T) {
 	assert.Len(t, items, 2)
 }
 
+func TestListIngredients_DietaryFilter(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	milk := newTestIngredient("milk")
+	milk.Allergens = []string{"milk"}
+	milk.DietaryTags = []string{"vegetarian"}
+	tofu := newTestIngredient("tofu")
+	tofu.Allergens = []string{"soy"}
+	tofu.DietaryTags = []string{"vegan", "vegetarian"}
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{milk, tofu}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients?free_of=milk,peanuts&diet=vegetarian", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got []map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "tofu", got[0]["Name"])
+}
+
+func TestListIngredients_InvalidFilter(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients?free_of=gravel", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 // ---------------------------------------------------------------------------
 // POST /ingredients
 // ---------------------------------------------------------------------------
@@ -113,6 +170,7 @@ func TestCreateIngredient_Success(t *testing.T) {
 	mockQ, router := setupRouter(t)
 
 	created := newTestIngredient("garlic")
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"garlic"}).Return(nil, nil)
 	mockQ.EXPECT().CreateIngredient(mock.Anything, mock.MatchedBy(func(p db.CreateIngredientParams) bool {
 		return p.Name == "garlic"
 	})).Return(created, nil)
@@ -130,6 +188,76 @@ func TestCreateIngredient_Success(t *testing.T) {
 	assert.Equal(t, created.ID.String(), got["ID"])
 }
 
+func TestCreateIngredient_ActorAndReason(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	created := newTestIngredient("garlic")
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"garlic"}).Return(nil, nil)
+	mockQ.EXPECT().CreateIngredient(mock.Anything, mock.MatchedBy(func(p db.CreateIngredientParams) bool {
+		return p.CreatedBy.String == "importer" && p.ChangeReason.String == "weekly sync"
+	})).Return(created, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/ingredients", jsonBody(t, map[string]any{"name": "garlic"}))
+	req.Header.Set("X-Actor", " importer ")
+	req.Header.Set("X-Change-Reason", "weekly sync")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateIngredient_UnknownCategory(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	mockQ.EXPECT().GetCategoryBySlug(mock.Anything, "dairy-eggs").Return(db.Category{}, sql.ErrNoRows)
+
+	body := jsonBody(t, map[string]any{"name": "milk", "category": "Dairy & Eggs"})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCreateIngredient_AliasConflict(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	garlic := newTestIngredient("garlic")
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"shallot", "clove"}).Return([]db.ListTermOwnersRow{
+		{ID: garlic.ID, Name: garlic.Name, Term: "clove"},
+	}, nil)
+
+	body := jsonBody(t, map[string]any{"name": "shallot", "aliases": []string{"Clove"}})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var got map[string]string
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Contains(t, got["error"], `"garlic"`)
+	assert.Contains(t, got["error"], garlic.ID.String())
+}
+
+func TestCreateIngredient_NameAsAlias(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	body := jsonBody(t, map[string]any{"name": "garlic", "aliases": []string{"Garlic"}})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestCreateIngredient_MissingName(t *testing.T) {
 	t.Parallel()
 	_, router := setupRouter(t)
@@ -169,12 +297,86 @@ func TestGetIngredient_Success(t *testing.T) {
 	assert.Equal(t, garlic.ID.String(), got["ID"])
 }
 
+func TestGetIngredient_ETag(t *testing.T) {
+	t.Parallel()
+
+	garlic := newTestIngredient("garlic")
+	garlic.Version = 4
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{name: "no header", wantStatus: http.StatusOK},
+		{name: "current", ifNoneMatch: `"4"`, wantStatus: http.StatusNotModified},
+		{name: "current among others, weak", ifNoneMatch: `"2", W/"4"`, wantStatus: http.StatusNotModified},
+		{name: "stale", ifNoneMatch: `"3"`, wantStatus: http.StatusOK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mockQ, router := setupRouter(t)
+			mockQ.EXPECT().GetIngredient(mock.Anything, garlic.ID).Return(garlic, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/ingredients/"+garlic.ID.String(), nil)
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+			if tc.wantStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestGetIngredient_AsOf(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	id := uuid.New()
+	asOf := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockQ.EXPECT().GetIngredientHistoryAsOf(mock.Anything, mock.MatchedBy(func(p db.GetIngredientHistoryAsOfParams) bool {
+		return p.IngredientID == id && p.AsOf.Equal(asOf)
+	})).Return(db.IngredientHistory{
+		IngredientID: id,
+		Version:      2,
+		Snapshot:     json.RawMessage(`{"id": "` + id.String() + `", "name": "garlic", "aliases": [], "version": 2}`),
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+id.String()+"?as_of=2024-05-01T12:00:00Z", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, "garlic", got["Name"])
+}
+
+func TestGetIngredient_InvalidAsOf(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+uuid.New().String()+"?as_of=last-month", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestGetIngredient_NotFound(t *testing.T) {
 	t.Parallel()
 	mockQ, router := setupRouter(t)
 
 	id := uuid.New()
 	mockQ.EXPECT().GetIngredient(mock.Anything, id).Return(db.Ingredient{}, sql.ErrNoRows)
+	mockQ.EXPECT().GetIngredientRedirect(mock.Anything, id).Return(db.IngredientRedirect{}, sql.ErrNoRows)
 
 	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+id.String(), nil)
 	rec := httptest.NewRecorder()
@@ -203,16 +405,19 @@ func TestUpdateIngredient_Success(t *testing.T) {
 	mockQ, router := setupRouter(t)
 
 	id := uuid.New()
+	produce := db.Category{ID: uuid.New(), Slug: "produce", DisplayName: "Produce"}
 	updated := db.Ingredient{
 		ID:          id,
 		Name:        "garlic",
 		Aliases:     []string{"garlic clove"},
-		Category:    sql.NullString{String: "produce", Valid: true},
+		CategoryID:  uuid.NullUUID{UUID: produce.ID, Valid: true},
 		DefaultUnit: sql.NullString{},
 		CreatedAt:   time.Now(),
 	}
+	mockQ.EXPECT().GetCategoryBySlug(mock.Anything, "produce").Return(produce, nil)
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"garlic clove"}).Return(nil, nil)
 	mockQ.EXPECT().UpdateIngredient(mock.Anything, mock.MatchedBy(func(p db.UpdateIngredientParams) bool {
-		return p.ID == id
+		return p.ID == id && p.CategoryID == updated.CategoryID
 	})).Return(updated, nil)
 
 	body := jsonBody(t, map[string]any{
@@ -247,84 +452,1457 @@ func TestUpdateIngredient_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
 
-// ---------------------------------------------------------------------------
-// POST /ingredients/resolve
-// ---------------------------------------------------------------------------
+func TestUpdateIngredient_IfMatch(t *testing.T) {
+	t.Parallel()
 
-func TestResolve_ExistingIngredient(t *testing.T) {
+	garlic := newTestIngredient("garlic")
+	garlic.Version = 5
+
+	tests := []struct {
+		name       string
+		ifMatch    string
+		setup      func(m *mocks.MockQuerier)
+		wantStatus int
+	}{
+		{
+			name:    "current",
+			ifMatch: `"5"`,
+			setup: func(m *mocks.MockQuerier) {
+				m.EXPECT().UpdateIngredient(mock.Anything, mock.MatchedBy(func(p db.UpdateIngredientParams) bool {
+					return p.ExpectedVersion == sql.NullInt64{Int64: 5, Valid: true}
+				})).Return(db.Ingredient{ID: garlic.ID, Name: "garlic", Version: 6}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "stale",
+			ifMatch: `"4"`,
+			setup: func(m *mocks.MockQuerier) {
+				m.EXPECT().UpdateIngredient(mock.Anything, mock.Anything).Return(db.Ingredient{}, sql.ErrNoRows)
+				m.EXPECT().GetIngredient(mock.Anything, garlic.ID).Return(garlic, nil)
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{name: "weak", ifMatch: `W/"5"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "not a version", ifMatch: `"abc"`, wantStatus: http.StatusPreconditionFailed},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mockQ, router := setupRouter(t)
+			if tc.setup != nil {
+				tc.setup(mockQ)
+			}
+
+			body := jsonBody(t, map[string]any{"aliases": []string{}})
+			req := httptest.NewRequest(http.MethodPut, "/ingredients/"+garlic.ID.String(), body)
+			req.Header.Set("If-Match", tc.ifMatch)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantStatus == http.StatusOK {
+				assert.Equal(t, `"6"`, rec.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestUpdateIngredient_AliasIsAnotherName(t *testing.T) {
 	t.Parallel()
 	mockQ, router := setupRouter(t)
 
-	garlic := newTestIngredient("garlic")
-	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{garlic}, nil)
+	id := uuid.New()
+	shallot := newTestIngredient("shallot")
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"shallot"}).Return([]db.ListTermOwnersRow{
+		{ID: shallot.ID, Name: shallot.Name, Term: shallot.Name, IsName: true},
+	}, nil)
 
-	body := jsonBody(t, map[string]string{"name": "garlic"})
-	req := httptest.NewRequest(http.MethodPost, "/ingredients/resolve", body)
+	body := jsonBody(t, map[string]any{"aliases": []string{"shallot"}})
+	req := httptest.NewRequest(http.MethodPut, "/ingredients/"+id.String(), body)
 	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusOK, rec.Code)
-
-	var resp map[string]any
-	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
-	ing := resp["ingredient"].(map[string]any)
-	assert.Equal(t, garlic.ID.String(), ing["ID"])
-	assert.Equal(t, 1.0, resp["confidence"])
-	assert.Equal(t, false, resp["created"])
+	assert.Equal(t, http.StatusConflict, rec.Code)
 }
 
-func TestResolve_CreatesNew(t *testing.T) {
+func TestUpdateIngredient_NameAsAlias(t *testing.T) {
 	t.Parallel()
 	mockQ, router := setupRouter(t)
 
-	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{}, nil)
-
-	created := newTestIngredient("butter")
-	mockQ.EXPECT().UpsertIngredient(mock.Anything, mock.Anything).Return(created, nil)
+	garlic := newTestIngredient("garlic")
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"garlic"}).Return([]db.ListTermOwnersRow{
+		{ID: garlic.ID, Name: garlic.Name, Term: garlic.Name, IsName: true},
+	}, nil)
 
-	body := jsonBody(t, map[string]string{"name": "Butter"})
-	req := httptest.NewRequest(http.MethodPost, "/ingredients/resolve", body)
+	body := jsonBody(t, map[string]any{"aliases": []string{"garlic"}})
+	req := httptest.NewRequest(http.MethodPut, "/ingredients/"+garlic.ID.String(), body)
 	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
 
-	var resp map[string]any
-	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
-	assert.Equal(t, true, resp["created"])
+func TestPatchIngredient_OnlyCategory(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	current := newTestIngredient("garlic")
+	current.Aliases = []string{"garlic clove"}
+	current.DefaultUnit = sql.NullString{String: "clove", Valid: true}
+	produce := db.Category{ID: uuid.New(), Slug: "produce", DisplayName: "Produce"}
+	mockQ.EXPECT().GetCategoryBySlug(mock.Anything, "produce").Return(produce, nil)
+	mockQ.EXPECT().GetIngredient(mock.Anything, current.ID).Return(current, nil)
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"garlic clove"}).Return(nil, nil)
+	mockQ.EXPECT().UpdateIngredient(mock.Anything, mock.MatchedBy(func(p db.UpdateIngredientParams) bool {
+		// Aliases and default unit survive a patch that does not mention them.
+		return p.ID == current.ID && p.CategoryID.UUID == produce.ID &&
+			len(p.Aliases) == 1 && p.DefaultUnit == current.DefaultUnit && !p.Name.Valid
+	})).Return(current, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/ingredients/"+current.ID.String(),
+		bytes.NewBufferString(`{"category":"produce"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestPatchIngredient_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New().String()
+	tests := []struct {
+		name string
+		id   string
+		body string
+	}{
+		{name: "invalid id", id: "bad", body: `{}`},
+		{name: "not an object", id: id, body: `[]`},
+		{name: "null name", id: id, body: `{"name":null}`},
+		{name: "aliases not an array", id: id, body: `{"aliases":"clove"}`},
+		{name: "unknown field", id: id, body: `{"colour":"white"}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, router := setupRouter(t)
+
+			req := httptest.NewRequest(http.MethodPatch, "/ingredients/"+tc.id, bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
 }
 
 // ---------------------------------------------------------------------------
-// POST /ingredients/merge
+// POST /ingredients/{id}/rename
 // ---------------------------------------------------------------------------
 
-func TestMerge_InvalidIDs(t *testing.T) {
+func TestRenameIngredient_Conflict(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	id := uuid.New()
+	mockQ.EXPECT().GetIngredient(mock.Anything, id).Return(db.Ingredient{ID: id, Name: "garlic clove", Aliases: []string{}, Version: 1}, nil)
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"garlic", "garlic clove"}).Return([]db.ListTermOwnersRow{
+		{ID: uuid.New(), Name: "garlic", Term: "garlic", IsName: true},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/"+id.String()+"/rename", bytes.NewBufferString(`{"name":"garlic"}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestRenameIngredient_InvalidRequest(t *testing.T) {
 	t.Parallel()
 
+	id := uuid.New().String()
 	tests := []struct {
 		name string
-		body map[string]string
+		id   string
+		body string
 	}{
-		{
-			name: "invalid winner_id",
-			body: map[string]string{"winner_id": "bad", "loser_id": uuid.New().String()},
-		},
-		{
-			name: "invalid loser_id",
-			body: map[string]string{"winner_id": uuid.New().String(), "loser_id": "bad"},
-		},
+		{name: "invalid id", id: "bad", body: `{"name":"garlic"}`},
+		{name: "invalid body", id: id, body: `not json`},
+		{name: "unknown policy", id: id, body: `{"name":"garlic","on_conflict":"overwrite"}`},
 	}
-
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			_, router := setupRouter(t)
 
-			body := jsonBody(t, tc.body)
-			req := httptest.NewRequest(http.MethodPost, "/ingredients/merge", body)
-			req.Header.Set("Content-Type", "application/json")
+			req := httptest.NewRequest(http.MethodPost, "/ingredients/"+tc.id+"/rename", bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /ingredients/{id}/history
+// ---------------------------------------------------------------------------
+
+func TestIngredientHistory_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	id := uuid.New()
+	snapshot := json.RawMessage(`{"id": "` + id.String() + `", "name": "garlic", "aliases": [], "version": 1}`)
+	mockQ.EXPECT().ListIngredientHistory(mock.Anything, id).Return([]db.IngredientHistory{
+		{IngredientID: id, Version: 1, Operation: "create", Actor: sql.NullString{String: "curator", Valid: true}, Snapshot: snapshot},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+id.String()+"/history", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got []map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "create", got[0]["operation"])
+	assert.Equal(t, "curator", got[0]["actor"])
+	assert.Nil(t, got[0]["reason"])
+}
+
+func TestIngredientHistory_NotFound(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	id := uuid.New()
+	mockQ.EXPECT().ListIngredientHistory(mock.Anything, id).Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+id.String()+"/history", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// ---------------------------------------------------------------------------
+// /ingredients/{id}/aliases
+// ---------------------------------------------------------------------------
+
+func TestListAliases_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	garlic := newTestIngredient("garlic")
+	mockQ.EXPECT().GetIngredient(mock.Anything, garlic.ID).Return(garlic, nil)
+	mockQ.EXPECT().ListIngredientAliases(mock.Anything, garlic.ID).Return([]db.IngredientAlias{
+		{IngredientID: garlic.ID, Alias: "clove", Source: "manual", CreatedAt: time.Now()},
+		{IngredientID: garlic.ID, Alias: "ajo", Source: "import", Locale: sql.NullString{String: "es", Valid: true}, CreatedAt: time.Now()},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+garlic.ID.String()+"/aliases", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got []map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	require.Len(t, got, 2)
+	assert.Equal(t, "clove", got[0]["alias"])
+	assert.Nil(t, got[0]["locale"])
+	assert.Equal(t, "es", got[1]["locale"])
+}
+
+func TestAddAlias_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New().String()
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{name: "invalid id", path: "/ingredients/bad/aliases/clove"},
+		{name: "unknown source", path: "/ingredients/" + id + "/aliases/clove", body: `{"source":"guess"}`},
+		{name: "malformed locale", path: "/ingredients/" + id + "/aliases/clove", body: `{"locale":"english!"}`},
+		{name: "malformed body", path: "/ingredients/" + id + "/aliases/clove", body: `{`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, router := setupRouter(t)
+
+			req := httptest.NewRequest(http.MethodPost, tc.path, bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestAddAlias_EscapedAlias(t *testing.T) {
+	t.Parallel()
+
+	// The unknown source stops the request in the service, after the alias
+	// has been read from the path.
+	id := uuid.New().String()
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "percent sign", path: "/ingredients/" + id + "/aliases/2%25%20milk"},
+		{name: "escaped slash", path: "/ingredients/" + id + "/aliases/half%2Fhalf"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, router := setupRouter(t)
+
+			req := httptest.NewRequest(http.MethodPost, tc.path, bytes.NewBufferString(`{"source":"guess"}`))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), "unknown source")
+		})
+	}
+}
+
+func TestRemoveAlias_InvalidID(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodDelete, "/ingredients/bad/aliases/clove", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// ---------------------------------------------------------------------------
+// POST /ingredients/resolve
+// ---------------------------------------------------------------------------
+
+func TestResolve_ExistingIngredient(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	garlic := newTestIngredient("garlic")
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{garlic}, nil)
+
+	body := jsonBody(t, map[string]string{"name": "garlic"})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/resolve", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	ing := resp["ingredient"].(map[string]any)
+	assert.Equal(t, garlic.ID.String(), ing["ID"])
+	assert.Equal(t, 1.0, resp["confidence"])
+	assert.Equal(t, false, resp["created"])
+}
+
+func TestResolve_CreatesNew(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{}, nil)
+
+	created := newTestIngredient("butter")
+	mockQ.EXPECT().UpsertIngredient(mock.Anything, mock.Anything).Return(created, nil)
+
+	body := jsonBody(t, map[string]string{"name": "Butter"})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/resolve", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, true, resp["created"])
+}
+
+func TestResolve_ArchivedNameConflict(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	butter := newTestIngredient("butter")
+	butter.ArchivedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{butter}, nil)
+	mockQ.EXPECT().UpsertIngredient(mock.Anything, mock.Anything).Return(db.Ingredient{}, sql.ErrNoRows)
+	mockQ.EXPECT().GetIngredientByName(mock.Anything, "butter").Return(butter, nil)
+
+	body := jsonBody(t, map[string]string{"name": "Butter"})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/resolve", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+// ---------------------------------------------------------------------------
+// POST /ingredients/merge
+// ---------------------------------------------------------------------------
+
+func TestResolve_RollUp(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	cheese := newTestIngredient("cheese")
+	cheddar := newTestIngredient("cheddar")
+	cheddar.ParentID = uuid.NullUUID{UUID: cheese.ID, Valid: true}
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{cheese, cheddar}, nil)
+	mockQ.EXPECT().ListIngredientAncestors(mock.Anything, cheddar.ID).
+		Return([]db.ListIngredientAncestorsRow{{Ingredient: cheese, Depth: 1}}, nil)
+
+	body := jsonBody(t, map[string]any{"name": "cheddar", "rollup_depth": 0})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/resolve", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, cheddar.ID.String(), resp["ingredient"].(map[string]any)["ID"])
+	assert.Equal(t, cheese.ID.String(), resp["rolled_up"].(map[string]any)["ID"])
+}
+
+func TestMerge_InvalidIDs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body map[string]string
+	}{
+		{
+			name: "invalid winner_id",
+			body: map[string]string{"winner_id": "bad", "loser_id": uuid.New().String()},
+		},
+		{
+			name: "invalid loser_id",
+			body: map[string]string{"winner_id": uuid.New().String(), "loser_id": "bad"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, router := setupRouter(t)
+
+			body := jsonBody(t, tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/ingredients/merge", body)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestMerge_IfMatchNotAVersion(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	body := jsonBody(t, map[string]string{
+		"winner_id": uuid.New().String(),
+		"loser_id":  uuid.New().String(),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/merge", body)
+	req.Header.Set("If-Match", `"1", "2"`)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+}
+
+func TestMerge_InvalidConversionPolicy(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	body := jsonBody(t, map[string]string{
+		"winner_id":         uuid.New().String(),
+		"loser_id":          uuid.New().String(),
+		"conversion_policy": "coin_flip",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/merge", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMerge_InvalidLosers(t *testing.T) {
+	t.Parallel()
+
+	winnerID, loserID := uuid.New().String(), uuid.New().String()
+	tests := []struct {
+		name string
+		body map[string]any
+	}{
+		{name: "no losers", body: map[string]any{"winner_id": winnerID}},
+		{name: "bad loser_ids entry", body: map[string]any{"winner_id": winnerID, "loser_ids": []string{uuid.New().String(), "nope"}}},
+		{name: "winner among losers", body: map[string]any{"winner_id": winnerID, "loser_ids": []string{winnerID}}},
+		{name: "unknown field rule", body: map[string]any{
+			"winner_id": winnerID, "loser_id": loserID,
+			"fields": map[string]any{"colour": map[string]string{"strategy": "prefer_loser"}},
+		}},
+		{name: "loser repeated", body: map[string]any{"winner_id": winnerID, "loser_id": loserID, "loser_ids": []string{loserID}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, router := setupRouter(t)
+
+			req := httptest.NewRequest(http.MethodPost, "/ingredients/merge", jsonBody(t, tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// POST /ingredients/scale
+// ---------------------------------------------------------------------------
+
+func TestScale_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	milk := newTestIngredient("milk")
+	mockQ.EXPECT().GetIngredient(mock.Anything, milk.ID).Return(milk, nil)
+	mockQ.EXPECT().ListUnitConversionsByIngredient(mock.Anything, milk.ID).Return(nil, nil)
+
+	body := jsonBody(t, map[string]any{
+		"items": []map[string]any{
+			{"ingredient_id": milk.ID.String(), "quantity": 2, "unit": "cups"},
+		},
+		"factor": 2.5,
+		"system": "metric",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/scale", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Items []map[string]any `json:"items"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "1.2 l", resp.Items[0]["display"])
+}
+
+func TestScale_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body map[string]any
+	}{
+		{
+			name: "non-positive factor",
+			body: map[string]any{"items": []any{}, "factor": 0},
+		},
+		{
+			name: "negative factor",
+			body: map[string]any{"items": []any{}, "factor": -2},
+		},
+		{
+			name: "negative quantity",
+			body: map[string]any{"items": []map[string]any{{"ingredient_id": uuid.New().String(), "quantity": -0.5, "unit": "cup"}}},
+		},
+		{
+			name: "unknown system",
+			body: map[string]any{"items": []any{}, "system": "imperial"},
+		},
+		{
+			name: "invalid ingredient_id",
+			body: map[string]any{"items": []map[string]any{{"ingredient_id": "bad", "quantity": 1}}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, router := setupRouter(t)
+
+			req := httptest.NewRequest(http.MethodPost, "/ingredients/scale", jsonBody(t, tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /conversions/validate
+// ---------------------------------------------------------------------------
+
+func TestValidateConversions(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	flour := newTestIngredient("flour")
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{flour}, nil)
+	mockQ.EXPECT().ListCategories(mock.Anything).Return([]db.Category{}, nil)
+	mockQ.EXPECT().ListUnitConversions(mock.Anything).Return([]db.UnitConversion{
+		{ID: uuid.New(), IngredientID: flour.ID, FromUnit: "cup", ToUnit: "g", Factor: 120},
+		{ID: uuid.New(), IngredientID: flour.ID, FromUnit: "g", ToUnit: "cup", Factor: 2},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/conversions/validate", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		IngredientsChecked int              `json:"ingredients_checked"`
+		Issues             []map[string]any `json:"issues"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, 1, resp.IngredientsChecked)
+	require.Len(t, resp.Issues, 1)
+	assert.Equal(t, "inverse_mismatch", resp.Issues[0]["kind"])
+	assert.Equal(t, false, resp.Issues[0]["repaired"])
+}
+
+// ---------------------------------------------------------------------------
+// /ingredients/:id/substitutes
+// ---------------------------------------------------------------------------
+
+func TestListSubstitutes_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	butter := newTestIngredient("butter")
+	margarine := newTestIngredient("margarine")
+	mockQ.EXPECT().GetIngredient(mock.Anything, butter.ID).Return(butter, nil)
+	mockQ.EXPECT().ListSubstitutesWithIngredient(mock.Anything, db.ListSubstitutesWithIngredientParams{IngredientID: butter.ID}).Return([]db.ListSubstitutesWithIngredientRow{
+		{
+			IngredientSubstitute: db.IngredientSubstitute{
+				ID: uuid.New(), IngredientID: butter.ID, SubstituteID: margarine.ID, Ratio: 1,
+				Notes: sql.NullString{String: "baking only", Valid: true},
+			},
+			Ingredient: margarine,
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+butter.ID.String()+"/substitutes", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got []map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "baking only", got[0]["notes"])
+	sub := got[0]["substitute"].(map[string]any)
+	assert.Equal(t, "margarine", sub["Name"])
+}
+
+func TestListSubstitutes_FilterByTags(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	egg := newTestIngredient("egg")
+	applesauce := newTestIngredient("applesauce")
+	mockQ.EXPECT().GetIngredient(mock.Anything, egg.ID).Return(egg, nil)
+	mockQ.EXPECT().ListSubstitutesWithIngredient(mock.Anything, db.ListSubstitutesWithIngredientParams{
+		IngredientID: egg.ID,
+		Context:      "baking",
+		Reason:       "vegan",
+	}).Return([]db.ListSubstitutesWithIngredientRow{
+		{
+			IngredientSubstitute: db.IngredientSubstitute{
+				ID: uuid.New(), IngredientID: egg.ID, SubstituteID: applesauce.ID, Ratio: 1,
+				Contexts: []string{"baking"}, Reasons: []string{"vegan"},
+			},
+			Ingredient: applesauce,
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+egg.ID.String()+"/substitutes?context=Baking&reason=vegan", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got []map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	require.Len(t, got, 1)
+	assert.Equal(t, []any{"baking"}, got[0]["contexts"])
+	assert.Equal(t, []any{"vegan"}, got[0]["reasons"])
+}
+
+func TestListSubstitutes_InvalidTag(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+uuid.New().String()+"/substitutes?context=grilling", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAddSubstitute_SelfSubstitution(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	id := uuid.New()
+	body := jsonBody(t, map[string]any{"substitute_id": id.String()})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/"+id.String()+"/substitutes", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAddSubstitute_Duplicate(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	butter := newTestIngredient("butter")
+	margarine := newTestIngredient("margarine")
+	mockQ.EXPECT().GetIngredient(mock.Anything, butter.ID).Return(butter, nil)
+	mockQ.EXPECT().GetIngredient(mock.Anything, margarine.ID).Return(margarine, nil)
+	mockQ.EXPECT().GetSubstitute(mock.Anything, mock.Anything).Return(db.IngredientSubstitute{}, nil)
+
+	body := jsonBody(t, map[string]any{"substitute_id": margarine.ID.String(), "ratio": 1})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/"+butter.ID.String()+"/substitutes", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestRemoveSubstitute_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	id, subID := uuid.New(), uuid.New()
+	mockQ.EXPECT().DeleteSubstitute(mock.Anything, db.DeleteSubstituteParams{
+		IngredientID: id,
+		SubstituteID: subID,
+	}).Return(1, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/ingredients/"+id.String()+"/substitutes/"+subID.String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestSearchSubstitutes_InvalidDepth(t *testing.T) {
+	t.Parallel()
+
+	for _, depth := range []int{-1, 0, 50} {
+		t.Run(strconv.Itoa(depth), func(t *testing.T) {
+			t.Parallel()
+			_, router := setupRouter(t)
+
+			body := jsonBody(t, map[string]any{"max_depth": depth})
+			req := httptest.NewRequest(http.MethodPost, "/ingredients/"+uuid.New().String()+"/substitutes/search", body)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// /ingredients/:id/composite-substitutes
+// ---------------------------------------------------------------------------
+
+func TestAddCompositeSubstitute_TooFewComponents(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	body := jsonBody(t, map[string]any{
+		"quantity":   1,
+		"unit":       "cup",
+		"components": []map[string]any{{"component_id": uuid.New().String(), "quantity": 1, "unit": "cup"}},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/"+uuid.New().String()+"/composite-substitutes", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRemoveCompositeSubstitute_NotFound(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	mockQ.EXPECT().DeleteCompositeSubstitute(mock.Anything, mock.Anything).Return(0, nil)
+
+	req := httptest.NewRequest(http.MethodDelete,
+		"/ingredients/"+uuid.New().String()+"/composite-substitutes/"+uuid.New().String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// ---------------------------------------------------------------------------
+// /ingredients/:id/parent, /ancestors, /descendants
+// ---------------------------------------------------------------------------
+
+func TestSetParent_Self(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	id := uuid.New()
+	body := jsonBody(t, map[string]any{"parent_id": id.String()})
+	req := httptest.NewRequest(http.MethodPut, "/ingredients/"+id.String()+"/parent", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestSetParent_InvalidParentID(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	body := jsonBody(t, map[string]any{"parent_id": "not-a-uuid"})
+	req := httptest.NewRequest(http.MethodPut, "/ingredients/"+uuid.New().String()+"/parent", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestListAncestors_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	cheese := newTestIngredient("cheese")
+	cheddar := newTestIngredient("cheddar")
+	mockQ.EXPECT().GetIngredient(mock.Anything, cheddar.ID).Return(cheddar, nil)
+	mockQ.EXPECT().ListIngredientAncestors(mock.Anything, cheddar.ID).
+		Return([]db.ListIngredientAncestorsRow{{Ingredient: cheese, Depth: 1}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+cheddar.ID.String()+"/ancestors", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got []map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	require.Len(t, got, 1)
+	assert.Equal(t, 1.0, got[0]["depth"])
+	assert.Equal(t, "cheese", got[0]["ingredient"].(map[string]any)["Name"])
+}
+
+func TestListDescendants_InvalidDepth(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+uuid.New().String()+"/descendants?max_depth=0", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// ---------------------------------------------------------------------------
+// /categories
+// ---------------------------------------------------------------------------
+
+func TestCreateCategory_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	created := db.Category{ID: uuid.New(), Slug: "dairy", DisplayName: "Dairy", CreatedAt: time.Now()}
+	mockQ.EXPECT().CreateCategory(mock.Anything, db.CreateCategoryParams{
+		Slug:        "dairy",
+		DisplayName: "Dairy",
+	}).Return(created, nil)
+
+	body := jsonBody(t, map[string]any{"display_name": "Dairy"})
+	req := httptest.NewRequest(http.MethodPost, "/categories", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var got map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, "dairy", got["Slug"])
+}
+
+func TestCreateCategory_MissingDisplayName(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	body := jsonBody(t, map[string]any{"slug": "dairy"})
+	req := httptest.NewRequest(http.MethodPost, "/categories", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetCategory_NotFound(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	id := uuid.New()
+	mockQ.EXPECT().GetCategory(mock.Anything, id).Return(db.Category{}, sql.ErrNoRows)
+
+	req := httptest.NewRequest(http.MethodGet, "/categories/"+id.String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// ---------------------------------------------------------------------------
+// /ingredients/:id/dietary
+// ---------------------------------------------------------------------------
+
+func TestSetDietary_InheritsFromParent(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	cheese := newTestIngredient("cheese")
+	cheese.Allergens = []string{"milk"}
+	cheese.DietaryTags = []string{"vegetarian"}
+	blue := newTestIngredient("blue cheese")
+	blue.ParentID = uuid.NullUUID{UUID: cheese.ID, Valid: true}
+	updated := blue
+	updated.Allergens = []string{"sulphites"}
+	updated.DietaryTags = []string{}
+
+	mockQ.EXPECT().UpdateIngredientDietary(mock.Anything, db.UpdateIngredientDietaryParams{
+		ID:          blue.ID,
+		Allergens:   []string{"sulphites"},
+		DietaryTags: []string{},
+		FreeOf:      []string{},
+	}).Return(updated, nil)
+	mockQ.EXPECT().ListIngredientAncestors(mock.Anything, blue.ID).
+		Return([]db.ListIngredientAncestorsRow{{Ingredient: cheese, Depth: 1}}, nil)
+
+	body := jsonBody(t, map[string]any{"allergens": []string{"sulphites"}})
+	req := httptest.NewRequest(http.MethodPut, "/ingredients/"+blue.ID.String()+"/dietary", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, []any{"milk", "sulphites"}, got["allergens"])
+	assert.Equal(t, []any{"vegetarian"}, got["dietary_tags"])
+	assert.Equal(t, []any{"sulphites"}, got["own_allergens"])
+	assert.Equal(t, []any{}, got["own_dietary_tags"])
+}
+
+func TestSetDietary_InvalidAllergen(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	body := jsonBody(t, map[string]any{"allergens": []string{"gravel"}})
+	req := httptest.NewRequest(http.MethodPut, "/ingredients/"+uuid.New().String()+"/dietary", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// ---------------------------------------------------------------------------
+// /ingredients/:id/nutrition, /nutrition/import
+// ---------------------------------------------------------------------------
+
+func TestGetNutrition_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	butter := newTestIngredient("butter")
+	mockQ.EXPECT().GetIngredient(mock.Anything, butter.ID).Return(butter, nil)
+	mockQ.EXPECT().GetIngredientNutrition(mock.Anything, butter.ID).Return(db.IngredientNutrition{
+		IngredientID: butter.ID,
+		Source:       "usda-fdc",
+		SourceName:   "Butter, salted",
+		EnergyKcal:   sql.NullFloat64{Float64: 717, Valid: true},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+butter.ID.String()+"/nutrition", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, 717.0, got["energy_kcal"])
+	assert.Nil(t, got["protein_g"])
+}
+
+func TestGetNutrition_NoData(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	butter := newTestIngredient("butter")
+	mockQ.EXPECT().GetIngredient(mock.Anything, butter.ID).Return(butter, nil)
+	mockQ.EXPECT().GetIngredientNutrition(mock.Anything, butter.ID).Return(db.IngredientNutrition{}, sql.ErrNoRows)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+butter.ID.String()+"/nutrition", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestImportNutrition_DryRun(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	butter := newTestIngredient("butter")
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{butter}, nil)
+
+	body := bytes.NewBufferString("name,energy_kcal\nButter,717\nKale,49\n")
+	req := httptest.NewRequest(http.MethodPost, "/nutrition/import?source=usda-fdc&dry_run=true", body)
+	req.Header.Set("Content-Type", "text/csv")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, true, got["dry_run"])
+	assert.Equal(t, 1.0, got["unmatched"])
+	rows := got["rows"].([]any)
+	require.Len(t, rows, 1)
+	assert.Equal(t, butter.ID.String(), rows[0].(map[string]any)["ingredient_id"])
+}
+
+func TestImportNutrition_MissingSource(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	body := bytes.NewBufferString("name,energy_kcal\nButter,717\n")
+	req := httptest.NewRequest(http.MethodPost, "/nutrition/import", body)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// ---------------------------------------------------------------------------
+// /ingredients/:id/storage, /ingredients/:id/expiry, /storage/import
+// ---------------------------------------------------------------------------
+
+func TestGetStorage_InheritsFromCategory(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	dairy := uuid.New()
+	milk := newTestIngredient("milk")
+	milk.CategoryID = uuid.NullUUID{UUID: dairy, Valid: true}
+	mockQ.EXPECT().GetIngredient(mock.Anything, milk.ID).Return(milk, nil)
+	mockQ.EXPECT().ListStorageGuidelinesByIngredient(mock.Anything, milk.ID).Return(nil, nil)
+	mockQ.EXPECT().ListStorageGuidelinesByCategory(mock.Anything, dairy).Return([]db.StorageGuideline{{
+		ID:           uuid.New(),
+		CategoryID:   uuid.NullUUID{UUID: dairy, Valid: true},
+		Location:     "fridge",
+		UnopenedDays: sql.NullInt32{Int32: 14, Valid: true},
+	}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+milk.ID.String()+"/storage", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, "category", got["source"])
+	assert.Equal(t, dairy.String(), got["category_id"])
+	guidelines := got["guidelines"].([]any)
+	require.Len(t, guidelines, 1)
+	assert.Equal(t, 14.0, guidelines[0].(map[string]any)["unopened_days"])
+	assert.Nil(t, guidelines[0].(map[string]any)["opened_days"])
+}
+
+func TestSetStorage_InvalidLocation(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	body := jsonBody(t, map[string]any{
+		"guidelines": []map[string]any{{"location": "cellar", "unopened_days": 30}},
+	})
+	req := httptest.NewRequest(http.MethodPut, "/ingredients/"+uuid.NewString()+"/storage", body)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestEstimateExpiry_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	milk := newTestIngredient("milk")
+	mockQ.EXPECT().GetIngredient(mock.Anything, milk.ID).Return(milk, nil)
+	mockQ.EXPECT().ListStorageGuidelinesByIngredient(mock.Anything, milk.ID).Return([]db.StorageGuideline{{
+		ID:           uuid.New(),
+		IngredientID: uuid.NullUUID{UUID: milk.ID, Valid: true},
+		Location:     "fridge",
+		UnopenedDays: sql.NullInt32{Int32: 10, Valid: true},
+		OpenedDays:   sql.NullInt32{Int32: 5, Valid: true},
+	}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/ingredients/"+milk.ID.String()+"/expiry?purchased=2026-05-01&opened=2026-05-02&location=fridge", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, "ingredient", got["source"])
+	estimates := got["estimates"].([]any)
+	require.Len(t, estimates, 1)
+	est := estimates[0].(map[string]any)
+	assert.Equal(t, "2026-05-07", est["expires_on"])
+	assert.Equal(t, 6.0, est["days"])
+	assert.Equal(t, "opened", est["basis"])
+}
+
+func TestEstimateExpiry_BadDate(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+uuid.NewString()+"/expiry?purchased=yesterday", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestImportStorage_DryRun(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	milk := newTestIngredient("milk")
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{milk}, nil)
+	mockQ.EXPECT().ListCategories(mock.Anything).Return(nil, nil)
+
+	body := bytes.NewBufferString("ingredient,location,unopened_days\nMilk,fridge,10\nKale,fridge,5\n")
+	req := httptest.NewRequest(http.MethodPost, "/storage/import?dry_run=true", body)
+	req.Header.Set("Content-Type", "text/csv")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, 2.0, got["rows_read"])
+	rows := got["rows"].([]any)
+	require.Len(t, rows, 2)
+	assert.Equal(t, milk.ID.String(), rows[0].(map[string]any)["ingredient_id"])
+	assert.Equal(t, "unmatched", rows[1].(map[string]any)["status"])
+}
+
+// ---------------------------------------------------------------------------
+// DELETE /ingredients/:id, /restore, DELETE /admin/ingredients/:id
+// ---------------------------------------------------------------------------
+
+func TestArchiveIngredient_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	salt := newTestIngredient("salt")
+	salt.ArchivedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	mockQ.EXPECT().ArchiveIngredient(mock.Anything, db.ArchiveIngredientParams{ID: salt.ID}).Return(salt, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/ingredients/"+salt.ID.String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got db.Ingredient
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.True(t, got.ArchivedAt.Valid)
+}
+
+func TestRestoreIngredient_NotFound(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	id := uuid.New()
+	mockQ.EXPECT().RestoreIngredient(mock.Anything, db.RestoreIngredientParams{ID: id}).Return(db.Ingredient{}, sql.ErrNoRows)
+
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/"+id.String()+"/restore", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestListIngredients_IncludeArchived(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	salt := newTestIngredient("salt")
+	old := newTestIngredient("old salt")
+	old.ArchivedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{old, salt}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients?include_archived=true", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got []db.Ingredient
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Len(t, got, 2)
+}
+
+func TestHardDeleteIngredient_NotArchived(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	salt := newTestIngredient("salt")
+	mockQ.EXPECT().DeleteArchivedIngredient(mock.Anything, db.DeleteArchivedIngredientParams{ID: salt.ID}).Return(0, nil)
+	mockQ.EXPECT().GetIngredient(mock.Anything, salt.ID).Return(salt, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/ingredients/"+salt.ID.String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestHardDeleteIngredient_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	id := uuid.New()
+	mockQ.EXPECT().DeleteArchivedIngredient(mock.Anything, db.DeleteArchivedIngredientParams{ID: id}).Return(1, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/ingredients/"+id.String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+// ---------------------------------------------------------------------------
+// merge redirects, /ingredients/translate
+// ---------------------------------------------------------------------------
+
+func TestGetIngredient_MergedRedirects(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	loserID, winnerID := uuid.New(), uuid.New()
+	mockQ.EXPECT().GetIngredient(mock.Anything, loserID).Return(db.Ingredient{}, sql.ErrNoRows)
+	mockQ.EXPECT().GetIngredientRedirect(mock.Anything, loserID).
+		Return(db.IngredientRedirect{OldID: loserID, NewID: winnerID}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+loserID.String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/ingredients/"+winnerID.String(), rec.Header().Get("Location"))
+
+	var got map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, winnerID.String(), got["merged_into"])
+}
+
+func TestTranslateIDs_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	current, merged, unknown, winner := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	ids := []uuid.UUID{current, merged, unknown}
+	mockQ.EXPECT().ListIngredientIDs(mock.Anything, ids).Return([]uuid.UUID{current}, nil)
+	mockQ.EXPECT().ListIngredientRedirects(mock.Anything, ids).
+		Return([]db.IngredientRedirect{{OldID: merged, NewID: winner}}, nil)
+
+	body := jsonBody(t, map[string]any{"ids": []string{current.String(), merged.String(), unknown.String()}})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/translate", body)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got struct {
+		Translations []struct {
+			ID        string  `json:"id"`
+			CurrentID *string `json:"current_id"`
+			Status    string  `json:"status"`
+		} `json:"translations"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	require.Len(t, got.Translations, 3)
+	assert.Equal(t, "current", got.Translations[0].Status)
+	assert.Equal(t, current.String(), *got.Translations[0].CurrentID)
+	assert.Equal(t, "merged", got.Translations[1].Status)
+	assert.Equal(t, winner.String(), *got.Translations[1].CurrentID)
+	assert.Equal(t, "unknown", got.Translations[2].Status)
+	assert.Nil(t, got.Translations[2].CurrentID)
+}
+
+func TestTranslateIDs_InvalidID(t *testing.T) {
+	t.Parallel()
+	_, router := setupRouter(t)
+
+	body := jsonBody(t, map[string]any{"ids": []string{"not-a-uuid"}})
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/translate", body)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// ---------------------------------------------------------------------------
+// POST /ingredients/{id}/split, GET /ingredients/{id}/merges
+// ---------------------------------------------------------------------------
+
+func TestSplit_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{name: "invalid id", path: "/ingredients/not-a-uuid/split", body: `{}`},
+		{name: "invalid loser_id", path: "/ingredients/" + uuid.New().String() + "/split", body: `{"loser_id":"nope"}`},
+		{name: "malformed body", path: "/ingredients/" + uuid.New().String() + "/split", body: `{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, router := setupRouter(t)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestListMerges_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	winnerID := uuid.New()
+	open := db.IngredientMerge{ID: uuid.New(), WinnerID: winnerID, LoserID: uuid.New(), LoserName: "coconut cream", MergedAt: time.Now()}
+	split := db.IngredientMerge{
+		ID: uuid.New(), WinnerID: winnerID, LoserID: uuid.New(), LoserName: "coco milk", MergedAt: time.Now(),
+		SplitAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	mockQ.EXPECT().ListIngredientMerges(mock.Anything, winnerID).Return([]db.IngredientMerge{open, split}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/"+winnerID.String()+"/merges", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got []map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	require.Len(t, got, 2)
+	assert.Equal(t, "coconut cream", got[0]["loser_name"])
+	assert.Nil(t, got[0]["split_at"])
+	assert.NotNil(t, got[1]["split_at"])
+	assert.NotContains(t, got[0], "snapshot")
+}
+
+// ---------------------------------------------------------------------------
+// GET /ingredients/duplicates
+// ---------------------------------------------------------------------------
+
+func TestFindDuplicates_Success(t *testing.T) {
+	t.Parallel()
+	mockQ, router := setupRouter(t)
+
+	chili, chile, salt := newTestIngredient("chili"), newTestIngredient("chile"), newTestIngredient("salt")
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{chili, chile, salt}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/duplicates?min_score=0.8", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var got struct {
+		Clusters []struct {
+			Score      float64 `json:"score"`
+			Suggestion struct {
+				WinnerID string   `json:"winner_id"`
+				LoserIDs []string `json:"loser_ids"`
+			} `json:"suggestion"`
+		} `json:"clusters"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	require.Len(t, got.Clusters, 1)
+	assert.InDelta(t, 0.8, got.Clusters[0].Score, 1e-9)
+	assert.Len(t, got.Clusters[0].Suggestion.LoserIDs, 1)
+}
+
+func TestFindDuplicates_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{"min_score=abc", "min_score=2", "limit=-1", "limit=x"} {
+		t.Run(query, func(t *testing.T) {
+			t.Parallel()
+			_, router := setupRouter(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/ingredients/duplicates?"+query, nil)
 			rec := httptest.NewRecorder()
 			router.ServeHTTP(rec, req)
 
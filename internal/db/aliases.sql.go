@@ -0,0 +1,206 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: aliases.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const addIngredientAlias = `-- name: AddIngredientAlias :one
+INSERT INTO ingredient_aliases (ingredient_id, alias, source, created_by, locale)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (alias) DO NOTHING
+RETURNING id, ingredient_id, alias, created_at, source, created_by, locale
+`
+
+type AddIngredientAliasParams struct {
+	IngredientID uuid.UUID
+	Alias        string
+	Source       string
+	CreatedBy    sql.NullString
+	Locale       sql.NullString
+}
+
+// Returns no row if the alias is already taken, by this ingredient or
+// another.
+func (q *Queries) AddIngredientAlias(ctx context.Context, arg AddIngredientAliasParams) (IngredientAlias, error) {
+	row := q.db.QueryRowContext(ctx, addIngredientAlias,
+		arg.IngredientID,
+		arg.Alias,
+		arg.Source,
+		arg.CreatedBy,
+		arg.Locale,
+	)
+	var i IngredientAlias
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.Alias,
+		&i.CreatedAt,
+		&i.Source,
+		&i.CreatedBy,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const deleteIngredientAlias = `-- name: DeleteIngredientAlias :execrows
+DELETE FROM ingredient_aliases WHERE ingredient_id = $1 AND alias = $2
+`
+
+type DeleteIngredientAliasParams struct {
+	IngredientID uuid.UUID
+	Alias        string
+}
+
+func (q *Queries) DeleteIngredientAlias(ctx context.Context, arg DeleteIngredientAliasParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteIngredientAlias, arg.IngredientID, arg.Alias)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getIngredientAlias = `-- name: GetIngredientAlias :one
+SELECT id, ingredient_id, alias, created_at, source, created_by, locale FROM ingredient_aliases WHERE alias = $1
+`
+
+func (q *Queries) GetIngredientAlias(ctx context.Context, alias string) (IngredientAlias, error) {
+	row := q.db.QueryRowContext(ctx, getIngredientAlias, alias)
+	var i IngredientAlias
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.Alias,
+		&i.CreatedAt,
+		&i.Source,
+		&i.CreatedBy,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const listIngredientAliases = `-- name: ListIngredientAliases :many
+SELECT ia.id, ia.ingredient_id, ia.alias, ia.created_at, ia.source, ia.created_by, ia.locale FROM ingredient_aliases ia JOIN ingredients i ON i.id = ia.ingredient_id
+WHERE ia.ingredient_id = $1
+ORDER BY array_position(i.aliases, ia.alias), ia.created_at, ia.alias
+`
+
+// Returns an ingredient's aliases in the order of its aliases column.
+func (q *Queries) ListIngredientAliases(ctx context.Context, ingredientID uuid.UUID) ([]IngredientAlias, error) {
+	rows, err := q.db.QueryContext(ctx, listIngredientAliases, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IngredientAlias
+	for rows.Next() {
+		var i IngredientAlias
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.Alias,
+			&i.CreatedAt,
+			&i.Source,
+			&i.CreatedBy,
+			&i.Locale,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTermOwners = `-- name: ListTermOwners :many
+SELECT i.id, i.name, ia.alias AS term, false AS is_name
+FROM ingredient_aliases ia JOIN ingredients i ON i.id = ia.ingredient_id
+WHERE ia.alias = ANY($1::text[])
+UNION ALL
+SELECT i.id, i.name, i.name AS term, true AS is_name
+FROM ingredients i
+WHERE i.name = ANY($1::text[])
+`
+
+type ListTermOwnersRow struct {
+	ID     uuid.UUID
+	Name   string
+	Term   string
+	IsName bool
+}
+
+// Returns the ingredients that hold any of terms as their name or as an
+// alias. is_name tells which.
+func (q *Queries) ListTermOwners(ctx context.Context, terms []string) ([]ListTermOwnersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTermOwners, pq.Array(terms))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTermOwnersRow
+	for rows.Next() {
+		var i ListTermOwnersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Term,
+			&i.IsName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const syncIngredientAliases = `-- name: SyncIngredientAliases :one
+UPDATE ingredients
+SET aliases = ARRAY(
+  SELECT ia.alias FROM ingredient_aliases ia
+  WHERE ia.ingredient_id = ingredients.id
+  ORDER BY array_position(ingredients.aliases, ia.alias), ia.created_at, ia.alias
+)
+WHERE ingredients.id = $1
+RETURNING id, name, aliases, default_unit, created_at, parent_id, category_id, allergens, dietary_tags, free_of, archived_at, version
+`
+
+// Rewrites the aliases column from ingredient_aliases. Aliases keep their
+// place in the column; new ones go at the end, oldest first.
+func (q *Queries) SyncIngredientAliases(ctx context.Context, id uuid.UUID) (Ingredient, error) {
+	row := q.db.QueryRowContext(ctx, syncIngredientAliases, id)
+	var i Ingredient
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		pq.Array(&i.Aliases),
+		&i.DefaultUnit,
+		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
@@ -0,0 +1,346 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: composite_substitutes.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const createCompositeComponent = `-- name: CreateCompositeComponent :one
+INSERT INTO composite_substitute_components (composite_id, component_id, quantity, unit)
+VALUES ($1, $2, $3, $4)
+RETURNING id, composite_id, component_id, quantity, unit
+`
+
+type CreateCompositeComponentParams struct {
+	CompositeID uuid.UUID
+	ComponentID uuid.UUID
+	Quantity    float64
+	Unit        sql.NullString
+}
+
+func (q *Queries) CreateCompositeComponent(ctx context.Context, arg CreateCompositeComponentParams) (CompositeSubstituteComponent, error) {
+	row := q.db.QueryRowContext(ctx, createCompositeComponent,
+		arg.CompositeID,
+		arg.ComponentID,
+		arg.Quantity,
+		arg.Unit,
+	)
+	var i CompositeSubstituteComponent
+	err := row.Scan(
+		&i.ID,
+		&i.CompositeID,
+		&i.ComponentID,
+		&i.Quantity,
+		&i.Unit,
+	)
+	return i, err
+}
+
+const createCompositeSubstitute = `-- name: CreateCompositeSubstitute :one
+INSERT INTO composite_substitutes (ingredient_id, quantity, unit, notes, contexts, reasons)
+VALUES ($1, $2, $3, $4, COALESCE($5::text[], '{}'), COALESCE($6::text[], '{}'))
+RETURNING id, ingredient_id, quantity, unit, notes, created_at, contexts, reasons
+`
+
+type CreateCompositeSubstituteParams struct {
+	IngredientID uuid.UUID
+	Quantity     float64
+	Unit         sql.NullString
+	Notes        sql.NullString
+	Contexts     []string
+	Reasons      []string
+}
+
+func (q *Queries) CreateCompositeSubstitute(ctx context.Context, arg CreateCompositeSubstituteParams) (CompositeSubstitute, error) {
+	row := q.db.QueryRowContext(ctx, createCompositeSubstitute,
+		arg.IngredientID,
+		arg.Quantity,
+		arg.Unit,
+		arg.Notes,
+		pq.Array(arg.Contexts),
+		pq.Array(arg.Reasons),
+	)
+	var i CompositeSubstitute
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.Quantity,
+		&i.Unit,
+		&i.Notes,
+		&i.CreatedAt,
+		pq.Array(&i.Contexts),
+		pq.Array(&i.Reasons),
+	)
+	return i, err
+}
+
+const deleteCompositeComponent = `-- name: DeleteCompositeComponent :exec
+DELETE FROM composite_substitute_components WHERE id = $1
+`
+
+func (q *Queries) DeleteCompositeComponent(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteCompositeComponent, id)
+	return err
+}
+
+const deleteCompositeSubstitute = `-- name: DeleteCompositeSubstitute :execrows
+DELETE FROM composite_substitutes WHERE id = $1 AND ingredient_id = $2
+`
+
+type DeleteCompositeSubstituteParams struct {
+	ID           uuid.UUID
+	IngredientID uuid.UUID
+}
+
+func (q *Queries) DeleteCompositeSubstitute(ctx context.Context, arg DeleteCompositeSubstituteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCompositeSubstitute, arg.ID, arg.IngredientID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteSelfReferencingComposites = `-- name: DeleteSelfReferencingComposites :exec
+DELETE FROM composite_substitutes
+WHERE composite_substitutes.ingredient_id = $1
+  AND EXISTS (
+    SELECT 1 FROM composite_substitute_components c
+    WHERE c.composite_id = composite_substitutes.id AND c.component_id = $1
+  )
+`
+
+// Removes composites of an ingredient that list the ingredient itself as a
+// component, which a merge can produce.
+func (q *Queries) DeleteSelfReferencingComposites(ctx context.Context, ingredientID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteSelfReferencingComposites, ingredientID)
+	return err
+}
+
+const deleteUndersizedComposites = `-- name: DeleteUndersizedComposites :many
+DELETE FROM composite_substitutes
+WHERE id = ANY($1::uuid[])
+  AND (
+    SELECT count(*) FROM composite_substitute_components c
+    WHERE c.composite_id = composite_substitutes.id
+  ) < 2
+RETURNING id, ingredient_id, quantity, unit, notes, created_at, contexts, reasons
+`
+
+// Removes composites among the given IDs that a merge left with fewer than
+// two components.
+func (q *Queries) DeleteUndersizedComposites(ctx context.Context, ids []uuid.UUID) ([]CompositeSubstitute, error) {
+	rows, err := q.db.QueryContext(ctx, deleteUndersizedComposites, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CompositeSubstitute
+	for rows.Next() {
+		var i CompositeSubstitute
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.Quantity,
+			&i.Unit,
+			&i.Notes,
+			&i.CreatedAt,
+			pq.Array(&i.Contexts),
+			pq.Array(&i.Reasons),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCompositeComponentsByComponent = `-- name: ListCompositeComponentsByComponent :many
+SELECT id, composite_id, component_id, quantity, unit FROM composite_substitute_components WHERE component_id = $1
+`
+
+func (q *Queries) ListCompositeComponentsByComponent(ctx context.Context, componentID uuid.UUID) ([]CompositeSubstituteComponent, error) {
+	rows, err := q.db.QueryContext(ctx, listCompositeComponentsByComponent, componentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CompositeSubstituteComponent
+	for rows.Next() {
+		var i CompositeSubstituteComponent
+		if err := rows.Scan(
+			&i.ID,
+			&i.CompositeID,
+			&i.ComponentID,
+			&i.Quantity,
+			&i.Unit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCompositeComponentsByIngredient = `-- name: ListCompositeComponentsByIngredient :many
+SELECT composite_substitute_components.id, composite_substitute_components.composite_id, composite_substitute_components.component_id, composite_substitute_components.quantity, composite_substitute_components.unit, ingredients.id, ingredients.name, ingredients.aliases, ingredients.default_unit, ingredients.created_at, ingredients.parent_id, ingredients.category_id, ingredients.allergens, ingredients.dietary_tags, ingredients.free_of, ingredients.archived_at, ingredients.version
+FROM composite_substitute_components
+JOIN composite_substitutes ON composite_substitutes.id = composite_substitute_components.composite_id
+JOIN ingredients ON ingredients.id = composite_substitute_components.component_id
+WHERE composite_substitutes.ingredient_id = $1
+ORDER BY ingredients.name
+`
+
+type ListCompositeComponentsByIngredientRow struct {
+	CompositeSubstituteComponent CompositeSubstituteComponent
+	Ingredient                   Ingredient
+}
+
+func (q *Queries) ListCompositeComponentsByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]ListCompositeComponentsByIngredientRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCompositeComponentsByIngredient, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCompositeComponentsByIngredientRow
+	for rows.Next() {
+		var i ListCompositeComponentsByIngredientRow
+		if err := rows.Scan(
+			&i.CompositeSubstituteComponent.ID,
+			&i.CompositeSubstituteComponent.CompositeID,
+			&i.CompositeSubstituteComponent.ComponentID,
+			&i.CompositeSubstituteComponent.Quantity,
+			&i.CompositeSubstituteComponent.Unit,
+			&i.Ingredient.ID,
+			&i.Ingredient.Name,
+			pq.Array(&i.Ingredient.Aliases),
+			&i.Ingredient.DefaultUnit,
+			&i.Ingredient.CreatedAt,
+			&i.Ingredient.ParentID,
+			&i.Ingredient.CategoryID,
+			pq.Array(&i.Ingredient.Allergens),
+			pq.Array(&i.Ingredient.DietaryTags),
+			pq.Array(&i.Ingredient.FreeOf),
+			&i.Ingredient.ArchivedAt,
+			&i.Ingredient.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCompositeSubstitutesByIngredient = `-- name: ListCompositeSubstitutesByIngredient :many
+SELECT id, ingredient_id, quantity, unit, notes, created_at, contexts, reasons FROM composite_substitutes
+WHERE ingredient_id = $1
+  AND ($2::text = '' OR cardinality(contexts) = 0 OR $2::text = ANY(contexts))
+  AND ($3::text = '' OR $3::text = ANY(reasons))
+ORDER BY created_at
+`
+
+type ListCompositeSubstitutesByIngredientParams struct {
+	IngredientID uuid.UUID
+	Context      string
+	Reason       string
+}
+
+// Filters behave as in ListSubstitutesWithIngredient.
+func (q *Queries) ListCompositeSubstitutesByIngredient(ctx context.Context, arg ListCompositeSubstitutesByIngredientParams) ([]CompositeSubstitute, error) {
+	rows, err := q.db.QueryContext(ctx, listCompositeSubstitutesByIngredient, arg.IngredientID, arg.Context, arg.Reason)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CompositeSubstitute
+	for rows.Next() {
+		var i CompositeSubstitute
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.Quantity,
+			&i.Unit,
+			&i.Notes,
+			&i.CreatedAt,
+			pq.Array(&i.Contexts),
+			pq.Array(&i.Reasons),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const replaceCompositeComponentIngredient = `-- name: ReplaceCompositeComponentIngredient :exec
+UPDATE composite_substitute_components SET component_id = $1 WHERE component_id = $2
+`
+
+type ReplaceCompositeComponentIngredientParams struct {
+	ComponentID   uuid.UUID
+	ComponentID_2 uuid.UUID
+}
+
+func (q *Queries) ReplaceCompositeComponentIngredient(ctx context.Context, arg ReplaceCompositeComponentIngredientParams) error {
+	_, err := q.db.ExecContext(ctx, replaceCompositeComponentIngredient, arg.ComponentID, arg.ComponentID_2)
+	return err
+}
+
+const replaceCompositeSubstituteIngredient = `-- name: ReplaceCompositeSubstituteIngredient :exec
+UPDATE composite_substitutes SET ingredient_id = $1 WHERE ingredient_id = $2
+`
+
+type ReplaceCompositeSubstituteIngredientParams struct {
+	IngredientID   uuid.UUID
+	IngredientID_2 uuid.UUID
+}
+
+func (q *Queries) ReplaceCompositeSubstituteIngredient(ctx context.Context, arg ReplaceCompositeSubstituteIngredientParams) error {
+	_, err := q.db.ExecContext(ctx, replaceCompositeSubstituteIngredient, arg.IngredientID, arg.IngredientID_2)
+	return err
+}
+
+const updateCompositeComponentQuantity = `-- name: UpdateCompositeComponentQuantity :exec
+UPDATE composite_substitute_components SET quantity = $2 WHERE id = $1
+`
+
+type UpdateCompositeComponentQuantityParams struct {
+	ID       uuid.UUID
+	Quantity float64
+}
+
+func (q *Queries) UpdateCompositeComponentQuantity(ctx context.Context, arg UpdateCompositeComponentQuantityParams) error {
+	_, err := q.db.ExecContext(ctx, updateCompositeComponentQuantity, arg.ID, arg.Quantity)
+	return err
+}
@@ -0,0 +1,140 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: history.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const getIngredientHistoryAsOf = `-- name: GetIngredientHistoryAsOf :one
+SELECT id, ingredient_id, version, operation, actor, reason, snapshot, deleted, recorded_at FROM ingredient_history
+WHERE ingredient_id = $1 AND recorded_at <= $2
+ORDER BY recorded_at DESC, version DESC
+LIMIT 1
+`
+
+type GetIngredientHistoryAsOfParams struct {
+	IngredientID uuid.UUID
+	AsOf         time.Time
+}
+
+// The latest entry for the ingredient recorded at or before as_of.
+func (q *Queries) GetIngredientHistoryAsOf(ctx context.Context, arg GetIngredientHistoryAsOfParams) (IngredientHistory, error) {
+	row := q.db.QueryRowContext(ctx, getIngredientHistoryAsOf, arg.IngredientID, arg.AsOf)
+	var i IngredientHistory
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.Version,
+		&i.Operation,
+		&i.Actor,
+		&i.Reason,
+		&i.Snapshot,
+		&i.Deleted,
+		&i.RecordedAt,
+	)
+	return i, err
+}
+
+const listIngredientHistory = `-- name: ListIngredientHistory :many
+SELECT id, ingredient_id, version, operation, actor, reason, snapshot, deleted, recorded_at FROM ingredient_history
+WHERE ingredient_id = $1
+ORDER BY version DESC
+`
+
+func (q *Queries) ListIngredientHistory(ctx context.Context, ingredientID uuid.UUID) ([]IngredientHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listIngredientHistory, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IngredientHistory
+	for rows.Next() {
+		var i IngredientHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.Version,
+			&i.Operation,
+			&i.Actor,
+			&i.Reason,
+			&i.Snapshot,
+			&i.Deleted,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIngredientHistoryAsOf = `-- name: ListIngredientHistoryAsOf :many
+SELECT DISTINCT ON (ingredient_id) id, ingredient_id, version, operation, actor, reason, snapshot, deleted, recorded_at FROM ingredient_history
+WHERE recorded_at <= $1
+ORDER BY ingredient_id, recorded_at DESC, version DESC
+`
+
+// The latest entry per ingredient recorded at or before as_of, including
+// deletions.
+func (q *Queries) ListIngredientHistoryAsOf(ctx context.Context, asOf time.Time) ([]IngredientHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listIngredientHistoryAsOf, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IngredientHistory
+	for rows.Next() {
+		var i IngredientHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.Version,
+			&i.Operation,
+			&i.Actor,
+			&i.Reason,
+			&i.Snapshot,
+			&i.Deleted,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setIngredientChange = `-- name: SetIngredientChange :exec
+SELECT set_ingredient_change($1::text, $2::text, $3::text)
+`
+
+type SetIngredientChangeParams struct {
+	Operation string
+	Actor     sql.NullString
+	Reason    sql.NullString
+}
+
+// Labels the rest of the transaction's ingredient writes in history.
+func (q *Queries) SetIngredientChange(ctx context.Context, arg SetIngredientChangeParams) error {
+	_, err := q.db.ExecContext(ctx, setIngredientChange, arg.Operation, arg.Actor, arg.Reason)
+	return err
+}
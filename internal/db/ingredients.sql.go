@@ -13,38 +13,126 @@ import (
 	"github.com/lib/pq"
 )
 
+const archiveIngredient = `-- name: ArchiveIngredient :one
+WITH change AS (
+  SELECT set_ingredient_change(NULL, $2::text, $3::text) AS ok
+)
+UPDATE ingredients SET archived_at = COALESCE(archived_at, now())
+FROM change
+WHERE ingredients.id = $1::uuid
+RETURNING ingredients.id, ingredients.name, ingredients.aliases, ingredients.default_unit, ingredients.created_at, ingredients.parent_id, ingredients.category_id, ingredients.allergens, ingredients.dietary_tags, ingredients.free_of, ingredients.archived_at, ingredients.version
+`
+
+type ArchiveIngredientParams struct {
+	ID     uuid.UUID
+	Actor  sql.NullString
+	Reason sql.NullString
+}
+
+// Archiving an already archived ingredient keeps its original archived_at.
+// History records the update with actor and reason.
+func (q *Queries) ArchiveIngredient(ctx context.Context, arg ArchiveIngredientParams) (Ingredient, error) {
+	row := q.db.QueryRowContext(ctx, archiveIngredient, arg.ID, arg.Actor, arg.Reason)
+	var i Ingredient
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		pq.Array(&i.Aliases),
+		&i.DefaultUnit,
+		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
 const createIngredient = `-- name: CreateIngredient :one
-INSERT INTO ingredients (name, aliases, category, default_unit)
-VALUES ($1, $2, $3, $4)
-RETURNING id, name, aliases, category, default_unit, created_at
+WITH change AS (
+  SELECT set_ingredient_change(NULL, $5::text, $6::text) AS ok
+), new_id AS (
+  SELECT gen_random_uuid() AS id
+), added AS (
+  INSERT INTO ingredient_aliases (ingredient_id, alias, source, created_by)
+  SELECT new_id.id, a.alias, COALESCE($7::text, 'manual'), $5::text
+  FROM new_id, unnest($2::text[]) AS a(alias)
+)
+INSERT INTO ingredients (id, name, aliases, category_id, default_unit)
+SELECT new_id.id, $1, $2::text[], $3::uuid, $4::text
+FROM new_id, change
+RETURNING id, name, aliases, default_unit, created_at, parent_id, category_id, allergens, dietary_tags, free_of, archived_at, version
 `
 
 type CreateIngredientParams struct {
-	Name        string
-	Aliases     []string
-	Category    sql.NullString
-	DefaultUnit sql.NullString
+	Name         string
+	Aliases      []string
+	CategoryID   uuid.NullUUID
+	DefaultUnit  sql.NullString
+	CreatedBy    sql.NullString
+	ChangeReason sql.NullString
+	AliasSource  sql.NullString
 }
 
+// The aliases are written to ingredient_aliases in the same statement, so an
+// alias another ingredient holds fails the insert with a unique violation.
+// New alias rows get alias_source, or manual when it is null. History
+// records created_by as the actor.
 func (q *Queries) CreateIngredient(ctx context.Context, arg CreateIngredientParams) (Ingredient, error) {
 	row := q.db.QueryRowContext(ctx, createIngredient,
 		arg.Name,
 		pq.Array(arg.Aliases),
-		arg.Category,
+		arg.CategoryID,
 		arg.DefaultUnit,
+		arg.CreatedBy,
+		arg.ChangeReason,
+		arg.AliasSource,
 	)
 	var i Ingredient
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		pq.Array(&i.Aliases),
-		&i.Category,
 		&i.DefaultUnit,
 		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
+const deleteArchivedIngredient = `-- name: DeleteArchivedIngredient :execrows
+WITH change AS (
+  SELECT set_ingredient_change(NULL, $2::text, $3::text) AS ok
+)
+DELETE FROM ingredients
+USING change
+WHERE ingredients.id = $1::uuid AND ingredients.archived_at IS NOT NULL
+`
+
+type DeleteArchivedIngredientParams struct {
+	ID     uuid.UUID
+	Actor  sql.NullString
+	Reason sql.NullString
+}
+
+// History records the deletion with actor and reason.
+func (q *Queries) DeleteArchivedIngredient(ctx context.Context, arg DeleteArchivedIngredientParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteArchivedIngredient, arg.ID, arg.Actor, arg.Reason)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteIngredient = `-- name: DeleteIngredient :exec
 DELETE FROM ingredients WHERE id = $1
 `
@@ -55,7 +143,7 @@ func (q *Queries) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
 }
 
 const getIngredient = `-- name: GetIngredient :one
-SELECT id, name, aliases, category, default_unit, created_at FROM ingredients WHERE id = $1
+SELECT id, name, aliases, default_unit, created_at, parent_id, category_id, allergens, dietary_tags, free_of, archived_at, version FROM ingredients WHERE id = $1
 `
 
 func (q *Queries) GetIngredient(ctx context.Context, id uuid.UUID) (Ingredient, error) {
@@ -65,15 +153,21 @@ func (q *Queries) GetIngredient(ctx context.Context, id uuid.UUID) (Ingredient,
 		&i.ID,
 		&i.Name,
 		pq.Array(&i.Aliases),
-		&i.Category,
 		&i.DefaultUnit,
 		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getIngredientByName = `-- name: GetIngredientByName :one
-SELECT id, name, aliases, category, default_unit, created_at FROM ingredients WHERE name = $1
+SELECT id, name, aliases, default_unit, created_at, parent_id, category_id, allergens, dietary_tags, free_of, archived_at, version FROM ingredients WHERE name = $1
 `
 
 func (q *Queries) GetIngredientByName(ctx context.Context, name string) (Ingredient, error) {
@@ -83,15 +177,209 @@ func (q *Queries) GetIngredientByName(ctx context.Context, name string) (Ingredi
 		&i.ID,
 		&i.Name,
 		pq.Array(&i.Aliases),
-		&i.Category,
 		&i.DefaultUnit,
 		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
+const getIngredientForUpdate = `-- name: GetIngredientForUpdate :one
+SELECT id, name, aliases, default_unit, created_at, parent_id, category_id, allergens, dietary_tags, free_of, archived_at, version FROM ingredients WHERE id = $1 FOR UPDATE
+`
+
+// Locks the row until the end of the transaction.
+func (q *Queries) GetIngredientForUpdate(ctx context.Context, id uuid.UUID) (Ingredient, error) {
+	row := q.db.QueryRowContext(ctx, getIngredientForUpdate, id)
+	var i Ingredient
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		pq.Array(&i.Aliases),
+		&i.DefaultUnit,
+		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const listIngredientAncestors = `-- name: ListIngredientAncestors :many
+WITH RECURSIVE ancestors (id, parent_id, depth) AS (
+  SELECT p.id, p.parent_id, 1
+  FROM ingredients c JOIN ingredients p ON p.id = c.parent_id
+  WHERE c.id = $1
+  UNION ALL
+  SELECT p.id, p.parent_id, a.depth + 1
+  FROM ancestors a JOIN ingredients p ON p.id = a.parent_id
+  WHERE a.depth < 64
+)
+SELECT ingredients.id, ingredients.name, ingredients.aliases, ingredients.default_unit, ingredients.created_at, ingredients.parent_id, ingredients.category_id, ingredients.allergens, ingredients.dietary_tags, ingredients.free_of, ingredients.archived_at, ingredients.version, ancestors.depth::int AS depth
+FROM ancestors JOIN ingredients ON ingredients.id = ancestors.id
+ORDER BY ancestors.depth
+`
+
+type ListIngredientAncestorsRow struct {
+	Ingredient Ingredient
+	Depth      int32
+}
+
+// Returns the ancestors of an ingredient, nearest first. depth is 1 for the
+// parent. The depth guard only matters if a cycle slipped past the service.
+func (q *Queries) ListIngredientAncestors(ctx context.Context, id uuid.UUID) ([]ListIngredientAncestorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listIngredientAncestors, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListIngredientAncestorsRow
+	for rows.Next() {
+		var i ListIngredientAncestorsRow
+		if err := rows.Scan(
+			&i.Ingredient.ID,
+			&i.Ingredient.Name,
+			pq.Array(&i.Ingredient.Aliases),
+			&i.Ingredient.DefaultUnit,
+			&i.Ingredient.CreatedAt,
+			&i.Ingredient.ParentID,
+			&i.Ingredient.CategoryID,
+			pq.Array(&i.Ingredient.Allergens),
+			pq.Array(&i.Ingredient.DietaryTags),
+			pq.Array(&i.Ingredient.FreeOf),
+			&i.Ingredient.ArchivedAt,
+			&i.Ingredient.Version,
+			&i.Depth,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIngredientChildren = `-- name: ListIngredientChildren :many
+SELECT id, name, aliases, default_unit, created_at, parent_id, category_id, allergens, dietary_tags, free_of, archived_at, version FROM ingredients WHERE parent_id = $1::uuid ORDER BY name
+`
+
+func (q *Queries) ListIngredientChildren(ctx context.Context, parentID uuid.UUID) ([]Ingredient, error) {
+	rows, err := q.db.QueryContext(ctx, listIngredientChildren, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Ingredient
+	for rows.Next() {
+		var i Ingredient
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			pq.Array(&i.Aliases),
+			&i.DefaultUnit,
+			&i.CreatedAt,
+			&i.ParentID,
+			&i.CategoryID,
+			pq.Array(&i.Allergens),
+			pq.Array(&i.DietaryTags),
+			pq.Array(&i.FreeOf),
+			&i.ArchivedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIngredientDescendants = `-- name: ListIngredientDescendants :many
+WITH RECURSIVE descendants (id, depth) AS (
+  SELECT c.id, 1
+  FROM ingredients c
+  WHERE c.parent_id = $1::uuid
+  UNION ALL
+  SELECT c.id, d.depth + 1
+  FROM descendants d JOIN ingredients c ON c.parent_id = d.id
+  WHERE d.depth < $2::int
+)
+SELECT ingredients.id, ingredients.name, ingredients.aliases, ingredients.default_unit, ingredients.created_at, ingredients.parent_id, ingredients.category_id, ingredients.allergens, ingredients.dietary_tags, ingredients.free_of, ingredients.archived_at, ingredients.version, descendants.depth::int AS depth
+FROM descendants JOIN ingredients ON ingredients.id = descendants.id
+ORDER BY descendants.depth, ingredients.name
+`
+
+type ListIngredientDescendantsParams struct {
+	ID       uuid.UUID
+	MaxDepth int32
+}
+
+type ListIngredientDescendantsRow struct {
+	Ingredient Ingredient
+	Depth      int32
+}
+
+// Returns the descendants of an ingredient up to max_depth levels below it,
+// ordered by depth then name. depth is 1 for direct children.
+func (q *Queries) ListIngredientDescendants(ctx context.Context, arg ListIngredientDescendantsParams) ([]ListIngredientDescendantsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listIngredientDescendants, arg.ID, arg.MaxDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListIngredientDescendantsRow
+	for rows.Next() {
+		var i ListIngredientDescendantsRow
+		if err := rows.Scan(
+			&i.Ingredient.ID,
+			&i.Ingredient.Name,
+			pq.Array(&i.Ingredient.Aliases),
+			&i.Ingredient.DefaultUnit,
+			&i.Ingredient.CreatedAt,
+			&i.Ingredient.ParentID,
+			&i.Ingredient.CategoryID,
+			pq.Array(&i.Ingredient.Allergens),
+			pq.Array(&i.Ingredient.DietaryTags),
+			pq.Array(&i.Ingredient.FreeOf),
+			&i.Ingredient.ArchivedAt,
+			&i.Ingredient.Version,
+			&i.Depth,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listIngredients = `-- name: ListIngredients :many
-SELECT id, name, aliases, category, default_unit, created_at FROM ingredients ORDER BY name
+SELECT id, name, aliases, default_unit, created_at, parent_id, category_id, allergens, dietary_tags, free_of, archived_at, version FROM ingredients ORDER BY name
 `
 
 func (q *Queries) ListIngredients(ctx context.Context) ([]Ingredient, error) {
@@ -107,9 +395,15 @@ func (q *Queries) ListIngredients(ctx context.Context) ([]Ingredient, error) {
 			&i.ID,
 			&i.Name,
 			pq.Array(&i.Aliases),
-			&i.Category,
 			&i.DefaultUnit,
 			&i.CreatedAt,
+			&i.ParentID,
+			&i.CategoryID,
+			pq.Array(&i.Allergens),
+			pq.Array(&i.DietaryTags),
+			pq.Array(&i.FreeOf),
+			&i.ArchivedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -124,68 +418,254 @@ func (q *Queries) ListIngredients(ctx context.Context) ([]Ingredient, error) {
 	return items, nil
 }
 
+const lockIngredientHierarchy = `-- name: LockIngredientHierarchy :exec
+SELECT pg_advisory_xact_lock(hashtext('ingredient_hierarchy'))
+`
+
+// Serializes parent changes for the rest of the transaction so that two
+// concurrent re-parents cannot together form a cycle.
+func (q *Queries) LockIngredientHierarchy(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, lockIngredientHierarchy)
+	return err
+}
+
+const restoreIngredient = `-- name: RestoreIngredient :one
+WITH change AS (
+  SELECT set_ingredient_change(NULL, $2::text, $3::text) AS ok
+)
+UPDATE ingredients SET archived_at = NULL
+FROM change
+WHERE ingredients.id = $1::uuid
+RETURNING ingredients.id, ingredients.name, ingredients.aliases, ingredients.default_unit, ingredients.created_at, ingredients.parent_id, ingredients.category_id, ingredients.allergens, ingredients.dietary_tags, ingredients.free_of, ingredients.archived_at, ingredients.version
+`
+
+type RestoreIngredientParams struct {
+	ID     uuid.UUID
+	Actor  sql.NullString
+	Reason sql.NullString
+}
+
+// History records the update with actor and reason.
+func (q *Queries) RestoreIngredient(ctx context.Context, arg RestoreIngredientParams) (Ingredient, error) {
+	row := q.db.QueryRowContext(ctx, restoreIngredient, arg.ID, arg.Actor, arg.Reason)
+	var i Ingredient
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		pq.Array(&i.Aliases),
+		&i.DefaultUnit,
+		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const setIngredientParent = `-- name: SetIngredientParent :one
+UPDATE ingredients SET parent_id = $2 WHERE id = $1
+RETURNING id, name, aliases, default_unit, created_at, parent_id, category_id, allergens, dietary_tags, free_of, archived_at, version
+`
+
+type SetIngredientParentParams struct {
+	ID       uuid.UUID
+	ParentID uuid.NullUUID
+}
+
+func (q *Queries) SetIngredientParent(ctx context.Context, arg SetIngredientParentParams) (Ingredient, error) {
+	row := q.db.QueryRowContext(ctx, setIngredientParent, arg.ID, arg.ParentID)
+	var i Ingredient
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		pq.Array(&i.Aliases),
+		&i.DefaultUnit,
+		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
 const updateIngredient = `-- name: UpdateIngredient :one
+WITH change AS (
+  SELECT set_ingredient_change($5::text, $6::text, $7::text) AS ok
+), target AS (
+  SELECT t.id FROM ingredients t
+  WHERE t.id = $8::uuid
+    AND ($9::bigint IS NULL OR t.version = $9::bigint)
+  FOR UPDATE
+), removed AS (
+  DELETE FROM ingredient_aliases
+  WHERE ingredient_id IN (SELECT target.id FROM target) AND NOT alias = ANY($2::text[])
+), added AS (
+  INSERT INTO ingredient_aliases (ingredient_id, alias, source, created_by)
+  SELECT i.id, a.alias, COALESCE($10::text, 'manual'), $6::text
+  FROM target i, unnest($2::text[]) AS a(alias)
+  WHERE NOT EXISTS (
+    SELECT 1 FROM ingredient_aliases x WHERE x.ingredient_id = i.id AND x.alias = a.alias
+  )
+)
 UPDATE ingredients
-SET aliases = $2, category = $3, default_unit = $4
-WHERE id = $1
-RETURNING id, name, aliases, category, default_unit, created_at
+SET name = COALESCE($1::text, ingredients.name),
+    aliases = $2::text[], category_id = $3::uuid, default_unit = $4::text
+FROM change
+WHERE ingredients.id IN (SELECT target.id FROM target)
+RETURNING ingredients.id, ingredients.name, ingredients.aliases, ingredients.default_unit, ingredients.created_at, ingredients.parent_id, ingredients.category_id, ingredients.allergens, ingredients.dietary_tags, ingredients.free_of, ingredients.archived_at, ingredients.version
 `
 
 type UpdateIngredientParams struct {
-	ID          uuid.UUID
-	Aliases     []string
-	Category    sql.NullString
-	DefaultUnit sql.NullString
+	Name            sql.NullString
+	Aliases         []string
+	CategoryID      uuid.NullUUID
+	DefaultUnit     sql.NullString
+	ChangeOperation sql.NullString
+	CreatedBy       sql.NullString
+	ChangeReason    sql.NullString
+	ID              uuid.UUID
+	ExpectedVersion sql.NullInt64
+	AliasSource     sql.NullString
 }
 
+// Replaces the rows in ingredient_aliases as well, keeping those of aliases
+// that stay. Like CreateIngredient, an alias another ingredient holds fails
+// the update with a unique violation, and new rows get alias_source. A null
+// name leaves the name as it is. With expected_version set, the update only
+// applies at that version and otherwise returns no rows, leaving the aliases
+// alone. History records change_operation (update when null) and
+// created_by as the actor; in a transaction that called
+// SetIngredientChange, null arguments keep its labels.
 func (q *Queries) UpdateIngredient(ctx context.Context, arg UpdateIngredientParams) (Ingredient, error) {
 	row := q.db.QueryRowContext(ctx, updateIngredient,
-		arg.ID,
+		arg.Name,
 		pq.Array(arg.Aliases),
-		arg.Category,
+		arg.CategoryID,
 		arg.DefaultUnit,
+		arg.ChangeOperation,
+		arg.CreatedBy,
+		arg.ChangeReason,
+		arg.ID,
+		arg.ExpectedVersion,
+		arg.AliasSource,
 	)
 	var i Ingredient
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		pq.Array(&i.Aliases),
-		&i.Category,
 		&i.DefaultUnit,
 		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const updateIngredientDietary = `-- name: UpdateIngredientDietary :one
+WITH change AS (
+  SELECT set_ingredient_change(NULL, $5::text, $6::text) AS ok
+)
+UPDATE ingredients
+SET allergens = $1::text[], dietary_tags = $2::text[], free_of = $3::text[]
+FROM change
+WHERE ingredients.id = $4::uuid
+RETURNING ingredients.id, ingredients.name, ingredients.aliases, ingredients.default_unit, ingredients.created_at, ingredients.parent_id, ingredients.category_id, ingredients.allergens, ingredients.dietary_tags, ingredients.free_of, ingredients.archived_at, ingredients.version
+`
+
+type UpdateIngredientDietaryParams struct {
+	Allergens   []string
+	DietaryTags []string
+	FreeOf      []string
+	ID          uuid.UUID
+	Actor       sql.NullString
+	Reason      sql.NullString
+}
+
+// History records the update with actor and reason; in a transaction that
+// called SetIngredientChange, null arguments keep its labels.
+func (q *Queries) UpdateIngredientDietary(ctx context.Context, arg UpdateIngredientDietaryParams) (Ingredient, error) {
+	row := q.db.QueryRowContext(ctx, updateIngredientDietary,
+		pq.Array(arg.Allergens),
+		pq.Array(arg.DietaryTags),
+		pq.Array(arg.FreeOf),
+		arg.ID,
+		arg.Actor,
+		arg.Reason,
+	)
+	var i Ingredient
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		pq.Array(&i.Aliases),
+		&i.DefaultUnit,
+		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const upsertIngredient = `-- name: UpsertIngredient :one
-INSERT INTO ingredients (name, aliases, category, default_unit)
-VALUES ($1, $2, $3, $4)
+WITH change AS (
+  SELECT set_ingredient_change('auto_create', $4::text, $5::text) AS ok
+)
+INSERT INTO ingredients (name, aliases, category_id, default_unit)
+SELECT $1, '{}', $2::uuid, $3::text
+FROM change
 ON CONFLICT (name) DO NOTHING
-RETURNING id, name, aliases, category, default_unit, created_at
+RETURNING id, name, aliases, default_unit, created_at, parent_id, category_id, allergens, dietary_tags, free_of, archived_at, version
 `
 
 type UpsertIngredientParams struct {
 	Name        string
-	Aliases     []string
-	Category    sql.NullString
+	CategoryID  uuid.NullUUID
 	DefaultUnit sql.NullString
+	Actor       sql.NullString
+	Reason      sql.NullString
 }
 
+// History records the insert as an auto_create.
 func (q *Queries) UpsertIngredient(ctx context.Context, arg UpsertIngredientParams) (Ingredient, error) {
 	row := q.db.QueryRowContext(ctx, upsertIngredient,
 		arg.Name,
-		pq.Array(arg.Aliases),
-		arg.Category,
+		arg.CategoryID,
 		arg.DefaultUnit,
+		arg.Actor,
+		arg.Reason,
 	)
 	var i Ingredient
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		pq.Array(&i.Aliases),
-		&i.Category,
 		&i.DefaultUnit,
 		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
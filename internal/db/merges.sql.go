@@ -0,0 +1,711 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: merges.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const createIngredientMerge = `-- name: CreateIngredientMerge :one
+INSERT INTO ingredient_merges (winner_id, loser_id, loser_name, snapshot)
+VALUES ($1, $2, $3, $4)
+RETURNING id, winner_id, loser_id, loser_name, snapshot, merged_at, split_at
+`
+
+type CreateIngredientMergeParams struct {
+	WinnerID  uuid.UUID
+	LoserID   uuid.UUID
+	LoserName string
+	Snapshot  json.RawMessage
+}
+
+func (q *Queries) CreateIngredientMerge(ctx context.Context, arg CreateIngredientMergeParams) (IngredientMerge, error) {
+	row := q.db.QueryRowContext(ctx, createIngredientMerge,
+		arg.WinnerID,
+		arg.LoserID,
+		arg.LoserName,
+		arg.Snapshot,
+	)
+	var i IngredientMerge
+	err := row.Scan(
+		&i.ID,
+		&i.WinnerID,
+		&i.LoserID,
+		&i.LoserName,
+		&i.Snapshot,
+		&i.MergedAt,
+		&i.SplitAt,
+	)
+	return i, err
+}
+
+const deleteIngredientRedirect = `-- name: DeleteIngredientRedirect :exec
+DELETE FROM ingredient_redirects WHERE old_id = $1
+`
+
+func (q *Queries) DeleteIngredientRedirect(ctx context.Context, oldID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteIngredientRedirect, oldID)
+	return err
+}
+
+const getLatestOpenIngredientMerge = `-- name: GetLatestOpenIngredientMerge :one
+SELECT id, winner_id, loser_id, loser_name, snapshot, merged_at, split_at FROM ingredient_merges
+WHERE winner_id = $1 AND split_at IS NULL
+ORDER BY merged_at DESC
+LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetLatestOpenIngredientMerge(ctx context.Context, winnerID uuid.UUID) (IngredientMerge, error) {
+	row := q.db.QueryRowContext(ctx, getLatestOpenIngredientMerge, winnerID)
+	var i IngredientMerge
+	err := row.Scan(
+		&i.ID,
+		&i.WinnerID,
+		&i.LoserID,
+		&i.LoserName,
+		&i.Snapshot,
+		&i.MergedAt,
+		&i.SplitAt,
+	)
+	return i, err
+}
+
+const getOpenIngredientMerge = `-- name: GetOpenIngredientMerge :one
+SELECT id, winner_id, loser_id, loser_name, snapshot, merged_at, split_at FROM ingredient_merges
+WHERE winner_id = $1 AND loser_id = $2 AND split_at IS NULL
+FOR UPDATE
+`
+
+type GetOpenIngredientMergeParams struct {
+	WinnerID uuid.UUID
+	LoserID  uuid.UUID
+}
+
+// Returns the unsplit merge of loser_id into winner_id, locking it.
+func (q *Queries) GetOpenIngredientMerge(ctx context.Context, arg GetOpenIngredientMergeParams) (IngredientMerge, error) {
+	row := q.db.QueryRowContext(ctx, getOpenIngredientMerge, arg.WinnerID, arg.LoserID)
+	var i IngredientMerge
+	err := row.Scan(
+		&i.ID,
+		&i.WinnerID,
+		&i.LoserID,
+		&i.LoserName,
+		&i.Snapshot,
+		&i.MergedAt,
+		&i.SplitAt,
+	)
+	return i, err
+}
+
+const insertCompositeComponentSnapshot = `-- name: InsertCompositeComponentSnapshot :execrows
+INSERT INTO composite_substitute_components (id, composite_id, component_id, quantity, unit)
+SELECT $1::uuid, $2::uuid, $3::uuid, $4::float8, $5::text
+WHERE EXISTS (SELECT 1 FROM composite_substitutes WHERE id = $2::uuid)
+ON CONFLICT DO NOTHING
+`
+
+type InsertCompositeComponentSnapshotParams struct {
+	ID          uuid.UUID
+	CompositeID uuid.UUID
+	ComponentID uuid.UUID
+	Quantity    float64
+	Unit        sql.NullString
+}
+
+// Recreates a component row a merge folded away, unless its composite is
+// gone or already lists the component again.
+func (q *Queries) InsertCompositeComponentSnapshot(ctx context.Context, arg InsertCompositeComponentSnapshotParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertCompositeComponentSnapshot,
+		arg.ID,
+		arg.CompositeID,
+		arg.ComponentID,
+		arg.Quantity,
+		arg.Unit,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const insertIngredientAliasSnapshot = `-- name: InsertIngredientAliasSnapshot :execrows
+INSERT INTO ingredient_aliases (id, ingredient_id, alias, source, created_by, locale, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT DO NOTHING
+`
+
+type InsertIngredientAliasSnapshotParams struct {
+	ID           uuid.UUID
+	IngredientID uuid.UUID
+	Alias        string
+	Source       string
+	CreatedBy    sql.NullString
+	Locale       sql.NullString
+	CreatedAt    time.Time
+}
+
+func (q *Queries) InsertIngredientAliasSnapshot(ctx context.Context, arg InsertIngredientAliasSnapshotParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertIngredientAliasSnapshot,
+		arg.ID,
+		arg.IngredientID,
+		arg.Alias,
+		arg.Source,
+		arg.CreatedBy,
+		arg.Locale,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const insertIngredientSnapshot = `-- name: InsertIngredientSnapshot :one
+INSERT INTO ingredients (
+  id, name, aliases, default_unit, created_at, parent_id, category_id,
+  allergens, dietary_tags, archived_at, version, free_of
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id, name, aliases, default_unit, created_at, parent_id, category_id, allergens, dietary_tags, free_of, archived_at, version
+`
+
+type InsertIngredientSnapshotParams struct {
+	ID          uuid.UUID
+	Name        string
+	Aliases     []string
+	DefaultUnit sql.NullString
+	CreatedAt   time.Time
+	ParentID    uuid.NullUUID
+	CategoryID  uuid.NullUUID
+	Allergens   []string
+	DietaryTags []string
+	ArchivedAt  sql.NullTime
+	Version     int64
+	FreeOf      []string
+}
+
+// Recreates an ingredient row exactly as captured, including its ID. The
+// alias rows are restored separately with InsertIngredientAliasSnapshot.
+// Pass a version past the captured one so old ETags no longer match and
+// history stays in order.
+func (q *Queries) InsertIngredientSnapshot(ctx context.Context, arg InsertIngredientSnapshotParams) (Ingredient, error) {
+	row := q.db.QueryRowContext(ctx, insertIngredientSnapshot,
+		arg.ID,
+		arg.Name,
+		pq.Array(arg.Aliases),
+		arg.DefaultUnit,
+		arg.CreatedAt,
+		arg.ParentID,
+		arg.CategoryID,
+		pq.Array(arg.Allergens),
+		pq.Array(arg.DietaryTags),
+		arg.ArchivedAt,
+		arg.Version,
+		pq.Array(arg.FreeOf),
+	)
+	var i Ingredient
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		pq.Array(&i.Aliases),
+		&i.DefaultUnit,
+		&i.CreatedAt,
+		&i.ParentID,
+		&i.CategoryID,
+		pq.Array(&i.Allergens),
+		pq.Array(&i.DietaryTags),
+		pq.Array(&i.FreeOf),
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const insertStorageGuidelineSnapshot = `-- name: InsertStorageGuidelineSnapshot :execrows
+INSERT INTO storage_guidelines (id, ingredient_id, location, unopened_days, opened_days, notes, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT DO NOTHING
+`
+
+type InsertStorageGuidelineSnapshotParams struct {
+	ID           uuid.UUID
+	IngredientID uuid.NullUUID
+	Location     string
+	UnopenedDays sql.NullInt32
+	OpenedDays   sql.NullInt32
+	Notes        sql.NullString
+	UpdatedAt    time.Time
+}
+
+func (q *Queries) InsertStorageGuidelineSnapshot(ctx context.Context, arg InsertStorageGuidelineSnapshotParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertStorageGuidelineSnapshot,
+		arg.ID,
+		arg.IngredientID,
+		arg.Location,
+		arg.UnopenedDays,
+		arg.OpenedDays,
+		arg.Notes,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const insertSubstituteSnapshot = `-- name: InsertSubstituteSnapshot :execrows
+INSERT INTO ingredient_substitutes (id, ingredient_id, substitute_id, ratio, notes, contexts, reasons)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT DO NOTHING
+`
+
+type InsertSubstituteSnapshotParams struct {
+	ID           uuid.UUID
+	IngredientID uuid.UUID
+	SubstituteID uuid.UUID
+	Ratio        float64
+	Notes        sql.NullString
+	Contexts     []string
+	Reasons      []string
+}
+
+func (q *Queries) InsertSubstituteSnapshot(ctx context.Context, arg InsertSubstituteSnapshotParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertSubstituteSnapshot,
+		arg.ID,
+		arg.IngredientID,
+		arg.SubstituteID,
+		arg.Ratio,
+		arg.Notes,
+		pq.Array(arg.Contexts),
+		pq.Array(arg.Reasons),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const insertUnitConversionSnapshot = `-- name: InsertUnitConversionSnapshot :execrows
+INSERT INTO unit_conversions (id, ingredient_id, from_unit, to_unit, factor)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT DO NOTHING
+`
+
+type InsertUnitConversionSnapshotParams struct {
+	ID           uuid.UUID
+	IngredientID uuid.UUID
+	FromUnit     string
+	ToUnit       string
+	Factor       float64
+}
+
+func (q *Queries) InsertUnitConversionSnapshot(ctx context.Context, arg InsertUnitConversionSnapshotParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertUnitConversionSnapshot,
+		arg.ID,
+		arg.IngredientID,
+		arg.FromUnit,
+		arg.ToUnit,
+		arg.Factor,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listCompositeComponentIDsByComponent = `-- name: ListCompositeComponentIDsByComponent :many
+SELECT id FROM composite_substitute_components WHERE component_id = $1
+`
+
+func (q *Queries) ListCompositeComponentIDsByComponent(ctx context.Context, componentID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listCompositeComponentIDsByComponent, componentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCompositeSubstituteIDsByIngredient = `-- name: ListCompositeSubstituteIDsByIngredient :many
+SELECT id FROM composite_substitutes WHERE ingredient_id = $1
+`
+
+func (q *Queries) ListCompositeSubstituteIDsByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listCompositeSubstituteIDsByIngredient, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIngredientMerges = `-- name: ListIngredientMerges :many
+SELECT id, winner_id, loser_id, loser_name, snapshot, merged_at, split_at FROM ingredient_merges WHERE winner_id = $1 ORDER BY merged_at DESC
+`
+
+func (q *Queries) ListIngredientMerges(ctx context.Context, winnerID uuid.UUID) ([]IngredientMerge, error) {
+	rows, err := q.db.QueryContext(ctx, listIngredientMerges, winnerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IngredientMerge
+	for rows.Next() {
+		var i IngredientMerge
+		if err := rows.Scan(
+			&i.ID,
+			&i.WinnerID,
+			&i.LoserID,
+			&i.LoserName,
+			&i.Snapshot,
+			&i.MergedAt,
+			&i.SplitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRedirectsTo = `-- name: ListRedirectsTo :many
+SELECT old_id FROM ingredient_redirects WHERE new_id = $1
+`
+
+func (q *Queries) ListRedirectsTo(ctx context.Context, newID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listRedirectsTo, newID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var old_id uuid.UUID
+		if err := rows.Scan(&old_id); err != nil {
+			return nil, err
+		}
+		items = append(items, old_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubstitutesTouching = `-- name: ListSubstitutesTouching :many
+SELECT id, ingredient_id, substitute_id, ratio, notes, contexts, reasons FROM ingredient_substitutes WHERE ingredient_id = $1 OR substitute_id = $1
+`
+
+func (q *Queries) ListSubstitutesTouching(ctx context.Context, ingredientID uuid.UUID) ([]IngredientSubstitute, error) {
+	rows, err := q.db.QueryContext(ctx, listSubstitutesTouching, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IngredientSubstitute
+	for rows.Next() {
+		var i IngredientSubstitute
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.SubstituteID,
+			&i.Ratio,
+			&i.Notes,
+			pq.Array(&i.Contexts),
+			pq.Array(&i.Reasons),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markIngredientMergeSplit = `-- name: MarkIngredientMergeSplit :exec
+UPDATE ingredient_merges SET split_at = now() WHERE id = $1
+`
+
+func (q *Queries) MarkIngredientMergeSplit(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, markIngredientMergeSplit, id)
+	return err
+}
+
+const moveIngredientAliases = `-- name: MoveIngredientAliases :exec
+UPDATE ingredient_aliases SET ingredient_id = $1::uuid
+WHERE ingredient_id = $2::uuid
+`
+
+type MoveIngredientAliasesParams struct {
+	WinnerID uuid.UUID
+	LoserID  uuid.UUID
+}
+
+// Hands the loser's alias rows, with their metadata, to the winner.
+func (q *Queries) MoveIngredientAliases(ctx context.Context, arg MoveIngredientAliasesParams) error {
+	_, err := q.db.ExecContext(ctx, moveIngredientAliases, arg.WinnerID, arg.LoserID)
+	return err
+}
+
+const restoreCompositeComponent = `-- name: RestoreCompositeComponent :execrows
+UPDATE composite_substitute_components SET component_id = $1::uuid
+WHERE id = $2::uuid AND component_id = $3::uuid
+`
+
+type RestoreCompositeComponentParams struct {
+	LoserID  uuid.UUID
+	ID       uuid.UUID
+	WinnerID uuid.UUID
+}
+
+func (q *Queries) RestoreCompositeComponent(ctx context.Context, arg RestoreCompositeComponentParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreCompositeComponent, arg.LoserID, arg.ID, arg.WinnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreCompositeComponentQuantity = `-- name: RestoreCompositeComponentQuantity :execrows
+UPDATE composite_substitute_components SET quantity = $1
+WHERE id = $2::uuid AND quantity = $3
+`
+
+type RestoreCompositeComponentQuantityParams struct {
+	Before float64
+	ID     uuid.UUID
+	After  float64
+}
+
+func (q *Queries) RestoreCompositeComponentQuantity(ctx context.Context, arg RestoreCompositeComponentQuantityParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreCompositeComponentQuantity, arg.Before, arg.ID, arg.After)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreCompositeSubstituteIngredient = `-- name: RestoreCompositeSubstituteIngredient :execrows
+UPDATE composite_substitutes SET ingredient_id = $1::uuid
+WHERE id = $2::uuid AND ingredient_id = $3::uuid
+`
+
+type RestoreCompositeSubstituteIngredientParams struct {
+	LoserID  uuid.UUID
+	ID       uuid.UUID
+	WinnerID uuid.UUID
+}
+
+func (q *Queries) RestoreCompositeSubstituteIngredient(ctx context.Context, arg RestoreCompositeSubstituteIngredientParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreCompositeSubstituteIngredient, arg.LoserID, arg.ID, arg.WinnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreIngredientNutrition = `-- name: RestoreIngredientNutrition :execrows
+UPDATE ingredient_nutrition SET ingredient_id = $1::uuid
+WHERE ingredient_id = $2::uuid AND updated_at = $3
+`
+
+type RestoreIngredientNutritionParams struct {
+	LoserID   uuid.UUID
+	WinnerID  uuid.UUID
+	UpdatedAt time.Time
+}
+
+// Moves nutrition back to the restored loser if the winner still holds the
+// row the merge moved over, identified by its updated_at.
+func (q *Queries) RestoreIngredientNutrition(ctx context.Context, arg RestoreIngredientNutritionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreIngredientNutrition, arg.LoserID, arg.WinnerID, arg.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreIngredientParent = `-- name: RestoreIngredientParent :execrows
+UPDATE ingredients SET parent_id = $1::uuid
+WHERE id = $2::uuid AND parent_id IS NOT DISTINCT FROM $3
+`
+
+type RestoreIngredientParentParams struct {
+	RestoredID       uuid.UUID
+	ID               uuid.UUID
+	ExpectedParentID uuid.NullUUID
+}
+
+func (q *Queries) RestoreIngredientParent(ctx context.Context, arg RestoreIngredientParentParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreIngredientParent, arg.RestoredID, arg.ID, arg.ExpectedParentID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreIngredientRedirect = `-- name: RestoreIngredientRedirect :execrows
+UPDATE ingredient_redirects SET new_id = $1::uuid
+WHERE old_id = $2::uuid AND new_id = $3::uuid
+`
+
+type RestoreIngredientRedirectParams struct {
+	LoserID  uuid.UUID
+	OldID    uuid.UUID
+	WinnerID uuid.UUID
+}
+
+func (q *Queries) RestoreIngredientRedirect(ctx context.Context, arg RestoreIngredientRedirectParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreIngredientRedirect, arg.LoserID, arg.OldID, arg.WinnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreStorageGuideline = `-- name: RestoreStorageGuideline :execrows
+UPDATE storage_guidelines SET ingredient_id = $1::uuid
+WHERE id = $2::uuid AND ingredient_id = $3::uuid
+`
+
+type RestoreStorageGuidelineParams struct {
+	LoserID  uuid.UUID
+	ID       uuid.UUID
+	WinnerID uuid.UUID
+}
+
+func (q *Queries) RestoreStorageGuideline(ctx context.Context, arg RestoreStorageGuidelineParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreStorageGuideline, arg.LoserID, arg.ID, arg.WinnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreSubstituteIngredient = `-- name: RestoreSubstituteIngredient :execrows
+UPDATE ingredient_substitutes SET ingredient_id = $1::uuid
+WHERE id = $2::uuid AND ingredient_id = $3::uuid
+`
+
+type RestoreSubstituteIngredientParams struct {
+	LoserID  uuid.UUID
+	ID       uuid.UUID
+	WinnerID uuid.UUID
+}
+
+func (q *Queries) RestoreSubstituteIngredient(ctx context.Context, arg RestoreSubstituteIngredientParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreSubstituteIngredient, arg.LoserID, arg.ID, arg.WinnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreSubstituteSubId = `-- name: RestoreSubstituteSubId :execrows
+UPDATE ingredient_substitutes SET substitute_id = $1::uuid
+WHERE id = $2::uuid AND substitute_id = $3::uuid
+`
+
+type RestoreSubstituteSubIdParams struct {
+	LoserID  uuid.UUID
+	ID       uuid.UUID
+	WinnerID uuid.UUID
+}
+
+func (q *Queries) RestoreSubstituteSubId(ctx context.Context, arg RestoreSubstituteSubIdParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreSubstituteSubId, arg.LoserID, arg.ID, arg.WinnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreUnitConversion = `-- name: RestoreUnitConversion :execrows
+UPDATE unit_conversions SET ingredient_id = $1::uuid
+WHERE id = $2::uuid AND ingredient_id = $3::uuid
+`
+
+type RestoreUnitConversionParams struct {
+	LoserID  uuid.UUID
+	ID       uuid.UUID
+	WinnerID uuid.UUID
+}
+
+func (q *Queries) RestoreUnitConversion(ctx context.Context, arg RestoreUnitConversionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreUnitConversion, arg.LoserID, arg.ID, arg.WinnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreUnitConversionFactor = `-- name: RestoreUnitConversionFactor :execrows
+UPDATE unit_conversions SET factor = $1
+WHERE id = $2::uuid AND factor = $3
+`
+
+type RestoreUnitConversionFactorParams struct {
+	Before float64
+	ID     uuid.UUID
+	After  float64
+}
+
+func (q *Queries) RestoreUnitConversionFactor(ctx context.Context, arg RestoreUnitConversionFactorParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreUnitConversionFactor, arg.Before, arg.ID, arg.After)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,4 @@
+ALTER TABLE ingredient_substitutes
+  DROP CONSTRAINT IF EXISTS ingredient_substitutes_ratio_positive,
+  DROP CONSTRAINT IF EXISTS ingredient_substitutes_not_self,
+  DROP CONSTRAINT IF EXISTS ingredient_substitutes_pair_key;
@@ -0,0 +1,12 @@
+DELETE FROM ingredient_substitutes WHERE ingredient_id = substitute_id;
+
+DELETE FROM ingredient_substitutes a
+USING ingredient_substitutes b
+WHERE a.ingredient_id = b.ingredient_id
+  AND a.substitute_id = b.substitute_id
+  AND a.id > b.id;
+
+ALTER TABLE ingredient_substitutes
+  ADD CONSTRAINT ingredient_substitutes_pair_key UNIQUE (ingredient_id, substitute_id),
+  ADD CONSTRAINT ingredient_substitutes_not_self CHECK (ingredient_id <> substitute_id),
+  ADD CONSTRAINT ingredient_substitutes_ratio_positive CHECK (ratio > 0);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS composite_substitute_components;
+DROP TABLE IF EXISTS composite_substitutes;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS composite_substitutes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  ingredient_id UUID NOT NULL REFERENCES ingredients(id) ON DELETE CASCADE,
+  quantity FLOAT8 NOT NULL DEFAULT 1.0 CHECK (quantity > 0),
+  unit TEXT,
+  notes TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS composite_substitute_components (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  composite_id UUID NOT NULL REFERENCES composite_substitutes(id) ON DELETE CASCADE,
+  component_id UUID NOT NULL REFERENCES ingredients(id) ON DELETE CASCADE,
+  quantity FLOAT8 NOT NULL CHECK (quantity > 0),
+  unit TEXT,
+  UNIQUE (composite_id, component_id)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE composite_substitutes
+  DROP COLUMN IF EXISTS reasons,
+  DROP COLUMN IF EXISTS contexts;
+
+ALTER TABLE ingredient_substitutes
+  DROP COLUMN IF EXISTS reasons,
+  DROP COLUMN IF EXISTS contexts;
@@ -0,0 +1,7 @@
+ALTER TABLE ingredient_substitutes
+  ADD COLUMN IF NOT EXISTS contexts TEXT[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS reasons TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE composite_substitutes
+  ADD COLUMN IF NOT EXISTS contexts TEXT[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS reasons TEXT[] NOT NULL DEFAULT '{}';
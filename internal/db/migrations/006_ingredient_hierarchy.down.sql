@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_ingredients_parent_id;
+
+ALTER TABLE ingredients
+  DROP CONSTRAINT IF EXISTS ingredients_parent_not_self,
+  DROP COLUMN IF EXISTS parent_id;
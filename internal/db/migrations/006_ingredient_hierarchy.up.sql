@@ -0,0 +1,5 @@
+ALTER TABLE ingredients
+  ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES ingredients(id) ON DELETE SET NULL,
+  ADD CONSTRAINT ingredients_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_ingredients_parent_id ON ingredients(parent_id);
@@ -0,0 +1,11 @@
+ALTER TABLE ingredients ADD COLUMN IF NOT EXISTS category TEXT;
+
+UPDATE ingredients i
+SET category = c.slug
+FROM categories c
+WHERE c.id = i.category_id;
+
+DROP INDEX IF EXISTS idx_ingredients_category_id;
+ALTER TABLE ingredients DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS categories;
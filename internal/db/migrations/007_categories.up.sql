@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS categories (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  slug TEXT NOT NULL UNIQUE,
+  display_name TEXT NOT NULL,
+  parent_id UUID REFERENCES categories(id) ON DELETE RESTRICT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT categories_parent_not_self CHECK (parent_id <> id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
+
+ALTER TABLE ingredients
+  ADD COLUMN IF NOT EXISTS category_id UUID REFERENCES categories(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_ingredients_category_id ON ingredients(category_id);
+
+-- Map existing free-text categories onto slugs. Values differing only in
+-- case or punctuation ("Dairy", "dairy ") share a slug and so a category.
+INSERT INTO categories (slug, display_name)
+SELECT DISTINCT ON (slug) slug, display_name
+FROM (
+  SELECT
+    trim(both '-' FROM regexp_replace(lower(category), '[^a-z0-9]+', '-', 'g')) AS slug,
+    initcap(trim(category)) AS display_name
+  FROM ingredients
+  WHERE category IS NOT NULL
+) c
+WHERE slug <> ''
+ORDER BY slug, display_name
+ON CONFLICT (slug) DO NOTHING;
+
+UPDATE ingredients i
+SET category_id = c.id
+FROM categories c
+WHERE c.slug = trim(both '-' FROM regexp_replace(lower(i.category), '[^a-z0-9]+', '-', 'g'));
+
+ALTER TABLE ingredients DROP COLUMN IF EXISTS category;
@@ -0,0 +1,4 @@
+ALTER TABLE ingredients
+  DROP COLUMN IF EXISTS free_of,
+  DROP COLUMN IF EXISTS dietary_tags,
+  DROP COLUMN IF EXISTS allergens;
@@ -0,0 +1,7 @@
+-- free_of lists allergens an ingredient is explicitly free of. They cancel
+-- the same allergens inherited from its ancestors, so "gluten-free bread"
+-- under "bread" does not carry gluten.
+ALTER TABLE ingredients
+  ADD COLUMN IF NOT EXISTS allergens TEXT[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS dietary_tags TEXT[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS free_of TEXT[] NOT NULL DEFAULT '{}';
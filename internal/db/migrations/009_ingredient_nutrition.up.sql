@@ -0,0 +1,21 @@
+-- Nutrition facts per 100 g of the ingredient. Nutrients missing from the
+-- source dataset are NULL rather than zero.
+CREATE TABLE IF NOT EXISTS ingredient_nutrition (
+  ingredient_id UUID PRIMARY KEY REFERENCES ingredients(id) ON DELETE CASCADE,
+  source TEXT NOT NULL,
+  source_id TEXT,
+  source_name TEXT NOT NULL,
+  energy_kcal DOUBLE PRECISION,
+  protein_g DOUBLE PRECISION,
+  fat_g DOUBLE PRECISION,
+  saturated_fat_g DOUBLE PRECISION,
+  carbohydrate_g DOUBLE PRECISION,
+  sugars_g DOUBLE PRECISION,
+  fiber_g DOUBLE PRECISION,
+  sodium_mg DOUBLE PRECISION,
+  potassium_mg DOUBLE PRECISION,
+  calcium_mg DOUBLE PRECISION,
+  iron_mg DOUBLE PRECISION,
+  vitamin_c_mg DOUBLE PRECISION,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
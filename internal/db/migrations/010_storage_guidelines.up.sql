@@ -0,0 +1,16 @@
+-- Storage guidance for an ingredient or, as a fallback for its ingredients,
+-- a category. One row per storage location; shelf lives are in days.
+CREATE TABLE IF NOT EXISTS storage_guidelines (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  ingredient_id UUID REFERENCES ingredients(id) ON DELETE CASCADE,
+  category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+  location TEXT NOT NULL CHECK (location IN ('pantry', 'fridge', 'freezer')),
+  unopened_days INTEGER CHECK (unopened_days > 0),
+  opened_days INTEGER CHECK (opened_days > 0),
+  notes TEXT,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT storage_guidelines_one_owner CHECK ((ingredient_id IS NULL) <> (category_id IS NULL)),
+  CONSTRAINT storage_guidelines_has_shelf_life CHECK (unopened_days IS NOT NULL OR opened_days IS NOT NULL),
+  UNIQUE (ingredient_id, location),
+  UNIQUE (category_id, location)
+);
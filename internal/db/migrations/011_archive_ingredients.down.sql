@@ -0,0 +1 @@
+ALTER TABLE ingredients DROP COLUMN IF EXISTS archived_at;
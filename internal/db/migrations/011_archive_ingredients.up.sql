@@ -0,0 +1 @@
+ALTER TABLE ingredients ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
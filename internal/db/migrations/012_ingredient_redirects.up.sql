@@ -0,0 +1,9 @@
+-- Tombstones for merged-away ingredient IDs. Chains are flattened on merge,
+-- so new_id is always a live ingredient.
+CREATE TABLE IF NOT EXISTS ingredient_redirects (
+  old_id UUID PRIMARY KEY,
+  new_id UUID NOT NULL REFERENCES ingredients(id) ON DELETE CASCADE,
+  merged_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingredient_redirects_new_id ON ingredient_redirects(new_id);
@@ -0,0 +1,15 @@
+-- One row per merge. snapshot holds what Split needs to undo it: the loser
+-- row, the rows it owned and what the merge changed on the winner. winner_id
+-- has no foreign key so history survives the winner being merged away.
+CREATE TABLE IF NOT EXISTS ingredient_merges (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  winner_id UUID NOT NULL,
+  loser_id UUID NOT NULL,
+  loser_name TEXT NOT NULL,
+  snapshot JSONB NOT NULL,
+  merged_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  split_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingredient_merges_winner_id ON ingredient_merges(winner_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ingredient_merges_open_loser ON ingredient_merges(loser_id) WHERE split_at IS NULL;
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS ingredients_term_unique ON ingredients;
+DROP TABLE IF EXISTS ingredient_aliases;
+DROP FUNCTION IF EXISTS check_ingredient_alias_term();
+DROP FUNCTION IF EXISTS check_ingredient_name_term();
+DROP FUNCTION IF EXISTS check_ingredient_term(TEXT);
+DROP FUNCTION IF EXISTS normalize_term(TEXT);
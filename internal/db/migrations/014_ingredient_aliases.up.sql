@@ -0,0 +1,99 @@
+-- Aliases live in their own table so that each normalized alias belongs to
+-- one ingredient only. ingredients.aliases stays as a read copy; the queries
+-- that write it write the alias rows in the same statement.
+CREATE TABLE IF NOT EXISTS ingredient_aliases (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  ingredient_id UUID NOT NULL REFERENCES ingredients(id) ON DELETE CASCADE,
+  alias TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingredient_aliases_ingredient_id ON ingredient_aliases(ingredient_id);
+
+-- normalize_term mirrors service.Normalize: it lowercases and trims the
+-- whitespace Go's unicode.IsSpace reports, not just ASCII spaces. lower()
+-- follows the database collation, which must be a UTF-8 locale for
+-- non-ASCII letters to fold as they do in Go.
+CREATE OR REPLACE FUNCTION normalize_term(term TEXT) RETURNS TEXT AS $$
+  SELECT lower(regexp_replace(
+    term,
+    '^[\s\u0085\u00a0\u1680\u2000-\u200a\u2028\u2029\u202f\u205f\u3000]+|[\s\u0085\u00a0\u1680\u2000-\u200a\u2028\u2029\u202f\u205f\u3000]+$',
+    '', 'g'
+  ));
+$$ LANGUAGE sql IMMUTABLE STRICT;
+
+-- Normalize existing aliases. An alias held by several ingredients goes to
+-- the oldest; one equal to an ingredient name is dropped.
+INSERT INTO ingredient_aliases (ingredient_id, alias)
+SELECT DISTINCT ON (a.alias) a.ingredient_id, a.alias
+FROM (
+  SELECT i.id AS ingredient_id, normalize_term(x.alias) AS alias, i.created_at
+  FROM ingredients i, unnest(i.aliases) AS x(alias)
+) a
+WHERE a.alias <> ''
+  AND NOT EXISTS (SELECT 1 FROM ingredients n WHERE n.name = a.alias)
+ORDER BY a.alias, a.created_at, a.ingredient_id
+ON CONFLICT (alias) DO NOTHING;
+
+-- Rewrite the read copy from the table, keeping each alias's first position.
+UPDATE ingredients i
+SET aliases = ARRAY(
+  SELECT k.alias
+  FROM (
+    SELECT normalize_term(x.alias) AS alias, min(x.n) AS n
+    FROM unnest(i.aliases) WITH ORDINALITY AS x(alias, n)
+    GROUP BY 1
+  ) k
+  JOIN ingredient_aliases ia ON ia.alias = k.alias AND ia.ingredient_id = i.id
+  ORDER BY k.n
+);
+
+-- Names and aliases share one namespace: a term may be held by one
+-- ingredient only, as its name or an alias. The unique constraints cover
+-- each column on its own; these triggers cover one ingredient's alias
+-- matching another's name. They run at commit, after a merge or split has
+-- moved a name between the two, and take a lock on the term first so two
+-- transactions claiming it cannot both pass the check.
+CREATE OR REPLACE FUNCTION check_ingredient_term(term TEXT) RETURNS void AS $$
+BEGIN
+  PERFORM pg_advisory_xact_lock(hashtext('ingredient_term:' || term));
+  IF (
+    SELECT count(DISTINCT t.id) FROM (
+      SELECT ingredient_id AS id FROM ingredient_aliases WHERE alias = term
+      UNION ALL
+      SELECT id FROM ingredients WHERE name = term
+    ) t
+  ) > 1 THEN
+    RAISE EXCEPTION 'term "%" is held by more than one ingredient', term
+      USING ERRCODE = 'unique_violation';
+  END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION check_ingredient_name_term() RETURNS trigger AS $$
+BEGIN
+  PERFORM check_ingredient_term(NEW.name);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION check_ingredient_alias_term() RETURNS trigger AS $$
+BEGIN
+  PERFORM check_ingredient_term(NEW.alias);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ingredients_term_unique ON ingredients;
+CREATE CONSTRAINT TRIGGER ingredients_term_unique
+  AFTER INSERT OR UPDATE OF name ON ingredients
+  DEFERRABLE INITIALLY DEFERRED
+  FOR EACH ROW
+  EXECUTE FUNCTION check_ingredient_name_term();
+
+DROP TRIGGER IF EXISTS ingredient_aliases_term_unique ON ingredient_aliases;
+CREATE CONSTRAINT TRIGGER ingredient_aliases_term_unique
+  AFTER INSERT OR UPDATE OF alias ON ingredient_aliases
+  DEFERRABLE INITIALLY DEFERRED
+  FOR EACH ROW
+  EXECUTE FUNCTION check_ingredient_alias_term();
@@ -0,0 +1,4 @@
+ALTER TABLE ingredient_aliases
+  DROP COLUMN IF EXISTS locale,
+  DROP COLUMN IF EXISTS created_by,
+  DROP COLUMN IF EXISTS source;
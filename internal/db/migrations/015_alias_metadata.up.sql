@@ -0,0 +1,12 @@
+-- Where each alias came from and who added it. Aliases that predate this
+-- are taken as manual, except a merge loser's name on its winner.
+ALTER TABLE ingredient_aliases
+  ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'manual'
+    CONSTRAINT ingredient_aliases_source_check CHECK (source IN ('manual', 'merge', 'learned', 'import')),
+  ADD COLUMN IF NOT EXISTS created_by TEXT,
+  ADD COLUMN IF NOT EXISTS locale TEXT;
+
+UPDATE ingredient_aliases ia
+SET source = 'merge'
+FROM ingredient_merges m
+WHERE m.winner_id = ia.ingredient_id AND m.loser_name = ia.alias AND m.split_at IS NULL;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS ingredients_bump_version ON ingredients;
+DROP FUNCTION IF EXISTS bump_ingredient_version();
+ALTER TABLE ingredients DROP COLUMN IF EXISTS version;
@@ -0,0 +1,18 @@
+-- A version number for optimistic concurrency. The trigger bumps it on any
+-- update that changes the row, including ones made by cascades, so every
+-- write invalidates an ETag built from it.
+ALTER TABLE ingredients ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION bump_ingredient_version() RETURNS trigger AS $$
+BEGIN
+  NEW.version := OLD.version + 1;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ingredients_bump_version ON ingredients;
+CREATE TRIGGER ingredients_bump_version
+  BEFORE UPDATE ON ingredients
+  FOR EACH ROW
+  WHEN (OLD.* IS DISTINCT FROM NEW.*)
+  EXECUTE FUNCTION bump_ingredient_version();
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS ingredients_history_update ON ingredients;
+DROP TRIGGER IF EXISTS ingredients_history_insert_delete ON ingredients;
+DROP FUNCTION IF EXISTS record_ingredient_history();
+DROP FUNCTION IF EXISTS set_ingredient_change(TEXT, TEXT, TEXT);
+DROP TABLE IF EXISTS ingredient_history;
@@ -0,0 +1,77 @@
+-- One row per ingredient version: the row as that write left it, or as it
+-- was when deleted. Triggers record every write, so the history has no gaps,
+-- with the operation, actor and reason the writer set through
+-- set_ingredient_change. ingredient_id has no foreign key so history
+-- outlives the row.
+CREATE TABLE IF NOT EXISTS ingredient_history (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  ingredient_id UUID NOT NULL,
+  version BIGINT NOT NULL,
+  operation TEXT NOT NULL,
+  actor TEXT,
+  reason TEXT,
+  snapshot JSONB NOT NULL,
+  deleted BOOLEAN NOT NULL DEFAULT false,
+  recorded_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (ingredient_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingredient_history_recorded_at ON ingredient_history(recorded_at);
+
+-- set_ingredient_change labels the writes of the current transaction for
+-- the history trigger. A null argument keeps what an earlier call in the
+-- transaction set. Single-statement writes call it from a CTE.
+CREATE OR REPLACE FUNCTION set_ingredient_change(operation TEXT, actor TEXT, reason TEXT) RETURNS BOOLEAN AS $$
+BEGIN
+  PERFORM set_config('ingredients.change_operation', COALESCE(operation, current_setting('ingredients.change_operation', true), ''), true);
+  PERFORM set_config('ingredients.change_actor', COALESCE(actor, current_setting('ingredients.change_actor', true), ''), true);
+  PERFORM set_config('ingredients.change_reason', COALESCE(reason, current_setting('ingredients.change_reason', true), ''), true);
+  RETURN true;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Unlabelled writes are recorded as create, update or delete. A deletion
+-- takes the version after the last one it removes. Entries are dated by
+-- clock_timestamp() rather than the transaction start, so a version written
+-- by a long transaction is never dated before the one it replaced.
+CREATE OR REPLACE FUNCTION record_ingredient_history() RETURNS trigger AS $$
+DECLARE
+  op TEXT := NULLIF(current_setting('ingredients.change_operation', true), '');
+  actor TEXT := NULLIF(current_setting('ingredients.change_actor', true), '');
+  reason TEXT := NULLIF(current_setting('ingredients.change_reason', true), '');
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    INSERT INTO ingredient_history (ingredient_id, version, operation, actor, reason, snapshot, deleted, recorded_at)
+    VALUES (OLD.id, OLD.version + 1, COALESCE(op, 'delete'), actor, reason, to_jsonb(OLD), true, clock_timestamp());
+    RETURN OLD;
+  END IF;
+  INSERT INTO ingredient_history (ingredient_id, version, operation, actor, reason, snapshot, recorded_at)
+  VALUES (
+    NEW.id, NEW.version,
+    COALESCE(op, CASE TG_OP WHEN 'INSERT' THEN 'create' ELSE 'update' END),
+    actor, reason, to_jsonb(NEW), clock_timestamp()
+  );
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ingredients_history_insert_delete ON ingredients;
+CREATE TRIGGER ingredients_history_insert_delete
+  AFTER INSERT OR DELETE ON ingredients
+  FOR EACH ROW
+  EXECUTE FUNCTION record_ingredient_history();
+
+-- Updates that change nothing keep their version and record nothing.
+DROP TRIGGER IF EXISTS ingredients_history_update ON ingredients;
+CREATE TRIGGER ingredients_history_update
+  AFTER UPDATE ON ingredients
+  FOR EACH ROW
+  WHEN (OLD.version IS DISTINCT FROM NEW.version)
+  EXECUTE FUNCTION record_ingredient_history();
+
+-- History starts here: existing ingredients get a baseline entry with their
+-- current state, dated at their creation.
+INSERT INTO ingredient_history (ingredient_id, version, operation, snapshot, recorded_at)
+SELECT i.id, i.version, 'baseline', to_jsonb(i), i.created_at
+FROM ingredients i
+ON CONFLICT (ingredient_id, version) DO NOTHING;
@@ -6,18 +6,110 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type Category struct {
+	ID          uuid.UUID
+	Slug        string
+	DisplayName string
+	ParentID    uuid.NullUUID
+	CreatedAt   time.Time
+}
+
+type CompositeSubstitute struct {
+	ID           uuid.UUID
+	IngredientID uuid.UUID
+	Quantity     float64
+	Unit         sql.NullString
+	Notes        sql.NullString
+	CreatedAt    time.Time
+	Contexts     []string
+	Reasons      []string
+}
+
+type CompositeSubstituteComponent struct {
+	ID          uuid.UUID
+	CompositeID uuid.UUID
+	ComponentID uuid.UUID
+	Quantity    float64
+	Unit        sql.NullString
+}
+
 type Ingredient struct {
 	ID          uuid.UUID
 	Name        string
 	Aliases     []string
-	Category    sql.NullString
 	DefaultUnit sql.NullString
 	CreatedAt   time.Time
+	ParentID    uuid.NullUUID
+	CategoryID  uuid.NullUUID
+	Allergens   []string
+	DietaryTags []string
+	FreeOf      []string
+	ArchivedAt  sql.NullTime
+	Version     int64
+}
+
+type IngredientAlias struct {
+	ID           uuid.UUID
+	IngredientID uuid.UUID
+	Alias        string
+	CreatedAt    time.Time
+	Source       string
+	CreatedBy    sql.NullString
+	Locale       sql.NullString
+}
+
+type IngredientHistory struct {
+	ID           uuid.UUID
+	IngredientID uuid.UUID
+	Version      int64
+	Operation    string
+	Actor        sql.NullString
+	Reason       sql.NullString
+	Snapshot     json.RawMessage
+	Deleted      bool
+	RecordedAt   time.Time
+}
+
+type IngredientMerge struct {
+	ID        uuid.UUID
+	WinnerID  uuid.UUID
+	LoserID   uuid.UUID
+	LoserName string
+	Snapshot  json.RawMessage
+	MergedAt  time.Time
+	SplitAt   sql.NullTime
+}
+
+type IngredientNutrition struct {
+	IngredientID  uuid.UUID
+	Source        string
+	SourceID      sql.NullString
+	SourceName    string
+	EnergyKcal    sql.NullFloat64
+	ProteinG      sql.NullFloat64
+	FatG          sql.NullFloat64
+	SaturatedFatG sql.NullFloat64
+	CarbohydrateG sql.NullFloat64
+	SugarsG       sql.NullFloat64
+	FiberG        sql.NullFloat64
+	SodiumMg      sql.NullFloat64
+	PotassiumMg   sql.NullFloat64
+	CalciumMg     sql.NullFloat64
+	IronMg        sql.NullFloat64
+	VitaminCMg    sql.NullFloat64
+	UpdatedAt     time.Time
+}
+
+type IngredientRedirect struct {
+	OldID    uuid.UUID
+	NewID    uuid.UUID
+	MergedAt time.Time
 }
 
 type IngredientSubstitute struct {
@@ -26,6 +118,19 @@ type IngredientSubstitute struct {
 	SubstituteID uuid.UUID
 	Ratio        float64
 	Notes        sql.NullString
+	Contexts     []string
+	Reasons      []string
+}
+
+type StorageGuideline struct {
+	ID           uuid.UUID
+	IngredientID uuid.NullUUID
+	CategoryID   uuid.NullUUID
+	Location     string
+	UnopenedDays sql.NullInt32
+	OpenedDays   sql.NullInt32
+	Notes        sql.NullString
+	UpdatedAt    time.Time
 }
 
 type UnitConversion struct {
@@ -0,0 +1,148 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: nutrition.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const getIngredientNutrition = `-- name: GetIngredientNutrition :one
+SELECT ingredient_id, source, source_id, source_name, energy_kcal, protein_g, fat_g, saturated_fat_g, carbohydrate_g, sugars_g, fiber_g, sodium_mg, potassium_mg, calcium_mg, iron_mg, vitamin_c_mg, updated_at FROM ingredient_nutrition WHERE ingredient_id = $1
+`
+
+func (q *Queries) GetIngredientNutrition(ctx context.Context, ingredientID uuid.UUID) (IngredientNutrition, error) {
+	row := q.db.QueryRowContext(ctx, getIngredientNutrition, ingredientID)
+	var i IngredientNutrition
+	err := row.Scan(
+		&i.IngredientID,
+		&i.Source,
+		&i.SourceID,
+		&i.SourceName,
+		&i.EnergyKcal,
+		&i.ProteinG,
+		&i.FatG,
+		&i.SaturatedFatG,
+		&i.CarbohydrateG,
+		&i.SugarsG,
+		&i.FiberG,
+		&i.SodiumMg,
+		&i.PotassiumMg,
+		&i.CalciumMg,
+		&i.IronMg,
+		&i.VitaminCMg,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const moveNutritionToWinner = `-- name: MoveNutritionToWinner :exec
+UPDATE ingredient_nutrition SET ingredient_id = $1
+WHERE ingredient_nutrition.ingredient_id = $2
+  AND NOT EXISTS (SELECT 1 FROM ingredient_nutrition n WHERE n.ingredient_id = $1)
+`
+
+type MoveNutritionToWinnerParams struct {
+	WinnerID uuid.UUID
+	LoserID  uuid.UUID
+}
+
+// Moves the loser's nutrition row to the winner during a merge, unless the
+// winner already has its own.
+func (q *Queries) MoveNutritionToWinner(ctx context.Context, arg MoveNutritionToWinnerParams) error {
+	_, err := q.db.ExecContext(ctx, moveNutritionToWinner, arg.WinnerID, arg.LoserID)
+	return err
+}
+
+const upsertIngredientNutrition = `-- name: UpsertIngredientNutrition :one
+INSERT INTO ingredient_nutrition (
+  ingredient_id, source, source_id, source_name,
+  energy_kcal, protein_g, fat_g, saturated_fat_g, carbohydrate_g, sugars_g,
+  fiber_g, sodium_mg, potassium_mg, calcium_mg, iron_mg, vitamin_c_mg
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+ON CONFLICT (ingredient_id) DO UPDATE SET
+  source = EXCLUDED.source,
+  source_id = EXCLUDED.source_id,
+  source_name = EXCLUDED.source_name,
+  energy_kcal = EXCLUDED.energy_kcal,
+  protein_g = EXCLUDED.protein_g,
+  fat_g = EXCLUDED.fat_g,
+  saturated_fat_g = EXCLUDED.saturated_fat_g,
+  carbohydrate_g = EXCLUDED.carbohydrate_g,
+  sugars_g = EXCLUDED.sugars_g,
+  fiber_g = EXCLUDED.fiber_g,
+  sodium_mg = EXCLUDED.sodium_mg,
+  potassium_mg = EXCLUDED.potassium_mg,
+  calcium_mg = EXCLUDED.calcium_mg,
+  iron_mg = EXCLUDED.iron_mg,
+  vitamin_c_mg = EXCLUDED.vitamin_c_mg,
+  updated_at = now()
+RETURNING ingredient_id, source, source_id, source_name, energy_kcal, protein_g, fat_g, saturated_fat_g, carbohydrate_g, sugars_g, fiber_g, sodium_mg, potassium_mg, calcium_mg, iron_mg, vitamin_c_mg, updated_at
+`
+
+type UpsertIngredientNutritionParams struct {
+	IngredientID  uuid.UUID
+	Source        string
+	SourceID      sql.NullString
+	SourceName    string
+	EnergyKcal    sql.NullFloat64
+	ProteinG      sql.NullFloat64
+	FatG          sql.NullFloat64
+	SaturatedFatG sql.NullFloat64
+	CarbohydrateG sql.NullFloat64
+	SugarsG       sql.NullFloat64
+	FiberG        sql.NullFloat64
+	SodiumMg      sql.NullFloat64
+	PotassiumMg   sql.NullFloat64
+	CalciumMg     sql.NullFloat64
+	IronMg        sql.NullFloat64
+	VitaminCMg    sql.NullFloat64
+}
+
+func (q *Queries) UpsertIngredientNutrition(ctx context.Context, arg UpsertIngredientNutritionParams) (IngredientNutrition, error) {
+	row := q.db.QueryRowContext(ctx, upsertIngredientNutrition,
+		arg.IngredientID,
+		arg.Source,
+		arg.SourceID,
+		arg.SourceName,
+		arg.EnergyKcal,
+		arg.ProteinG,
+		arg.FatG,
+		arg.SaturatedFatG,
+		arg.CarbohydrateG,
+		arg.SugarsG,
+		arg.FiberG,
+		arg.SodiumMg,
+		arg.PotassiumMg,
+		arg.CalciumMg,
+		arg.IronMg,
+		arg.VitaminCMg,
+	)
+	var i IngredientNutrition
+	err := row.Scan(
+		&i.IngredientID,
+		&i.Source,
+		&i.SourceID,
+		&i.SourceName,
+		&i.EnergyKcal,
+		&i.ProteinG,
+		&i.FatG,
+		&i.SaturatedFatG,
+		&i.CarbohydrateG,
+		&i.SugarsG,
+		&i.FiberG,
+		&i.SodiumMg,
+		&i.PotassiumMg,
+		&i.CalciumMg,
+		&i.IronMg,
+		&i.VitaminCMg,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -6,26 +6,180 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	// Returns no row if the alias is already taken, by this ingredient or
+	// another.
+	AddIngredientAlias(ctx context.Context, arg AddIngredientAliasParams) (IngredientAlias, error)
+	// Archiving an already archived ingredient keeps its original archived_at.
+	// History records the update with actor and reason.
+	ArchiveIngredient(ctx context.Context, arg ArchiveIngredientParams) (Ingredient, error)
+	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateCompositeComponent(ctx context.Context, arg CreateCompositeComponentParams) (CompositeSubstituteComponent, error)
+	CreateCompositeSubstitute(ctx context.Context, arg CreateCompositeSubstituteParams) (CompositeSubstitute, error)
+	// The aliases are written to ingredient_aliases in the same statement, so an
+	// alias another ingredient holds fails the insert with a unique violation.
+	// New alias rows get alias_source, or manual when it is null. History
+	// records created_by as the actor.
 	CreateIngredient(ctx context.Context, arg CreateIngredientParams) (Ingredient, error)
+	CreateIngredientMerge(ctx context.Context, arg CreateIngredientMergeParams) (IngredientMerge, error)
+	CreateIngredientRedirect(ctx context.Context, arg CreateIngredientRedirectParams) error
 	CreateSubstitute(ctx context.Context, arg CreateSubstituteParams) (IngredientSubstitute, error)
 	CreateUnitConversion(ctx context.Context, arg CreateUnitConversionParams) (UnitConversion, error)
+	// History records the deletion with actor and reason.
+	DeleteArchivedIngredient(ctx context.Context, arg DeleteArchivedIngredientParams) (int64, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteCategoryStorageGuidelines(ctx context.Context, categoryID uuid.UUID) error
+	DeleteCompositeComponent(ctx context.Context, id uuid.UUID) error
+	DeleteCompositeSubstitute(ctx context.Context, arg DeleteCompositeSubstituteParams) (int64, error)
 	DeleteIngredient(ctx context.Context, id uuid.UUID) error
+	DeleteIngredientAlias(ctx context.Context, arg DeleteIngredientAliasParams) (int64, error)
+	DeleteIngredientRedirect(ctx context.Context, oldID uuid.UUID) error
+	DeleteIngredientStorageGuidelines(ctx context.Context, ingredientID uuid.UUID) error
+	// Removes loser substitute rows that would duplicate a winner row or become
+	// self-substitutions once re-pointed from loser to winner.
+	DeleteRedundantMergeSubstitutes(ctx context.Context, arg DeleteRedundantMergeSubstitutesParams) error
+	// Removes composites of an ingredient that list the ingredient itself as a
+	// component, which a merge can produce.
+	DeleteSelfReferencingComposites(ctx context.Context, ingredientID uuid.UUID) error
+	DeleteSubstitute(ctx context.Context, arg DeleteSubstituteParams) (int64, error)
 	DeleteSubstitutesByIngredient(ctx context.Context, ingredientID uuid.UUID) error
+	// Removes composites among the given IDs that a merge left with fewer than
+	// two components.
+	DeleteUndersizedComposites(ctx context.Context, ids []uuid.UUID) ([]CompositeSubstitute, error)
+	DeleteUnitConversion(ctx context.Context, id uuid.UUID) error
+	GetCategory(ctx context.Context, id uuid.UUID) (Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (Category, error)
 	GetIngredient(ctx context.Context, id uuid.UUID) (Ingredient, error)
+	GetIngredientAlias(ctx context.Context, alias string) (IngredientAlias, error)
 	GetIngredientByName(ctx context.Context, name string) (Ingredient, error)
+	// Locks the row until the end of the transaction.
+	GetIngredientForUpdate(ctx context.Context, id uuid.UUID) (Ingredient, error)
+	// The latest entry for the ingredient recorded at or before as_of.
+	GetIngredientHistoryAsOf(ctx context.Context, arg GetIngredientHistoryAsOfParams) (IngredientHistory, error)
+	GetIngredientNutrition(ctx context.Context, ingredientID uuid.UUID) (IngredientNutrition, error)
+	GetIngredientRedirect(ctx context.Context, oldID uuid.UUID) (IngredientRedirect, error)
+	GetLatestOpenIngredientMerge(ctx context.Context, winnerID uuid.UUID) (IngredientMerge, error)
+	// Returns the unsplit merge of loser_id into winner_id, locking it.
+	GetOpenIngredientMerge(ctx context.Context, arg GetOpenIngredientMergeParams) (IngredientMerge, error)
+	GetSubstitute(ctx context.Context, arg GetSubstituteParams) (IngredientSubstitute, error)
+	// Recreates a component row a merge folded away, unless its composite is
+	// gone or already lists the component again.
+	InsertCompositeComponentSnapshot(ctx context.Context, arg InsertCompositeComponentSnapshotParams) (int64, error)
+	InsertIngredientAliasSnapshot(ctx context.Context, arg InsertIngredientAliasSnapshotParams) (int64, error)
+	// Recreates an ingredient row exactly as captured, including its ID. The
+	// alias rows are restored separately with InsertIngredientAliasSnapshot.
+	// Pass a version past the captured one so old ETags no longer match and
+	// history stays in order.
+	InsertIngredientSnapshot(ctx context.Context, arg InsertIngredientSnapshotParams) (Ingredient, error)
+	InsertStorageGuidelineSnapshot(ctx context.Context, arg InsertStorageGuidelineSnapshotParams) (int64, error)
+	InsertSubstituteSnapshot(ctx context.Context, arg InsertSubstituteSnapshotParams) (int64, error)
+	InsertUnitConversionSnapshot(ctx context.Context, arg InsertUnitConversionSnapshotParams) (int64, error)
+	ListAllSubstitutes(ctx context.Context) ([]IngredientSubstitute, error)
+	ListCategories(ctx context.Context) ([]Category, error)
+	ListCompositeComponentIDsByComponent(ctx context.Context, componentID uuid.UUID) ([]uuid.UUID, error)
+	ListCompositeComponentsByComponent(ctx context.Context, componentID uuid.UUID) ([]CompositeSubstituteComponent, error)
+	ListCompositeComponentsByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]ListCompositeComponentsByIngredientRow, error)
+	ListCompositeSubstituteIDsByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]uuid.UUID, error)
+	// Filters behave as in ListSubstitutesWithIngredient.
+	ListCompositeSubstitutesByIngredient(ctx context.Context, arg ListCompositeSubstitutesByIngredientParams) ([]CompositeSubstitute, error)
+	// Returns an ingredient's aliases in the order of its aliases column.
+	ListIngredientAliases(ctx context.Context, ingredientID uuid.UUID) ([]IngredientAlias, error)
+	// Returns the ancestors of an ingredient, nearest first. depth is 1 for the
+	// parent. The depth guard only matters if a cycle slipped past the service.
+	ListIngredientAncestors(ctx context.Context, id uuid.UUID) ([]ListIngredientAncestorsRow, error)
+	ListIngredientChildren(ctx context.Context, parentID uuid.UUID) ([]Ingredient, error)
+	// Returns the descendants of an ingredient up to max_depth levels below it,
+	// ordered by depth then name. depth is 1 for direct children.
+	ListIngredientDescendants(ctx context.Context, arg ListIngredientDescendantsParams) ([]ListIngredientDescendantsRow, error)
+	ListIngredientHistory(ctx context.Context, ingredientID uuid.UUID) ([]IngredientHistory, error)
+	// The latest entry per ingredient recorded at or before as_of, including
+	// deletions.
+	ListIngredientHistoryAsOf(ctx context.Context, asOf time.Time) ([]IngredientHistory, error)
+	ListIngredientIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	ListIngredientMerges(ctx context.Context, winnerID uuid.UUID) ([]IngredientMerge, error)
+	ListIngredientRedirects(ctx context.Context, oldIds []uuid.UUID) ([]IngredientRedirect, error)
 	ListIngredients(ctx context.Context) ([]Ingredient, error)
+	ListRedirectsTo(ctx context.Context, newID uuid.UUID) ([]uuid.UUID, error)
+	ListStorageGuidelinesByCategory(ctx context.Context, categoryID uuid.UUID) ([]StorageGuideline, error)
+	ListStorageGuidelinesByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]StorageGuideline, error)
 	ListSubstitutesByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]IngredientSubstitute, error)
+	ListSubstitutesTouching(ctx context.Context, ingredientID uuid.UUID) ([]IngredientSubstitute, error)
+	// An empty context or reason matches everything. Substitutes without any
+	// context apply in every context; a reason filter requires the tag.
+	ListSubstitutesWithIngredient(ctx context.Context, arg ListSubstitutesWithIngredientParams) ([]ListSubstitutesWithIngredientRow, error)
+	// Returns the ingredients that hold any of terms as their name or as an
+	// alias. is_name tells which.
+	ListTermOwners(ctx context.Context, terms []string) ([]ListTermOwnersRow, error)
+	ListUnitConversions(ctx context.Context) ([]UnitConversion, error)
 	ListUnitConversionsByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]UnitConversion, error)
+	// Serializes parent changes for the rest of the transaction so that two
+	// concurrent re-parents cannot together form a cycle.
+	LockIngredientHierarchy(ctx context.Context) error
+	MarkIngredientMergeSplit(ctx context.Context, id uuid.UUID) error
+	// Hands the loser's alias rows, with their metadata, to the winner.
+	MoveIngredientAliases(ctx context.Context, arg MoveIngredientAliasesParams) error
+	// Moves the loser's nutrition row to the winner during a merge, unless the
+	// winner already has its own.
+	MoveNutritionToWinner(ctx context.Context, arg MoveNutritionToWinnerParams) error
+	// Moves the loser's storage guidelines to the winner during a merge, unless
+	// the winner already has its own.
+	MoveStorageGuidelinesToWinner(ctx context.Context, arg MoveStorageGuidelinesToWinnerParams) error
+	// Rewrites a conversion as the reciprocal of its inverse, provided neither
+	// row has changed since they were read as factor and keep_factor.
+	RepairUnitConversionFactor(ctx context.Context, arg RepairUnitConversionFactorParams) (int64, error)
+	ReplaceCompositeComponentIngredient(ctx context.Context, arg ReplaceCompositeComponentIngredientParams) error
+	ReplaceCompositeSubstituteIngredient(ctx context.Context, arg ReplaceCompositeSubstituteIngredientParams) error
 	ReplaceSubstituteIngredient(ctx context.Context, arg ReplaceSubstituteIngredientParams) error
 	ReplaceSubstituteSubId(ctx context.Context, arg ReplaceSubstituteSubIdParams) error
 	ReplaceUnitConversionIngredient(ctx context.Context, arg ReplaceUnitConversionIngredientParams) error
+	RestoreCompositeComponent(ctx context.Context, arg RestoreCompositeComponentParams) (int64, error)
+	RestoreCompositeComponentQuantity(ctx context.Context, arg RestoreCompositeComponentQuantityParams) (int64, error)
+	RestoreCompositeSubstituteIngredient(ctx context.Context, arg RestoreCompositeSubstituteIngredientParams) (int64, error)
+	// History records the update with actor and reason.
+	RestoreIngredient(ctx context.Context, arg RestoreIngredientParams) (Ingredient, error)
+	// Moves nutrition back to the restored loser if the winner still holds the
+	// row the merge moved over, identified by its updated_at.
+	RestoreIngredientNutrition(ctx context.Context, arg RestoreIngredientNutritionParams) (int64, error)
+	RestoreIngredientParent(ctx context.Context, arg RestoreIngredientParentParams) (int64, error)
+	RestoreIngredientRedirect(ctx context.Context, arg RestoreIngredientRedirectParams) (int64, error)
+	RestoreStorageGuideline(ctx context.Context, arg RestoreStorageGuidelineParams) (int64, error)
+	RestoreSubstituteIngredient(ctx context.Context, arg RestoreSubstituteIngredientParams) (int64, error)
+	RestoreSubstituteSubId(ctx context.Context, arg RestoreSubstituteSubIdParams) (int64, error)
+	RestoreUnitConversion(ctx context.Context, arg RestoreUnitConversionParams) (int64, error)
+	RestoreUnitConversionFactor(ctx context.Context, arg RestoreUnitConversionFactorParams) (int64, error)
+	// Points redirects to the loser at the winner, keeping chains one hop long.
+	RetargetIngredientRedirects(ctx context.Context, arg RetargetIngredientRedirectsParams) error
+	// Labels the rest of the transaction's ingredient writes in history.
+	SetIngredientChange(ctx context.Context, arg SetIngredientChangeParams) error
+	SetIngredientParent(ctx context.Context, arg SetIngredientParentParams) (Ingredient, error)
+	// Rewrites the aliases column from ingredient_aliases. Aliases keep their
+	// place in the column; new ones go at the end, oldest first.
+	SyncIngredientAliases(ctx context.Context, id uuid.UUID) (Ingredient, error)
+	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
+	UpdateCompositeComponentQuantity(ctx context.Context, arg UpdateCompositeComponentQuantityParams) error
+	// Replaces the rows in ingredient_aliases as well, keeping those of aliases
+	// that stay. Like CreateIngredient, an alias another ingredient holds fails
+	// the update with a unique violation, and new rows get alias_source. A null
+	// name leaves the name as it is. With expected_version set, the update only
+	// applies at that version and otherwise returns no rows, leaving the aliases
+	// alone. History records change_operation (update when null) and
+	// created_by as the actor; in a transaction that called
+	// SetIngredientChange, null arguments keep its labels.
 	UpdateIngredient(ctx context.Context, arg UpdateIngredientParams) (Ingredient, error)
+	// History records the update with actor and reason; in a transaction that
+	// called SetIngredientChange, null arguments keep its labels.
+	UpdateIngredientDietary(ctx context.Context, arg UpdateIngredientDietaryParams) (Ingredient, error)
+	UpdateUnitConversionFactor(ctx context.Context, arg UpdateUnitConversionFactorParams) (UnitConversion, error)
+	UpsertCategoryStorageGuideline(ctx context.Context, arg UpsertCategoryStorageGuidelineParams) (StorageGuideline, error)
+	// History records the insert as an auto_create.
 	UpsertIngredient(ctx context.Context, arg UpsertIngredientParams) (Ingredient, error)
+	UpsertIngredientNutrition(ctx context.Context, arg UpsertIngredientNutritionParams) (IngredientNutrition, error)
+	UpsertIngredientStorageGuideline(ctx context.Context, arg UpsertIngredientStorageGuidelineParams) (StorageGuideline, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,42 @@
+-- name: ListTermOwners :many
+-- Returns the ingredients that hold any of terms as their name or as an
+-- alias. is_name tells which.
+SELECT i.id, i.name, ia.alias AS term, false AS is_name
+FROM ingredient_aliases ia JOIN ingredients i ON i.id = ia.ingredient_id
+WHERE ia.alias = ANY(@terms::text[])
+UNION ALL
+SELECT i.id, i.name, i.name AS term, true AS is_name
+FROM ingredients i
+WHERE i.name = ANY(@terms::text[]);
+
+-- name: ListIngredientAliases :many
+-- Returns an ingredient's aliases in the order of its aliases column.
+SELECT ia.* FROM ingredient_aliases ia JOIN ingredients i ON i.id = ia.ingredient_id
+WHERE ia.ingredient_id = $1
+ORDER BY array_position(i.aliases, ia.alias), ia.created_at, ia.alias;
+
+-- name: GetIngredientAlias :one
+SELECT * FROM ingredient_aliases WHERE alias = $1;
+
+-- name: AddIngredientAlias :one
+-- Returns no row if the alias is already taken, by this ingredient or
+-- another.
+INSERT INTO ingredient_aliases (ingredient_id, alias, source, created_by, locale)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (alias) DO NOTHING
+RETURNING *;
+
+-- name: DeleteIngredientAlias :execrows
+DELETE FROM ingredient_aliases WHERE ingredient_id = $1 AND alias = $2;
+
+-- name: SyncIngredientAliases :one
+-- Rewrites the aliases column from ingredient_aliases. Aliases keep their
+-- place in the column; new ones go at the end, oldest first.
+UPDATE ingredients
+SET aliases = ARRAY(
+  SELECT ia.alias FROM ingredient_aliases ia
+  WHERE ia.ingredient_id = ingredients.id
+  ORDER BY array_position(ingredients.aliases, ia.alias), ia.created_at, ia.alias
+)
+WHERE ingredients.id = $1
+RETURNING *;
@@ -0,0 +1,22 @@
+-- name: ListCategories :many
+SELECT * FROM categories ORDER BY slug;
+
+-- name: GetCategory :one
+SELECT * FROM categories WHERE id = $1;
+
+-- name: GetCategoryBySlug :one
+SELECT * FROM categories WHERE slug = $1;
+
+-- name: CreateCategory :one
+INSERT INTO categories (slug, display_name, parent_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateCategory :one
+UPDATE categories
+SET slug = $2, display_name = $3, parent_id = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteCategory :execrows
+DELETE FROM categories WHERE id = $1;
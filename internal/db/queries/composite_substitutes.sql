@@ -0,0 +1,64 @@
+-- name: CreateCompositeSubstitute :one
+INSERT INTO composite_substitutes (ingredient_id, quantity, unit, notes, contexts, reasons)
+VALUES ($1, $2, $3, $4, COALESCE(@contexts::text[], '{}'), COALESCE(@reasons::text[], '{}'))
+RETURNING *;
+
+-- name: CreateCompositeComponent :one
+INSERT INTO composite_substitute_components (composite_id, component_id, quantity, unit)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListCompositeSubstitutesByIngredient :many
+-- Filters behave as in ListSubstitutesWithIngredient.
+SELECT * FROM composite_substitutes
+WHERE ingredient_id = @ingredient_id
+  AND (@context::text = '' OR cardinality(contexts) = 0 OR @context::text = ANY(contexts))
+  AND (@reason::text = '' OR @reason::text = ANY(reasons))
+ORDER BY created_at;
+
+-- name: ListCompositeComponentsByIngredient :many
+SELECT sqlc.embed(composite_substitute_components), sqlc.embed(ingredients)
+FROM composite_substitute_components
+JOIN composite_substitutes ON composite_substitutes.id = composite_substitute_components.composite_id
+JOIN ingredients ON ingredients.id = composite_substitute_components.component_id
+WHERE composite_substitutes.ingredient_id = $1
+ORDER BY ingredients.name;
+
+-- name: DeleteCompositeSubstitute :execrows
+DELETE FROM composite_substitutes WHERE id = $1 AND ingredient_id = $2;
+
+-- name: ReplaceCompositeSubstituteIngredient :exec
+UPDATE composite_substitutes SET ingredient_id = $1 WHERE ingredient_id = $2;
+
+-- name: ReplaceCompositeComponentIngredient :exec
+UPDATE composite_substitute_components SET component_id = $1 WHERE component_id = $2;
+
+-- name: DeleteSelfReferencingComposites :exec
+-- Removes composites of an ingredient that list the ingredient itself as a
+-- component, which a merge can produce.
+DELETE FROM composite_substitutes
+WHERE composite_substitutes.ingredient_id = $1
+  AND EXISTS (
+    SELECT 1 FROM composite_substitute_components c
+    WHERE c.composite_id = composite_substitutes.id AND c.component_id = $1
+  );
+
+-- name: ListCompositeComponentsByComponent :many
+SELECT * FROM composite_substitute_components WHERE component_id = $1;
+
+-- name: UpdateCompositeComponentQuantity :exec
+UPDATE composite_substitute_components SET quantity = $2 WHERE id = $1;
+
+-- name: DeleteCompositeComponent :exec
+DELETE FROM composite_substitute_components WHERE id = $1;
+
+-- name: DeleteUndersizedComposites :many
+-- Removes composites among the given IDs that a merge left with fewer than
+-- two components.
+DELETE FROM composite_substitutes
+WHERE id = ANY(@ids::uuid[])
+  AND (
+    SELECT count(*) FROM composite_substitute_components c
+    WHERE c.composite_id = composite_substitutes.id
+  ) < 2
+RETURNING *;
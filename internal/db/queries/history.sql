@@ -0,0 +1,22 @@
+-- name: ListIngredientHistory :many
+SELECT * FROM ingredient_history
+WHERE ingredient_id = $1
+ORDER BY version DESC;
+
+-- name: GetIngredientHistoryAsOf :one
+-- The latest entry for the ingredient recorded at or before as_of.
+SELECT * FROM ingredient_history
+WHERE ingredient_id = @ingredient_id AND recorded_at <= @as_of
+ORDER BY recorded_at DESC, version DESC
+LIMIT 1;
+
+-- name: ListIngredientHistoryAsOf :many
+-- The latest entry per ingredient recorded at or before as_of, including
+-- deletions.
+SELECT DISTINCT ON (ingredient_id) * FROM ingredient_history
+WHERE recorded_at <= @as_of
+ORDER BY ingredient_id, recorded_at DESC, version DESC;
+
+-- name: SetIngredientChange :exec
+-- Labels the rest of the transaction's ingredient writes in history.
+SELECT set_ingredient_change(@operation::text, sqlc.narg(actor)::text, sqlc.narg(reason)::text);
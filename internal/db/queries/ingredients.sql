@@ -4,25 +4,162 @@ SELECT * FROM ingredients ORDER BY name;
 -- name: GetIngredient :one
 SELECT * FROM ingredients WHERE id = $1;
 
+-- name: GetIngredientForUpdate :one
+-- Locks the row until the end of the transaction.
+SELECT * FROM ingredients WHERE id = $1 FOR UPDATE;
+
 -- name: GetIngredientByName :one
 SELECT * FROM ingredients WHERE name = $1;
 
 -- name: CreateIngredient :one
-INSERT INTO ingredients (name, aliases, category, default_unit)
-VALUES ($1, $2, $3, $4)
+-- The aliases are written to ingredient_aliases in the same statement, so an
+-- alias another ingredient holds fails the insert with a unique violation.
+-- New alias rows get alias_source, or manual when it is null. History
+-- records created_by as the actor.
+WITH change AS (
+  SELECT set_ingredient_change(NULL, sqlc.narg(created_by)::text, sqlc.narg(change_reason)::text) AS ok
+), new_id AS (
+  SELECT gen_random_uuid() AS id
+), added AS (
+  INSERT INTO ingredient_aliases (ingredient_id, alias, source, created_by)
+  SELECT new_id.id, a.alias, COALESCE(sqlc.narg(alias_source)::text, 'manual'), sqlc.narg(created_by)::text
+  FROM new_id, unnest(@aliases::text[]) AS a(alias)
+)
+INSERT INTO ingredients (id, name, aliases, category_id, default_unit)
+SELECT new_id.id, @name, @aliases::text[], sqlc.narg(category_id)::uuid, sqlc.narg(default_unit)::text
+FROM new_id, change
 RETURNING *;
 
 -- name: UpsertIngredient :one
-INSERT INTO ingredients (name, aliases, category, default_unit)
-VALUES ($1, $2, $3, $4)
+-- History records the insert as an auto_create.
+WITH change AS (
+  SELECT set_ingredient_change('auto_create', sqlc.narg(actor)::text, sqlc.narg(reason)::text) AS ok
+)
+INSERT INTO ingredients (name, aliases, category_id, default_unit)
+SELECT @name, '{}', sqlc.narg(category_id)::uuid, sqlc.narg(default_unit)::text
+FROM change
 ON CONFLICT (name) DO NOTHING
 RETURNING *;
 
 -- name: UpdateIngredient :one
+-- Replaces the rows in ingredient_aliases as well, keeping those of aliases
+-- that stay. Like CreateIngredient, an alias another ingredient holds fails
+-- the update with a unique violation, and new rows get alias_source. A null
+-- name leaves the name as it is. With expected_version set, the update only
+-- applies at that version and otherwise returns no rows, leaving the aliases
+-- alone. History records change_operation (update when null) and
+-- created_by as the actor; in a transaction that called
+-- SetIngredientChange, null arguments keep its labels.
+WITH change AS (
+  SELECT set_ingredient_change(sqlc.narg(change_operation)::text, sqlc.narg(created_by)::text, sqlc.narg(change_reason)::text) AS ok
+), target AS (
+  SELECT t.id FROM ingredients t
+  WHERE t.id = @id::uuid
+    AND (sqlc.narg(expected_version)::bigint IS NULL OR t.version = sqlc.narg(expected_version)::bigint)
+  FOR UPDATE
+), removed AS (
+  DELETE FROM ingredient_aliases
+  WHERE ingredient_id IN (SELECT target.id FROM target) AND NOT alias = ANY(@aliases::text[])
+), added AS (
+  INSERT INTO ingredient_aliases (ingredient_id, alias, source, created_by)
+  SELECT i.id, a.alias, COALESCE(sqlc.narg(alias_source)::text, 'manual'), sqlc.narg(created_by)::text
+  FROM target i, unnest(@aliases::text[]) AS a(alias)
+  WHERE NOT EXISTS (
+    SELECT 1 FROM ingredient_aliases x WHERE x.ingredient_id = i.id AND x.alias = a.alias
+  )
+)
 UPDATE ingredients
-SET aliases = $2, category = $3, default_unit = $4
-WHERE id = $1
-RETURNING *;
+SET name = COALESCE(sqlc.narg(name)::text, ingredients.name),
+    aliases = @aliases::text[], category_id = sqlc.narg(category_id)::uuid, default_unit = sqlc.narg(default_unit)::text
+FROM change
+WHERE ingredients.id IN (SELECT target.id FROM target)
+RETURNING ingredients.*;
 
 -- name: DeleteIngredient :exec
 DELETE FROM ingredients WHERE id = $1;
+
+-- name: ArchiveIngredient :one
+-- Archiving an already archived ingredient keeps its original archived_at.
+-- History records the update with actor and reason.
+WITH change AS (
+  SELECT set_ingredient_change(NULL, sqlc.narg(actor)::text, sqlc.narg(reason)::text) AS ok
+)
+UPDATE ingredients SET archived_at = COALESCE(archived_at, now())
+FROM change
+WHERE ingredients.id = @id::uuid
+RETURNING ingredients.*;
+
+-- name: RestoreIngredient :one
+-- History records the update with actor and reason.
+WITH change AS (
+  SELECT set_ingredient_change(NULL, sqlc.narg(actor)::text, sqlc.narg(reason)::text) AS ok
+)
+UPDATE ingredients SET archived_at = NULL
+FROM change
+WHERE ingredients.id = @id::uuid
+RETURNING ingredients.*;
+
+-- name: DeleteArchivedIngredient :execrows
+-- History records the deletion with actor and reason.
+WITH change AS (
+  SELECT set_ingredient_change(NULL, sqlc.narg(actor)::text, sqlc.narg(reason)::text) AS ok
+)
+DELETE FROM ingredients
+USING change
+WHERE ingredients.id = @id::uuid AND ingredients.archived_at IS NOT NULL;
+
+-- name: SetIngredientParent :one
+UPDATE ingredients SET parent_id = $2 WHERE id = $1
+RETURNING *;
+
+-- name: ListIngredientChildren :many
+SELECT * FROM ingredients WHERE parent_id = @parent_id::uuid ORDER BY name;
+
+-- name: ListIngredientAncestors :many
+-- Returns the ancestors of an ingredient, nearest first. depth is 1 for the
+-- parent. The depth guard only matters if a cycle slipped past the service.
+WITH RECURSIVE ancestors (id, parent_id, depth) AS (
+  SELECT p.id, p.parent_id, 1
+  FROM ingredients c JOIN ingredients p ON p.id = c.parent_id
+  WHERE c.id = $1
+  UNION ALL
+  SELECT p.id, p.parent_id, a.depth + 1
+  FROM ancestors a JOIN ingredients p ON p.id = a.parent_id
+  WHERE a.depth < 64
+)
+SELECT sqlc.embed(ingredients), ancestors.depth::int AS depth
+FROM ancestors JOIN ingredients ON ingredients.id = ancestors.id
+ORDER BY ancestors.depth;
+
+-- name: ListIngredientDescendants :many
+-- Returns the descendants of an ingredient up to max_depth levels below it,
+-- ordered by depth then name. depth is 1 for direct children.
+WITH RECURSIVE descendants (id, depth) AS (
+  SELECT c.id, 1
+  FROM ingredients c
+  WHERE c.parent_id = @id::uuid
+  UNION ALL
+  SELECT c.id, d.depth + 1
+  FROM descendants d JOIN ingredients c ON c.parent_id = d.id
+  WHERE d.depth < @max_depth::int
+)
+SELECT sqlc.embed(ingredients), descendants.depth::int AS depth
+FROM descendants JOIN ingredients ON ingredients.id = descendants.id
+ORDER BY descendants.depth, ingredients.name;
+
+-- name: LockIngredientHierarchy :exec
+-- Serializes parent changes for the rest of the transaction so that two
+-- concurrent re-parents cannot together form a cycle.
+SELECT pg_advisory_xact_lock(hashtext('ingredient_hierarchy'));
+
+-- name: UpdateIngredientDietary :one
+-- History records the update with actor and reason; in a transaction that
+-- called SetIngredientChange, null arguments keep its labels.
+WITH change AS (
+  SELECT set_ingredient_change(NULL, sqlc.narg(actor)::text, sqlc.narg(reason)::text) AS ok
+)
+UPDATE ingredients
+SET allergens = @allergens::text[], dietary_tags = @dietary_tags::text[], free_of = @free_of::text[]
+FROM change
+WHERE ingredients.id = @id::uuid
+RETURNING ingredients.*;
@@ -0,0 +1,129 @@
+-- name: CreateIngredientMerge :one
+INSERT INTO ingredient_merges (winner_id, loser_id, loser_name, snapshot)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListIngredientMerges :many
+SELECT * FROM ingredient_merges WHERE winner_id = $1 ORDER BY merged_at DESC;
+
+-- name: GetOpenIngredientMerge :one
+-- Returns the unsplit merge of loser_id into winner_id, locking it.
+SELECT * FROM ingredient_merges
+WHERE winner_id = $1 AND loser_id = $2 AND split_at IS NULL
+FOR UPDATE;
+
+-- name: GetLatestOpenIngredientMerge :one
+SELECT * FROM ingredient_merges
+WHERE winner_id = $1 AND split_at IS NULL
+ORDER BY merged_at DESC
+LIMIT 1
+FOR UPDATE;
+
+-- name: MarkIngredientMergeSplit :exec
+UPDATE ingredient_merges SET split_at = now() WHERE id = $1;
+
+-- name: ListSubstitutesTouching :many
+SELECT * FROM ingredient_substitutes WHERE ingredient_id = $1 OR substitute_id = $1;
+
+-- name: ListCompositeSubstituteIDsByIngredient :many
+SELECT id FROM composite_substitutes WHERE ingredient_id = $1;
+
+-- name: ListCompositeComponentIDsByComponent :many
+SELECT id FROM composite_substitute_components WHERE component_id = $1;
+
+-- name: ListRedirectsTo :many
+SELECT old_id FROM ingredient_redirects WHERE new_id = $1;
+
+-- name: InsertIngredientSnapshot :one
+-- Recreates an ingredient row exactly as captured, including its ID. The
+-- alias rows are restored separately with InsertIngredientAliasSnapshot.
+-- Pass a version past the captured one so old ETags no longer match and
+-- history stays in order.
+INSERT INTO ingredients (
+  id, name, aliases, default_unit, created_at, parent_id, category_id,
+  allergens, dietary_tags, archived_at, version, free_of
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: InsertIngredientAliasSnapshot :execrows
+INSERT INTO ingredient_aliases (id, ingredient_id, alias, source, created_by, locale, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT DO NOTHING;
+
+-- name: MoveIngredientAliases :exec
+-- Hands the loser's alias rows, with their metadata, to the winner.
+UPDATE ingredient_aliases SET ingredient_id = @winner_id::uuid
+WHERE ingredient_id = @loser_id::uuid;
+
+-- name: RestoreIngredientParent :execrows
+UPDATE ingredients SET parent_id = @restored_id::uuid
+WHERE id = @id::uuid AND parent_id IS NOT DISTINCT FROM @expected_parent_id;
+
+-- name: RestoreSubstituteIngredient :execrows
+UPDATE ingredient_substitutes SET ingredient_id = @loser_id::uuid
+WHERE id = @id::uuid AND ingredient_id = @winner_id::uuid;
+
+-- name: RestoreSubstituteSubId :execrows
+UPDATE ingredient_substitutes SET substitute_id = @loser_id::uuid
+WHERE id = @id::uuid AND substitute_id = @winner_id::uuid;
+
+-- name: InsertSubstituteSnapshot :execrows
+INSERT INTO ingredient_substitutes (id, ingredient_id, substitute_id, ratio, notes, contexts, reasons)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT DO NOTHING;
+
+-- name: RestoreCompositeSubstituteIngredient :execrows
+UPDATE composite_substitutes SET ingredient_id = @loser_id::uuid
+WHERE id = @id::uuid AND ingredient_id = @winner_id::uuid;
+
+-- name: RestoreCompositeComponent :execrows
+UPDATE composite_substitute_components SET component_id = @loser_id::uuid
+WHERE id = @id::uuid AND component_id = @winner_id::uuid;
+
+-- name: InsertCompositeComponentSnapshot :execrows
+-- Recreates a component row a merge folded away, unless its composite is
+-- gone or already lists the component again.
+INSERT INTO composite_substitute_components (id, composite_id, component_id, quantity, unit)
+SELECT @id::uuid, @composite_id::uuid, @component_id::uuid, @quantity::float8, sqlc.narg(unit)::text
+WHERE EXISTS (SELECT 1 FROM composite_substitutes WHERE id = @composite_id::uuid)
+ON CONFLICT DO NOTHING;
+
+-- name: RestoreCompositeComponentQuantity :execrows
+UPDATE composite_substitute_components SET quantity = @before
+WHERE id = @id::uuid AND quantity = @after;
+
+-- name: RestoreUnitConversion :execrows
+UPDATE unit_conversions SET ingredient_id = @loser_id::uuid
+WHERE id = @id::uuid AND ingredient_id = @winner_id::uuid;
+
+-- name: InsertUnitConversionSnapshot :execrows
+INSERT INTO unit_conversions (id, ingredient_id, from_unit, to_unit, factor)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT DO NOTHING;
+
+-- name: RestoreUnitConversionFactor :execrows
+UPDATE unit_conversions SET factor = @before
+WHERE id = @id::uuid AND factor = @after;
+
+-- name: RestoreStorageGuideline :execrows
+UPDATE storage_guidelines SET ingredient_id = @loser_id::uuid
+WHERE id = @id::uuid AND ingredient_id = @winner_id::uuid;
+
+-- name: InsertStorageGuidelineSnapshot :execrows
+INSERT INTO storage_guidelines (id, ingredient_id, location, unopened_days, opened_days, notes, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT DO NOTHING;
+
+-- name: RestoreIngredientNutrition :execrows
+-- Moves nutrition back to the restored loser if the winner still holds the
+-- row the merge moved over, identified by its updated_at.
+UPDATE ingredient_nutrition SET ingredient_id = @loser_id::uuid
+WHERE ingredient_id = @winner_id::uuid AND updated_at = @updated_at;
+
+-- name: RestoreIngredientRedirect :execrows
+UPDATE ingredient_redirects SET new_id = @loser_id::uuid
+WHERE old_id = @old_id::uuid AND new_id = @winner_id::uuid;
+
+-- name: DeleteIngredientRedirect :exec
+DELETE FROM ingredient_redirects WHERE old_id = $1;
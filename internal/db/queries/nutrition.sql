@@ -0,0 +1,35 @@
+-- name: GetIngredientNutrition :one
+SELECT * FROM ingredient_nutrition WHERE ingredient_id = $1;
+
+-- name: UpsertIngredientNutrition :one
+INSERT INTO ingredient_nutrition (
+  ingredient_id, source, source_id, source_name,
+  energy_kcal, protein_g, fat_g, saturated_fat_g, carbohydrate_g, sugars_g,
+  fiber_g, sodium_mg, potassium_mg, calcium_mg, iron_mg, vitamin_c_mg
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+ON CONFLICT (ingredient_id) DO UPDATE SET
+  source = EXCLUDED.source,
+  source_id = EXCLUDED.source_id,
+  source_name = EXCLUDED.source_name,
+  energy_kcal = EXCLUDED.energy_kcal,
+  protein_g = EXCLUDED.protein_g,
+  fat_g = EXCLUDED.fat_g,
+  saturated_fat_g = EXCLUDED.saturated_fat_g,
+  carbohydrate_g = EXCLUDED.carbohydrate_g,
+  sugars_g = EXCLUDED.sugars_g,
+  fiber_g = EXCLUDED.fiber_g,
+  sodium_mg = EXCLUDED.sodium_mg,
+  potassium_mg = EXCLUDED.potassium_mg,
+  calcium_mg = EXCLUDED.calcium_mg,
+  iron_mg = EXCLUDED.iron_mg,
+  vitamin_c_mg = EXCLUDED.vitamin_c_mg,
+  updated_at = now()
+RETURNING *;
+
+-- name: MoveNutritionToWinner :exec
+-- Moves the loser's nutrition row to the winner during a merge, unless the
+-- winner already has its own.
+UPDATE ingredient_nutrition SET ingredient_id = @winner_id
+WHERE ingredient_nutrition.ingredient_id = @loser_id
+  AND NOT EXISTS (SELECT 1 FROM ingredient_nutrition n WHERE n.ingredient_id = @winner_id);
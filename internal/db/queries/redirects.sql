@@ -0,0 +1,16 @@
+-- name: GetIngredientRedirect :one
+SELECT * FROM ingredient_redirects WHERE old_id = $1;
+
+-- name: ListIngredientRedirects :many
+SELECT * FROM ingredient_redirects WHERE old_id = ANY(@old_ids::uuid[]);
+
+-- name: ListIngredientIDs :many
+SELECT id FROM ingredients WHERE id = ANY(@ids::uuid[]);
+
+-- name: RetargetIngredientRedirects :exec
+-- Points redirects to the loser at the winner, keeping chains one hop long.
+UPDATE ingredient_redirects SET new_id = @winner_id::uuid WHERE new_id = @loser_id::uuid;
+
+-- name: CreateIngredientRedirect :exec
+INSERT INTO ingredient_redirects (old_id, new_id) VALUES ($1, $2)
+ON CONFLICT (old_id) DO UPDATE SET new_id = EXCLUDED.new_id, merged_at = now();
@@ -0,0 +1,40 @@
+-- name: ListStorageGuidelinesByIngredient :many
+SELECT * FROM storage_guidelines WHERE ingredient_id = @ingredient_id::uuid
+ORDER BY array_position(ARRAY['pantry', 'fridge', 'freezer'], location);
+
+-- name: ListStorageGuidelinesByCategory :many
+SELECT * FROM storage_guidelines WHERE category_id = @category_id::uuid
+ORDER BY array_position(ARRAY['pantry', 'fridge', 'freezer'], location);
+
+-- name: UpsertIngredientStorageGuideline :one
+INSERT INTO storage_guidelines (ingredient_id, location, unopened_days, opened_days, notes)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (ingredient_id, location) DO UPDATE SET
+  unopened_days = EXCLUDED.unopened_days,
+  opened_days = EXCLUDED.opened_days,
+  notes = EXCLUDED.notes,
+  updated_at = now()
+RETURNING *;
+
+-- name: UpsertCategoryStorageGuideline :one
+INSERT INTO storage_guidelines (category_id, location, unopened_days, opened_days, notes)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (category_id, location) DO UPDATE SET
+  unopened_days = EXCLUDED.unopened_days,
+  opened_days = EXCLUDED.opened_days,
+  notes = EXCLUDED.notes,
+  updated_at = now()
+RETURNING *;
+
+-- name: DeleteIngredientStorageGuidelines :exec
+DELETE FROM storage_guidelines WHERE ingredient_id = @ingredient_id::uuid;
+
+-- name: DeleteCategoryStorageGuidelines :exec
+DELETE FROM storage_guidelines WHERE category_id = @category_id::uuid;
+
+-- name: MoveStorageGuidelinesToWinner :exec
+-- Moves the loser's storage guidelines to the winner during a merge, unless
+-- the winner already has its own.
+UPDATE storage_guidelines SET ingredient_id = @winner_id::uuid
+WHERE storage_guidelines.ingredient_id = @loser_id::uuid
+  AND NOT EXISTS (SELECT 1 FROM storage_guidelines g WHERE g.ingredient_id = @winner_id::uuid);
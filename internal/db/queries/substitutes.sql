@@ -2,8 +2,8 @@
 SELECT * FROM ingredient_substitutes WHERE ingredient_id = $1;
 
 -- name: CreateSubstitute :one
-INSERT INTO ingredient_substitutes (ingredient_id, substitute_id, ratio, notes)
-VALUES ($1, $2, $3, $4)
+INSERT INTO ingredient_substitutes (ingredient_id, substitute_id, ratio, notes, contexts, reasons)
+VALUES ($1, $2, $3, $4, COALESCE(@contexts::text[], '{}'), COALESCE(@reasons::text[], '{}'))
 RETURNING *;
 
 -- name: ReplaceSubstituteIngredient :exec
@@ -14,3 +14,36 @@ UPDATE ingredient_substitutes SET substitute_id = $1 WHERE substitute_id = $2;
 
 -- name: DeleteSubstitutesByIngredient :exec
 DELETE FROM ingredient_substitutes WHERE ingredient_id = $1 OR substitute_id = $1;
+
+-- name: ListSubstitutesWithIngredient :many
+-- An empty context or reason matches everything. Substitutes without any
+-- context apply in every context; a reason filter requires the tag.
+SELECT sqlc.embed(ingredient_substitutes), sqlc.embed(ingredients)
+FROM ingredient_substitutes
+JOIN ingredients ON ingredients.id = ingredient_substitutes.substitute_id
+WHERE ingredient_substitutes.ingredient_id = @ingredient_id
+  AND (@context::text = ''
+       OR cardinality(ingredient_substitutes.contexts) = 0
+       OR @context::text = ANY(ingredient_substitutes.contexts))
+  AND (@reason::text = '' OR @reason::text = ANY(ingredient_substitutes.reasons))
+ORDER BY ingredients.name;
+
+-- name: GetSubstitute :one
+SELECT * FROM ingredient_substitutes WHERE ingredient_id = $1 AND substitute_id = $2;
+
+-- name: DeleteSubstitute :execrows
+DELETE FROM ingredient_substitutes WHERE ingredient_id = $1 AND substitute_id = $2;
+
+-- name: DeleteRedundantMergeSubstitutes :exec
+-- Removes loser substitute rows that would duplicate a winner row or become
+-- self-substitutions once re-pointed from loser to winner.
+DELETE FROM ingredient_substitutes s
+WHERE (s.ingredient_id = @loser_id::uuid AND (
+        s.substitute_id = @winner_id::uuid
+        OR s.substitute_id IN (SELECT w.substitute_id FROM ingredient_substitutes w WHERE w.ingredient_id = @winner_id::uuid)))
+   OR (s.substitute_id = @loser_id::uuid AND (
+        s.ingredient_id = @winner_id::uuid
+        OR s.ingredient_id IN (SELECT w.ingredient_id FROM ingredient_substitutes w WHERE w.substitute_id = @winner_id::uuid)));
+
+-- name: ListAllSubstitutes :many
+SELECT * FROM ingredient_substitutes;
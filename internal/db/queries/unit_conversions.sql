@@ -8,3 +8,25 @@ RETURNING *;
 
 -- name: ReplaceUnitConversionIngredient :exec
 UPDATE unit_conversions SET ingredient_id = $1 WHERE ingredient_id = $2;
+
+-- name: ListUnitConversions :many
+SELECT * FROM unit_conversions ORDER BY ingredient_id, from_unit, to_unit;
+
+-- name: UpdateUnitConversionFactor :one
+UPDATE unit_conversions SET factor = $2 WHERE id = $1
+RETURNING *;
+
+-- name: RepairUnitConversionFactor :execrows
+-- Rewrites a conversion as the reciprocal of its inverse, provided neither
+-- row has changed since they were read as factor and keep_factor.
+WITH keep AS (
+  SELECT k.factor FROM unit_conversions k
+  WHERE k.id = @keep_id::uuid AND k.factor = @keep_factor::float8
+  FOR UPDATE
+)
+UPDATE unit_conversions SET factor = 1 / keep.factor
+FROM keep
+WHERE unit_conversions.id = @id::uuid AND unit_conversions.factor = @factor::float8;
+
+-- name: DeleteUnitConversion :exec
+DELETE FROM unit_conversions WHERE id = $1;
@@ -0,0 +1,108 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: redirects.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const createIngredientRedirect = `-- name: CreateIngredientRedirect :exec
+INSERT INTO ingredient_redirects (old_id, new_id) VALUES ($1, $2)
+ON CONFLICT (old_id) DO UPDATE SET new_id = EXCLUDED.new_id, merged_at = now()
+`
+
+type CreateIngredientRedirectParams struct {
+	OldID uuid.UUID
+	NewID uuid.UUID
+}
+
+func (q *Queries) CreateIngredientRedirect(ctx context.Context, arg CreateIngredientRedirectParams) error {
+	_, err := q.db.ExecContext(ctx, createIngredientRedirect, arg.OldID, arg.NewID)
+	return err
+}
+
+const getIngredientRedirect = `-- name: GetIngredientRedirect :one
+SELECT old_id, new_id, merged_at FROM ingredient_redirects WHERE old_id = $1
+`
+
+func (q *Queries) GetIngredientRedirect(ctx context.Context, oldID uuid.UUID) (IngredientRedirect, error) {
+	row := q.db.QueryRowContext(ctx, getIngredientRedirect, oldID)
+	var i IngredientRedirect
+	err := row.Scan(&i.OldID, &i.NewID, &i.MergedAt)
+	return i, err
+}
+
+const listIngredientIDs = `-- name: ListIngredientIDs :many
+SELECT id FROM ingredients WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) ListIngredientIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listIngredientIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIngredientRedirects = `-- name: ListIngredientRedirects :many
+SELECT old_id, new_id, merged_at FROM ingredient_redirects WHERE old_id = ANY($1::uuid[])
+`
+
+func (q *Queries) ListIngredientRedirects(ctx context.Context, oldIds []uuid.UUID) ([]IngredientRedirect, error) {
+	rows, err := q.db.QueryContext(ctx, listIngredientRedirects, pq.Array(oldIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IngredientRedirect
+	for rows.Next() {
+		var i IngredientRedirect
+		if err := rows.Scan(&i.OldID, &i.NewID, &i.MergedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retargetIngredientRedirects = `-- name: RetargetIngredientRedirects :exec
+UPDATE ingredient_redirects SET new_id = $1::uuid WHERE new_id = $2::uuid
+`
+
+type RetargetIngredientRedirectsParams struct {
+	WinnerID uuid.UUID
+	LoserID  uuid.UUID
+}
+
+// Points redirects to the loser at the winner, keeping chains one hop long.
+func (q *Queries) RetargetIngredientRedirects(ctx context.Context, arg RetargetIngredientRedirectsParams) error {
+	_, err := q.db.ExecContext(ctx, retargetIngredientRedirects, arg.WinnerID, arg.LoserID)
+	return err
+}
@@ -0,0 +1,205 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: storage_guidelines.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const deleteCategoryStorageGuidelines = `-- name: DeleteCategoryStorageGuidelines :exec
+DELETE FROM storage_guidelines WHERE category_id = $1::uuid
+`
+
+func (q *Queries) DeleteCategoryStorageGuidelines(ctx context.Context, categoryID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteCategoryStorageGuidelines, categoryID)
+	return err
+}
+
+const deleteIngredientStorageGuidelines = `-- name: DeleteIngredientStorageGuidelines :exec
+DELETE FROM storage_guidelines WHERE ingredient_id = $1::uuid
+`
+
+func (q *Queries) DeleteIngredientStorageGuidelines(ctx context.Context, ingredientID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteIngredientStorageGuidelines, ingredientID)
+	return err
+}
+
+const listStorageGuidelinesByCategory = `-- name: ListStorageGuidelinesByCategory :many
+SELECT id, ingredient_id, category_id, location, unopened_days, opened_days, notes, updated_at FROM storage_guidelines WHERE category_id = $1::uuid
+ORDER BY array_position(ARRAY['pantry', 'fridge', 'freezer'], location)
+`
+
+func (q *Queries) ListStorageGuidelinesByCategory(ctx context.Context, categoryID uuid.UUID) ([]StorageGuideline, error) {
+	rows, err := q.db.QueryContext(ctx, listStorageGuidelinesByCategory, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StorageGuideline
+	for rows.Next() {
+		var i StorageGuideline
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.CategoryID,
+			&i.Location,
+			&i.UnopenedDays,
+			&i.OpenedDays,
+			&i.Notes,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStorageGuidelinesByIngredient = `-- name: ListStorageGuidelinesByIngredient :many
+SELECT id, ingredient_id, category_id, location, unopened_days, opened_days, notes, updated_at FROM storage_guidelines WHERE ingredient_id = $1::uuid
+ORDER BY array_position(ARRAY['pantry', 'fridge', 'freezer'], location)
+`
+
+func (q *Queries) ListStorageGuidelinesByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]StorageGuideline, error) {
+	rows, err := q.db.QueryContext(ctx, listStorageGuidelinesByIngredient, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StorageGuideline
+	for rows.Next() {
+		var i StorageGuideline
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.CategoryID,
+			&i.Location,
+			&i.UnopenedDays,
+			&i.OpenedDays,
+			&i.Notes,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveStorageGuidelinesToWinner = `-- name: MoveStorageGuidelinesToWinner :exec
+UPDATE storage_guidelines SET ingredient_id = $1::uuid
+WHERE storage_guidelines.ingredient_id = $2::uuid
+  AND NOT EXISTS (SELECT 1 FROM storage_guidelines g WHERE g.ingredient_id = $1::uuid)
+`
+
+type MoveStorageGuidelinesToWinnerParams struct {
+	WinnerID uuid.UUID
+	LoserID  uuid.UUID
+}
+
+// Moves the loser's storage guidelines to the winner during a merge, unless
+// the winner already has its own.
+func (q *Queries) MoveStorageGuidelinesToWinner(ctx context.Context, arg MoveStorageGuidelinesToWinnerParams) error {
+	_, err := q.db.ExecContext(ctx, moveStorageGuidelinesToWinner, arg.WinnerID, arg.LoserID)
+	return err
+}
+
+const upsertCategoryStorageGuideline = `-- name: UpsertCategoryStorageGuideline :one
+INSERT INTO storage_guidelines (category_id, location, unopened_days, opened_days, notes)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (category_id, location) DO UPDATE SET
+  unopened_days = EXCLUDED.unopened_days,
+  opened_days = EXCLUDED.opened_days,
+  notes = EXCLUDED.notes,
+  updated_at = now()
+RETURNING id, ingredient_id, category_id, location, unopened_days, opened_days, notes, updated_at
+`
+
+type UpsertCategoryStorageGuidelineParams struct {
+	CategoryID   uuid.NullUUID
+	Location     string
+	UnopenedDays sql.NullInt32
+	OpenedDays   sql.NullInt32
+	Notes        sql.NullString
+}
+
+func (q *Queries) UpsertCategoryStorageGuideline(ctx context.Context, arg UpsertCategoryStorageGuidelineParams) (StorageGuideline, error) {
+	row := q.db.QueryRowContext(ctx, upsertCategoryStorageGuideline,
+		arg.CategoryID,
+		arg.Location,
+		arg.UnopenedDays,
+		arg.OpenedDays,
+		arg.Notes,
+	)
+	var i StorageGuideline
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.CategoryID,
+		&i.Location,
+		&i.UnopenedDays,
+		&i.OpenedDays,
+		&i.Notes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertIngredientStorageGuideline = `-- name: UpsertIngredientStorageGuideline :one
+INSERT INTO storage_guidelines (ingredient_id, location, unopened_days, opened_days, notes)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (ingredient_id, location) DO UPDATE SET
+  unopened_days = EXCLUDED.unopened_days,
+  opened_days = EXCLUDED.opened_days,
+  notes = EXCLUDED.notes,
+  updated_at = now()
+RETURNING id, ingredient_id, category_id, location, unopened_days, opened_days, notes, updated_at
+`
+
+type UpsertIngredientStorageGuidelineParams struct {
+	IngredientID uuid.NullUUID
+	Location     string
+	UnopenedDays sql.NullInt32
+	OpenedDays   sql.NullInt32
+	Notes        sql.NullString
+}
+
+func (q *Queries) UpsertIngredientStorageGuideline(ctx context.Context, arg UpsertIngredientStorageGuidelineParams) (StorageGuideline, error) {
+	row := q.db.QueryRowContext(ctx, upsertIngredientStorageGuideline,
+		arg.IngredientID,
+		arg.Location,
+		arg.UnopenedDays,
+		arg.OpenedDays,
+		arg.Notes,
+	)
+	var i StorageGuideline
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.CategoryID,
+		&i.Location,
+		&i.UnopenedDays,
+		&i.OpenedDays,
+		&i.Notes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
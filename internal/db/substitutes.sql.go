@@ -10,12 +10,13 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createSubstitute = `-- name: CreateSubstitute :one
-INSERT INTO ingredient_substitutes (ingredient_id, substitute_id, ratio, notes)
-VALUES ($1, $2, $3, $4)
-RETURNING id, ingredient_id, substitute_id, ratio, notes
+INSERT INTO ingredient_substitutes (ingredient_id, substitute_id, ratio, notes, contexts, reasons)
+VALUES ($1, $2, $3, $4, COALESCE($5::text[], '{}'), COALESCE($6::text[], '{}'))
+RETURNING id, ingredient_id, substitute_id, ratio, notes, contexts, reasons
 `
 
 type CreateSubstituteParams struct {
@@ -23,6 +24,8 @@ type CreateSubstituteParams struct {
 	SubstituteID uuid.UUID
 	Ratio        float64
 	Notes        sql.NullString
+	Contexts     []string
+	Reasons      []string
 }
 
 func (q *Queries) CreateSubstitute(ctx context.Context, arg CreateSubstituteParams) (IngredientSubstitute, error) {
@@ -31,6 +34,8 @@ func (q *Queries) CreateSubstitute(ctx context.Context, arg CreateSubstitutePara
 		arg.SubstituteID,
 		arg.Ratio,
 		arg.Notes,
+		pq.Array(arg.Contexts),
+		pq.Array(arg.Reasons),
 	)
 	var i IngredientSubstitute
 	err := row.Scan(
@@ -39,10 +44,51 @@ func (q *Queries) CreateSubstitute(ctx context.Context, arg CreateSubstitutePara
 		&i.SubstituteID,
 		&i.Ratio,
 		&i.Notes,
+		pq.Array(&i.Contexts),
+		pq.Array(&i.Reasons),
 	)
 	return i, err
 }
 
+const deleteRedundantMergeSubstitutes = `-- name: DeleteRedundantMergeSubstitutes :exec
+DELETE FROM ingredient_substitutes s
+WHERE (s.ingredient_id = $1::uuid AND (
+        s.substitute_id = $2::uuid
+        OR s.substitute_id IN (SELECT w.substitute_id FROM ingredient_substitutes w WHERE w.ingredient_id = $2::uuid)))
+   OR (s.substitute_id = $1::uuid AND (
+        s.ingredient_id = $2::uuid
+        OR s.ingredient_id IN (SELECT w.ingredient_id FROM ingredient_substitutes w WHERE w.substitute_id = $2::uuid)))
+`
+
+type DeleteRedundantMergeSubstitutesParams struct {
+	LoserID  uuid.UUID
+	WinnerID uuid.UUID
+}
+
+// Removes loser substitute rows that would duplicate a winner row or become
+// self-substitutions once re-pointed from loser to winner.
+func (q *Queries) DeleteRedundantMergeSubstitutes(ctx context.Context, arg DeleteRedundantMergeSubstitutesParams) error {
+	_, err := q.db.ExecContext(ctx, deleteRedundantMergeSubstitutes, arg.LoserID, arg.WinnerID)
+	return err
+}
+
+const deleteSubstitute = `-- name: DeleteSubstitute :execrows
+DELETE FROM ingredient_substitutes WHERE ingredient_id = $1 AND substitute_id = $2
+`
+
+type DeleteSubstituteParams struct {
+	IngredientID uuid.UUID
+	SubstituteID uuid.UUID
+}
+
+func (q *Queries) DeleteSubstitute(ctx context.Context, arg DeleteSubstituteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteSubstitute, arg.IngredientID, arg.SubstituteID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteSubstitutesByIngredient = `-- name: DeleteSubstitutesByIngredient :exec
 DELETE FROM ingredient_substitutes WHERE ingredient_id = $1 OR substitute_id = $1
 `
@@ -52,8 +98,67 @@ func (q *Queries) DeleteSubstitutesByIngredient(ctx context.Context, ingredientI
 	return err
 }
 
+const getSubstitute = `-- name: GetSubstitute :one
+SELECT id, ingredient_id, substitute_id, ratio, notes, contexts, reasons FROM ingredient_substitutes WHERE ingredient_id = $1 AND substitute_id = $2
+`
+
+type GetSubstituteParams struct {
+	IngredientID uuid.UUID
+	SubstituteID uuid.UUID
+}
+
+func (q *Queries) GetSubstitute(ctx context.Context, arg GetSubstituteParams) (IngredientSubstitute, error) {
+	row := q.db.QueryRowContext(ctx, getSubstitute, arg.IngredientID, arg.SubstituteID)
+	var i IngredientSubstitute
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.SubstituteID,
+		&i.Ratio,
+		&i.Notes,
+		pq.Array(&i.Contexts),
+		pq.Array(&i.Reasons),
+	)
+	return i, err
+}
+
+const listAllSubstitutes = `-- name: ListAllSubstitutes :many
+SELECT id, ingredient_id, substitute_id, ratio, notes, contexts, reasons FROM ingredient_substitutes
+`
+
+func (q *Queries) ListAllSubstitutes(ctx context.Context) ([]IngredientSubstitute, error) {
+	rows, err := q.db.QueryContext(ctx, listAllSubstitutes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IngredientSubstitute
+	for rows.Next() {
+		var i IngredientSubstitute
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.SubstituteID,
+			&i.Ratio,
+			&i.Notes,
+			pq.Array(&i.Contexts),
+			pq.Array(&i.Reasons),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSubstitutesByIngredient = `-- name: ListSubstitutesByIngredient :many
-SELECT id, ingredient_id, substitute_id, ratio, notes FROM ingredient_substitutes WHERE ingredient_id = $1
+SELECT id, ingredient_id, substitute_id, ratio, notes, contexts, reasons FROM ingredient_substitutes WHERE ingredient_id = $1
 `
 
 func (q *Queries) ListSubstitutesByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]IngredientSubstitute, error) {
@@ -71,6 +176,76 @@ func (q *Queries) ListSubstitutesByIngredient(ctx context.Context, ingredientID
 			&i.SubstituteID,
 			&i.Ratio,
 			&i.Notes,
+			pq.Array(&i.Contexts),
+			pq.Array(&i.Reasons),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubstitutesWithIngredient = `-- name: ListSubstitutesWithIngredient :many
+SELECT ingredient_substitutes.id, ingredient_substitutes.ingredient_id, ingredient_substitutes.substitute_id, ingredient_substitutes.ratio, ingredient_substitutes.notes, ingredient_substitutes.contexts, ingredient_substitutes.reasons, ingredients.id, ingredients.name, ingredients.aliases, ingredients.default_unit, ingredients.created_at, ingredients.parent_id, ingredients.category_id, ingredients.allergens, ingredients.dietary_tags, ingredients.free_of, ingredients.archived_at, ingredients.version
+FROM ingredient_substitutes
+JOIN ingredients ON ingredients.id = ingredient_substitutes.substitute_id
+WHERE ingredient_substitutes.ingredient_id = $1
+  AND ($2::text = ''
+       OR cardinality(ingredient_substitutes.contexts) = 0
+       OR $2::text = ANY(ingredient_substitutes.contexts))
+  AND ($3::text = '' OR $3::text = ANY(ingredient_substitutes.reasons))
+ORDER BY ingredients.name
+`
+
+type ListSubstitutesWithIngredientParams struct {
+	IngredientID uuid.UUID
+	Context      string
+	Reason       string
+}
+
+type ListSubstitutesWithIngredientRow struct {
+	IngredientSubstitute IngredientSubstitute
+	Ingredient           Ingredient
+}
+
+// An empty context or reason matches everything. Substitutes without any
+// context apply in every context; a reason filter requires the tag.
+func (q *Queries) ListSubstitutesWithIngredient(ctx context.Context, arg ListSubstitutesWithIngredientParams) ([]ListSubstitutesWithIngredientRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSubstitutesWithIngredient, arg.IngredientID, arg.Context, arg.Reason)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSubstitutesWithIngredientRow
+	for rows.Next() {
+		var i ListSubstitutesWithIngredientRow
+		if err := rows.Scan(
+			&i.IngredientSubstitute.ID,
+			&i.IngredientSubstitute.IngredientID,
+			&i.IngredientSubstitute.SubstituteID,
+			&i.IngredientSubstitute.Ratio,
+			&i.IngredientSubstitute.Notes,
+			pq.Array(&i.IngredientSubstitute.Contexts),
+			pq.Array(&i.IngredientSubstitute.Reasons),
+			&i.Ingredient.ID,
+			&i.Ingredient.Name,
+			pq.Array(&i.Ingredient.Aliases),
+			&i.Ingredient.DefaultUnit,
+			&i.Ingredient.CreatedAt,
+			&i.Ingredient.ParentID,
+			&i.Ingredient.CategoryID,
+			pq.Array(&i.Ingredient.Allergens),
+			pq.Array(&i.Ingredient.DietaryTags),
+			pq.Array(&i.Ingredient.FreeOf),
+			&i.Ingredient.ArchivedAt,
+			&i.Ingredient.Version,
 		); err != nil {
 			return nil, err
 		}
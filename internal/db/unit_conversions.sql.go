@@ -42,6 +42,48 @@ func (q *Queries) CreateUnitConversion(ctx context.Context, arg CreateUnitConver
 	return i, err
 }
 
+const deleteUnitConversion = `-- name: DeleteUnitConversion :exec
+DELETE FROM unit_conversions WHERE id = $1
+`
+
+func (q *Queries) DeleteUnitConversion(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteUnitConversion, id)
+	return err
+}
+
+const listUnitConversions = `-- name: ListUnitConversions :many
+SELECT id, ingredient_id, from_unit, to_unit, factor FROM unit_conversions ORDER BY ingredient_id, from_unit, to_unit
+`
+
+func (q *Queries) ListUnitConversions(ctx context.Context) ([]UnitConversion, error) {
+	rows, err := q.db.QueryContext(ctx, listUnitConversions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UnitConversion
+	for rows.Next() {
+		var i UnitConversion
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.FromUnit,
+			&i.ToUnit,
+			&i.Factor,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUnitConversionsByIngredient = `-- name: ListUnitConversionsByIngredient :many
 SELECT id, ingredient_id, from_unit, to_unit, factor FROM unit_conversions WHERE ingredient_id = $1
 `
@@ -75,6 +117,39 @@ func (q *Queries) ListUnitConversionsByIngredient(ctx context.Context, ingredien
 	return items, nil
 }
 
+const repairUnitConversionFactor = `-- name: RepairUnitConversionFactor :execrows
+WITH keep AS (
+  SELECT k.factor FROM unit_conversions k
+  WHERE k.id = $3::uuid AND k.factor = $4::float8
+  FOR UPDATE
+)
+UPDATE unit_conversions SET factor = 1 / keep.factor
+FROM keep
+WHERE unit_conversions.id = $1::uuid AND unit_conversions.factor = $2::float8
+`
+
+type RepairUnitConversionFactorParams struct {
+	ID         uuid.UUID
+	Factor     float64
+	KeepID     uuid.UUID
+	KeepFactor float64
+}
+
+// Rewrites a conversion as the reciprocal of its inverse, provided neither
+// row has changed since they were read as factor and keep_factor.
+func (q *Queries) RepairUnitConversionFactor(ctx context.Context, arg RepairUnitConversionFactorParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, repairUnitConversionFactor,
+		arg.ID,
+		arg.Factor,
+		arg.KeepID,
+		arg.KeepFactor,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const replaceUnitConversionIngredient = `-- name: ReplaceUnitConversionIngredient :exec
 UPDATE unit_conversions SET ingredient_id = $1 WHERE ingredient_id = $2
 `
@@ -88,3 +163,26 @@ func (q *Queries) ReplaceUnitConversionIngredient(ctx context.Context, arg Repla
 	_, err := q.db.ExecContext(ctx, replaceUnitConversionIngredient, arg.IngredientID, arg.IngredientID_2)
 	return err
 }
+
+const updateUnitConversionFactor = `-- name: UpdateUnitConversionFactor :one
+UPDATE unit_conversions SET factor = $2 WHERE id = $1
+RETURNING id, ingredient_id, from_unit, to_unit, factor
+`
+
+type UpdateUnitConversionFactorParams struct {
+	ID     uuid.UUID
+	Factor float64
+}
+
+func (q *Queries) UpdateUnitConversionFactor(ctx context.Context, arg UpdateUnitConversionFactorParams) (UnitConversion, error) {
+	row := q.db.QueryRowContext(ctx, updateUnitConversionFactor, arg.ID, arg.Factor)
+	var i UnitConversion
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.FromUnit,
+		&i.ToUnit,
+		&i.Factor,
+	)
+	return i, err
+}
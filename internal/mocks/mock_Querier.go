@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	db "github.com/mwhite7112/woodpantry-ingredients/internal/db"
@@ -23,26 +24,26 @@ func (_m *MockQuerier) EXPECT() *MockQuerier_Expecter {
 	return &MockQuerier_Expecter{mock: &_m.Mock}
 }
 
-// CreateIngredient provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) CreateIngredient(ctx context.Context, arg db.CreateIngredientParams) (db.Ingredient, error) {
+// AddIngredientAlias provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) AddIngredientAlias(ctx context.Context, arg db.AddIngredientAliasParams) (db.IngredientAlias, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateIngredient")
+		panic("no return value specified for AddIngredientAlias")
 	}
 
-	var r0 db.Ingredient
+	var r0 db.IngredientAlias
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIngredientParams) (db.Ingredient, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddIngredientAliasParams) (db.IngredientAlias, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIngredientParams) db.Ingredient); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddIngredientAliasParams) db.IngredientAlias); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Ingredient)
+		r0 = ret.Get(0).(db.IngredientAlias)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateIngredientParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.AddIngredientAliasParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -51,55 +52,55 @@ func (_m *MockQuerier) CreateIngredient(ctx context.Context, arg db.CreateIngred
 	return r0, r1
 }
 
-// MockQuerier_CreateIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIngredient'
-type MockQuerier_CreateIngredient_Call struct {
+// MockQuerier_AddIngredientAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddIngredientAlias'
+type MockQuerier_AddIngredientAlias_Call struct {
 	*mock.Call
 }
 
-// CreateIngredient is a helper method to define mock.On call
+// AddIngredientAlias is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateIngredientParams
-func (_e *MockQuerier_Expecter) CreateIngredient(ctx interface{}, arg interface{}) *MockQuerier_CreateIngredient_Call {
-	return &MockQuerier_CreateIngredient_Call{Call: _e.mock.On("CreateIngredient", ctx, arg)}
+//   - arg db.AddIngredientAliasParams
+func (_e *MockQuerier_Expecter) AddIngredientAlias(ctx interface{}, arg interface{}) *MockQuerier_AddIngredientAlias_Call {
+	return &MockQuerier_AddIngredientAlias_Call{Call: _e.mock.On("AddIngredientAlias", ctx, arg)}
 }
 
-func (_c *MockQuerier_CreateIngredient_Call) Run(run func(ctx context.Context, arg db.CreateIngredientParams)) *MockQuerier_CreateIngredient_Call {
+func (_c *MockQuerier_AddIngredientAlias_Call) Run(run func(ctx context.Context, arg db.AddIngredientAliasParams)) *MockQuerier_AddIngredientAlias_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.CreateIngredientParams))
+		run(args[0].(context.Context), args[1].(db.AddIngredientAliasParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_CreateIngredient_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_CreateIngredient_Call {
+func (_c *MockQuerier_AddIngredientAlias_Call) Return(_a0 db.IngredientAlias, _a1 error) *MockQuerier_AddIngredientAlias_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_CreateIngredient_Call) RunAndReturn(run func(context.Context, db.CreateIngredientParams) (db.Ingredient, error)) *MockQuerier_CreateIngredient_Call {
+func (_c *MockQuerier_AddIngredientAlias_Call) RunAndReturn(run func(context.Context, db.AddIngredientAliasParams) (db.IngredientAlias, error)) *MockQuerier_AddIngredientAlias_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateSubstitute provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) CreateSubstitute(ctx context.Context, arg db.CreateSubstituteParams) (db.IngredientSubstitute, error) {
+// ArchiveIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ArchiveIngredient(ctx context.Context, arg db.ArchiveIngredientParams) (db.Ingredient, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateSubstitute")
+		panic("no return value specified for ArchiveIngredient")
 	}
 
-	var r0 db.IngredientSubstitute
+	var r0 db.Ingredient
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateSubstituteParams) (db.IngredientSubstitute, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ArchiveIngredientParams) (db.Ingredient, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateSubstituteParams) db.IngredientSubstitute); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ArchiveIngredientParams) db.Ingredient); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.IngredientSubstitute)
+		r0 = ret.Get(0).(db.Ingredient)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateSubstituteParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.ArchiveIngredientParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -108,55 +109,55 @@ func (_m *MockQuerier) CreateSubstitute(ctx context.Context, arg db.CreateSubsti
 	return r0, r1
 }
 
-// MockQuerier_CreateSubstitute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSubstitute'
-type MockQuerier_CreateSubstitute_Call struct {
+// MockQuerier_ArchiveIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveIngredient'
+type MockQuerier_ArchiveIngredient_Call struct {
 	*mock.Call
 }
 
-// CreateSubstitute is a helper method to define mock.On call
+// ArchiveIngredient is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateSubstituteParams
-func (_e *MockQuerier_Expecter) CreateSubstitute(ctx interface{}, arg interface{}) *MockQuerier_CreateSubstitute_Call {
-	return &MockQuerier_CreateSubstitute_Call{Call: _e.mock.On("CreateSubstitute", ctx, arg)}
+//   - arg db.ArchiveIngredientParams
+func (_e *MockQuerier_Expecter) ArchiveIngredient(ctx interface{}, arg interface{}) *MockQuerier_ArchiveIngredient_Call {
+	return &MockQuerier_ArchiveIngredient_Call{Call: _e.mock.On("ArchiveIngredient", ctx, arg)}
 }
 
-func (_c *MockQuerier_CreateSubstitute_Call) Run(run func(ctx context.Context, arg db.CreateSubstituteParams)) *MockQuerier_CreateSubstitute_Call {
+func (_c *MockQuerier_ArchiveIngredient_Call) Run(run func(ctx context.Context, arg db.ArchiveIngredientParams)) *MockQuerier_ArchiveIngredient_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.CreateSubstituteParams))
+		run(args[0].(context.Context), args[1].(db.ArchiveIngredientParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_CreateSubstitute_Call) Return(_a0 db.IngredientSubstitute, _a1 error) *MockQuerier_CreateSubstitute_Call {
+func (_c *MockQuerier_ArchiveIngredient_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_ArchiveIngredient_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_CreateSubstitute_Call) RunAndReturn(run func(context.Context, db.CreateSubstituteParams) (db.IngredientSubstitute, error)) *MockQuerier_CreateSubstitute_Call {
+func (_c *MockQuerier_ArchiveIngredient_Call) RunAndReturn(run func(context.Context, db.ArchiveIngredientParams) (db.Ingredient, error)) *MockQuerier_ArchiveIngredient_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUnitConversion provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) CreateUnitConversion(ctx context.Context, arg db.CreateUnitConversionParams) (db.UnitConversion, error) {
+// CreateCategory provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateCategory(ctx context.Context, arg db.CreateCategoryParams) (db.Category, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUnitConversion")
+		panic("no return value specified for CreateCategory")
 	}
 
-	var r0 db.UnitConversion
+	var r0 db.Category
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUnitConversionParams) (db.UnitConversion, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCategoryParams) (db.Category, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUnitConversionParams) db.UnitConversion); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCategoryParams) db.Category); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.UnitConversion)
+		r0 = ret.Get(0).(db.Category)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateUnitConversionParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateCategoryParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -165,150 +166,170 @@ func (_m *MockQuerier) CreateUnitConversion(ctx context.Context, arg db.CreateUn
 	return r0, r1
 }
 
-// MockQuerier_CreateUnitConversion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUnitConversion'
-type MockQuerier_CreateUnitConversion_Call struct {
+// MockQuerier_CreateCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCategory'
+type MockQuerier_CreateCategory_Call struct {
 	*mock.Call
 }
 
-// CreateUnitConversion is a helper method to define mock.On call
+// CreateCategory is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateUnitConversionParams
-func (_e *MockQuerier_Expecter) CreateUnitConversion(ctx interface{}, arg interface{}) *MockQuerier_CreateUnitConversion_Call {
-	return &MockQuerier_CreateUnitConversion_Call{Call: _e.mock.On("CreateUnitConversion", ctx, arg)}
+//   - arg db.CreateCategoryParams
+func (_e *MockQuerier_Expecter) CreateCategory(ctx interface{}, arg interface{}) *MockQuerier_CreateCategory_Call {
+	return &MockQuerier_CreateCategory_Call{Call: _e.mock.On("CreateCategory", ctx, arg)}
 }
 
-func (_c *MockQuerier_CreateUnitConversion_Call) Run(run func(ctx context.Context, arg db.CreateUnitConversionParams)) *MockQuerier_CreateUnitConversion_Call {
+func (_c *MockQuerier_CreateCategory_Call) Run(run func(ctx context.Context, arg db.CreateCategoryParams)) *MockQuerier_CreateCategory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.CreateUnitConversionParams))
+		run(args[0].(context.Context), args[1].(db.CreateCategoryParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_CreateUnitConversion_Call) Return(_a0 db.UnitConversion, _a1 error) *MockQuerier_CreateUnitConversion_Call {
+func (_c *MockQuerier_CreateCategory_Call) Return(_a0 db.Category, _a1 error) *MockQuerier_CreateCategory_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_CreateUnitConversion_Call) RunAndReturn(run func(context.Context, db.CreateUnitConversionParams) (db.UnitConversion, error)) *MockQuerier_CreateUnitConversion_Call {
+func (_c *MockQuerier_CreateCategory_Call) RunAndReturn(run func(context.Context, db.CreateCategoryParams) (db.Category, error)) *MockQuerier_CreateCategory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteIngredient provides a mock function with given fields: ctx, id
-func (_m *MockQuerier) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
-	ret := _m.Called(ctx, id)
+// CreateCompositeComponent provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateCompositeComponent(ctx context.Context, arg db.CreateCompositeComponentParams) (db.CompositeSubstituteComponent, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteIngredient")
+		panic("no return value specified for CreateCompositeComponent")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, id)
+	var r0 db.CompositeSubstituteComponent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCompositeComponentParams) (db.CompositeSubstituteComponent, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCompositeComponentParams) db.CompositeSubstituteComponent); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.CompositeSubstituteComponent)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateCompositeComponentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockQuerier_DeleteIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIngredient'
-type MockQuerier_DeleteIngredient_Call struct {
+// MockQuerier_CreateCompositeComponent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCompositeComponent'
+type MockQuerier_CreateCompositeComponent_Call struct {
 	*mock.Call
 }
 
-// DeleteIngredient is a helper method to define mock.On call
+// CreateCompositeComponent is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockQuerier_Expecter) DeleteIngredient(ctx interface{}, id interface{}) *MockQuerier_DeleteIngredient_Call {
-	return &MockQuerier_DeleteIngredient_Call{Call: _e.mock.On("DeleteIngredient", ctx, id)}
+//   - arg db.CreateCompositeComponentParams
+func (_e *MockQuerier_Expecter) CreateCompositeComponent(ctx interface{}, arg interface{}) *MockQuerier_CreateCompositeComponent_Call {
+	return &MockQuerier_CreateCompositeComponent_Call{Call: _e.mock.On("CreateCompositeComponent", ctx, arg)}
 }
 
-func (_c *MockQuerier_DeleteIngredient_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_DeleteIngredient_Call {
+func (_c *MockQuerier_CreateCompositeComponent_Call) Run(run func(ctx context.Context, arg db.CreateCompositeComponentParams)) *MockQuerier_CreateCompositeComponent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(db.CreateCompositeComponentParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_DeleteIngredient_Call) Return(_a0 error) *MockQuerier_DeleteIngredient_Call {
-	_c.Call.Return(_a0)
+func (_c *MockQuerier_CreateCompositeComponent_Call) Return(_a0 db.CompositeSubstituteComponent, _a1 error) *MockQuerier_CreateCompositeComponent_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_DeleteIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteIngredient_Call {
+func (_c *MockQuerier_CreateCompositeComponent_Call) RunAndReturn(run func(context.Context, db.CreateCompositeComponentParams) (db.CompositeSubstituteComponent, error)) *MockQuerier_CreateCompositeComponent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteSubstitutesByIngredient provides a mock function with given fields: ctx, ingredientID
-func (_m *MockQuerier) DeleteSubstitutesByIngredient(ctx context.Context, ingredientID uuid.UUID) error {
-	ret := _m.Called(ctx, ingredientID)
+// CreateCompositeSubstitute provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateCompositeSubstitute(ctx context.Context, arg db.CreateCompositeSubstituteParams) (db.CompositeSubstitute, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteSubstitutesByIngredient")
+		panic("no return value specified for CreateCompositeSubstitute")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, ingredientID)
+	var r0 db.CompositeSubstitute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCompositeSubstituteParams) (db.CompositeSubstitute, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCompositeSubstituteParams) db.CompositeSubstitute); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.CompositeSubstitute)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateCompositeSubstituteParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockQuerier_DeleteSubstitutesByIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubstitutesByIngredient'
-type MockQuerier_DeleteSubstitutesByIngredient_Call struct {
+// MockQuerier_CreateCompositeSubstitute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCompositeSubstitute'
+type MockQuerier_CreateCompositeSubstitute_Call struct {
 	*mock.Call
 }
 
-// DeleteSubstitutesByIngredient is a helper method to define mock.On call
+// CreateCompositeSubstitute is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ingredientID uuid.UUID
-func (_e *MockQuerier_Expecter) DeleteSubstitutesByIngredient(ctx interface{}, ingredientID interface{}) *MockQuerier_DeleteSubstitutesByIngredient_Call {
-	return &MockQuerier_DeleteSubstitutesByIngredient_Call{Call: _e.mock.On("DeleteSubstitutesByIngredient", ctx, ingredientID)}
+//   - arg db.CreateCompositeSubstituteParams
+func (_e *MockQuerier_Expecter) CreateCompositeSubstitute(ctx interface{}, arg interface{}) *MockQuerier_CreateCompositeSubstitute_Call {
+	return &MockQuerier_CreateCompositeSubstitute_Call{Call: _e.mock.On("CreateCompositeSubstitute", ctx, arg)}
 }
 
-func (_c *MockQuerier_DeleteSubstitutesByIngredient_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_DeleteSubstitutesByIngredient_Call {
+func (_c *MockQuerier_CreateCompositeSubstitute_Call) Run(run func(ctx context.Context, arg db.CreateCompositeSubstituteParams)) *MockQuerier_CreateCompositeSubstitute_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(db.CreateCompositeSubstituteParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_DeleteSubstitutesByIngredient_Call) Return(_a0 error) *MockQuerier_DeleteSubstitutesByIngredient_Call {
-	_c.Call.Return(_a0)
+func (_c *MockQuerier_CreateCompositeSubstitute_Call) Return(_a0 db.CompositeSubstitute, _a1 error) *MockQuerier_CreateCompositeSubstitute_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_DeleteSubstitutesByIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteSubstitutesByIngredient_Call {
+func (_c *MockQuerier_CreateCompositeSubstitute_Call) RunAndReturn(run func(context.Context, db.CreateCompositeSubstituteParams) (db.CompositeSubstitute, error)) *MockQuerier_CreateCompositeSubstitute_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetIngredient provides a mock function with given fields: ctx, id
-func (_m *MockQuerier) GetIngredient(ctx context.Context, id uuid.UUID) (db.Ingredient, error) {
-	ret := _m.Called(ctx, id)
+// CreateIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateIngredient(ctx context.Context, arg db.CreateIngredientParams) (db.Ingredient, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetIngredient")
+		panic("no return value specified for CreateIngredient")
 	}
 
 	var r0 db.Ingredient
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.Ingredient, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIngredientParams) (db.Ingredient, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.Ingredient); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIngredientParams) db.Ingredient); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(db.Ingredient)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -316,56 +337,56 @@ func (_m *MockQuerier) GetIngredient(ctx context.Context, id uuid.UUID) (db.Ingr
 	return r0, r1
 }
 
-// MockQuerier_GetIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngredient'
-type MockQuerier_GetIngredient_Call struct {
+// MockQuerier_CreateIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIngredient'
+type MockQuerier_CreateIngredient_Call struct {
 	*mock.Call
 }
 
-// GetIngredient is a helper method to define mock.On call
+// CreateIngredient is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockQuerier_Expecter) GetIngredient(ctx interface{}, id interface{}) *MockQuerier_GetIngredient_Call {
-	return &MockQuerier_GetIngredient_Call{Call: _e.mock.On("GetIngredient", ctx, id)}
+//   - arg db.CreateIngredientParams
+func (_e *MockQuerier_Expecter) CreateIngredient(ctx interface{}, arg interface{}) *MockQuerier_CreateIngredient_Call {
+	return &MockQuerier_CreateIngredient_Call{Call: _e.mock.On("CreateIngredient", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetIngredient_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_GetIngredient_Call {
+func (_c *MockQuerier_CreateIngredient_Call) Run(run func(ctx context.Context, arg db.CreateIngredientParams)) *MockQuerier_CreateIngredient_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(db.CreateIngredientParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_GetIngredient_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_GetIngredient_Call {
+func (_c *MockQuerier_CreateIngredient_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_CreateIngredient_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_GetIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.Ingredient, error)) *MockQuerier_GetIngredient_Call {
+func (_c *MockQuerier_CreateIngredient_Call) RunAndReturn(run func(context.Context, db.CreateIngredientParams) (db.Ingredient, error)) *MockQuerier_CreateIngredient_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetIngredientByName provides a mock function with given fields: ctx, name
-func (_m *MockQuerier) GetIngredientByName(ctx context.Context, name string) (db.Ingredient, error) {
-	ret := _m.Called(ctx, name)
+// CreateIngredientMerge provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateIngredientMerge(ctx context.Context, arg db.CreateIngredientMergeParams) (db.IngredientMerge, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetIngredientByName")
+		panic("no return value specified for CreateIngredientMerge")
 	}
 
-	var r0 db.Ingredient
+	var r0 db.IngredientMerge
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Ingredient, error)); ok {
-		return rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIngredientMergeParams) (db.IngredientMerge, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.Ingredient); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIngredientMergeParams) db.IngredientMerge); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Ingredient)
+		r0 = ret.Get(0).(db.IngredientMerge)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateIngredientMergeParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -373,116 +394,103 @@ func (_m *MockQuerier) GetIngredientByName(ctx context.Context, name string) (db
 	return r0, r1
 }
 
-// MockQuerier_GetIngredientByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngredientByName'
-type MockQuerier_GetIngredientByName_Call struct {
+// MockQuerier_CreateIngredientMerge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIngredientMerge'
+type MockQuerier_CreateIngredientMerge_Call struct {
 	*mock.Call
 }
 
-// GetIngredientByName is a helper method to define mock.On call
+// CreateIngredientMerge is a helper method to define mock.On call
 //   - ctx context.Context
-//   - name string
-func (_e *MockQuerier_Expecter) GetIngredientByName(ctx interface{}, name interface{}) *MockQuerier_GetIngredientByName_Call {
-	return &MockQuerier_GetIngredientByName_Call{Call: _e.mock.On("GetIngredientByName", ctx, name)}
+//   - arg db.CreateIngredientMergeParams
+func (_e *MockQuerier_Expecter) CreateIngredientMerge(ctx interface{}, arg interface{}) *MockQuerier_CreateIngredientMerge_Call {
+	return &MockQuerier_CreateIngredientMerge_Call{Call: _e.mock.On("CreateIngredientMerge", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetIngredientByName_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_GetIngredientByName_Call {
+func (_c *MockQuerier_CreateIngredientMerge_Call) Run(run func(ctx context.Context, arg db.CreateIngredientMergeParams)) *MockQuerier_CreateIngredientMerge_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(db.CreateIngredientMergeParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_GetIngredientByName_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_GetIngredientByName_Call {
+func (_c *MockQuerier_CreateIngredientMerge_Call) Return(_a0 db.IngredientMerge, _a1 error) *MockQuerier_CreateIngredientMerge_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_GetIngredientByName_Call) RunAndReturn(run func(context.Context, string) (db.Ingredient, error)) *MockQuerier_GetIngredientByName_Call {
+func (_c *MockQuerier_CreateIngredientMerge_Call) RunAndReturn(run func(context.Context, db.CreateIngredientMergeParams) (db.IngredientMerge, error)) *MockQuerier_CreateIngredientMerge_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListIngredients provides a mock function with given fields: ctx
-func (_m *MockQuerier) ListIngredients(ctx context.Context) ([]db.Ingredient, error) {
-	ret := _m.Called(ctx)
+// CreateIngredientRedirect provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateIngredientRedirect(ctx context.Context, arg db.CreateIngredientRedirectParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListIngredients")
-	}
-
-	var r0 []db.Ingredient
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]db.Ingredient, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) []db.Ingredient); ok {
-		r0 = rf(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Ingredient)
-		}
+		panic("no return value specified for CreateIngredientRedirect")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIngredientRedirectParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockQuerier_ListIngredients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredients'
-type MockQuerier_ListIngredients_Call struct {
+// MockQuerier_CreateIngredientRedirect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIngredientRedirect'
+type MockQuerier_CreateIngredientRedirect_Call struct {
 	*mock.Call
 }
 
-// ListIngredients is a helper method to define mock.On call
+// CreateIngredientRedirect is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockQuerier_Expecter) ListIngredients(ctx interface{}) *MockQuerier_ListIngredients_Call {
-	return &MockQuerier_ListIngredients_Call{Call: _e.mock.On("ListIngredients", ctx)}
+//   - arg db.CreateIngredientRedirectParams
+func (_e *MockQuerier_Expecter) CreateIngredientRedirect(ctx interface{}, arg interface{}) *MockQuerier_CreateIngredientRedirect_Call {
+	return &MockQuerier_CreateIngredientRedirect_Call{Call: _e.mock.On("CreateIngredientRedirect", ctx, arg)}
 }
 
-func (_c *MockQuerier_ListIngredients_Call) Run(run func(ctx context.Context)) *MockQuerier_ListIngredients_Call {
+func (_c *MockQuerier_CreateIngredientRedirect_Call) Run(run func(ctx context.Context, arg db.CreateIngredientRedirectParams)) *MockQuerier_CreateIngredientRedirect_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(db.CreateIngredientRedirectParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ListIngredients_Call) Return(_a0 []db.Ingredient, _a1 error) *MockQuerier_ListIngredients_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockQuerier_CreateIngredientRedirect_Call) Return(_a0 error) *MockQuerier_CreateIngredientRedirect_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockQuerier_ListIngredients_Call) RunAndReturn(run func(context.Context) ([]db.Ingredient, error)) *MockQuerier_ListIngredients_Call {
+func (_c *MockQuerier_CreateIngredientRedirect_Call) RunAndReturn(run func(context.Context, db.CreateIngredientRedirectParams) error) *MockQuerier_CreateIngredientRedirect_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListSubstitutesByIngredient provides a mock function with given fields: ctx, ingredientID
-func (_m *MockQuerier) ListSubstitutesByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]db.IngredientSubstitute, error) {
-	ret := _m.Called(ctx, ingredientID)
+// CreateSubstitute provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateSubstitute(ctx context.Context, arg db.CreateSubstituteParams) (db.IngredientSubstitute, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListSubstitutesByIngredient")
+		panic("no return value specified for CreateSubstitute")
 	}
 
-	var r0 []db.IngredientSubstitute
+	var r0 db.IngredientSubstitute
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.IngredientSubstitute, error)); ok {
-		return rf(ctx, ingredientID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateSubstituteParams) (db.IngredientSubstitute, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.IngredientSubstitute); ok {
-		r0 = rf(ctx, ingredientID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateSubstituteParams) db.IngredientSubstitute); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.IngredientSubstitute)
-		}
+		r0 = ret.Get(0).(db.IngredientSubstitute)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, ingredientID)
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateSubstituteParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -490,58 +498,4854 @@ func (_m *MockQuerier) ListSubstitutesByIngredient(ctx context.Context, ingredie
 	return r0, r1
 }
 
-// MockQuerier_ListSubstitutesByIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubstitutesByIngredient'
-type MockQuerier_ListSubstitutesByIngredient_Call struct {
+// MockQuerier_CreateSubstitute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSubstitute'
+type MockQuerier_CreateSubstitute_Call struct {
 	*mock.Call
 }
 
-// ListSubstitutesByIngredient is a helper method to define mock.On call
+// CreateSubstitute is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ingredientID uuid.UUID
+//   - arg db.CreateSubstituteParams
+func (_e *MockQuerier_Expecter) CreateSubstitute(ctx interface{}, arg interface{}) *MockQuerier_CreateSubstitute_Call {
+	return &MockQuerier_CreateSubstitute_Call{Call: _e.mock.On("CreateSubstitute", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateSubstitute_Call) Run(run func(ctx context.Context, arg db.CreateSubstituteParams)) *MockQuerier_CreateSubstitute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateSubstituteParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateSubstitute_Call) Return(_a0 db.IngredientSubstitute, _a1 error) *MockQuerier_CreateSubstitute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateSubstitute_Call) RunAndReturn(run func(context.Context, db.CreateSubstituteParams) (db.IngredientSubstitute, error)) *MockQuerier_CreateSubstitute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUnitConversion provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateUnitConversion(ctx context.Context, arg db.CreateUnitConversionParams) (db.UnitConversion, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUnitConversion")
+	}
+
+	var r0 db.UnitConversion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUnitConversionParams) (db.UnitConversion, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUnitConversionParams) db.UnitConversion); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.UnitConversion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateUnitConversionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateUnitConversion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUnitConversion'
+type MockQuerier_CreateUnitConversion_Call struct {
+	*mock.Call
+}
+
+// CreateUnitConversion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateUnitConversionParams
+func (_e *MockQuerier_Expecter) CreateUnitConversion(ctx interface{}, arg interface{}) *MockQuerier_CreateUnitConversion_Call {
+	return &MockQuerier_CreateUnitConversion_Call{Call: _e.mock.On("CreateUnitConversion", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateUnitConversion_Call) Run(run func(ctx context.Context, arg db.CreateUnitConversionParams)) *MockQuerier_CreateUnitConversion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateUnitConversionParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateUnitConversion_Call) Return(_a0 db.UnitConversion, _a1 error) *MockQuerier_CreateUnitConversion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateUnitConversion_Call) RunAndReturn(run func(context.Context, db.CreateUnitConversionParams) (db.UnitConversion, error)) *MockQuerier_CreateUnitConversion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteArchivedIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) DeleteArchivedIngredient(ctx context.Context, arg db.DeleteArchivedIngredientParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteArchivedIngredient")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteArchivedIngredientParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteArchivedIngredientParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteArchivedIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteArchivedIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteArchivedIngredient'
+type MockQuerier_DeleteArchivedIngredient_Call struct {
+	*mock.Call
+}
+
+// DeleteArchivedIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteArchivedIngredientParams
+func (_e *MockQuerier_Expecter) DeleteArchivedIngredient(ctx interface{}, arg interface{}) *MockQuerier_DeleteArchivedIngredient_Call {
+	return &MockQuerier_DeleteArchivedIngredient_Call{Call: _e.mock.On("DeleteArchivedIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteArchivedIngredient_Call) Run(run func(ctx context.Context, arg db.DeleteArchivedIngredientParams)) *MockQuerier_DeleteArchivedIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeleteArchivedIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteArchivedIngredient_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteArchivedIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteArchivedIngredient_Call) RunAndReturn(run func(context.Context, db.DeleteArchivedIngredientParams) (int64, error)) *MockQuerier_DeleteArchivedIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCategory provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) DeleteCategory(ctx context.Context, id uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategory")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCategory'
+type MockQuerier_DeleteCategory_Call struct {
+	*mock.Call
+}
+
+// DeleteCategory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteCategory(ctx interface{}, id interface{}) *MockQuerier_DeleteCategory_Call {
+	return &MockQuerier_DeleteCategory_Call{Call: _e.mock.On("DeleteCategory", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteCategory_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_DeleteCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCategory_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteCategory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCategory_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockQuerier_DeleteCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCategoryStorageGuidelines provides a mock function with given fields: ctx, categoryID
+func (_m *MockQuerier) DeleteCategoryStorageGuidelines(ctx context.Context, categoryID uuid.UUID) error {
+	ret := _m.Called(ctx, categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategoryStorageGuidelines")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, categoryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteCategoryStorageGuidelines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCategoryStorageGuidelines'
+type MockQuerier_DeleteCategoryStorageGuidelines_Call struct {
+	*mock.Call
+}
+
+// DeleteCategoryStorageGuidelines is a helper method to define mock.On call
+//   - ctx context.Context
+//   - categoryID uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteCategoryStorageGuidelines(ctx interface{}, categoryID interface{}) *MockQuerier_DeleteCategoryStorageGuidelines_Call {
+	return &MockQuerier_DeleteCategoryStorageGuidelines_Call{Call: _e.mock.On("DeleteCategoryStorageGuidelines", ctx, categoryID)}
+}
+
+func (_c *MockQuerier_DeleteCategoryStorageGuidelines_Call) Run(run func(ctx context.Context, categoryID uuid.UUID)) *MockQuerier_DeleteCategoryStorageGuidelines_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCategoryStorageGuidelines_Call) Return(_a0 error) *MockQuerier_DeleteCategoryStorageGuidelines_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCategoryStorageGuidelines_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteCategoryStorageGuidelines_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCompositeComponent provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) DeleteCompositeComponent(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCompositeComponent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteCompositeComponent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCompositeComponent'
+type MockQuerier_DeleteCompositeComponent_Call struct {
+	*mock.Call
+}
+
+// DeleteCompositeComponent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteCompositeComponent(ctx interface{}, id interface{}) *MockQuerier_DeleteCompositeComponent_Call {
+	return &MockQuerier_DeleteCompositeComponent_Call{Call: _e.mock.On("DeleteCompositeComponent", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteCompositeComponent_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_DeleteCompositeComponent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCompositeComponent_Call) Return(_a0 error) *MockQuerier_DeleteCompositeComponent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCompositeComponent_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteCompositeComponent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCompositeSubstitute provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) DeleteCompositeSubstitute(ctx context.Context, arg db.DeleteCompositeSubstituteParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCompositeSubstitute")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteCompositeSubstituteParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteCompositeSubstituteParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteCompositeSubstituteParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteCompositeSubstitute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCompositeSubstitute'
+type MockQuerier_DeleteCompositeSubstitute_Call struct {
+	*mock.Call
+}
+
+// DeleteCompositeSubstitute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteCompositeSubstituteParams
+func (_e *MockQuerier_Expecter) DeleteCompositeSubstitute(ctx interface{}, arg interface{}) *MockQuerier_DeleteCompositeSubstitute_Call {
+	return &MockQuerier_DeleteCompositeSubstitute_Call{Call: _e.mock.On("DeleteCompositeSubstitute", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteCompositeSubstitute_Call) Run(run func(ctx context.Context, arg db.DeleteCompositeSubstituteParams)) *MockQuerier_DeleteCompositeSubstitute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeleteCompositeSubstituteParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCompositeSubstitute_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteCompositeSubstitute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCompositeSubstitute_Call) RunAndReturn(run func(context.Context, db.DeleteCompositeSubstituteParams) (int64, error)) *MockQuerier_DeleteCompositeSubstitute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIngredient provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIngredient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIngredient'
+type MockQuerier_DeleteIngredient_Call struct {
+	*mock.Call
+}
+
+// DeleteIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteIngredient(ctx interface{}, id interface{}) *MockQuerier_DeleteIngredient_Call {
+	return &MockQuerier_DeleteIngredient_Call{Call: _e.mock.On("DeleteIngredient", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteIngredient_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_DeleteIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredient_Call) Return(_a0 error) *MockQuerier_DeleteIngredient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIngredientAlias provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) DeleteIngredientAlias(ctx context.Context, arg db.DeleteIngredientAliasParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIngredientAlias")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteIngredientAliasParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteIngredientAliasParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteIngredientAliasParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteIngredientAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIngredientAlias'
+type MockQuerier_DeleteIngredientAlias_Call struct {
+	*mock.Call
+}
+
+// DeleteIngredientAlias is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteIngredientAliasParams
+func (_e *MockQuerier_Expecter) DeleteIngredientAlias(ctx interface{}, arg interface{}) *MockQuerier_DeleteIngredientAlias_Call {
+	return &MockQuerier_DeleteIngredientAlias_Call{Call: _e.mock.On("DeleteIngredientAlias", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteIngredientAlias_Call) Run(run func(ctx context.Context, arg db.DeleteIngredientAliasParams)) *MockQuerier_DeleteIngredientAlias_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeleteIngredientAliasParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredientAlias_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteIngredientAlias_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredientAlias_Call) RunAndReturn(run func(context.Context, db.DeleteIngredientAliasParams) (int64, error)) *MockQuerier_DeleteIngredientAlias_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIngredientRedirect provides a mock function with given fields: ctx, oldID
+func (_m *MockQuerier) DeleteIngredientRedirect(ctx context.Context, oldID uuid.UUID) error {
+	ret := _m.Called(ctx, oldID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIngredientRedirect")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, oldID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteIngredientRedirect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIngredientRedirect'
+type MockQuerier_DeleteIngredientRedirect_Call struct {
+	*mock.Call
+}
+
+// DeleteIngredientRedirect is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldID uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteIngredientRedirect(ctx interface{}, oldID interface{}) *MockQuerier_DeleteIngredientRedirect_Call {
+	return &MockQuerier_DeleteIngredientRedirect_Call{Call: _e.mock.On("DeleteIngredientRedirect", ctx, oldID)}
+}
+
+func (_c *MockQuerier_DeleteIngredientRedirect_Call) Run(run func(ctx context.Context, oldID uuid.UUID)) *MockQuerier_DeleteIngredientRedirect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredientRedirect_Call) Return(_a0 error) *MockQuerier_DeleteIngredientRedirect_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredientRedirect_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteIngredientRedirect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIngredientStorageGuidelines provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) DeleteIngredientStorageGuidelines(ctx context.Context, ingredientID uuid.UUID) error {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIngredientStorageGuidelines")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteIngredientStorageGuidelines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIngredientStorageGuidelines'
+type MockQuerier_DeleteIngredientStorageGuidelines_Call struct {
+	*mock.Call
+}
+
+// DeleteIngredientStorageGuidelines is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteIngredientStorageGuidelines(ctx interface{}, ingredientID interface{}) *MockQuerier_DeleteIngredientStorageGuidelines_Call {
+	return &MockQuerier_DeleteIngredientStorageGuidelines_Call{Call: _e.mock.On("DeleteIngredientStorageGuidelines", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_DeleteIngredientStorageGuidelines_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_DeleteIngredientStorageGuidelines_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredientStorageGuidelines_Call) Return(_a0 error) *MockQuerier_DeleteIngredientStorageGuidelines_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredientStorageGuidelines_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteIngredientStorageGuidelines_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRedundantMergeSubstitutes provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) DeleteRedundantMergeSubstitutes(ctx context.Context, arg db.DeleteRedundantMergeSubstitutesParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRedundantMergeSubstitutes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteRedundantMergeSubstitutesParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteRedundantMergeSubstitutes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRedundantMergeSubstitutes'
+type MockQuerier_DeleteRedundantMergeSubstitutes_Call struct {
+	*mock.Call
+}
+
+// DeleteRedundantMergeSubstitutes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteRedundantMergeSubstitutesParams
+func (_e *MockQuerier_Expecter) DeleteRedundantMergeSubstitutes(ctx interface{}, arg interface{}) *MockQuerier_DeleteRedundantMergeSubstitutes_Call {
+	return &MockQuerier_DeleteRedundantMergeSubstitutes_Call{Call: _e.mock.On("DeleteRedundantMergeSubstitutes", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteRedundantMergeSubstitutes_Call) Run(run func(ctx context.Context, arg db.DeleteRedundantMergeSubstitutesParams)) *MockQuerier_DeleteRedundantMergeSubstitutes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeleteRedundantMergeSubstitutesParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteRedundantMergeSubstitutes_Call) Return(_a0 error) *MockQuerier_DeleteRedundantMergeSubstitutes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteRedundantMergeSubstitutes_Call) RunAndReturn(run func(context.Context, db.DeleteRedundantMergeSubstitutesParams) error) *MockQuerier_DeleteRedundantMergeSubstitutes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSelfReferencingComposites provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) DeleteSelfReferencingComposites(ctx context.Context, ingredientID uuid.UUID) error {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSelfReferencingComposites")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteSelfReferencingComposites_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSelfReferencingComposites'
+type MockQuerier_DeleteSelfReferencingComposites_Call struct {
+	*mock.Call
+}
+
+// DeleteSelfReferencingComposites is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteSelfReferencingComposites(ctx interface{}, ingredientID interface{}) *MockQuerier_DeleteSelfReferencingComposites_Call {
+	return &MockQuerier_DeleteSelfReferencingComposites_Call{Call: _e.mock.On("DeleteSelfReferencingComposites", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_DeleteSelfReferencingComposites_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_DeleteSelfReferencingComposites_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteSelfReferencingComposites_Call) Return(_a0 error) *MockQuerier_DeleteSelfReferencingComposites_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteSelfReferencingComposites_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteSelfReferencingComposites_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSubstitute provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) DeleteSubstitute(ctx context.Context, arg db.DeleteSubstituteParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubstitute")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteSubstituteParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteSubstituteParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteSubstituteParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteSubstitute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubstitute'
+type MockQuerier_DeleteSubstitute_Call struct {
+	*mock.Call
+}
+
+// DeleteSubstitute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteSubstituteParams
+func (_e *MockQuerier_Expecter) DeleteSubstitute(ctx interface{}, arg interface{}) *MockQuerier_DeleteSubstitute_Call {
+	return &MockQuerier_DeleteSubstitute_Call{Call: _e.mock.On("DeleteSubstitute", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteSubstitute_Call) Run(run func(ctx context.Context, arg db.DeleteSubstituteParams)) *MockQuerier_DeleteSubstitute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeleteSubstituteParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteSubstitute_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteSubstitute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteSubstitute_Call) RunAndReturn(run func(context.Context, db.DeleteSubstituteParams) (int64, error)) *MockQuerier_DeleteSubstitute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSubstitutesByIngredient provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) DeleteSubstitutesByIngredient(ctx context.Context, ingredientID uuid.UUID) error {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubstitutesByIngredient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteSubstitutesByIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubstitutesByIngredient'
+type MockQuerier_DeleteSubstitutesByIngredient_Call struct {
+	*mock.Call
+}
+
+// DeleteSubstitutesByIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteSubstitutesByIngredient(ctx interface{}, ingredientID interface{}) *MockQuerier_DeleteSubstitutesByIngredient_Call {
+	return &MockQuerier_DeleteSubstitutesByIngredient_Call{Call: _e.mock.On("DeleteSubstitutesByIngredient", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_DeleteSubstitutesByIngredient_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_DeleteSubstitutesByIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteSubstitutesByIngredient_Call) Return(_a0 error) *MockQuerier_DeleteSubstitutesByIngredient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteSubstitutesByIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteSubstitutesByIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUndersizedComposites provides a mock function with given fields: ctx, ids
+func (_m *MockQuerier) DeleteUndersizedComposites(ctx context.Context, ids []uuid.UUID) ([]db.CompositeSubstitute, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUndersizedComposites")
+	}
+
+	var r0 []db.CompositeSubstitute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]db.CompositeSubstitute, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []db.CompositeSubstitute); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CompositeSubstitute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteUndersizedComposites_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUndersizedComposites'
+type MockQuerier_DeleteUndersizedComposites_Call struct {
+	*mock.Call
+}
+
+// DeleteUndersizedComposites is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteUndersizedComposites(ctx interface{}, ids interface{}) *MockQuerier_DeleteUndersizedComposites_Call {
+	return &MockQuerier_DeleteUndersizedComposites_Call{Call: _e.mock.On("DeleteUndersizedComposites", ctx, ids)}
+}
+
+func (_c *MockQuerier_DeleteUndersizedComposites_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockQuerier_DeleteUndersizedComposites_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUndersizedComposites_Call) Return(_a0 []db.CompositeSubstitute, _a1 error) *MockQuerier_DeleteUndersizedComposites_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUndersizedComposites_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]db.CompositeSubstitute, error)) *MockQuerier_DeleteUndersizedComposites_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUnitConversion provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) DeleteUnitConversion(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnitConversion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteUnitConversion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUnitConversion'
+type MockQuerier_DeleteUnitConversion_Call struct {
+	*mock.Call
+}
+
+// DeleteUnitConversion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteUnitConversion(ctx interface{}, id interface{}) *MockQuerier_DeleteUnitConversion_Call {
+	return &MockQuerier_DeleteUnitConversion_Call{Call: _e.mock.On("DeleteUnitConversion", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteUnitConversion_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_DeleteUnitConversion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUnitConversion_Call) Return(_a0 error) *MockQuerier_DeleteUnitConversion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUnitConversion_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteUnitConversion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCategory provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetCategory(ctx context.Context, id uuid.UUID) (db.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategory")
+	}
+
+	var r0 db.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.Category, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Category)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCategory'
+type MockQuerier_GetCategory_Call struct {
+	*mock.Call
+}
+
+// GetCategory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) GetCategory(ctx interface{}, id interface{}) *MockQuerier_GetCategory_Call {
+	return &MockQuerier_GetCategory_Call{Call: _e.mock.On("GetCategory", ctx, id)}
+}
+
+func (_c *MockQuerier_GetCategory_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_GetCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCategory_Call) Return(_a0 db.Category, _a1 error) *MockQuerier_GetCategory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCategory_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.Category, error)) *MockQuerier_GetCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCategoryBySlug provides a mock function with given fields: ctx, slug
+func (_m *MockQuerier) GetCategoryBySlug(ctx context.Context, slug string) (db.Category, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryBySlug")
+	}
+
+	var r0 db.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Category, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.Category); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(db.Category)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCategoryBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCategoryBySlug'
+type MockQuerier_GetCategoryBySlug_Call struct {
+	*mock.Call
+}
+
+// GetCategoryBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *MockQuerier_Expecter) GetCategoryBySlug(ctx interface{}, slug interface{}) *MockQuerier_GetCategoryBySlug_Call {
+	return &MockQuerier_GetCategoryBySlug_Call{Call: _e.mock.On("GetCategoryBySlug", ctx, slug)}
+}
+
+func (_c *MockQuerier_GetCategoryBySlug_Call) Run(run func(ctx context.Context, slug string)) *MockQuerier_GetCategoryBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCategoryBySlug_Call) Return(_a0 db.Category, _a1 error) *MockQuerier_GetCategoryBySlug_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCategoryBySlug_Call) RunAndReturn(run func(context.Context, string) (db.Category, error)) *MockQuerier_GetCategoryBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIngredient provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetIngredient(ctx context.Context, id uuid.UUID) (db.Ingredient, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIngredient")
+	}
+
+	var r0 db.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.Ingredient, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.Ingredient); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Ingredient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngredient'
+type MockQuerier_GetIngredient_Call struct {
+	*mock.Call
+}
+
+// GetIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) GetIngredient(ctx interface{}, id interface{}) *MockQuerier_GetIngredient_Call {
+	return &MockQuerier_GetIngredient_Call{Call: _e.mock.On("GetIngredient", ctx, id)}
+}
+
+func (_c *MockQuerier_GetIngredient_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_GetIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredient_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_GetIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.Ingredient, error)) *MockQuerier_GetIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIngredientAlias provides a mock function with given fields: ctx, alias
+func (_m *MockQuerier) GetIngredientAlias(ctx context.Context, alias string) (db.IngredientAlias, error) {
+	ret := _m.Called(ctx, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIngredientAlias")
+	}
+
+	var r0 db.IngredientAlias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.IngredientAlias, error)); ok {
+		return rf(ctx, alias)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.IngredientAlias); ok {
+		r0 = rf(ctx, alias)
+	} else {
+		r0 = ret.Get(0).(db.IngredientAlias)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, alias)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetIngredientAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngredientAlias'
+type MockQuerier_GetIngredientAlias_Call struct {
+	*mock.Call
+}
+
+// GetIngredientAlias is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alias string
+func (_e *MockQuerier_Expecter) GetIngredientAlias(ctx interface{}, alias interface{}) *MockQuerier_GetIngredientAlias_Call {
+	return &MockQuerier_GetIngredientAlias_Call{Call: _e.mock.On("GetIngredientAlias", ctx, alias)}
+}
+
+func (_c *MockQuerier_GetIngredientAlias_Call) Run(run func(ctx context.Context, alias string)) *MockQuerier_GetIngredientAlias_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientAlias_Call) Return(_a0 db.IngredientAlias, _a1 error) *MockQuerier_GetIngredientAlias_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientAlias_Call) RunAndReturn(run func(context.Context, string) (db.IngredientAlias, error)) *MockQuerier_GetIngredientAlias_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIngredientByName provides a mock function with given fields: ctx, name
+func (_m *MockQuerier) GetIngredientByName(ctx context.Context, name string) (db.Ingredient, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIngredientByName")
+	}
+
+	var r0 db.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Ingredient, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.Ingredient); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(db.Ingredient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetIngredientByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngredientByName'
+type MockQuerier_GetIngredientByName_Call struct {
+	*mock.Call
+}
+
+// GetIngredientByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockQuerier_Expecter) GetIngredientByName(ctx interface{}, name interface{}) *MockQuerier_GetIngredientByName_Call {
+	return &MockQuerier_GetIngredientByName_Call{Call: _e.mock.On("GetIngredientByName", ctx, name)}
+}
+
+func (_c *MockQuerier_GetIngredientByName_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_GetIngredientByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientByName_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_GetIngredientByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientByName_Call) RunAndReturn(run func(context.Context, string) (db.Ingredient, error)) *MockQuerier_GetIngredientByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIngredientForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetIngredientForUpdate(ctx context.Context, id uuid.UUID) (db.Ingredient, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIngredientForUpdate")
+	}
+
+	var r0 db.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.Ingredient, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.Ingredient); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Ingredient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetIngredientForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngredientForUpdate'
+type MockQuerier_GetIngredientForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetIngredientForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) GetIngredientForUpdate(ctx interface{}, id interface{}) *MockQuerier_GetIngredientForUpdate_Call {
+	return &MockQuerier_GetIngredientForUpdate_Call{Call: _e.mock.On("GetIngredientForUpdate", ctx, id)}
+}
+
+func (_c *MockQuerier_GetIngredientForUpdate_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_GetIngredientForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientForUpdate_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_GetIngredientForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientForUpdate_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.Ingredient, error)) *MockQuerier_GetIngredientForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIngredientHistoryAsOf provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) GetIngredientHistoryAsOf(ctx context.Context, arg db.GetIngredientHistoryAsOfParams) (db.IngredientHistory, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIngredientHistoryAsOf")
+	}
+
+	var r0 db.IngredientHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetIngredientHistoryAsOfParams) (db.IngredientHistory, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetIngredientHistoryAsOfParams) db.IngredientHistory); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IngredientHistory)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetIngredientHistoryAsOfParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetIngredientHistoryAsOf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngredientHistoryAsOf'
+type MockQuerier_GetIngredientHistoryAsOf_Call struct {
+	*mock.Call
+}
+
+// GetIngredientHistoryAsOf is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetIngredientHistoryAsOfParams
+func (_e *MockQuerier_Expecter) GetIngredientHistoryAsOf(ctx interface{}, arg interface{}) *MockQuerier_GetIngredientHistoryAsOf_Call {
+	return &MockQuerier_GetIngredientHistoryAsOf_Call{Call: _e.mock.On("GetIngredientHistoryAsOf", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetIngredientHistoryAsOf_Call) Run(run func(ctx context.Context, arg db.GetIngredientHistoryAsOfParams)) *MockQuerier_GetIngredientHistoryAsOf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.GetIngredientHistoryAsOfParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientHistoryAsOf_Call) Return(_a0 db.IngredientHistory, _a1 error) *MockQuerier_GetIngredientHistoryAsOf_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientHistoryAsOf_Call) RunAndReturn(run func(context.Context, db.GetIngredientHistoryAsOfParams) (db.IngredientHistory, error)) *MockQuerier_GetIngredientHistoryAsOf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIngredientNutrition provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) GetIngredientNutrition(ctx context.Context, ingredientID uuid.UUID) (db.IngredientNutrition, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIngredientNutrition")
+	}
+
+	var r0 db.IngredientNutrition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.IngredientNutrition, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.IngredientNutrition); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		r0 = ret.Get(0).(db.IngredientNutrition)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetIngredientNutrition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngredientNutrition'
+type MockQuerier_GetIngredientNutrition_Call struct {
+	*mock.Call
+}
+
+// GetIngredientNutrition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) GetIngredientNutrition(ctx interface{}, ingredientID interface{}) *MockQuerier_GetIngredientNutrition_Call {
+	return &MockQuerier_GetIngredientNutrition_Call{Call: _e.mock.On("GetIngredientNutrition", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_GetIngredientNutrition_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_GetIngredientNutrition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientNutrition_Call) Return(_a0 db.IngredientNutrition, _a1 error) *MockQuerier_GetIngredientNutrition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientNutrition_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.IngredientNutrition, error)) *MockQuerier_GetIngredientNutrition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIngredientRedirect provides a mock function with given fields: ctx, oldID
+func (_m *MockQuerier) GetIngredientRedirect(ctx context.Context, oldID uuid.UUID) (db.IngredientRedirect, error) {
+	ret := _m.Called(ctx, oldID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIngredientRedirect")
+	}
+
+	var r0 db.IngredientRedirect
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.IngredientRedirect, error)); ok {
+		return rf(ctx, oldID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.IngredientRedirect); ok {
+		r0 = rf(ctx, oldID)
+	} else {
+		r0 = ret.Get(0).(db.IngredientRedirect)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, oldID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetIngredientRedirect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngredientRedirect'
+type MockQuerier_GetIngredientRedirect_Call struct {
+	*mock.Call
+}
+
+// GetIngredientRedirect is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldID uuid.UUID
+func (_e *MockQuerier_Expecter) GetIngredientRedirect(ctx interface{}, oldID interface{}) *MockQuerier_GetIngredientRedirect_Call {
+	return &MockQuerier_GetIngredientRedirect_Call{Call: _e.mock.On("GetIngredientRedirect", ctx, oldID)}
+}
+
+func (_c *MockQuerier_GetIngredientRedirect_Call) Run(run func(ctx context.Context, oldID uuid.UUID)) *MockQuerier_GetIngredientRedirect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientRedirect_Call) Return(_a0 db.IngredientRedirect, _a1 error) *MockQuerier_GetIngredientRedirect_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetIngredientRedirect_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.IngredientRedirect, error)) *MockQuerier_GetIngredientRedirect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestOpenIngredientMerge provides a mock function with given fields: ctx, winnerID
+func (_m *MockQuerier) GetLatestOpenIngredientMerge(ctx context.Context, winnerID uuid.UUID) (db.IngredientMerge, error) {
+	ret := _m.Called(ctx, winnerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestOpenIngredientMerge")
+	}
+
+	var r0 db.IngredientMerge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.IngredientMerge, error)); ok {
+		return rf(ctx, winnerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.IngredientMerge); ok {
+		r0 = rf(ctx, winnerID)
+	} else {
+		r0 = ret.Get(0).(db.IngredientMerge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, winnerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetLatestOpenIngredientMerge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestOpenIngredientMerge'
+type MockQuerier_GetLatestOpenIngredientMerge_Call struct {
+	*mock.Call
+}
+
+// GetLatestOpenIngredientMerge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - winnerID uuid.UUID
+func (_e *MockQuerier_Expecter) GetLatestOpenIngredientMerge(ctx interface{}, winnerID interface{}) *MockQuerier_GetLatestOpenIngredientMerge_Call {
+	return &MockQuerier_GetLatestOpenIngredientMerge_Call{Call: _e.mock.On("GetLatestOpenIngredientMerge", ctx, winnerID)}
+}
+
+func (_c *MockQuerier_GetLatestOpenIngredientMerge_Call) Run(run func(ctx context.Context, winnerID uuid.UUID)) *MockQuerier_GetLatestOpenIngredientMerge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetLatestOpenIngredientMerge_Call) Return(_a0 db.IngredientMerge, _a1 error) *MockQuerier_GetLatestOpenIngredientMerge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetLatestOpenIngredientMerge_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.IngredientMerge, error)) *MockQuerier_GetLatestOpenIngredientMerge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenIngredientMerge provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) GetOpenIngredientMerge(ctx context.Context, arg db.GetOpenIngredientMergeParams) (db.IngredientMerge, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenIngredientMerge")
+	}
+
+	var r0 db.IngredientMerge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetOpenIngredientMergeParams) (db.IngredientMerge, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetOpenIngredientMergeParams) db.IngredientMerge); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IngredientMerge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetOpenIngredientMergeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetOpenIngredientMerge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenIngredientMerge'
+type MockQuerier_GetOpenIngredientMerge_Call struct {
+	*mock.Call
+}
+
+// GetOpenIngredientMerge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetOpenIngredientMergeParams
+func (_e *MockQuerier_Expecter) GetOpenIngredientMerge(ctx interface{}, arg interface{}) *MockQuerier_GetOpenIngredientMerge_Call {
+	return &MockQuerier_GetOpenIngredientMerge_Call{Call: _e.mock.On("GetOpenIngredientMerge", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetOpenIngredientMerge_Call) Run(run func(ctx context.Context, arg db.GetOpenIngredientMergeParams)) *MockQuerier_GetOpenIngredientMerge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.GetOpenIngredientMergeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetOpenIngredientMerge_Call) Return(_a0 db.IngredientMerge, _a1 error) *MockQuerier_GetOpenIngredientMerge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetOpenIngredientMerge_Call) RunAndReturn(run func(context.Context, db.GetOpenIngredientMergeParams) (db.IngredientMerge, error)) *MockQuerier_GetOpenIngredientMerge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubstitute provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) GetSubstitute(ctx context.Context, arg db.GetSubstituteParams) (db.IngredientSubstitute, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubstitute")
+	}
+
+	var r0 db.IngredientSubstitute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetSubstituteParams) (db.IngredientSubstitute, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetSubstituteParams) db.IngredientSubstitute); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IngredientSubstitute)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetSubstituteParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetSubstitute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubstitute'
+type MockQuerier_GetSubstitute_Call struct {
+	*mock.Call
+}
+
+// GetSubstitute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetSubstituteParams
+func (_e *MockQuerier_Expecter) GetSubstitute(ctx interface{}, arg interface{}) *MockQuerier_GetSubstitute_Call {
+	return &MockQuerier_GetSubstitute_Call{Call: _e.mock.On("GetSubstitute", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetSubstitute_Call) Run(run func(ctx context.Context, arg db.GetSubstituteParams)) *MockQuerier_GetSubstitute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.GetSubstituteParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetSubstitute_Call) Return(_a0 db.IngredientSubstitute, _a1 error) *MockQuerier_GetSubstitute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetSubstitute_Call) RunAndReturn(run func(context.Context, db.GetSubstituteParams) (db.IngredientSubstitute, error)) *MockQuerier_GetSubstitute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertCompositeComponentSnapshot provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) InsertCompositeComponentSnapshot(ctx context.Context, arg db.InsertCompositeComponentSnapshotParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertCompositeComponentSnapshot")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertCompositeComponentSnapshotParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertCompositeComponentSnapshotParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertCompositeComponentSnapshotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_InsertCompositeComponentSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertCompositeComponentSnapshot'
+type MockQuerier_InsertCompositeComponentSnapshot_Call struct {
+	*mock.Call
+}
+
+// InsertCompositeComponentSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertCompositeComponentSnapshotParams
+func (_e *MockQuerier_Expecter) InsertCompositeComponentSnapshot(ctx interface{}, arg interface{}) *MockQuerier_InsertCompositeComponentSnapshot_Call {
+	return &MockQuerier_InsertCompositeComponentSnapshot_Call{Call: _e.mock.On("InsertCompositeComponentSnapshot", ctx, arg)}
+}
+
+func (_c *MockQuerier_InsertCompositeComponentSnapshot_Call) Run(run func(ctx context.Context, arg db.InsertCompositeComponentSnapshotParams)) *MockQuerier_InsertCompositeComponentSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertCompositeComponentSnapshotParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_InsertCompositeComponentSnapshot_Call) Return(_a0 int64, _a1 error) *MockQuerier_InsertCompositeComponentSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_InsertCompositeComponentSnapshot_Call) RunAndReturn(run func(context.Context, db.InsertCompositeComponentSnapshotParams) (int64, error)) *MockQuerier_InsertCompositeComponentSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertIngredientAliasSnapshot provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) InsertIngredientAliasSnapshot(ctx context.Context, arg db.InsertIngredientAliasSnapshotParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertIngredientAliasSnapshot")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertIngredientAliasSnapshotParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertIngredientAliasSnapshotParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertIngredientAliasSnapshotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_InsertIngredientAliasSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertIngredientAliasSnapshot'
+type MockQuerier_InsertIngredientAliasSnapshot_Call struct {
+	*mock.Call
+}
+
+// InsertIngredientAliasSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertIngredientAliasSnapshotParams
+func (_e *MockQuerier_Expecter) InsertIngredientAliasSnapshot(ctx interface{}, arg interface{}) *MockQuerier_InsertIngredientAliasSnapshot_Call {
+	return &MockQuerier_InsertIngredientAliasSnapshot_Call{Call: _e.mock.On("InsertIngredientAliasSnapshot", ctx, arg)}
+}
+
+func (_c *MockQuerier_InsertIngredientAliasSnapshot_Call) Run(run func(ctx context.Context, arg db.InsertIngredientAliasSnapshotParams)) *MockQuerier_InsertIngredientAliasSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertIngredientAliasSnapshotParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_InsertIngredientAliasSnapshot_Call) Return(_a0 int64, _a1 error) *MockQuerier_InsertIngredientAliasSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_InsertIngredientAliasSnapshot_Call) RunAndReturn(run func(context.Context, db.InsertIngredientAliasSnapshotParams) (int64, error)) *MockQuerier_InsertIngredientAliasSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertIngredientSnapshot provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) InsertIngredientSnapshot(ctx context.Context, arg db.InsertIngredientSnapshotParams) (db.Ingredient, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertIngredientSnapshot")
+	}
+
+	var r0 db.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertIngredientSnapshotParams) (db.Ingredient, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertIngredientSnapshotParams) db.Ingredient); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Ingredient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertIngredientSnapshotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_InsertIngredientSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertIngredientSnapshot'
+type MockQuerier_InsertIngredientSnapshot_Call struct {
+	*mock.Call
+}
+
+// InsertIngredientSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertIngredientSnapshotParams
+func (_e *MockQuerier_Expecter) InsertIngredientSnapshot(ctx interface{}, arg interface{}) *MockQuerier_InsertIngredientSnapshot_Call {
+	return &MockQuerier_InsertIngredientSnapshot_Call{Call: _e.mock.On("InsertIngredientSnapshot", ctx, arg)}
+}
+
+func (_c *MockQuerier_InsertIngredientSnapshot_Call) Run(run func(ctx context.Context, arg db.InsertIngredientSnapshotParams)) *MockQuerier_InsertIngredientSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertIngredientSnapshotParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_InsertIngredientSnapshot_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_InsertIngredientSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_InsertIngredientSnapshot_Call) RunAndReturn(run func(context.Context, db.InsertIngredientSnapshotParams) (db.Ingredient, error)) *MockQuerier_InsertIngredientSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertStorageGuidelineSnapshot provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) InsertStorageGuidelineSnapshot(ctx context.Context, arg db.InsertStorageGuidelineSnapshotParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertStorageGuidelineSnapshot")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertStorageGuidelineSnapshotParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertStorageGuidelineSnapshotParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertStorageGuidelineSnapshotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_InsertStorageGuidelineSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertStorageGuidelineSnapshot'
+type MockQuerier_InsertStorageGuidelineSnapshot_Call struct {
+	*mock.Call
+}
+
+// InsertStorageGuidelineSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertStorageGuidelineSnapshotParams
+func (_e *MockQuerier_Expecter) InsertStorageGuidelineSnapshot(ctx interface{}, arg interface{}) *MockQuerier_InsertStorageGuidelineSnapshot_Call {
+	return &MockQuerier_InsertStorageGuidelineSnapshot_Call{Call: _e.mock.On("InsertStorageGuidelineSnapshot", ctx, arg)}
+}
+
+func (_c *MockQuerier_InsertStorageGuidelineSnapshot_Call) Run(run func(ctx context.Context, arg db.InsertStorageGuidelineSnapshotParams)) *MockQuerier_InsertStorageGuidelineSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertStorageGuidelineSnapshotParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_InsertStorageGuidelineSnapshot_Call) Return(_a0 int64, _a1 error) *MockQuerier_InsertStorageGuidelineSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_InsertStorageGuidelineSnapshot_Call) RunAndReturn(run func(context.Context, db.InsertStorageGuidelineSnapshotParams) (int64, error)) *MockQuerier_InsertStorageGuidelineSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertSubstituteSnapshot provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) InsertSubstituteSnapshot(ctx context.Context, arg db.InsertSubstituteSnapshotParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertSubstituteSnapshot")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertSubstituteSnapshotParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertSubstituteSnapshotParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertSubstituteSnapshotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_InsertSubstituteSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertSubstituteSnapshot'
+type MockQuerier_InsertSubstituteSnapshot_Call struct {
+	*mock.Call
+}
+
+// InsertSubstituteSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertSubstituteSnapshotParams
+func (_e *MockQuerier_Expecter) InsertSubstituteSnapshot(ctx interface{}, arg interface{}) *MockQuerier_InsertSubstituteSnapshot_Call {
+	return &MockQuerier_InsertSubstituteSnapshot_Call{Call: _e.mock.On("InsertSubstituteSnapshot", ctx, arg)}
+}
+
+func (_c *MockQuerier_InsertSubstituteSnapshot_Call) Run(run func(ctx context.Context, arg db.InsertSubstituteSnapshotParams)) *MockQuerier_InsertSubstituteSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertSubstituteSnapshotParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_InsertSubstituteSnapshot_Call) Return(_a0 int64, _a1 error) *MockQuerier_InsertSubstituteSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_InsertSubstituteSnapshot_Call) RunAndReturn(run func(context.Context, db.InsertSubstituteSnapshotParams) (int64, error)) *MockQuerier_InsertSubstituteSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertUnitConversionSnapshot provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) InsertUnitConversionSnapshot(ctx context.Context, arg db.InsertUnitConversionSnapshotParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertUnitConversionSnapshot")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertUnitConversionSnapshotParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertUnitConversionSnapshotParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertUnitConversionSnapshotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_InsertUnitConversionSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertUnitConversionSnapshot'
+type MockQuerier_InsertUnitConversionSnapshot_Call struct {
+	*mock.Call
+}
+
+// InsertUnitConversionSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertUnitConversionSnapshotParams
+func (_e *MockQuerier_Expecter) InsertUnitConversionSnapshot(ctx interface{}, arg interface{}) *MockQuerier_InsertUnitConversionSnapshot_Call {
+	return &MockQuerier_InsertUnitConversionSnapshot_Call{Call: _e.mock.On("InsertUnitConversionSnapshot", ctx, arg)}
+}
+
+func (_c *MockQuerier_InsertUnitConversionSnapshot_Call) Run(run func(ctx context.Context, arg db.InsertUnitConversionSnapshotParams)) *MockQuerier_InsertUnitConversionSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertUnitConversionSnapshotParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_InsertUnitConversionSnapshot_Call) Return(_a0 int64, _a1 error) *MockQuerier_InsertUnitConversionSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_InsertUnitConversionSnapshot_Call) RunAndReturn(run func(context.Context, db.InsertUnitConversionSnapshotParams) (int64, error)) *MockQuerier_InsertUnitConversionSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAllSubstitutes provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListAllSubstitutes(ctx context.Context) ([]db.IngredientSubstitute, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllSubstitutes")
+	}
+
+	var r0 []db.IngredientSubstitute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.IngredientSubstitute, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.IngredientSubstitute); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngredientSubstitute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListAllSubstitutes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllSubstitutes'
+type MockQuerier_ListAllSubstitutes_Call struct {
+	*mock.Call
+}
+
+// ListAllSubstitutes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListAllSubstitutes(ctx interface{}) *MockQuerier_ListAllSubstitutes_Call {
+	return &MockQuerier_ListAllSubstitutes_Call{Call: _e.mock.On("ListAllSubstitutes", ctx)}
+}
+
+func (_c *MockQuerier_ListAllSubstitutes_Call) Run(run func(ctx context.Context)) *MockQuerier_ListAllSubstitutes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListAllSubstitutes_Call) Return(_a0 []db.IngredientSubstitute, _a1 error) *MockQuerier_ListAllSubstitutes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListAllSubstitutes_Call) RunAndReturn(run func(context.Context) ([]db.IngredientSubstitute, error)) *MockQuerier_ListAllSubstitutes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCategories provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListCategories(ctx context.Context) ([]db.Category, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCategories")
+	}
+
+	var r0 []db.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.Category, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.Category); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCategories'
+type MockQuerier_ListCategories_Call struct {
+	*mock.Call
+}
+
+// ListCategories is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListCategories(ctx interface{}) *MockQuerier_ListCategories_Call {
+	return &MockQuerier_ListCategories_Call{Call: _e.mock.On("ListCategories", ctx)}
+}
+
+func (_c *MockQuerier_ListCategories_Call) Run(run func(ctx context.Context)) *MockQuerier_ListCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCategories_Call) Return(_a0 []db.Category, _a1 error) *MockQuerier_ListCategories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCategories_Call) RunAndReturn(run func(context.Context) ([]db.Category, error)) *MockQuerier_ListCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCompositeComponentIDsByComponent provides a mock function with given fields: ctx, componentID
+func (_m *MockQuerier) ListCompositeComponentIDsByComponent(ctx context.Context, componentID uuid.UUID) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, componentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCompositeComponentIDsByComponent")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return rf(ctx, componentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []uuid.UUID); ok {
+		r0 = rf(ctx, componentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, componentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCompositeComponentIDsByComponent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCompositeComponentIDsByComponent'
+type MockQuerier_ListCompositeComponentIDsByComponent_Call struct {
+	*mock.Call
+}
+
+// ListCompositeComponentIDsByComponent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - componentID uuid.UUID
+func (_e *MockQuerier_Expecter) ListCompositeComponentIDsByComponent(ctx interface{}, componentID interface{}) *MockQuerier_ListCompositeComponentIDsByComponent_Call {
+	return &MockQuerier_ListCompositeComponentIDsByComponent_Call{Call: _e.mock.On("ListCompositeComponentIDsByComponent", ctx, componentID)}
+}
+
+func (_c *MockQuerier_ListCompositeComponentIDsByComponent_Call) Run(run func(ctx context.Context, componentID uuid.UUID)) *MockQuerier_ListCompositeComponentIDsByComponent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCompositeComponentIDsByComponent_Call) Return(_a0 []uuid.UUID, _a1 error) *MockQuerier_ListCompositeComponentIDsByComponent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCompositeComponentIDsByComponent_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]uuid.UUID, error)) *MockQuerier_ListCompositeComponentIDsByComponent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCompositeComponentsByComponent provides a mock function with given fields: ctx, componentID
+func (_m *MockQuerier) ListCompositeComponentsByComponent(ctx context.Context, componentID uuid.UUID) ([]db.CompositeSubstituteComponent, error) {
+	ret := _m.Called(ctx, componentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCompositeComponentsByComponent")
+	}
+
+	var r0 []db.CompositeSubstituteComponent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.CompositeSubstituteComponent, error)); ok {
+		return rf(ctx, componentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.CompositeSubstituteComponent); ok {
+		r0 = rf(ctx, componentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CompositeSubstituteComponent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, componentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCompositeComponentsByComponent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCompositeComponentsByComponent'
+type MockQuerier_ListCompositeComponentsByComponent_Call struct {
+	*mock.Call
+}
+
+// ListCompositeComponentsByComponent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - componentID uuid.UUID
+func (_e *MockQuerier_Expecter) ListCompositeComponentsByComponent(ctx interface{}, componentID interface{}) *MockQuerier_ListCompositeComponentsByComponent_Call {
+	return &MockQuerier_ListCompositeComponentsByComponent_Call{Call: _e.mock.On("ListCompositeComponentsByComponent", ctx, componentID)}
+}
+
+func (_c *MockQuerier_ListCompositeComponentsByComponent_Call) Run(run func(ctx context.Context, componentID uuid.UUID)) *MockQuerier_ListCompositeComponentsByComponent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCompositeComponentsByComponent_Call) Return(_a0 []db.CompositeSubstituteComponent, _a1 error) *MockQuerier_ListCompositeComponentsByComponent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCompositeComponentsByComponent_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.CompositeSubstituteComponent, error)) *MockQuerier_ListCompositeComponentsByComponent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCompositeComponentsByIngredient provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) ListCompositeComponentsByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]db.ListCompositeComponentsByIngredientRow, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCompositeComponentsByIngredient")
+	}
+
+	var r0 []db.ListCompositeComponentsByIngredientRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.ListCompositeComponentsByIngredientRow, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.ListCompositeComponentsByIngredientRow); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListCompositeComponentsByIngredientRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCompositeComponentsByIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCompositeComponentsByIngredient'
+type MockQuerier_ListCompositeComponentsByIngredient_Call struct {
+	*mock.Call
+}
+
+// ListCompositeComponentsByIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) ListCompositeComponentsByIngredient(ctx interface{}, ingredientID interface{}) *MockQuerier_ListCompositeComponentsByIngredient_Call {
+	return &MockQuerier_ListCompositeComponentsByIngredient_Call{Call: _e.mock.On("ListCompositeComponentsByIngredient", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_ListCompositeComponentsByIngredient_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_ListCompositeComponentsByIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCompositeComponentsByIngredient_Call) Return(_a0 []db.ListCompositeComponentsByIngredientRow, _a1 error) *MockQuerier_ListCompositeComponentsByIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCompositeComponentsByIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.ListCompositeComponentsByIngredientRow, error)) *MockQuerier_ListCompositeComponentsByIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCompositeSubstituteIDsByIngredient provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) ListCompositeSubstituteIDsByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCompositeSubstituteIDsByIngredient")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []uuid.UUID); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCompositeSubstituteIDsByIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCompositeSubstituteIDsByIngredient'
+type MockQuerier_ListCompositeSubstituteIDsByIngredient_Call struct {
+	*mock.Call
+}
+
+// ListCompositeSubstituteIDsByIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) ListCompositeSubstituteIDsByIngredient(ctx interface{}, ingredientID interface{}) *MockQuerier_ListCompositeSubstituteIDsByIngredient_Call {
+	return &MockQuerier_ListCompositeSubstituteIDsByIngredient_Call{Call: _e.mock.On("ListCompositeSubstituteIDsByIngredient", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_ListCompositeSubstituteIDsByIngredient_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_ListCompositeSubstituteIDsByIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCompositeSubstituteIDsByIngredient_Call) Return(_a0 []uuid.UUID, _a1 error) *MockQuerier_ListCompositeSubstituteIDsByIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCompositeSubstituteIDsByIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]uuid.UUID, error)) *MockQuerier_ListCompositeSubstituteIDsByIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCompositeSubstitutesByIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListCompositeSubstitutesByIngredient(ctx context.Context, arg db.ListCompositeSubstitutesByIngredientParams) ([]db.CompositeSubstitute, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCompositeSubstitutesByIngredient")
+	}
+
+	var r0 []db.CompositeSubstitute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListCompositeSubstitutesByIngredientParams) ([]db.CompositeSubstitute, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListCompositeSubstitutesByIngredientParams) []db.CompositeSubstitute); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CompositeSubstitute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListCompositeSubstitutesByIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCompositeSubstitutesByIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCompositeSubstitutesByIngredient'
+type MockQuerier_ListCompositeSubstitutesByIngredient_Call struct {
+	*mock.Call
+}
+
+// ListCompositeSubstitutesByIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListCompositeSubstitutesByIngredientParams
+func (_e *MockQuerier_Expecter) ListCompositeSubstitutesByIngredient(ctx interface{}, arg interface{}) *MockQuerier_ListCompositeSubstitutesByIngredient_Call {
+	return &MockQuerier_ListCompositeSubstitutesByIngredient_Call{Call: _e.mock.On("ListCompositeSubstitutesByIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListCompositeSubstitutesByIngredient_Call) Run(run func(ctx context.Context, arg db.ListCompositeSubstitutesByIngredientParams)) *MockQuerier_ListCompositeSubstitutesByIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListCompositeSubstitutesByIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCompositeSubstitutesByIngredient_Call) Return(_a0 []db.CompositeSubstitute, _a1 error) *MockQuerier_ListCompositeSubstitutesByIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCompositeSubstitutesByIngredient_Call) RunAndReturn(run func(context.Context, db.ListCompositeSubstitutesByIngredientParams) ([]db.CompositeSubstitute, error)) *MockQuerier_ListCompositeSubstitutesByIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredientAliases provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) ListIngredientAliases(ctx context.Context, ingredientID uuid.UUID) ([]db.IngredientAlias, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredientAliases")
+	}
+
+	var r0 []db.IngredientAlias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.IngredientAlias, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.IngredientAlias); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngredientAlias)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredientAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredientAliases'
+type MockQuerier_ListIngredientAliases_Call struct {
+	*mock.Call
+}
+
+// ListIngredientAliases is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) ListIngredientAliases(ctx interface{}, ingredientID interface{}) *MockQuerier_ListIngredientAliases_Call {
+	return &MockQuerier_ListIngredientAliases_Call{Call: _e.mock.On("ListIngredientAliases", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_ListIngredientAliases_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_ListIngredientAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientAliases_Call) Return(_a0 []db.IngredientAlias, _a1 error) *MockQuerier_ListIngredientAliases_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientAliases_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.IngredientAlias, error)) *MockQuerier_ListIngredientAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredientAncestors provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) ListIngredientAncestors(ctx context.Context, id uuid.UUID) ([]db.ListIngredientAncestorsRow, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredientAncestors")
+	}
+
+	var r0 []db.ListIngredientAncestorsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.ListIngredientAncestorsRow, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.ListIngredientAncestorsRow); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListIngredientAncestorsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredientAncestors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredientAncestors'
+type MockQuerier_ListIngredientAncestors_Call struct {
+	*mock.Call
+}
+
+// ListIngredientAncestors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) ListIngredientAncestors(ctx interface{}, id interface{}) *MockQuerier_ListIngredientAncestors_Call {
+	return &MockQuerier_ListIngredientAncestors_Call{Call: _e.mock.On("ListIngredientAncestors", ctx, id)}
+}
+
+func (_c *MockQuerier_ListIngredientAncestors_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_ListIngredientAncestors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientAncestors_Call) Return(_a0 []db.ListIngredientAncestorsRow, _a1 error) *MockQuerier_ListIngredientAncestors_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientAncestors_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.ListIngredientAncestorsRow, error)) *MockQuerier_ListIngredientAncestors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredientChildren provides a mock function with given fields: ctx, parentID
+func (_m *MockQuerier) ListIngredientChildren(ctx context.Context, parentID uuid.UUID) ([]db.Ingredient, error) {
+	ret := _m.Called(ctx, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredientChildren")
+	}
+
+	var r0 []db.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.Ingredient, error)); ok {
+		return rf(ctx, parentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.Ingredient); ok {
+		r0 = rf(ctx, parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Ingredient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredientChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredientChildren'
+type MockQuerier_ListIngredientChildren_Call struct {
+	*mock.Call
+}
+
+// ListIngredientChildren is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentID uuid.UUID
+func (_e *MockQuerier_Expecter) ListIngredientChildren(ctx interface{}, parentID interface{}) *MockQuerier_ListIngredientChildren_Call {
+	return &MockQuerier_ListIngredientChildren_Call{Call: _e.mock.On("ListIngredientChildren", ctx, parentID)}
+}
+
+func (_c *MockQuerier_ListIngredientChildren_Call) Run(run func(ctx context.Context, parentID uuid.UUID)) *MockQuerier_ListIngredientChildren_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientChildren_Call) Return(_a0 []db.Ingredient, _a1 error) *MockQuerier_ListIngredientChildren_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientChildren_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.Ingredient, error)) *MockQuerier_ListIngredientChildren_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredientDescendants provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListIngredientDescendants(ctx context.Context, arg db.ListIngredientDescendantsParams) ([]db.ListIngredientDescendantsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredientDescendants")
+	}
+
+	var r0 []db.ListIngredientDescendantsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListIngredientDescendantsParams) ([]db.ListIngredientDescendantsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListIngredientDescendantsParams) []db.ListIngredientDescendantsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListIngredientDescendantsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListIngredientDescendantsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredientDescendants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredientDescendants'
+type MockQuerier_ListIngredientDescendants_Call struct {
+	*mock.Call
+}
+
+// ListIngredientDescendants is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListIngredientDescendantsParams
+func (_e *MockQuerier_Expecter) ListIngredientDescendants(ctx interface{}, arg interface{}) *MockQuerier_ListIngredientDescendants_Call {
+	return &MockQuerier_ListIngredientDescendants_Call{Call: _e.mock.On("ListIngredientDescendants", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListIngredientDescendants_Call) Run(run func(ctx context.Context, arg db.ListIngredientDescendantsParams)) *MockQuerier_ListIngredientDescendants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListIngredientDescendantsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientDescendants_Call) Return(_a0 []db.ListIngredientDescendantsRow, _a1 error) *MockQuerier_ListIngredientDescendants_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientDescendants_Call) RunAndReturn(run func(context.Context, db.ListIngredientDescendantsParams) ([]db.ListIngredientDescendantsRow, error)) *MockQuerier_ListIngredientDescendants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredientHistory provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) ListIngredientHistory(ctx context.Context, ingredientID uuid.UUID) ([]db.IngredientHistory, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredientHistory")
+	}
+
+	var r0 []db.IngredientHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.IngredientHistory, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.IngredientHistory); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngredientHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredientHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredientHistory'
+type MockQuerier_ListIngredientHistory_Call struct {
+	*mock.Call
+}
+
+// ListIngredientHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) ListIngredientHistory(ctx interface{}, ingredientID interface{}) *MockQuerier_ListIngredientHistory_Call {
+	return &MockQuerier_ListIngredientHistory_Call{Call: _e.mock.On("ListIngredientHistory", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_ListIngredientHistory_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_ListIngredientHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientHistory_Call) Return(_a0 []db.IngredientHistory, _a1 error) *MockQuerier_ListIngredientHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientHistory_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.IngredientHistory, error)) *MockQuerier_ListIngredientHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredientHistoryAsOf provides a mock function with given fields: ctx, asOf
+func (_m *MockQuerier) ListIngredientHistoryAsOf(ctx context.Context, asOf time.Time) ([]db.IngredientHistory, error) {
+	ret := _m.Called(ctx, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredientHistoryAsOf")
+	}
+
+	var r0 []db.IngredientHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.IngredientHistory, error)); ok {
+		return rf(ctx, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.IngredientHistory); ok {
+		r0 = rf(ctx, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngredientHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredientHistoryAsOf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredientHistoryAsOf'
+type MockQuerier_ListIngredientHistoryAsOf_Call struct {
+	*mock.Call
+}
+
+// ListIngredientHistoryAsOf is a helper method to define mock.On call
+//   - ctx context.Context
+//   - asOf time.Time
+func (_e *MockQuerier_Expecter) ListIngredientHistoryAsOf(ctx interface{}, asOf interface{}) *MockQuerier_ListIngredientHistoryAsOf_Call {
+	return &MockQuerier_ListIngredientHistoryAsOf_Call{Call: _e.mock.On("ListIngredientHistoryAsOf", ctx, asOf)}
+}
+
+func (_c *MockQuerier_ListIngredientHistoryAsOf_Call) Run(run func(ctx context.Context, asOf time.Time)) *MockQuerier_ListIngredientHistoryAsOf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientHistoryAsOf_Call) Return(_a0 []db.IngredientHistory, _a1 error) *MockQuerier_ListIngredientHistoryAsOf_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientHistoryAsOf_Call) RunAndReturn(run func(context.Context, time.Time) ([]db.IngredientHistory, error)) *MockQuerier_ListIngredientHistoryAsOf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredientIDs provides a mock function with given fields: ctx, ids
+func (_m *MockQuerier) ListIngredientIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredientIDs")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]uuid.UUID, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []uuid.UUID); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredientIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredientIDs'
+type MockQuerier_ListIngredientIDs_Call struct {
+	*mock.Call
+}
+
+// ListIngredientIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *MockQuerier_Expecter) ListIngredientIDs(ctx interface{}, ids interface{}) *MockQuerier_ListIngredientIDs_Call {
+	return &MockQuerier_ListIngredientIDs_Call{Call: _e.mock.On("ListIngredientIDs", ctx, ids)}
+}
+
+func (_c *MockQuerier_ListIngredientIDs_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockQuerier_ListIngredientIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientIDs_Call) Return(_a0 []uuid.UUID, _a1 error) *MockQuerier_ListIngredientIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]uuid.UUID, error)) *MockQuerier_ListIngredientIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredientMerges provides a mock function with given fields: ctx, winnerID
+func (_m *MockQuerier) ListIngredientMerges(ctx context.Context, winnerID uuid.UUID) ([]db.IngredientMerge, error) {
+	ret := _m.Called(ctx, winnerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredientMerges")
+	}
+
+	var r0 []db.IngredientMerge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.IngredientMerge, error)); ok {
+		return rf(ctx, winnerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.IngredientMerge); ok {
+		r0 = rf(ctx, winnerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngredientMerge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, winnerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredientMerges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredientMerges'
+type MockQuerier_ListIngredientMerges_Call struct {
+	*mock.Call
+}
+
+// ListIngredientMerges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - winnerID uuid.UUID
+func (_e *MockQuerier_Expecter) ListIngredientMerges(ctx interface{}, winnerID interface{}) *MockQuerier_ListIngredientMerges_Call {
+	return &MockQuerier_ListIngredientMerges_Call{Call: _e.mock.On("ListIngredientMerges", ctx, winnerID)}
+}
+
+func (_c *MockQuerier_ListIngredientMerges_Call) Run(run func(ctx context.Context, winnerID uuid.UUID)) *MockQuerier_ListIngredientMerges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientMerges_Call) Return(_a0 []db.IngredientMerge, _a1 error) *MockQuerier_ListIngredientMerges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientMerges_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.IngredientMerge, error)) *MockQuerier_ListIngredientMerges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredientRedirects provides a mock function with given fields: ctx, oldIds
+func (_m *MockQuerier) ListIngredientRedirects(ctx context.Context, oldIds []uuid.UUID) ([]db.IngredientRedirect, error) {
+	ret := _m.Called(ctx, oldIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredientRedirects")
+	}
+
+	var r0 []db.IngredientRedirect
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]db.IngredientRedirect, error)); ok {
+		return rf(ctx, oldIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []db.IngredientRedirect); ok {
+		r0 = rf(ctx, oldIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngredientRedirect)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, oldIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredientRedirects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredientRedirects'
+type MockQuerier_ListIngredientRedirects_Call struct {
+	*mock.Call
+}
+
+// ListIngredientRedirects is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldIds []uuid.UUID
+func (_e *MockQuerier_Expecter) ListIngredientRedirects(ctx interface{}, oldIds interface{}) *MockQuerier_ListIngredientRedirects_Call {
+	return &MockQuerier_ListIngredientRedirects_Call{Call: _e.mock.On("ListIngredientRedirects", ctx, oldIds)}
+}
+
+func (_c *MockQuerier_ListIngredientRedirects_Call) Run(run func(ctx context.Context, oldIds []uuid.UUID)) *MockQuerier_ListIngredientRedirects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientRedirects_Call) Return(_a0 []db.IngredientRedirect, _a1 error) *MockQuerier_ListIngredientRedirects_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientRedirects_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]db.IngredientRedirect, error)) *MockQuerier_ListIngredientRedirects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredients provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListIngredients(ctx context.Context) ([]db.Ingredient, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredients")
+	}
+
+	var r0 []db.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.Ingredient, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.Ingredient); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Ingredient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredients'
+type MockQuerier_ListIngredients_Call struct {
+	*mock.Call
+}
+
+// ListIngredients is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListIngredients(ctx interface{}) *MockQuerier_ListIngredients_Call {
+	return &MockQuerier_ListIngredients_Call{Call: _e.mock.On("ListIngredients", ctx)}
+}
+
+func (_c *MockQuerier_ListIngredients_Call) Run(run func(ctx context.Context)) *MockQuerier_ListIngredients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredients_Call) Return(_a0 []db.Ingredient, _a1 error) *MockQuerier_ListIngredients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredients_Call) RunAndReturn(run func(context.Context) ([]db.Ingredient, error)) *MockQuerier_ListIngredients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRedirectsTo provides a mock function with given fields: ctx, newID
+func (_m *MockQuerier) ListRedirectsTo(ctx context.Context, newID uuid.UUID) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, newID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRedirectsTo")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return rf(ctx, newID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []uuid.UUID); ok {
+		r0 = rf(ctx, newID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, newID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListRedirectsTo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRedirectsTo'
+type MockQuerier_ListRedirectsTo_Call struct {
+	*mock.Call
+}
+
+// ListRedirectsTo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - newID uuid.UUID
+func (_e *MockQuerier_Expecter) ListRedirectsTo(ctx interface{}, newID interface{}) *MockQuerier_ListRedirectsTo_Call {
+	return &MockQuerier_ListRedirectsTo_Call{Call: _e.mock.On("ListRedirectsTo", ctx, newID)}
+}
+
+func (_c *MockQuerier_ListRedirectsTo_Call) Run(run func(ctx context.Context, newID uuid.UUID)) *MockQuerier_ListRedirectsTo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListRedirectsTo_Call) Return(_a0 []uuid.UUID, _a1 error) *MockQuerier_ListRedirectsTo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListRedirectsTo_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]uuid.UUID, error)) *MockQuerier_ListRedirectsTo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStorageGuidelinesByCategory provides a mock function with given fields: ctx, categoryID
+func (_m *MockQuerier) ListStorageGuidelinesByCategory(ctx context.Context, categoryID uuid.UUID) ([]db.StorageGuideline, error) {
+	ret := _m.Called(ctx, categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStorageGuidelinesByCategory")
+	}
+
+	var r0 []db.StorageGuideline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.StorageGuideline, error)); ok {
+		return rf(ctx, categoryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.StorageGuideline); ok {
+		r0 = rf(ctx, categoryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StorageGuideline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, categoryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListStorageGuidelinesByCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStorageGuidelinesByCategory'
+type MockQuerier_ListStorageGuidelinesByCategory_Call struct {
+	*mock.Call
+}
+
+// ListStorageGuidelinesByCategory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - categoryID uuid.UUID
+func (_e *MockQuerier_Expecter) ListStorageGuidelinesByCategory(ctx interface{}, categoryID interface{}) *MockQuerier_ListStorageGuidelinesByCategory_Call {
+	return &MockQuerier_ListStorageGuidelinesByCategory_Call{Call: _e.mock.On("ListStorageGuidelinesByCategory", ctx, categoryID)}
+}
+
+func (_c *MockQuerier_ListStorageGuidelinesByCategory_Call) Run(run func(ctx context.Context, categoryID uuid.UUID)) *MockQuerier_ListStorageGuidelinesByCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListStorageGuidelinesByCategory_Call) Return(_a0 []db.StorageGuideline, _a1 error) *MockQuerier_ListStorageGuidelinesByCategory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListStorageGuidelinesByCategory_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.StorageGuideline, error)) *MockQuerier_ListStorageGuidelinesByCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStorageGuidelinesByIngredient provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) ListStorageGuidelinesByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]db.StorageGuideline, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStorageGuidelinesByIngredient")
+	}
+
+	var r0 []db.StorageGuideline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.StorageGuideline, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.StorageGuideline); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StorageGuideline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListStorageGuidelinesByIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStorageGuidelinesByIngredient'
+type MockQuerier_ListStorageGuidelinesByIngredient_Call struct {
+	*mock.Call
+}
+
+// ListStorageGuidelinesByIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) ListStorageGuidelinesByIngredient(ctx interface{}, ingredientID interface{}) *MockQuerier_ListStorageGuidelinesByIngredient_Call {
+	return &MockQuerier_ListStorageGuidelinesByIngredient_Call{Call: _e.mock.On("ListStorageGuidelinesByIngredient", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_ListStorageGuidelinesByIngredient_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_ListStorageGuidelinesByIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListStorageGuidelinesByIngredient_Call) Return(_a0 []db.StorageGuideline, _a1 error) *MockQuerier_ListStorageGuidelinesByIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListStorageGuidelinesByIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.StorageGuideline, error)) *MockQuerier_ListStorageGuidelinesByIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSubstitutesByIngredient provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) ListSubstitutesByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]db.IngredientSubstitute, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubstitutesByIngredient")
+	}
+
+	var r0 []db.IngredientSubstitute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.IngredientSubstitute, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.IngredientSubstitute); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngredientSubstitute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListSubstitutesByIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubstitutesByIngredient'
+type MockQuerier_ListSubstitutesByIngredient_Call struct {
+	*mock.Call
+}
+
+// ListSubstitutesByIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
 func (_e *MockQuerier_Expecter) ListSubstitutesByIngredient(ctx interface{}, ingredientID interface{}) *MockQuerier_ListSubstitutesByIngredient_Call {
 	return &MockQuerier_ListSubstitutesByIngredient_Call{Call: _e.mock.On("ListSubstitutesByIngredient", ctx, ingredientID)}
 }
 
-func (_c *MockQuerier_ListSubstitutesByIngredient_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_ListSubstitutesByIngredient_Call {
+func (_c *MockQuerier_ListSubstitutesByIngredient_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_ListSubstitutesByIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListSubstitutesByIngredient_Call) Return(_a0 []db.IngredientSubstitute, _a1 error) *MockQuerier_ListSubstitutesByIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListSubstitutesByIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.IngredientSubstitute, error)) *MockQuerier_ListSubstitutesByIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSubstitutesTouching provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) ListSubstitutesTouching(ctx context.Context, ingredientID uuid.UUID) ([]db.IngredientSubstitute, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubstitutesTouching")
+	}
+
+	var r0 []db.IngredientSubstitute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.IngredientSubstitute, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.IngredientSubstitute); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngredientSubstitute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListSubstitutesTouching_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubstitutesTouching'
+type MockQuerier_ListSubstitutesTouching_Call struct {
+	*mock.Call
+}
+
+// ListSubstitutesTouching is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) ListSubstitutesTouching(ctx interface{}, ingredientID interface{}) *MockQuerier_ListSubstitutesTouching_Call {
+	return &MockQuerier_ListSubstitutesTouching_Call{Call: _e.mock.On("ListSubstitutesTouching", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_ListSubstitutesTouching_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_ListSubstitutesTouching_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListSubstitutesTouching_Call) Return(_a0 []db.IngredientSubstitute, _a1 error) *MockQuerier_ListSubstitutesTouching_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListSubstitutesTouching_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.IngredientSubstitute, error)) *MockQuerier_ListSubstitutesTouching_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSubstitutesWithIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListSubstitutesWithIngredient(ctx context.Context, arg db.ListSubstitutesWithIngredientParams) ([]db.ListSubstitutesWithIngredientRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubstitutesWithIngredient")
+	}
+
+	var r0 []db.ListSubstitutesWithIngredientRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListSubstitutesWithIngredientParams) ([]db.ListSubstitutesWithIngredientRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListSubstitutesWithIngredientParams) []db.ListSubstitutesWithIngredientRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListSubstitutesWithIngredientRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListSubstitutesWithIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListSubstitutesWithIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubstitutesWithIngredient'
+type MockQuerier_ListSubstitutesWithIngredient_Call struct {
+	*mock.Call
+}
+
+// ListSubstitutesWithIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListSubstitutesWithIngredientParams
+func (_e *MockQuerier_Expecter) ListSubstitutesWithIngredient(ctx interface{}, arg interface{}) *MockQuerier_ListSubstitutesWithIngredient_Call {
+	return &MockQuerier_ListSubstitutesWithIngredient_Call{Call: _e.mock.On("ListSubstitutesWithIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListSubstitutesWithIngredient_Call) Run(run func(ctx context.Context, arg db.ListSubstitutesWithIngredientParams)) *MockQuerier_ListSubstitutesWithIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListSubstitutesWithIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListSubstitutesWithIngredient_Call) Return(_a0 []db.ListSubstitutesWithIngredientRow, _a1 error) *MockQuerier_ListSubstitutesWithIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListSubstitutesWithIngredient_Call) RunAndReturn(run func(context.Context, db.ListSubstitutesWithIngredientParams) ([]db.ListSubstitutesWithIngredientRow, error)) *MockQuerier_ListSubstitutesWithIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTermOwners provides a mock function with given fields: ctx, terms
+func (_m *MockQuerier) ListTermOwners(ctx context.Context, terms []string) ([]db.ListTermOwnersRow, error) {
+	ret := _m.Called(ctx, terms)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTermOwners")
+	}
+
+	var r0 []db.ListTermOwnersRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]db.ListTermOwnersRow, error)); ok {
+		return rf(ctx, terms)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []db.ListTermOwnersRow); ok {
+		r0 = rf(ctx, terms)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTermOwnersRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, terms)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListTermOwners_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTermOwners'
+type MockQuerier_ListTermOwners_Call struct {
+	*mock.Call
+}
+
+// ListTermOwners is a helper method to define mock.On call
+//   - ctx context.Context
+//   - terms []string
+func (_e *MockQuerier_Expecter) ListTermOwners(ctx interface{}, terms interface{}) *MockQuerier_ListTermOwners_Call {
+	return &MockQuerier_ListTermOwners_Call{Call: _e.mock.On("ListTermOwners", ctx, terms)}
+}
+
+func (_c *MockQuerier_ListTermOwners_Call) Run(run func(ctx context.Context, terms []string)) *MockQuerier_ListTermOwners_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListTermOwners_Call) Return(_a0 []db.ListTermOwnersRow, _a1 error) *MockQuerier_ListTermOwners_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListTermOwners_Call) RunAndReturn(run func(context.Context, []string) ([]db.ListTermOwnersRow, error)) *MockQuerier_ListTermOwners_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnitConversions provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListUnitConversions(ctx context.Context) ([]db.UnitConversion, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnitConversions")
+	}
+
+	var r0 []db.UnitConversion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.UnitConversion, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.UnitConversion); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.UnitConversion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListUnitConversions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnitConversions'
+type MockQuerier_ListUnitConversions_Call struct {
+	*mock.Call
+}
+
+// ListUnitConversions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListUnitConversions(ctx interface{}) *MockQuerier_ListUnitConversions_Call {
+	return &MockQuerier_ListUnitConversions_Call{Call: _e.mock.On("ListUnitConversions", ctx)}
+}
+
+func (_c *MockQuerier_ListUnitConversions_Call) Run(run func(ctx context.Context)) *MockQuerier_ListUnitConversions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUnitConversions_Call) Return(_a0 []db.UnitConversion, _a1 error) *MockQuerier_ListUnitConversions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListUnitConversions_Call) RunAndReturn(run func(context.Context) ([]db.UnitConversion, error)) *MockQuerier_ListUnitConversions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnitConversionsByIngredient provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) ListUnitConversionsByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]db.UnitConversion, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnitConversionsByIngredient")
+	}
+
+	var r0 []db.UnitConversion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.UnitConversion, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.UnitConversion); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.UnitConversion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListUnitConversionsByIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnitConversionsByIngredient'
+type MockQuerier_ListUnitConversionsByIngredient_Call struct {
+	*mock.Call
+}
+
+// ListUnitConversionsByIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) ListUnitConversionsByIngredient(ctx interface{}, ingredientID interface{}) *MockQuerier_ListUnitConversionsByIngredient_Call {
+	return &MockQuerier_ListUnitConversionsByIngredient_Call{Call: _e.mock.On("ListUnitConversionsByIngredient", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_ListUnitConversionsByIngredient_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_ListUnitConversionsByIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUnitConversionsByIngredient_Call) Return(_a0 []db.UnitConversion, _a1 error) *MockQuerier_ListUnitConversionsByIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListUnitConversionsByIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.UnitConversion, error)) *MockQuerier_ListUnitConversionsByIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockIngredientHierarchy provides a mock function with given fields: ctx
+func (_m *MockQuerier) LockIngredientHierarchy(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockIngredientHierarchy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_LockIngredientHierarchy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockIngredientHierarchy'
+type MockQuerier_LockIngredientHierarchy_Call struct {
+	*mock.Call
+}
+
+// LockIngredientHierarchy is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) LockIngredientHierarchy(ctx interface{}) *MockQuerier_LockIngredientHierarchy_Call {
+	return &MockQuerier_LockIngredientHierarchy_Call{Call: _e.mock.On("LockIngredientHierarchy", ctx)}
+}
+
+func (_c *MockQuerier_LockIngredientHierarchy_Call) Run(run func(ctx context.Context)) *MockQuerier_LockIngredientHierarchy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_LockIngredientHierarchy_Call) Return(_a0 error) *MockQuerier_LockIngredientHierarchy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_LockIngredientHierarchy_Call) RunAndReturn(run func(context.Context) error) *MockQuerier_LockIngredientHierarchy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkIngredientMergeSplit provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) MarkIngredientMergeSplit(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkIngredientMergeSplit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_MarkIngredientMergeSplit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkIngredientMergeSplit'
+type MockQuerier_MarkIngredientMergeSplit_Call struct {
+	*mock.Call
+}
+
+// MarkIngredientMergeSplit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) MarkIngredientMergeSplit(ctx interface{}, id interface{}) *MockQuerier_MarkIngredientMergeSplit_Call {
+	return &MockQuerier_MarkIngredientMergeSplit_Call{Call: _e.mock.On("MarkIngredientMergeSplit", ctx, id)}
+}
+
+func (_c *MockQuerier_MarkIngredientMergeSplit_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_MarkIngredientMergeSplit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MarkIngredientMergeSplit_Call) Return(_a0 error) *MockQuerier_MarkIngredientMergeSplit_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_MarkIngredientMergeSplit_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_MarkIngredientMergeSplit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveIngredientAliases provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) MoveIngredientAliases(ctx context.Context, arg db.MoveIngredientAliasesParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveIngredientAliases")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MoveIngredientAliasesParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_MoveIngredientAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveIngredientAliases'
+type MockQuerier_MoveIngredientAliases_Call struct {
+	*mock.Call
+}
+
+// MoveIngredientAliases is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.MoveIngredientAliasesParams
+func (_e *MockQuerier_Expecter) MoveIngredientAliases(ctx interface{}, arg interface{}) *MockQuerier_MoveIngredientAliases_Call {
+	return &MockQuerier_MoveIngredientAliases_Call{Call: _e.mock.On("MoveIngredientAliases", ctx, arg)}
+}
+
+func (_c *MockQuerier_MoveIngredientAliases_Call) Run(run func(ctx context.Context, arg db.MoveIngredientAliasesParams)) *MockQuerier_MoveIngredientAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.MoveIngredientAliasesParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MoveIngredientAliases_Call) Return(_a0 error) *MockQuerier_MoveIngredientAliases_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_MoveIngredientAliases_Call) RunAndReturn(run func(context.Context, db.MoveIngredientAliasesParams) error) *MockQuerier_MoveIngredientAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveNutritionToWinner provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) MoveNutritionToWinner(ctx context.Context, arg db.MoveNutritionToWinnerParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveNutritionToWinner")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MoveNutritionToWinnerParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_MoveNutritionToWinner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveNutritionToWinner'
+type MockQuerier_MoveNutritionToWinner_Call struct {
+	*mock.Call
+}
+
+// MoveNutritionToWinner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.MoveNutritionToWinnerParams
+func (_e *MockQuerier_Expecter) MoveNutritionToWinner(ctx interface{}, arg interface{}) *MockQuerier_MoveNutritionToWinner_Call {
+	return &MockQuerier_MoveNutritionToWinner_Call{Call: _e.mock.On("MoveNutritionToWinner", ctx, arg)}
+}
+
+func (_c *MockQuerier_MoveNutritionToWinner_Call) Run(run func(ctx context.Context, arg db.MoveNutritionToWinnerParams)) *MockQuerier_MoveNutritionToWinner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.MoveNutritionToWinnerParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MoveNutritionToWinner_Call) Return(_a0 error) *MockQuerier_MoveNutritionToWinner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_MoveNutritionToWinner_Call) RunAndReturn(run func(context.Context, db.MoveNutritionToWinnerParams) error) *MockQuerier_MoveNutritionToWinner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveStorageGuidelinesToWinner provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) MoveStorageGuidelinesToWinner(ctx context.Context, arg db.MoveStorageGuidelinesToWinnerParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveStorageGuidelinesToWinner")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MoveStorageGuidelinesToWinnerParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_MoveStorageGuidelinesToWinner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveStorageGuidelinesToWinner'
+type MockQuerier_MoveStorageGuidelinesToWinner_Call struct {
+	*mock.Call
+}
+
+// MoveStorageGuidelinesToWinner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.MoveStorageGuidelinesToWinnerParams
+func (_e *MockQuerier_Expecter) MoveStorageGuidelinesToWinner(ctx interface{}, arg interface{}) *MockQuerier_MoveStorageGuidelinesToWinner_Call {
+	return &MockQuerier_MoveStorageGuidelinesToWinner_Call{Call: _e.mock.On("MoveStorageGuidelinesToWinner", ctx, arg)}
+}
+
+func (_c *MockQuerier_MoveStorageGuidelinesToWinner_Call) Run(run func(ctx context.Context, arg db.MoveStorageGuidelinesToWinnerParams)) *MockQuerier_MoveStorageGuidelinesToWinner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.MoveStorageGuidelinesToWinnerParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MoveStorageGuidelinesToWinner_Call) Return(_a0 error) *MockQuerier_MoveStorageGuidelinesToWinner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_MoveStorageGuidelinesToWinner_Call) RunAndReturn(run func(context.Context, db.MoveStorageGuidelinesToWinnerParams) error) *MockQuerier_MoveStorageGuidelinesToWinner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RepairUnitConversionFactor provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RepairUnitConversionFactor(ctx context.Context, arg db.RepairUnitConversionFactorParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepairUnitConversionFactor")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RepairUnitConversionFactorParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RepairUnitConversionFactorParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RepairUnitConversionFactorParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RepairUnitConversionFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RepairUnitConversionFactor'
+type MockQuerier_RepairUnitConversionFactor_Call struct {
+	*mock.Call
+}
+
+// RepairUnitConversionFactor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RepairUnitConversionFactorParams
+func (_e *MockQuerier_Expecter) RepairUnitConversionFactor(ctx interface{}, arg interface{}) *MockQuerier_RepairUnitConversionFactor_Call {
+	return &MockQuerier_RepairUnitConversionFactor_Call{Call: _e.mock.On("RepairUnitConversionFactor", ctx, arg)}
+}
+
+func (_c *MockQuerier_RepairUnitConversionFactor_Call) Run(run func(ctx context.Context, arg db.RepairUnitConversionFactorParams)) *MockQuerier_RepairUnitConversionFactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RepairUnitConversionFactorParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RepairUnitConversionFactor_Call) Return(_a0 int64, _a1 error) *MockQuerier_RepairUnitConversionFactor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RepairUnitConversionFactor_Call) RunAndReturn(run func(context.Context, db.RepairUnitConversionFactorParams) (int64, error)) *MockQuerier_RepairUnitConversionFactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceCompositeComponentIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ReplaceCompositeComponentIngredient(ctx context.Context, arg db.ReplaceCompositeComponentIngredientParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceCompositeComponentIngredient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ReplaceCompositeComponentIngredientParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_ReplaceCompositeComponentIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceCompositeComponentIngredient'
+type MockQuerier_ReplaceCompositeComponentIngredient_Call struct {
+	*mock.Call
+}
+
+// ReplaceCompositeComponentIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ReplaceCompositeComponentIngredientParams
+func (_e *MockQuerier_Expecter) ReplaceCompositeComponentIngredient(ctx interface{}, arg interface{}) *MockQuerier_ReplaceCompositeComponentIngredient_Call {
+	return &MockQuerier_ReplaceCompositeComponentIngredient_Call{Call: _e.mock.On("ReplaceCompositeComponentIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_ReplaceCompositeComponentIngredient_Call) Run(run func(ctx context.Context, arg db.ReplaceCompositeComponentIngredientParams)) *MockQuerier_ReplaceCompositeComponentIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ReplaceCompositeComponentIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ReplaceCompositeComponentIngredient_Call) Return(_a0 error) *MockQuerier_ReplaceCompositeComponentIngredient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_ReplaceCompositeComponentIngredient_Call) RunAndReturn(run func(context.Context, db.ReplaceCompositeComponentIngredientParams) error) *MockQuerier_ReplaceCompositeComponentIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceCompositeSubstituteIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ReplaceCompositeSubstituteIngredient(ctx context.Context, arg db.ReplaceCompositeSubstituteIngredientParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceCompositeSubstituteIngredient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ReplaceCompositeSubstituteIngredientParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_ReplaceCompositeSubstituteIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceCompositeSubstituteIngredient'
+type MockQuerier_ReplaceCompositeSubstituteIngredient_Call struct {
+	*mock.Call
+}
+
+// ReplaceCompositeSubstituteIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ReplaceCompositeSubstituteIngredientParams
+func (_e *MockQuerier_Expecter) ReplaceCompositeSubstituteIngredient(ctx interface{}, arg interface{}) *MockQuerier_ReplaceCompositeSubstituteIngredient_Call {
+	return &MockQuerier_ReplaceCompositeSubstituteIngredient_Call{Call: _e.mock.On("ReplaceCompositeSubstituteIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_ReplaceCompositeSubstituteIngredient_Call) Run(run func(ctx context.Context, arg db.ReplaceCompositeSubstituteIngredientParams)) *MockQuerier_ReplaceCompositeSubstituteIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ReplaceCompositeSubstituteIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ReplaceCompositeSubstituteIngredient_Call) Return(_a0 error) *MockQuerier_ReplaceCompositeSubstituteIngredient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_ReplaceCompositeSubstituteIngredient_Call) RunAndReturn(run func(context.Context, db.ReplaceCompositeSubstituteIngredientParams) error) *MockQuerier_ReplaceCompositeSubstituteIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceSubstituteIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ReplaceSubstituteIngredient(ctx context.Context, arg db.ReplaceSubstituteIngredientParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceSubstituteIngredient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ReplaceSubstituteIngredientParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_ReplaceSubstituteIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceSubstituteIngredient'
+type MockQuerier_ReplaceSubstituteIngredient_Call struct {
+	*mock.Call
+}
+
+// ReplaceSubstituteIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ReplaceSubstituteIngredientParams
+func (_e *MockQuerier_Expecter) ReplaceSubstituteIngredient(ctx interface{}, arg interface{}) *MockQuerier_ReplaceSubstituteIngredient_Call {
+	return &MockQuerier_ReplaceSubstituteIngredient_Call{Call: _e.mock.On("ReplaceSubstituteIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_ReplaceSubstituteIngredient_Call) Run(run func(ctx context.Context, arg db.ReplaceSubstituteIngredientParams)) *MockQuerier_ReplaceSubstituteIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ReplaceSubstituteIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ReplaceSubstituteIngredient_Call) Return(_a0 error) *MockQuerier_ReplaceSubstituteIngredient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_ReplaceSubstituteIngredient_Call) RunAndReturn(run func(context.Context, db.ReplaceSubstituteIngredientParams) error) *MockQuerier_ReplaceSubstituteIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceSubstituteSubId provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ReplaceSubstituteSubId(ctx context.Context, arg db.ReplaceSubstituteSubIdParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceSubstituteSubId")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ReplaceSubstituteSubIdParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_ReplaceSubstituteSubId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceSubstituteSubId'
+type MockQuerier_ReplaceSubstituteSubId_Call struct {
+	*mock.Call
+}
+
+// ReplaceSubstituteSubId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ReplaceSubstituteSubIdParams
+func (_e *MockQuerier_Expecter) ReplaceSubstituteSubId(ctx interface{}, arg interface{}) *MockQuerier_ReplaceSubstituteSubId_Call {
+	return &MockQuerier_ReplaceSubstituteSubId_Call{Call: _e.mock.On("ReplaceSubstituteSubId", ctx, arg)}
+}
+
+func (_c *MockQuerier_ReplaceSubstituteSubId_Call) Run(run func(ctx context.Context, arg db.ReplaceSubstituteSubIdParams)) *MockQuerier_ReplaceSubstituteSubId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ReplaceSubstituteSubIdParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ReplaceSubstituteSubId_Call) Return(_a0 error) *MockQuerier_ReplaceSubstituteSubId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_ReplaceSubstituteSubId_Call) RunAndReturn(run func(context.Context, db.ReplaceSubstituteSubIdParams) error) *MockQuerier_ReplaceSubstituteSubId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceUnitConversionIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ReplaceUnitConversionIngredient(ctx context.Context, arg db.ReplaceUnitConversionIngredientParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceUnitConversionIngredient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ReplaceUnitConversionIngredientParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_ReplaceUnitConversionIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceUnitConversionIngredient'
+type MockQuerier_ReplaceUnitConversionIngredient_Call struct {
+	*mock.Call
+}
+
+// ReplaceUnitConversionIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ReplaceUnitConversionIngredientParams
+func (_e *MockQuerier_Expecter) ReplaceUnitConversionIngredient(ctx interface{}, arg interface{}) *MockQuerier_ReplaceUnitConversionIngredient_Call {
+	return &MockQuerier_ReplaceUnitConversionIngredient_Call{Call: _e.mock.On("ReplaceUnitConversionIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_ReplaceUnitConversionIngredient_Call) Run(run func(ctx context.Context, arg db.ReplaceUnitConversionIngredientParams)) *MockQuerier_ReplaceUnitConversionIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ReplaceUnitConversionIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ReplaceUnitConversionIngredient_Call) Return(_a0 error) *MockQuerier_ReplaceUnitConversionIngredient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_ReplaceUnitConversionIngredient_Call) RunAndReturn(run func(context.Context, db.ReplaceUnitConversionIngredientParams) error) *MockQuerier_ReplaceUnitConversionIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreCompositeComponent provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreCompositeComponent(ctx context.Context, arg db.RestoreCompositeComponentParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreCompositeComponent")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreCompositeComponentParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreCompositeComponentParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreCompositeComponentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreCompositeComponent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreCompositeComponent'
+type MockQuerier_RestoreCompositeComponent_Call struct {
+	*mock.Call
+}
+
+// RestoreCompositeComponent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreCompositeComponentParams
+func (_e *MockQuerier_Expecter) RestoreCompositeComponent(ctx interface{}, arg interface{}) *MockQuerier_RestoreCompositeComponent_Call {
+	return &MockQuerier_RestoreCompositeComponent_Call{Call: _e.mock.On("RestoreCompositeComponent", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreCompositeComponent_Call) Run(run func(ctx context.Context, arg db.RestoreCompositeComponentParams)) *MockQuerier_RestoreCompositeComponent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreCompositeComponentParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreCompositeComponent_Call) Return(_a0 int64, _a1 error) *MockQuerier_RestoreCompositeComponent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreCompositeComponent_Call) RunAndReturn(run func(context.Context, db.RestoreCompositeComponentParams) (int64, error)) *MockQuerier_RestoreCompositeComponent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreCompositeComponentQuantity provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreCompositeComponentQuantity(ctx context.Context, arg db.RestoreCompositeComponentQuantityParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreCompositeComponentQuantity")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreCompositeComponentQuantityParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreCompositeComponentQuantityParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreCompositeComponentQuantityParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreCompositeComponentQuantity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreCompositeComponentQuantity'
+type MockQuerier_RestoreCompositeComponentQuantity_Call struct {
+	*mock.Call
+}
+
+// RestoreCompositeComponentQuantity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreCompositeComponentQuantityParams
+func (_e *MockQuerier_Expecter) RestoreCompositeComponentQuantity(ctx interface{}, arg interface{}) *MockQuerier_RestoreCompositeComponentQuantity_Call {
+	return &MockQuerier_RestoreCompositeComponentQuantity_Call{Call: _e.mock.On("RestoreCompositeComponentQuantity", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreCompositeComponentQuantity_Call) Run(run func(ctx context.Context, arg db.RestoreCompositeComponentQuantityParams)) *MockQuerier_RestoreCompositeComponentQuantity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreCompositeComponentQuantityParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreCompositeComponentQuantity_Call) Return(_a0 int64, _a1 error) *MockQuerier_RestoreCompositeComponentQuantity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreCompositeComponentQuantity_Call) RunAndReturn(run func(context.Context, db.RestoreCompositeComponentQuantityParams) (int64, error)) *MockQuerier_RestoreCompositeComponentQuantity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreCompositeSubstituteIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreCompositeSubstituteIngredient(ctx context.Context, arg db.RestoreCompositeSubstituteIngredientParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreCompositeSubstituteIngredient")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreCompositeSubstituteIngredientParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreCompositeSubstituteIngredientParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreCompositeSubstituteIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreCompositeSubstituteIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreCompositeSubstituteIngredient'
+type MockQuerier_RestoreCompositeSubstituteIngredient_Call struct {
+	*mock.Call
+}
+
+// RestoreCompositeSubstituteIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreCompositeSubstituteIngredientParams
+func (_e *MockQuerier_Expecter) RestoreCompositeSubstituteIngredient(ctx interface{}, arg interface{}) *MockQuerier_RestoreCompositeSubstituteIngredient_Call {
+	return &MockQuerier_RestoreCompositeSubstituteIngredient_Call{Call: _e.mock.On("RestoreCompositeSubstituteIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreCompositeSubstituteIngredient_Call) Run(run func(ctx context.Context, arg db.RestoreCompositeSubstituteIngredientParams)) *MockQuerier_RestoreCompositeSubstituteIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreCompositeSubstituteIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreCompositeSubstituteIngredient_Call) Return(_a0 int64, _a1 error) *MockQuerier_RestoreCompositeSubstituteIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreCompositeSubstituteIngredient_Call) RunAndReturn(run func(context.Context, db.RestoreCompositeSubstituteIngredientParams) (int64, error)) *MockQuerier_RestoreCompositeSubstituteIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreIngredient(ctx context.Context, arg db.RestoreIngredientParams) (db.Ingredient, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreIngredient")
+	}
+
+	var r0 db.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreIngredientParams) (db.Ingredient, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreIngredientParams) db.Ingredient); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Ingredient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreIngredient'
+type MockQuerier_RestoreIngredient_Call struct {
+	*mock.Call
+}
+
+// RestoreIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreIngredientParams
+func (_e *MockQuerier_Expecter) RestoreIngredient(ctx interface{}, arg interface{}) *MockQuerier_RestoreIngredient_Call {
+	return &MockQuerier_RestoreIngredient_Call{Call: _e.mock.On("RestoreIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreIngredient_Call) Run(run func(ctx context.Context, arg db.RestoreIngredientParams)) *MockQuerier_RestoreIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreIngredient_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_RestoreIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreIngredient_Call) RunAndReturn(run func(context.Context, db.RestoreIngredientParams) (db.Ingredient, error)) *MockQuerier_RestoreIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreIngredientNutrition provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreIngredientNutrition(ctx context.Context, arg db.RestoreIngredientNutritionParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreIngredientNutrition")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreIngredientNutritionParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreIngredientNutritionParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreIngredientNutritionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreIngredientNutrition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreIngredientNutrition'
+type MockQuerier_RestoreIngredientNutrition_Call struct {
+	*mock.Call
+}
+
+// RestoreIngredientNutrition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreIngredientNutritionParams
+func (_e *MockQuerier_Expecter) RestoreIngredientNutrition(ctx interface{}, arg interface{}) *MockQuerier_RestoreIngredientNutrition_Call {
+	return &MockQuerier_RestoreIngredientNutrition_Call{Call: _e.mock.On("RestoreIngredientNutrition", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreIngredientNutrition_Call) Run(run func(ctx context.Context, arg db.RestoreIngredientNutritionParams)) *MockQuerier_RestoreIngredientNutrition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreIngredientNutritionParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreIngredientNutrition_Call) Return(_a0 int64, _a1 error) *MockQuerier_RestoreIngredientNutrition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreIngredientNutrition_Call) RunAndReturn(run func(context.Context, db.RestoreIngredientNutritionParams) (int64, error)) *MockQuerier_RestoreIngredientNutrition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreIngredientParent provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreIngredientParent(ctx context.Context, arg db.RestoreIngredientParentParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreIngredientParent")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreIngredientParentParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreIngredientParentParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreIngredientParentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreIngredientParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreIngredientParent'
+type MockQuerier_RestoreIngredientParent_Call struct {
+	*mock.Call
+}
+
+// RestoreIngredientParent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreIngredientParentParams
+func (_e *MockQuerier_Expecter) RestoreIngredientParent(ctx interface{}, arg interface{}) *MockQuerier_RestoreIngredientParent_Call {
+	return &MockQuerier_RestoreIngredientParent_Call{Call: _e.mock.On("RestoreIngredientParent", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreIngredientParent_Call) Run(run func(ctx context.Context, arg db.RestoreIngredientParentParams)) *MockQuerier_RestoreIngredientParent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreIngredientParentParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreIngredientParent_Call) Return(_a0 int64, _a1 error) *MockQuerier_RestoreIngredientParent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreIngredientParent_Call) RunAndReturn(run func(context.Context, db.RestoreIngredientParentParams) (int64, error)) *MockQuerier_RestoreIngredientParent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreIngredientRedirect provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreIngredientRedirect(ctx context.Context, arg db.RestoreIngredientRedirectParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreIngredientRedirect")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreIngredientRedirectParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreIngredientRedirectParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreIngredientRedirectParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreIngredientRedirect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreIngredientRedirect'
+type MockQuerier_RestoreIngredientRedirect_Call struct {
+	*mock.Call
+}
+
+// RestoreIngredientRedirect is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreIngredientRedirectParams
+func (_e *MockQuerier_Expecter) RestoreIngredientRedirect(ctx interface{}, arg interface{}) *MockQuerier_RestoreIngredientRedirect_Call {
+	return &MockQuerier_RestoreIngredientRedirect_Call{Call: _e.mock.On("RestoreIngredientRedirect", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreIngredientRedirect_Call) Run(run func(ctx context.Context, arg db.RestoreIngredientRedirectParams)) *MockQuerier_RestoreIngredientRedirect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreIngredientRedirectParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreIngredientRedirect_Call) Return(_a0 int64, _a1 error) *MockQuerier_RestoreIngredientRedirect_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreIngredientRedirect_Call) RunAndReturn(run func(context.Context, db.RestoreIngredientRedirectParams) (int64, error)) *MockQuerier_RestoreIngredientRedirect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreStorageGuideline provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreStorageGuideline(ctx context.Context, arg db.RestoreStorageGuidelineParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreStorageGuideline")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreStorageGuidelineParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreStorageGuidelineParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreStorageGuidelineParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreStorageGuideline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreStorageGuideline'
+type MockQuerier_RestoreStorageGuideline_Call struct {
+	*mock.Call
+}
+
+// RestoreStorageGuideline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreStorageGuidelineParams
+func (_e *MockQuerier_Expecter) RestoreStorageGuideline(ctx interface{}, arg interface{}) *MockQuerier_RestoreStorageGuideline_Call {
+	return &MockQuerier_RestoreStorageGuideline_Call{Call: _e.mock.On("RestoreStorageGuideline", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreStorageGuideline_Call) Run(run func(ctx context.Context, arg db.RestoreStorageGuidelineParams)) *MockQuerier_RestoreStorageGuideline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreStorageGuidelineParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreStorageGuideline_Call) Return(_a0 int64, _a1 error) *MockQuerier_RestoreStorageGuideline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreStorageGuideline_Call) RunAndReturn(run func(context.Context, db.RestoreStorageGuidelineParams) (int64, error)) *MockQuerier_RestoreStorageGuideline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreSubstituteIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreSubstituteIngredient(ctx context.Context, arg db.RestoreSubstituteIngredientParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSubstituteIngredient")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreSubstituteIngredientParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreSubstituteIngredientParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreSubstituteIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreSubstituteIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSubstituteIngredient'
+type MockQuerier_RestoreSubstituteIngredient_Call struct {
+	*mock.Call
+}
+
+// RestoreSubstituteIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreSubstituteIngredientParams
+func (_e *MockQuerier_Expecter) RestoreSubstituteIngredient(ctx interface{}, arg interface{}) *MockQuerier_RestoreSubstituteIngredient_Call {
+	return &MockQuerier_RestoreSubstituteIngredient_Call{Call: _e.mock.On("RestoreSubstituteIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreSubstituteIngredient_Call) Run(run func(ctx context.Context, arg db.RestoreSubstituteIngredientParams)) *MockQuerier_RestoreSubstituteIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreSubstituteIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreSubstituteIngredient_Call) Return(_a0 int64, _a1 error) *MockQuerier_RestoreSubstituteIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreSubstituteIngredient_Call) RunAndReturn(run func(context.Context, db.RestoreSubstituteIngredientParams) (int64, error)) *MockQuerier_RestoreSubstituteIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreSubstituteSubId provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreSubstituteSubId(ctx context.Context, arg db.RestoreSubstituteSubIdParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSubstituteSubId")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreSubstituteSubIdParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreSubstituteSubIdParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreSubstituteSubIdParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreSubstituteSubId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSubstituteSubId'
+type MockQuerier_RestoreSubstituteSubId_Call struct {
+	*mock.Call
+}
+
+// RestoreSubstituteSubId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreSubstituteSubIdParams
+func (_e *MockQuerier_Expecter) RestoreSubstituteSubId(ctx interface{}, arg interface{}) *MockQuerier_RestoreSubstituteSubId_Call {
+	return &MockQuerier_RestoreSubstituteSubId_Call{Call: _e.mock.On("RestoreSubstituteSubId", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreSubstituteSubId_Call) Run(run func(ctx context.Context, arg db.RestoreSubstituteSubIdParams)) *MockQuerier_RestoreSubstituteSubId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreSubstituteSubIdParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreSubstituteSubId_Call) Return(_a0 int64, _a1 error) *MockQuerier_RestoreSubstituteSubId_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreSubstituteSubId_Call) RunAndReturn(run func(context.Context, db.RestoreSubstituteSubIdParams) (int64, error)) *MockQuerier_RestoreSubstituteSubId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreUnitConversion provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreUnitConversion(ctx context.Context, arg db.RestoreUnitConversionParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreUnitConversion")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreUnitConversionParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreUnitConversionParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreUnitConversionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreUnitConversion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreUnitConversion'
+type MockQuerier_RestoreUnitConversion_Call struct {
+	*mock.Call
+}
+
+// RestoreUnitConversion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreUnitConversionParams
+func (_e *MockQuerier_Expecter) RestoreUnitConversion(ctx interface{}, arg interface{}) *MockQuerier_RestoreUnitConversion_Call {
+	return &MockQuerier_RestoreUnitConversion_Call{Call: _e.mock.On("RestoreUnitConversion", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreUnitConversion_Call) Run(run func(ctx context.Context, arg db.RestoreUnitConversionParams)) *MockQuerier_RestoreUnitConversion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreUnitConversionParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreUnitConversion_Call) Return(_a0 int64, _a1 error) *MockQuerier_RestoreUnitConversion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreUnitConversion_Call) RunAndReturn(run func(context.Context, db.RestoreUnitConversionParams) (int64, error)) *MockQuerier_RestoreUnitConversion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreUnitConversionFactor provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreUnitConversionFactor(ctx context.Context, arg db.RestoreUnitConversionFactorParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreUnitConversionFactor")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreUnitConversionFactorParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreUnitConversionFactorParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreUnitConversionFactorParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RestoreUnitConversionFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreUnitConversionFactor'
+type MockQuerier_RestoreUnitConversionFactor_Call struct {
+	*mock.Call
+}
+
+// RestoreUnitConversionFactor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreUnitConversionFactorParams
+func (_e *MockQuerier_Expecter) RestoreUnitConversionFactor(ctx interface{}, arg interface{}) *MockQuerier_RestoreUnitConversionFactor_Call {
+	return &MockQuerier_RestoreUnitConversionFactor_Call{Call: _e.mock.On("RestoreUnitConversionFactor", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreUnitConversionFactor_Call) Run(run func(ctx context.Context, arg db.RestoreUnitConversionFactorParams)) *MockQuerier_RestoreUnitConversionFactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreUnitConversionFactorParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreUnitConversionFactor_Call) Return(_a0 int64, _a1 error) *MockQuerier_RestoreUnitConversionFactor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreUnitConversionFactor_Call) RunAndReturn(run func(context.Context, db.RestoreUnitConversionFactorParams) (int64, error)) *MockQuerier_RestoreUnitConversionFactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetargetIngredientRedirects provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RetargetIngredientRedirects(ctx context.Context, arg db.RetargetIngredientRedirectsParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetargetIngredientRedirects")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RetargetIngredientRedirectsParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_RetargetIngredientRedirects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetargetIngredientRedirects'
+type MockQuerier_RetargetIngredientRedirects_Call struct {
+	*mock.Call
+}
+
+// RetargetIngredientRedirects is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RetargetIngredientRedirectsParams
+func (_e *MockQuerier_Expecter) RetargetIngredientRedirects(ctx interface{}, arg interface{}) *MockQuerier_RetargetIngredientRedirects_Call {
+	return &MockQuerier_RetargetIngredientRedirects_Call{Call: _e.mock.On("RetargetIngredientRedirects", ctx, arg)}
+}
+
+func (_c *MockQuerier_RetargetIngredientRedirects_Call) Run(run func(ctx context.Context, arg db.RetargetIngredientRedirectsParams)) *MockQuerier_RetargetIngredientRedirects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RetargetIngredientRedirectsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RetargetIngredientRedirects_Call) Return(_a0 error) *MockQuerier_RetargetIngredientRedirects_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_RetargetIngredientRedirects_Call) RunAndReturn(run func(context.Context, db.RetargetIngredientRedirectsParams) error) *MockQuerier_RetargetIngredientRedirects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetIngredientChange provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) SetIngredientChange(ctx context.Context, arg db.SetIngredientChangeParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIngredientChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetIngredientChangeParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_SetIngredientChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIngredientChange'
+type MockQuerier_SetIngredientChange_Call struct {
+	*mock.Call
+}
+
+// SetIngredientChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetIngredientChangeParams
+func (_e *MockQuerier_Expecter) SetIngredientChange(ctx interface{}, arg interface{}) *MockQuerier_SetIngredientChange_Call {
+	return &MockQuerier_SetIngredientChange_Call{Call: _e.mock.On("SetIngredientChange", ctx, arg)}
+}
+
+func (_c *MockQuerier_SetIngredientChange_Call) Run(run func(ctx context.Context, arg db.SetIngredientChangeParams)) *MockQuerier_SetIngredientChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetIngredientChangeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_SetIngredientChange_Call) Return(_a0 error) *MockQuerier_SetIngredientChange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_SetIngredientChange_Call) RunAndReturn(run func(context.Context, db.SetIngredientChangeParams) error) *MockQuerier_SetIngredientChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetIngredientParent provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) SetIngredientParent(ctx context.Context, arg db.SetIngredientParentParams) (db.Ingredient, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIngredientParent")
+	}
+
+	var r0 db.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetIngredientParentParams) (db.Ingredient, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetIngredientParentParams) db.Ingredient); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Ingredient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetIngredientParentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_SetIngredientParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIngredientParent'
+type MockQuerier_SetIngredientParent_Call struct {
+	*mock.Call
+}
+
+// SetIngredientParent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetIngredientParentParams
+func (_e *MockQuerier_Expecter) SetIngredientParent(ctx interface{}, arg interface{}) *MockQuerier_SetIngredientParent_Call {
+	return &MockQuerier_SetIngredientParent_Call{Call: _e.mock.On("SetIngredientParent", ctx, arg)}
+}
+
+func (_c *MockQuerier_SetIngredientParent_Call) Run(run func(ctx context.Context, arg db.SetIngredientParentParams)) *MockQuerier_SetIngredientParent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetIngredientParentParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_SetIngredientParent_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_SetIngredientParent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_SetIngredientParent_Call) RunAndReturn(run func(context.Context, db.SetIngredientParentParams) (db.Ingredient, error)) *MockQuerier_SetIngredientParent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SyncIngredientAliases provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) SyncIngredientAliases(ctx context.Context, id uuid.UUID) (db.Ingredient, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncIngredientAliases")
+	}
+
+	var r0 db.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.Ingredient, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.Ingredient); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Ingredient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_SyncIngredientAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncIngredientAliases'
+type MockQuerier_SyncIngredientAliases_Call struct {
+	*mock.Call
+}
+
+// SyncIngredientAliases is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) SyncIngredientAliases(ctx interface{}, id interface{}) *MockQuerier_SyncIngredientAliases_Call {
+	return &MockQuerier_SyncIngredientAliases_Call{Call: _e.mock.On("SyncIngredientAliases", ctx, id)}
+}
+
+func (_c *MockQuerier_SyncIngredientAliases_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_SyncIngredientAliases_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ListSubstitutesByIngredient_Call) Return(_a0 []db.IngredientSubstitute, _a1 error) *MockQuerier_ListSubstitutesByIngredient_Call {
+func (_c *MockQuerier_SyncIngredientAliases_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_SyncIngredientAliases_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_ListSubstitutesByIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.IngredientSubstitute, error)) *MockQuerier_ListSubstitutesByIngredient_Call {
+func (_c *MockQuerier_SyncIngredientAliases_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.Ingredient, error)) *MockQuerier_SyncIngredientAliases_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListUnitConversionsByIngredient provides a mock function with given fields: ctx, ingredientID
-func (_m *MockQuerier) ListUnitConversionsByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]db.UnitConversion, error) {
-	ret := _m.Called(ctx, ingredientID)
+// UpdateCategory provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateCategory(ctx context.Context, arg db.UpdateCategoryParams) (db.Category, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListUnitConversionsByIngredient")
+		panic("no return value specified for UpdateCategory")
 	}
 
-	var r0 []db.UnitConversion
+	var r0 db.Category
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.UnitConversion, error)); ok {
-		return rf(ctx, ingredientID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateCategoryParams) (db.Category, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.UnitConversion); ok {
-		r0 = rf(ctx, ingredientID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateCategoryParams) db.Category); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.UnitConversion)
-		}
+		r0 = ret.Get(0).(db.Category)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, ingredientID)
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateCategoryParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -549,45 +5353,45 @@ func (_m *MockQuerier) ListUnitConversionsByIngredient(ctx context.Context, ingr
 	return r0, r1
 }
 
-// MockQuerier_ListUnitConversionsByIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnitConversionsByIngredient'
-type MockQuerier_ListUnitConversionsByIngredient_Call struct {
+// MockQuerier_UpdateCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCategory'
+type MockQuerier_UpdateCategory_Call struct {
 	*mock.Call
 }
 
-// ListUnitConversionsByIngredient is a helper method to define mock.On call
+// UpdateCategory is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ingredientID uuid.UUID
-func (_e *MockQuerier_Expecter) ListUnitConversionsByIngredient(ctx interface{}, ingredientID interface{}) *MockQuerier_ListUnitConversionsByIngredient_Call {
-	return &MockQuerier_ListUnitConversionsByIngredient_Call{Call: _e.mock.On("ListUnitConversionsByIngredient", ctx, ingredientID)}
+//   - arg db.UpdateCategoryParams
+func (_e *MockQuerier_Expecter) UpdateCategory(ctx interface{}, arg interface{}) *MockQuerier_UpdateCategory_Call {
+	return &MockQuerier_UpdateCategory_Call{Call: _e.mock.On("UpdateCategory", ctx, arg)}
 }
 
-func (_c *MockQuerier_ListUnitConversionsByIngredient_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_ListUnitConversionsByIngredient_Call {
+func (_c *MockQuerier_UpdateCategory_Call) Run(run func(ctx context.Context, arg db.UpdateCategoryParams)) *MockQuerier_UpdateCategory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(db.UpdateCategoryParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ListUnitConversionsByIngredient_Call) Return(_a0 []db.UnitConversion, _a1 error) *MockQuerier_ListUnitConversionsByIngredient_Call {
+func (_c *MockQuerier_UpdateCategory_Call) Return(_a0 db.Category, _a1 error) *MockQuerier_UpdateCategory_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_ListUnitConversionsByIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.UnitConversion, error)) *MockQuerier_ListUnitConversionsByIngredient_Call {
+func (_c *MockQuerier_UpdateCategory_Call) RunAndReturn(run func(context.Context, db.UpdateCategoryParams) (db.Category, error)) *MockQuerier_UpdateCategory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ReplaceSubstituteIngredient provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) ReplaceSubstituteIngredient(ctx context.Context, arg db.ReplaceSubstituteIngredientParams) error {
+// UpdateCompositeComponentQuantity provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateCompositeComponentQuantity(ctx context.Context, arg db.UpdateCompositeComponentQuantityParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ReplaceSubstituteIngredient")
+		panic("no return value specified for UpdateCompositeComponentQuantity")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ReplaceSubstituteIngredientParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateCompositeComponentQuantityParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -596,149 +5400,169 @@ func (_m *MockQuerier) ReplaceSubstituteIngredient(ctx context.Context, arg db.R
 	return r0
 }
 
-// MockQuerier_ReplaceSubstituteIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceSubstituteIngredient'
-type MockQuerier_ReplaceSubstituteIngredient_Call struct {
+// MockQuerier_UpdateCompositeComponentQuantity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCompositeComponentQuantity'
+type MockQuerier_UpdateCompositeComponentQuantity_Call struct {
 	*mock.Call
 }
 
-// ReplaceSubstituteIngredient is a helper method to define mock.On call
+// UpdateCompositeComponentQuantity is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.ReplaceSubstituteIngredientParams
-func (_e *MockQuerier_Expecter) ReplaceSubstituteIngredient(ctx interface{}, arg interface{}) *MockQuerier_ReplaceSubstituteIngredient_Call {
-	return &MockQuerier_ReplaceSubstituteIngredient_Call{Call: _e.mock.On("ReplaceSubstituteIngredient", ctx, arg)}
+//   - arg db.UpdateCompositeComponentQuantityParams
+func (_e *MockQuerier_Expecter) UpdateCompositeComponentQuantity(ctx interface{}, arg interface{}) *MockQuerier_UpdateCompositeComponentQuantity_Call {
+	return &MockQuerier_UpdateCompositeComponentQuantity_Call{Call: _e.mock.On("UpdateCompositeComponentQuantity", ctx, arg)}
 }
 
-func (_c *MockQuerier_ReplaceSubstituteIngredient_Call) Run(run func(ctx context.Context, arg db.ReplaceSubstituteIngredientParams)) *MockQuerier_ReplaceSubstituteIngredient_Call {
+func (_c *MockQuerier_UpdateCompositeComponentQuantity_Call) Run(run func(ctx context.Context, arg db.UpdateCompositeComponentQuantityParams)) *MockQuerier_UpdateCompositeComponentQuantity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.ReplaceSubstituteIngredientParams))
+		run(args[0].(context.Context), args[1].(db.UpdateCompositeComponentQuantityParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ReplaceSubstituteIngredient_Call) Return(_a0 error) *MockQuerier_ReplaceSubstituteIngredient_Call {
+func (_c *MockQuerier_UpdateCompositeComponentQuantity_Call) Return(_a0 error) *MockQuerier_UpdateCompositeComponentQuantity_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockQuerier_ReplaceSubstituteIngredient_Call) RunAndReturn(run func(context.Context, db.ReplaceSubstituteIngredientParams) error) *MockQuerier_ReplaceSubstituteIngredient_Call {
+func (_c *MockQuerier_UpdateCompositeComponentQuantity_Call) RunAndReturn(run func(context.Context, db.UpdateCompositeComponentQuantityParams) error) *MockQuerier_UpdateCompositeComponentQuantity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ReplaceSubstituteSubId provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) ReplaceSubstituteSubId(ctx context.Context, arg db.ReplaceSubstituteSubIdParams) error {
+// UpdateIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateIngredient(ctx context.Context, arg db.UpdateIngredientParams) (db.Ingredient, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ReplaceSubstituteSubId")
+		panic("no return value specified for UpdateIngredient")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ReplaceSubstituteSubIdParams) error); ok {
+	var r0 db.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateIngredientParams) (db.Ingredient, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateIngredientParams) db.Ingredient); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Ingredient)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockQuerier_ReplaceSubstituteSubId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceSubstituteSubId'
-type MockQuerier_ReplaceSubstituteSubId_Call struct {
+// MockQuerier_UpdateIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateIngredient'
+type MockQuerier_UpdateIngredient_Call struct {
 	*mock.Call
 }
 
-// ReplaceSubstituteSubId is a helper method to define mock.On call
+// UpdateIngredient is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.ReplaceSubstituteSubIdParams
-func (_e *MockQuerier_Expecter) ReplaceSubstituteSubId(ctx interface{}, arg interface{}) *MockQuerier_ReplaceSubstituteSubId_Call {
-	return &MockQuerier_ReplaceSubstituteSubId_Call{Call: _e.mock.On("ReplaceSubstituteSubId", ctx, arg)}
+//   - arg db.UpdateIngredientParams
+func (_e *MockQuerier_Expecter) UpdateIngredient(ctx interface{}, arg interface{}) *MockQuerier_UpdateIngredient_Call {
+	return &MockQuerier_UpdateIngredient_Call{Call: _e.mock.On("UpdateIngredient", ctx, arg)}
 }
 
-func (_c *MockQuerier_ReplaceSubstituteSubId_Call) Run(run func(ctx context.Context, arg db.ReplaceSubstituteSubIdParams)) *MockQuerier_ReplaceSubstituteSubId_Call {
+func (_c *MockQuerier_UpdateIngredient_Call) Run(run func(ctx context.Context, arg db.UpdateIngredientParams)) *MockQuerier_UpdateIngredient_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.ReplaceSubstituteSubIdParams))
+		run(args[0].(context.Context), args[1].(db.UpdateIngredientParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ReplaceSubstituteSubId_Call) Return(_a0 error) *MockQuerier_ReplaceSubstituteSubId_Call {
-	_c.Call.Return(_a0)
+func (_c *MockQuerier_UpdateIngredient_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_UpdateIngredient_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_ReplaceSubstituteSubId_Call) RunAndReturn(run func(context.Context, db.ReplaceSubstituteSubIdParams) error) *MockQuerier_ReplaceSubstituteSubId_Call {
+func (_c *MockQuerier_UpdateIngredient_Call) RunAndReturn(run func(context.Context, db.UpdateIngredientParams) (db.Ingredient, error)) *MockQuerier_UpdateIngredient_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ReplaceUnitConversionIngredient provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) ReplaceUnitConversionIngredient(ctx context.Context, arg db.ReplaceUnitConversionIngredientParams) error {
+// UpdateIngredientDietary provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateIngredientDietary(ctx context.Context, arg db.UpdateIngredientDietaryParams) (db.Ingredient, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ReplaceUnitConversionIngredient")
+		panic("no return value specified for UpdateIngredientDietary")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ReplaceUnitConversionIngredientParams) error); ok {
+	var r0 db.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateIngredientDietaryParams) (db.Ingredient, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateIngredientDietaryParams) db.Ingredient); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Ingredient)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateIngredientDietaryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockQuerier_ReplaceUnitConversionIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceUnitConversionIngredient'
-type MockQuerier_ReplaceUnitConversionIngredient_Call struct {
+// MockQuerier_UpdateIngredientDietary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateIngredientDietary'
+type MockQuerier_UpdateIngredientDietary_Call struct {
 	*mock.Call
 }
 
-// ReplaceUnitConversionIngredient is a helper method to define mock.On call
+// UpdateIngredientDietary is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.ReplaceUnitConversionIngredientParams
-func (_e *MockQuerier_Expecter) ReplaceUnitConversionIngredient(ctx interface{}, arg interface{}) *MockQuerier_ReplaceUnitConversionIngredient_Call {
-	return &MockQuerier_ReplaceUnitConversionIngredient_Call{Call: _e.mock.On("ReplaceUnitConversionIngredient", ctx, arg)}
+//   - arg db.UpdateIngredientDietaryParams
+func (_e *MockQuerier_Expecter) UpdateIngredientDietary(ctx interface{}, arg interface{}) *MockQuerier_UpdateIngredientDietary_Call {
+	return &MockQuerier_UpdateIngredientDietary_Call{Call: _e.mock.On("UpdateIngredientDietary", ctx, arg)}
 }
 
-func (_c *MockQuerier_ReplaceUnitConversionIngredient_Call) Run(run func(ctx context.Context, arg db.ReplaceUnitConversionIngredientParams)) *MockQuerier_ReplaceUnitConversionIngredient_Call {
+func (_c *MockQuerier_UpdateIngredientDietary_Call) Run(run func(ctx context.Context, arg db.UpdateIngredientDietaryParams)) *MockQuerier_UpdateIngredientDietary_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.ReplaceUnitConversionIngredientParams))
+		run(args[0].(context.Context), args[1].(db.UpdateIngredientDietaryParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ReplaceUnitConversionIngredient_Call) Return(_a0 error) *MockQuerier_ReplaceUnitConversionIngredient_Call {
-	_c.Call.Return(_a0)
+func (_c *MockQuerier_UpdateIngredientDietary_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_UpdateIngredientDietary_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_ReplaceUnitConversionIngredient_Call) RunAndReturn(run func(context.Context, db.ReplaceUnitConversionIngredientParams) error) *MockQuerier_ReplaceUnitConversionIngredient_Call {
+func (_c *MockQuerier_UpdateIngredientDietary_Call) RunAndReturn(run func(context.Context, db.UpdateIngredientDietaryParams) (db.Ingredient, error)) *MockQuerier_UpdateIngredientDietary_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateIngredient provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) UpdateIngredient(ctx context.Context, arg db.UpdateIngredientParams) (db.Ingredient, error) {
+// UpdateUnitConversionFactor provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateUnitConversionFactor(ctx context.Context, arg db.UpdateUnitConversionFactorParams) (db.UnitConversion, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateIngredient")
+		panic("no return value specified for UpdateUnitConversionFactor")
 	}
 
-	var r0 db.Ingredient
+	var r0 db.UnitConversion
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateIngredientParams) (db.Ingredient, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateUnitConversionFactorParams) (db.UnitConversion, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateIngredientParams) db.Ingredient); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateUnitConversionFactorParams) db.UnitConversion); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Ingredient)
+		r0 = ret.Get(0).(db.UnitConversion)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateIngredientParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateUnitConversionFactorParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -747,31 +5571,88 @@ func (_m *MockQuerier) UpdateIngredient(ctx context.Context, arg db.UpdateIngred
 	return r0, r1
 }
 
-// MockQuerier_UpdateIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateIngredient'
-type MockQuerier_UpdateIngredient_Call struct {
+// MockQuerier_UpdateUnitConversionFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUnitConversionFactor'
+type MockQuerier_UpdateUnitConversionFactor_Call struct {
 	*mock.Call
 }
 
-// UpdateIngredient is a helper method to define mock.On call
+// UpdateUnitConversionFactor is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.UpdateIngredientParams
-func (_e *MockQuerier_Expecter) UpdateIngredient(ctx interface{}, arg interface{}) *MockQuerier_UpdateIngredient_Call {
-	return &MockQuerier_UpdateIngredient_Call{Call: _e.mock.On("UpdateIngredient", ctx, arg)}
+//   - arg db.UpdateUnitConversionFactorParams
+func (_e *MockQuerier_Expecter) UpdateUnitConversionFactor(ctx interface{}, arg interface{}) *MockQuerier_UpdateUnitConversionFactor_Call {
+	return &MockQuerier_UpdateUnitConversionFactor_Call{Call: _e.mock.On("UpdateUnitConversionFactor", ctx, arg)}
 }
 
-func (_c *MockQuerier_UpdateIngredient_Call) Run(run func(ctx context.Context, arg db.UpdateIngredientParams)) *MockQuerier_UpdateIngredient_Call {
+func (_c *MockQuerier_UpdateUnitConversionFactor_Call) Run(run func(ctx context.Context, arg db.UpdateUnitConversionFactorParams)) *MockQuerier_UpdateUnitConversionFactor_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.UpdateIngredientParams))
+		run(args[0].(context.Context), args[1].(db.UpdateUnitConversionFactorParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_UpdateIngredient_Call) Return(_a0 db.Ingredient, _a1 error) *MockQuerier_UpdateIngredient_Call {
+func (_c *MockQuerier_UpdateUnitConversionFactor_Call) Return(_a0 db.UnitConversion, _a1 error) *MockQuerier_UpdateUnitConversionFactor_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_UpdateIngredient_Call) RunAndReturn(run func(context.Context, db.UpdateIngredientParams) (db.Ingredient, error)) *MockQuerier_UpdateIngredient_Call {
+func (_c *MockQuerier_UpdateUnitConversionFactor_Call) RunAndReturn(run func(context.Context, db.UpdateUnitConversionFactorParams) (db.UnitConversion, error)) *MockQuerier_UpdateUnitConversionFactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertCategoryStorageGuideline provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertCategoryStorageGuideline(ctx context.Context, arg db.UpsertCategoryStorageGuidelineParams) (db.StorageGuideline, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertCategoryStorageGuideline")
+	}
+
+	var r0 db.StorageGuideline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertCategoryStorageGuidelineParams) (db.StorageGuideline, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertCategoryStorageGuidelineParams) db.StorageGuideline); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StorageGuideline)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertCategoryStorageGuidelineParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertCategoryStorageGuideline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertCategoryStorageGuideline'
+type MockQuerier_UpsertCategoryStorageGuideline_Call struct {
+	*mock.Call
+}
+
+// UpsertCategoryStorageGuideline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertCategoryStorageGuidelineParams
+func (_e *MockQuerier_Expecter) UpsertCategoryStorageGuideline(ctx interface{}, arg interface{}) *MockQuerier_UpsertCategoryStorageGuideline_Call {
+	return &MockQuerier_UpsertCategoryStorageGuideline_Call{Call: _e.mock.On("UpsertCategoryStorageGuideline", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertCategoryStorageGuideline_Call) Run(run func(ctx context.Context, arg db.UpsertCategoryStorageGuidelineParams)) *MockQuerier_UpsertCategoryStorageGuideline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertCategoryStorageGuidelineParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertCategoryStorageGuideline_Call) Return(_a0 db.StorageGuideline, _a1 error) *MockQuerier_UpsertCategoryStorageGuideline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertCategoryStorageGuideline_Call) RunAndReturn(run func(context.Context, db.UpsertCategoryStorageGuidelineParams) (db.StorageGuideline, error)) *MockQuerier_UpsertCategoryStorageGuideline_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -833,6 +5714,120 @@ func (_c *MockQuerier_UpsertIngredient_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// UpsertIngredientNutrition provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertIngredientNutrition(ctx context.Context, arg db.UpsertIngredientNutritionParams) (db.IngredientNutrition, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertIngredientNutrition")
+	}
+
+	var r0 db.IngredientNutrition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertIngredientNutritionParams) (db.IngredientNutrition, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertIngredientNutritionParams) db.IngredientNutrition); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IngredientNutrition)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertIngredientNutritionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertIngredientNutrition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertIngredientNutrition'
+type MockQuerier_UpsertIngredientNutrition_Call struct {
+	*mock.Call
+}
+
+// UpsertIngredientNutrition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertIngredientNutritionParams
+func (_e *MockQuerier_Expecter) UpsertIngredientNutrition(ctx interface{}, arg interface{}) *MockQuerier_UpsertIngredientNutrition_Call {
+	return &MockQuerier_UpsertIngredientNutrition_Call{Call: _e.mock.On("UpsertIngredientNutrition", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertIngredientNutrition_Call) Run(run func(ctx context.Context, arg db.UpsertIngredientNutritionParams)) *MockQuerier_UpsertIngredientNutrition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertIngredientNutritionParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertIngredientNutrition_Call) Return(_a0 db.IngredientNutrition, _a1 error) *MockQuerier_UpsertIngredientNutrition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertIngredientNutrition_Call) RunAndReturn(run func(context.Context, db.UpsertIngredientNutritionParams) (db.IngredientNutrition, error)) *MockQuerier_UpsertIngredientNutrition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertIngredientStorageGuideline provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertIngredientStorageGuideline(ctx context.Context, arg db.UpsertIngredientStorageGuidelineParams) (db.StorageGuideline, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertIngredientStorageGuideline")
+	}
+
+	var r0 db.StorageGuideline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertIngredientStorageGuidelineParams) (db.StorageGuideline, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertIngredientStorageGuidelineParams) db.StorageGuideline); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StorageGuideline)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertIngredientStorageGuidelineParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertIngredientStorageGuideline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertIngredientStorageGuideline'
+type MockQuerier_UpsertIngredientStorageGuideline_Call struct {
+	*mock.Call
+}
+
+// UpsertIngredientStorageGuideline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertIngredientStorageGuidelineParams
+func (_e *MockQuerier_Expecter) UpsertIngredientStorageGuideline(ctx interface{}, arg interface{}) *MockQuerier_UpsertIngredientStorageGuideline_Call {
+	return &MockQuerier_UpsertIngredientStorageGuideline_Call{Call: _e.mock.On("UpsertIngredientStorageGuideline", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertIngredientStorageGuideline_Call) Run(run func(ctx context.Context, arg db.UpsertIngredientStorageGuidelineParams)) *MockQuerier_UpsertIngredientStorageGuideline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertIngredientStorageGuidelineParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertIngredientStorageGuideline_Call) Return(_a0 db.StorageGuideline, _a1 error) *MockQuerier_UpsertIngredientStorageGuideline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertIngredientStorageGuideline_Call) RunAndReturn(run func(context.Context, db.UpsertIngredientStorageGuidelineParams) (db.StorageGuideline, error)) *MockQuerier_UpsertIngredientStorageGuideline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockQuerier creates a new instance of MockQuerier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockQuerier(t interface {
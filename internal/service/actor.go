@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+)
+
+type (
+	actorKey  struct{}
+	reasonKey struct{}
+)
+
+// WithActor returns a copy of ctx naming the user or service making
+// changes, which is recorded as created_by on the aliases they add and as
+// the actor in ingredient history.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// WithReason returns a copy of ctx carrying a free-text reason for the
+// changes made with it, recorded in ingredient history.
+func WithReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, reasonKey{}, reason)
+}
+
+// actorFrom returns the actor set by WithActor, if any.
+func actorFrom(ctx context.Context) sql.NullString {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return nullString(actor)
+}
+
+// reasonFrom returns the reason set by WithReason, if any.
+func reasonFrom(ctx context.Context) sql.NullString {
+	reason, _ := ctx.Value(reasonKey{}).(string)
+	return nullString(reason)
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// AliasConflictError is returned when a name or alias is already held by
+// another ingredient. Every normalized alias belongs to one ingredient, and
+// no alias may equal another ingredient's name.
+type AliasConflictError struct {
+	// Term is the contested name or alias.
+	Term      string
+	OwnerID   uuid.UUID
+	OwnerName string
+	// IsName is set when Term is the owner's name rather than its alias.
+	IsName bool
+}
+
+func (e *AliasConflictError) Error() string {
+	if e.IsName {
+		return fmt.Sprintf("%q is already the name of ingredient %s", e.Term, e.OwnerID)
+	}
+	return fmt.Sprintf("%q is already an alias of ingredient %q (%s)", e.Term, e.OwnerName, e.OwnerID)
+}
+
+// AliasSource records where an alias came from.
+type AliasSource string
+
+const (
+	// AliasSourceManual is an alias a curator added. This is the default.
+	AliasSourceManual AliasSource = "manual"
+	// AliasSourceMerge is a merged-away ingredient's name.
+	AliasSourceMerge AliasSource = "merge"
+	// AliasSourceLearned is an alias inferred from usage.
+	AliasSourceLearned AliasSource = "learned"
+	// AliasSourceImport is an alias loaded from an external dataset.
+	AliasSourceImport AliasSource = "import"
+)
+
+func (s AliasSource) valid() bool {
+	switch s {
+	case AliasSourceManual, AliasSourceMerge, AliasSourceLearned, AliasSourceImport:
+		return true
+	}
+	return false
+}
+
+var (
+	// ErrInvalidAlias is returned for an empty alias, one equal to the
+	// ingredient's own name, or an unknown source or malformed locale.
+	ErrInvalidAlias = errors.New("invalid alias")
+	// ErrAliasNotFound is returned when removing an alias the ingredient
+	// does not have.
+	ErrAliasNotFound = errors.New("alias not found")
+)
+
+// localePattern loosely matches a BCP 47 language tag such as "en" or
+// "pt-BR".
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// AliasInput describes an alias to add. An empty Source means
+// AliasSourceManual; Locale is optional.
+type AliasInput struct {
+	Alias  string
+	Source AliasSource
+	Locale string
+}
+
+// IngredientInput holds the editable fields of an ingredient. Name is only
+// used by CreateIngredient. Version, if set, is the version UpdateIngredient
+// expects the ingredient to be at.
+type IngredientInput struct {
+	Name        string
+	Aliases     []string
+	CategoryID  uuid.NullUUID
+	DefaultUnit sql.NullString
+	Version     int64
+}
+
+// CreateIngredient creates an ingredient with a normalized name and aliases.
+// It returns ErrInvalidAlias if an alias is the name itself and an
+// *AliasConflictError if the name or an alias is taken.
+func (s *Service) CreateIngredient(ctx context.Context, in IngredientInput) (db.Ingredient, error) {
+	name := Normalize(in.Name)
+	aliases := normalizeAliases(in.Aliases)
+	if slices.Contains(aliases, name) {
+		return db.Ingredient{}, nameAsAlias(name)
+	}
+	terms := append([]string{name}, aliases...)
+	if err := checkTerms(ctx, s.q, terms); err != nil {
+		return db.Ingredient{}, err
+	}
+	ing, err := s.q.CreateIngredient(ctx, db.CreateIngredientParams{
+		Name:         name,
+		Aliases:      aliases,
+		CategoryID:   in.CategoryID,
+		DefaultUnit:  in.DefaultUnit,
+		CreatedBy:    actorFrom(ctx),
+		ChangeReason: reasonFrom(ctx),
+	})
+	if err != nil {
+		return db.Ingredient{}, termsTaken(ctx, s.q, err, terms)
+	}
+	return ing, nil
+}
+
+// UpdateIngredient replaces an ingredient's aliases, category and default
+// unit. It returns sql.ErrNoRows if the ingredient does not exist,
+// ErrVersionMismatch if in.Version is set and the ingredient is at another
+// version, ErrInvalidAlias if an alias is the ingredient's name and an
+// *AliasConflictError if an alias belongs to another ingredient.
+func (s *Service) UpdateIngredient(ctx context.Context, id uuid.UUID, in IngredientInput) (db.Ingredient, error) {
+	aliases := normalizeAliases(in.Aliases)
+	if err := checkAliasTerms(ctx, s.q, id, aliases); err != nil {
+		return db.Ingredient{}, err
+	}
+	ing, err := s.q.UpdateIngredient(ctx, db.UpdateIngredientParams{
+		ID:              id,
+		Aliases:         aliases,
+		CategoryID:      in.CategoryID,
+		DefaultUnit:     in.DefaultUnit,
+		ExpectedVersion: expectVersion(in.Version),
+		CreatedBy:       actorFrom(ctx),
+		ChangeReason:    reasonFrom(ctx),
+	})
+	if err != nil {
+		if in.Version != 0 {
+			err = versionMissed(ctx, s.q, id, err)
+		}
+		return db.Ingredient{}, termsTaken(ctx, s.q, err, aliases, id)
+	}
+	return ing, nil
+}
+
+// ListAliases returns an ingredient's aliases with their metadata. It
+// returns sql.ErrNoRows if the ingredient does not exist.
+func (s *Service) ListAliases(ctx context.Context, id uuid.UUID) ([]db.IngredientAlias, error) {
+	if _, err := s.q.GetIngredient(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.q.ListIngredientAliases(ctx, id)
+}
+
+// AddAlias gives an ingredient one more alias, leaving the others alone.
+// Adding an alias the ingredient already has returns the existing row with
+// created false. It returns sql.ErrNoRows if the ingredient does not exist,
+// ErrInvalidAlias for a bad input and an *AliasConflictError if another
+// ingredient holds the alias.
+func (s *Service) AddAlias(ctx context.Context, id uuid.UUID, in AliasInput) (alias db.IngredientAlias, created bool, err error) {
+	if in, err = normalizeAliasInput(in); err != nil {
+		return db.IngredientAlias{}, false, err
+	}
+
+	tx, err := s.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return db.IngredientAlias{}, false, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	qtx := db.New(tx)
+	if err := setChange(ctx, qtx, ChangeUpdate); err != nil {
+		return db.IngredientAlias{}, false, err
+	}
+	alias, created, err = addAlias(ctx, qtx, id, in)
+	if err != nil {
+		return db.IngredientAlias{}, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return db.IngredientAlias{}, false, termsTaken(ctx, s.q, err, []string{in.Alias}, id)
+	}
+	return alias, created, nil
+}
+
+// RemoveAlias takes one alias off an ingredient. It returns sql.ErrNoRows if
+// the ingredient does not exist and ErrAliasNotFound if it has no such
+// alias.
+func (s *Service) RemoveAlias(ctx context.Context, id uuid.UUID, alias string) error {
+	tx, err := s.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	qtx := db.New(tx)
+	if err := setChange(ctx, qtx, ChangeUpdate); err != nil {
+		return err
+	}
+	if err := removeAlias(ctx, qtx, id, Normalize(alias)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func normalizeAliasInput(in AliasInput) (AliasInput, error) {
+	in.Alias = Normalize(in.Alias)
+	if in.Alias == "" {
+		return in, fmt.Errorf("%w: alias is required", ErrInvalidAlias)
+	}
+	if in.Source == "" {
+		in.Source = AliasSourceManual
+	}
+	if !in.Source.valid() {
+		return in, fmt.Errorf("%w: unknown source %q", ErrInvalidAlias, in.Source)
+	}
+	in.Locale = strings.TrimSpace(in.Locale)
+	if in.Locale != "" && !localePattern.MatchString(in.Locale) {
+		return in, fmt.Errorf("%w: malformed locale %q", ErrInvalidAlias, in.Locale)
+	}
+	return in, nil
+}
+
+// addAlias inserts the alias row for AddAlias and refreshes the
+// ingredient's aliases column.
+func addAlias(ctx context.Context, q db.Querier, id uuid.UUID, in AliasInput) (db.IngredientAlias, bool, error) {
+	ing, err := q.GetIngredient(ctx, id)
+	if err != nil {
+		return db.IngredientAlias{}, false, err
+	}
+	if in.Alias == ing.Name {
+		return db.IngredientAlias{}, false, nameAsAlias(in.Alias)
+	}
+	if err := checkTerms(ctx, q, []string{in.Alias}, id); err != nil {
+		return db.IngredientAlias{}, false, err
+	}
+	row, err := q.AddIngredientAlias(ctx, db.AddIngredientAliasParams{
+		IngredientID: id,
+		Alias:        in.Alias,
+		Source:       string(in.Source),
+		CreatedBy:    actorFrom(ctx),
+		Locale:       nullString(in.Locale),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// Taken after all: either this ingredient already has it, or
+		// someone else has just added it.
+		existing, err := q.GetIngredientAlias(ctx, in.Alias)
+		if err != nil {
+			return db.IngredientAlias{}, false, err
+		}
+		if existing.IngredientID == id {
+			return existing, false, nil
+		}
+		owner, err := q.GetIngredient(ctx, existing.IngredientID)
+		if err != nil {
+			return db.IngredientAlias{}, false, err
+		}
+		return db.IngredientAlias{}, false, &AliasConflictError{Term: in.Alias, OwnerID: owner.ID, OwnerName: owner.Name}
+	}
+	if err != nil {
+		return db.IngredientAlias{}, false, err
+	}
+	if _, err := q.SyncIngredientAliases(ctx, id); err != nil {
+		return db.IngredientAlias{}, false, err
+	}
+	return row, true, nil
+}
+
+// removeAlias deletes the alias row for RemoveAlias and refreshes the
+// ingredient's aliases column.
+func removeAlias(ctx context.Context, q db.Querier, id uuid.UUID, alias string) error {
+	n, err := q.DeleteIngredientAlias(ctx, db.DeleteIngredientAliasParams{IngredientID: id, Alias: alias})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		if _, err := q.GetIngredient(ctx, id); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %q", ErrAliasNotFound, alias)
+	}
+	_, err = q.SyncIngredientAliases(ctx, id)
+	return err
+}
+
+// normalizeAliases normalizes aliases and drops empty and repeated ones,
+// keeping the first occurrence. The result is never nil.
+func normalizeAliases(aliases []string) []string {
+	out := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		if a = Normalize(a); a != "" && !slices.Contains(out, a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// termOwners returns, for each of terms held as a name or alias by an
+// ingredient not in allowed, the ingredient holding it.
+func termOwners(ctx context.Context, q db.Querier, terms []string, allowed ...uuid.UUID) (map[string]db.ListTermOwnersRow, error) {
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	rows, err := q.ListTermOwners(ctx, terms)
+	if err != nil {
+		return nil, err
+	}
+	owners := make(map[string]db.ListTermOwnersRow)
+	for _, row := range rows {
+		if !slices.Contains(allowed, row.ID) {
+			owners[row.Term] = row
+		}
+	}
+	return owners, nil
+}
+
+// checkTerms returns an *AliasConflictError for the first of terms held by
+// an ingredient not in allowed.
+func checkTerms(ctx context.Context, q db.Querier, terms []string, allowed ...uuid.UUID) error {
+	owners, err := termOwners(ctx, q, terms, allowed...)
+	if err != nil {
+		return err
+	}
+	for _, term := range terms {
+		if owner, ok := owners[term]; ok {
+			return &AliasConflictError{Term: term, OwnerID: owner.ID, OwnerName: owner.Name, IsName: owner.IsName}
+		}
+	}
+	return nil
+}
+
+// checkAliasTerms is checkTerms for the aliases of ingredient id, which
+// also returns ErrInvalidAlias for an alias that is the ingredient's name.
+func checkAliasTerms(ctx context.Context, q db.Querier, id uuid.UUID, aliases []string) error {
+	owners, err := termOwners(ctx, q, aliases)
+	if err != nil {
+		return err
+	}
+	for _, term := range aliases {
+		owner, ok := owners[term]
+		switch {
+		case !ok:
+		case owner.ID != id:
+			return &AliasConflictError{Term: term, OwnerID: owner.ID, OwnerName: owner.Name, IsName: owner.IsName}
+		case owner.IsName:
+			return nameAsAlias(term)
+		}
+	}
+	return nil
+}
+
+// nameAsAlias is the error for an ingredient listing its own name as an
+// alias.
+func nameAsAlias(name string) error {
+	return fmt.Errorf("%w: %q is the ingredient's name", ErrInvalidAlias, name)
+}
+
+// termsTaken turns a unique violation from a write that checkTerms passed
+// into the conflict a concurrent write created. Other errors are returned
+// as they are.
+func termsTaken(ctx context.Context, q db.Querier, err error, terms []string, allowed ...uuid.UUID) error {
+	if !isUniqueViolation(err) {
+		return err
+	}
+	if cerr := checkTerms(ctx, q, terms, allowed...); cerr != nil {
+		return cerr
+	}
+	return err
+}
@@ -0,0 +1,170 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAliasUniqueness_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	garlic, err := svc.CreateIngredient(ctx, IngredientInput{Name: "Garlic", Aliases: []string{"Garlic Clove", "clove"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"garlic clove", "clove"}, garlic.Aliases)
+
+	var conflict *AliasConflictError
+
+	// Another ingredient cannot take the alias, in any case.
+	_, err = svc.CreateIngredient(ctx, IngredientInput{Name: "clove spice", Aliases: []string{"CLOVE"}})
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, garlic.ID, conflict.OwnerID)
+
+	// Nor be named after it.
+	_, err = svc.CreateIngredient(ctx, IngredientInput{Name: "garlic clove"})
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, garlic.ID, conflict.OwnerID)
+
+	// Nor use garlic's name as an alias.
+	shallot, err := svc.CreateIngredient(ctx, IngredientInput{Name: "shallot"})
+	require.NoError(t, err)
+	_, err = svc.UpdateIngredient(ctx, shallot.ID, IngredientInput{Aliases: []string{"garlic"}})
+	require.ErrorAs(t, err, &conflict)
+	assert.True(t, conflict.IsName)
+
+	// The database is the backstop when the check is skipped, both for an
+	// alias another ingredient holds and across names and aliases.
+	_, err = q.UpdateIngredient(ctx, db.UpdateIngredientParams{ID: shallot.ID, Aliases: []string{"clove"}})
+	assert.True(t, isUniqueViolation(err))
+	_, err = q.UpdateIngredient(ctx, db.UpdateIngredientParams{ID: shallot.ID, Aliases: []string{"garlic"}})
+	assert.True(t, isUniqueViolation(err))
+	_, err = q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "garlic clove", Aliases: []string{}})
+	assert.True(t, isUniqueViolation(err))
+
+	// Dropping an alias frees it.
+	garlic, err = svc.UpdateIngredient(ctx, garlic.ID, IngredientInput{Aliases: []string{"garlic clove"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"garlic clove"}, garlic.Aliases)
+	shallot, err = svc.UpdateIngredient(ctx, shallot.ID, IngredientInput{Aliases: []string{"clove"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"clove"}, shallot.Aliases)
+
+	result, err := svc.Resolve(ctx, "clove", ResolveOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, shallot.ID, result.Ingredient.ID)
+}
+
+func TestNormalizeTerm_MatchesNormalize_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+
+	for _, raw := range []string{
+		"  Garlic  ",
+		"\t\n OLIVE OIL \n\t",
+		"\u00a0Jalapeño\u3000",
+		"\u2003crème fraîche\u0085",
+		"   ",
+		"",
+	} {
+		var got string
+		require.NoError(t, sqlDB.QueryRow("SELECT normalize_term($1)", raw).Scan(&got))
+		assert.Equal(t, Normalize(raw), got, "normalizing %q", raw)
+	}
+}
+
+func TestMerge_AliasConflict_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	winner, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "spring onion", Aliases: []string{"green onion"}})
+	require.NoError(t, err)
+	loser, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "scallion", Aliases: []string{"green onion stalk"}})
+	require.NoError(t, err)
+	// Only written around the service can a name equal another's alias.
+	other, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "chives", Aliases: []string{"scallion"}})
+	require.NoError(t, err)
+
+	_, err = svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{})
+	var conflict *AliasConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, other.ID, conflict.OwnerID)
+	assert.Equal(t, "scallion", conflict.Term)
+
+	// Without the third ingredient the loser's aliases move to the winner.
+	require.NoError(t, q.DeleteIngredient(ctx, other.ID))
+	result, err := svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"green onion", "scallion", "green onion stalk"}, result.Ingredient.Aliases)
+
+	owners, err := q.ListTermOwners(ctx, []string{"scallion", "green onion stalk"})
+	require.NoError(t, err)
+	require.Len(t, owners, 2)
+	for _, o := range owners {
+		assert.Equal(t, winner.ID, o.ID)
+	}
+}
+
+func TestAliasMetadata_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := WithActor(context.Background(), "curator")
+
+	winner, err := svc.CreateIngredient(ctx, IngredientInput{Name: "coriander", Aliases: []string{"cilantro"}})
+	require.NoError(t, err)
+	loser, err := svc.CreateIngredient(ctx, IngredientInput{Name: "chinese parsley"})
+	require.NoError(t, err)
+
+	row, created, err := svc.AddAlias(ctx, loser.ID, AliasInput{Alias: "Dhania", Source: AliasSourceImport, Locale: "hi"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "dhania", row.Alias)
+	assert.Equal(t, "import", row.Source)
+	assert.Equal(t, "curator", row.CreatedBy.String)
+
+	// Adding it again is a no-op; adding it elsewhere is a conflict.
+	_, created, err = svc.AddAlias(ctx, loser.ID, AliasInput{Alias: "dhania"})
+	require.NoError(t, err)
+	assert.False(t, created)
+	var conflict *AliasConflictError
+	_, _, err = svc.AddAlias(ctx, winner.ID, AliasInput{Alias: "dhania"})
+	require.ErrorAs(t, err, &conflict)
+
+	// Merging moves the row with its metadata and records the loser's name
+	// as a merge alias.
+	_, err = svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{})
+	require.NoError(t, err)
+	aliases, err := svc.ListAliases(ctx, winner.ID)
+	require.NoError(t, err)
+	sources := map[string]string{}
+	for _, a := range aliases {
+		sources[a.Alias] = a.Source
+	}
+	assert.Equal(t, map[string]string{"cilantro": "manual", "chinese parsley": "merge", "dhania": "import"}, sources)
+
+	// Splitting gives it back unchanged.
+	_, err = svc.Split(ctx, winner.ID, uuid.NullUUID{})
+	require.NoError(t, err)
+	aliases, err = svc.ListAliases(ctx, loser.ID)
+	require.NoError(t, err)
+	require.Len(t, aliases, 1)
+	assert.Equal(t, row.ID, aliases[0].ID)
+	assert.Equal(t, "hi", aliases[0].Locale.String)
+
+	require.NoError(t, svc.RemoveAlias(ctx, loser.ID, "DHANIA"))
+	assert.ErrorIs(t, svc.RemoveAlias(ctx, loser.ID, "dhania"), ErrAliasNotFound)
+	restored, err := q.GetIngredient(ctx, loser.ID)
+	require.NoError(t, err)
+	assert.Empty(t, restored.Aliases)
+}
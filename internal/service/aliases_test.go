@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAliases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		aliases []string
+		want    []string
+	}{
+		{name: "nil", aliases: nil, want: []string{}},
+		{name: "normalized", aliases: []string{" Garlic Clove ", "CLOVE"}, want: []string{"garlic clove", "clove"}},
+		{name: "repeats keep first", aliases: []string{"clove", "Clove", "paste", "clove "}, want: []string{"clove", "paste"}},
+		{name: "blanks dropped", aliases: []string{"", "  ", "clove"}, want: []string{"clove"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, normalizeAliases(tc.aliases))
+		})
+	}
+}
+
+func TestCheckTerms(t *testing.T) {
+	t.Parallel()
+
+	self, other := uuid.New(), uuid.New()
+	rows := []db.ListTermOwnersRow{
+		{ID: self, Name: "garlic", Term: "clove"},
+		{ID: other, Name: "shallot", Term: "eschalot"},
+		{ID: other, Name: "shallot", Term: "shallot", IsName: true},
+	}
+
+	tests := []struct {
+		name  string
+		terms []string
+		want  *AliasConflictError
+	}{
+		{name: "own alias", terms: []string{"clove"}},
+		{name: "alias of another", terms: []string{"clove", "eschalot"}, want: &AliasConflictError{Term: "eschalot", OwnerID: other, OwnerName: "shallot"}},
+		{name: "name of another", terms: []string{"shallot"}, want: &AliasConflictError{Term: "shallot", OwnerID: other, OwnerName: "shallot", IsName: true}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mockQ := mocks.NewMockQuerier(t)
+			mockQ.EXPECT().ListTermOwners(mock.Anything, tc.terms).Return(rows, nil)
+
+			err := checkTerms(context.Background(), mockQ, tc.terms, self)
+			if tc.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var conflict *AliasConflictError
+			require.ErrorAs(t, err, &conflict)
+			assert.Equal(t, tc.want, conflict)
+		})
+	}
+}
+
+func TestCreateIngredient_Normalizes(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	created := newIngredient("garlic", []string{"garlic clove"})
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"garlic", "garlic clove"}).Return(nil, nil)
+	mockQ.EXPECT().CreateIngredient(mock.Anything, db.CreateIngredientParams{
+		Name:    "garlic",
+		Aliases: []string{"garlic clove"},
+	}).Return(created, nil)
+
+	got, err := svc.CreateIngredient(context.Background(), IngredientInput{
+		Name:    " Garlic",
+		Aliases: []string{"Garlic Clove", "garlic clove"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, got.ID)
+}
+
+func TestCreateIngredient_NameIsAnotherAlias(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	garlic := newIngredient("garlic", []string{"garlic clove"})
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"garlic clove"}).Return([]db.ListTermOwnersRow{
+		{ID: garlic.ID, Name: garlic.Name, Term: "garlic clove"},
+	}, nil)
+
+	_, err := svc.CreateIngredient(context.Background(), IngredientInput{Name: "garlic clove"})
+	var conflict *AliasConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, garlic.ID, conflict.OwnerID)
+	assert.Contains(t, err.Error(), `"garlic"`)
+}
+
+func TestCreateIngredient_NameAsAlias(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	_, err := svc.CreateIngredient(context.Background(), IngredientInput{
+		Name:    "garlic",
+		Aliases: []string{"clove", " Garlic"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidAlias)
+}
+
+func TestUpdateIngredient_NameAsAlias(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	id := uuid.New()
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"clove", "garlic"}).Return([]db.ListTermOwnersRow{
+		{ID: id, Name: "garlic", Term: "clove"},
+		{ID: id, Name: "garlic", Term: "garlic", IsName: true},
+	}, nil)
+
+	_, err := svc.UpdateIngredient(context.Background(), id, IngredientInput{Aliases: []string{"clove", "garlic"}})
+	assert.ErrorIs(t, err, ErrInvalidAlias)
+}
+
+func TestUpdateIngredient_LostRace(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	id, winner := uuid.New(), uuid.New()
+	// The check passes, then a concurrent write takes the alias before the
+	// update lands.
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"clove"}).Return(nil, nil).Once()
+	mockQ.EXPECT().UpdateIngredient(mock.Anything, mock.Anything).Return(db.Ingredient{}, &pq.Error{Code: "23505"})
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"clove"}).Return([]db.ListTermOwnersRow{
+		{ID: winner, Name: "garlic", Term: "clove"},
+	}, nil).Once()
+
+	_, err := svc.UpdateIngredient(context.Background(), id, IngredientInput{Aliases: []string{"clove"}})
+	var conflict *AliasConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, winner, conflict.OwnerID)
+}
+
+func TestNormalizeAliasInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      AliasInput
+		want    AliasInput
+		wantErr bool
+	}{
+		{name: "defaults to manual", in: AliasInput{Alias: " Clove "}, want: AliasInput{Alias: "clove", Source: AliasSourceManual}},
+		{name: "source and locale kept", in: AliasInput{Alias: "ajo", Source: AliasSourceImport, Locale: " es-MX "}, want: AliasInput{Alias: "ajo", Source: AliasSourceImport, Locale: "es-MX"}},
+		{name: "empty alias", in: AliasInput{Alias: "  "}, wantErr: true},
+		{name: "unknown source", in: AliasInput{Alias: "clove", Source: "guess"}, wantErr: true},
+		{name: "malformed locale", in: AliasInput{Alias: "clove", Locale: "english!"}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := normalizeAliasInput(tc.in)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAlias)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAddAlias(t *testing.T) {
+	t.Parallel()
+
+	garlic := newIngredient("garlic", []string{"clove"})
+	shallot := newIngredient("shallot", nil)
+	ctx := WithActor(context.Background(), "curator@example.com")
+
+	t.Run("adds with metadata", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		row := db.IngredientAlias{IngredientID: garlic.ID, Alias: "ajo", Source: "import"}
+		mockQ.EXPECT().GetIngredient(mock.Anything, garlic.ID).Return(garlic, nil)
+		mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"ajo"}).Return(nil, nil)
+		mockQ.EXPECT().AddIngredientAlias(mock.Anything, db.AddIngredientAliasParams{
+			IngredientID: garlic.ID,
+			Alias:        "ajo",
+			Source:       "import",
+			CreatedBy:    nullString("curator@example.com"),
+			Locale:       nullString("es"),
+		}).Return(row, nil)
+		mockQ.EXPECT().SyncIngredientAliases(mock.Anything, garlic.ID).Return(garlic, nil)
+
+		got, created, err := addAlias(ctx, mockQ, garlic.ID, AliasInput{Alias: "ajo", Source: AliasSourceImport, Locale: "es"})
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, row, got)
+	})
+
+	t.Run("already has it", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		existing := db.IngredientAlias{IngredientID: garlic.ID, Alias: "clove", Source: "manual"}
+		mockQ.EXPECT().GetIngredient(mock.Anything, garlic.ID).Return(garlic, nil)
+		mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"clove"}).Return([]db.ListTermOwnersRow{
+			{ID: garlic.ID, Name: garlic.Name, Term: "clove"},
+		}, nil)
+		mockQ.EXPECT().AddIngredientAlias(mock.Anything, mock.Anything).Return(db.IngredientAlias{}, sql.ErrNoRows)
+		mockQ.EXPECT().GetIngredientAlias(mock.Anything, "clove").Return(existing, nil)
+
+		got, created, err := addAlias(ctx, mockQ, garlic.ID, AliasInput{Alias: "clove", Source: AliasSourceManual})
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, existing, got)
+	})
+
+	t.Run("own name", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		mockQ.EXPECT().GetIngredient(mock.Anything, garlic.ID).Return(garlic, nil)
+
+		_, _, err := addAlias(ctx, mockQ, garlic.ID, AliasInput{Alias: "garlic", Source: AliasSourceManual})
+		assert.ErrorIs(t, err, ErrInvalidAlias)
+	})
+
+	t.Run("lost race", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		mockQ.EXPECT().GetIngredient(mock.Anything, shallot.ID).Return(shallot, nil)
+		mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"eschalot"}).Return(nil, nil)
+		mockQ.EXPECT().AddIngredientAlias(mock.Anything, mock.Anything).Return(db.IngredientAlias{}, sql.ErrNoRows)
+		mockQ.EXPECT().GetIngredientAlias(mock.Anything, "eschalot").Return(db.IngredientAlias{IngredientID: garlic.ID, Alias: "eschalot"}, nil)
+		mockQ.EXPECT().GetIngredient(mock.Anything, garlic.ID).Return(garlic, nil)
+
+		_, _, err := addAlias(ctx, mockQ, shallot.ID, AliasInput{Alias: "eschalot", Source: AliasSourceManual})
+		var conflict *AliasConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, garlic.ID, conflict.OwnerID)
+	})
+}
+
+func TestRemoveAlias(t *testing.T) {
+	t.Parallel()
+
+	garlic := newIngredient("garlic", []string{"clove"})
+
+	tests := []struct {
+		name    string
+		deleted int64
+		getErr  error
+		wantErr error
+	}{
+		{name: "removed", deleted: 1},
+		{name: "no such alias", wantErr: ErrAliasNotFound},
+		{name: "no such ingredient", getErr: sql.ErrNoRows, wantErr: sql.ErrNoRows},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mockQ := mocks.NewMockQuerier(t)
+			mockQ.EXPECT().DeleteIngredientAlias(mock.Anything, db.DeleteIngredientAliasParams{
+				IngredientID: garlic.ID,
+				Alias:        "clove",
+			}).Return(tc.deleted, nil)
+			if tc.deleted == 1 {
+				mockQ.EXPECT().SyncIngredientAliases(mock.Anything, garlic.ID).Return(garlic, nil)
+			} else {
+				mockQ.EXPECT().GetIngredient(mock.Anything, garlic.ID).Return(garlic, tc.getErr)
+			}
+
+			err := removeAlias(context.Background(), mockQ, garlic.ID, "clove")
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// ErrNotArchived is returned when hard-deleting an ingredient that has not
+// been archived first.
+var ErrNotArchived = errors.New("ingredient must be archived before it can be deleted")
+
+// ArchiveIngredient soft-deletes an ingredient. Archived ingredients keep
+// their ID, so references held by other services stay valid, but they are
+// skipped by Resolve and left out of default listings. Archiving twice is a
+// no-op. It returns sql.ErrNoRows if the ingredient does not exist.
+func (s *Service) ArchiveIngredient(ctx context.Context, id uuid.UUID) (db.Ingredient, error) {
+	ing, err := s.q.ArchiveIngredient(ctx, db.ArchiveIngredientParams{
+		ID:     id,
+		Actor:  actorFrom(ctx),
+		Reason: reasonFrom(ctx),
+	})
+	if err != nil {
+		return db.Ingredient{}, err
+	}
+	slog.Info("ingredient archived", "id", id, "name", ing.Name)
+	return ing, nil
+}
+
+// RestoreIngredient brings an archived ingredient back. Restoring an active
+// ingredient is a no-op. It returns sql.ErrNoRows if the ingredient does not
+// exist.
+func (s *Service) RestoreIngredient(ctx context.Context, id uuid.UUID) (db.Ingredient, error) {
+	ing, err := s.q.RestoreIngredient(ctx, db.RestoreIngredientParams{
+		ID:     id,
+		Actor:  actorFrom(ctx),
+		Reason: reasonFrom(ctx),
+	})
+	if err != nil {
+		return db.Ingredient{}, err
+	}
+	slog.Info("ingredient restored", "id", id, "name", ing.Name)
+	return ing, nil
+}
+
+// DeleteIngredient permanently removes an archived ingredient along with its
+// substitutes, conversions and other dependent rows. It returns sql.ErrNoRows
+// if the ingredient does not exist and ErrNotArchived if it is still active.
+func (s *Service) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
+	n, err := s.q.DeleteArchivedIngredient(ctx, db.DeleteArchivedIngredientParams{
+		ID:     id,
+		Actor:  actorFrom(ctx),
+		Reason: reasonFrom(ctx),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		if _, err := s.q.GetIngredient(ctx, id); err != nil {
+			return err
+		}
+		return ErrNotArchived
+	}
+	slog.Warn("ingredient hard-deleted", "id", id)
+	return nil
+}
+
+// restoreIfArchived restores ing when it is archived, for Resolve callers
+// that opted in with ResolveOptions.RestoreArchived.
+func (s *Service) restoreIfArchived(ctx context.Context, ing db.Ingredient) (db.Ingredient, error) {
+	if !ing.ArchivedAt.Valid {
+		return ing, nil
+	}
+	restored, err := s.q.RestoreIngredient(ctx, db.RestoreIngredientParams{
+		ID:     ing.ID,
+		Actor:  actorFrom(ctx),
+		Reason: reasonFrom(ctx),
+	})
+	if err != nil {
+		return db.Ingredient{}, err
+	}
+	slog.Info("resolve: restored archived ingredient", "id", ing.ID, "name", ing.Name)
+	return restored, nil
+}
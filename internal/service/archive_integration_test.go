@@ -0,0 +1,61 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveIngredient_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	garlic, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "garlic", Aliases: []string{}})
+	require.NoError(t, err)
+
+	archived, err := svc.ArchiveIngredient(ctx, garlic.ID)
+	require.NoError(t, err)
+	assert.True(t, archived.ArchivedAt.Valid)
+
+	// Archiving again keeps the original timestamp.
+	again, err := svc.ArchiveIngredient(ctx, garlic.ID)
+	require.NoError(t, err)
+	assert.True(t, archived.ArchivedAt.Time.Equal(again.ArchivedAt.Time))
+
+	list, err := svc.ListIngredients(ctx, IngredientFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	// Still fetchable by ID.
+	_, err = q.GetIngredient(ctx, garlic.ID)
+	require.NoError(t, err)
+
+	// Resolving the exact name neither matches nor duplicates it...
+	_, err = svc.Resolve(ctx, "Garlic", ResolveOptions{})
+	var archivedErr *ArchivedError
+	require.ErrorAs(t, err, &archivedErr)
+	assert.Equal(t, garlic.ID, archivedErr.ID)
+
+	// ...unless the caller asks for it to be restored.
+	result, err := svc.Resolve(ctx, "Garlic", ResolveOptions{RestoreArchived: true})
+	require.NoError(t, err)
+	assert.Equal(t, garlic.ID, result.Ingredient.ID)
+	assert.False(t, result.Ingredient.ArchivedAt.Valid)
+
+	// Hard delete needs the ingredient archived first.
+	assert.ErrorIs(t, svc.DeleteIngredient(ctx, garlic.ID), ErrNotArchived)
+	_, err = svc.ArchiveIngredient(ctx, garlic.ID)
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteIngredient(ctx, garlic.ID))
+	_, err = q.GetIngredient(ctx, garlic.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
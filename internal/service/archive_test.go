@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func archived(ing db.Ingredient) db.Ingredient {
+	ing.ArchivedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return ing
+}
+
+func TestDeleteIngredient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		setup   func(*mocks.MockQuerier, uuid.UUID)
+		wantErr error
+	}{
+		{
+			name: "archived ingredient is deleted",
+			setup: func(m *mocks.MockQuerier, id uuid.UUID) {
+				m.EXPECT().DeleteArchivedIngredient(mock.Anything, db.DeleteArchivedIngredientParams{ID: id}).Return(1, nil)
+			},
+		},
+		{
+			name: "active ingredient is refused",
+			setup: func(m *mocks.MockQuerier, id uuid.UUID) {
+				m.EXPECT().DeleteArchivedIngredient(mock.Anything, db.DeleteArchivedIngredientParams{ID: id}).Return(0, nil)
+				m.EXPECT().GetIngredient(mock.Anything, id).Return(newIngredient("salt", nil), nil)
+			},
+			wantErr: ErrNotArchived,
+		},
+		{
+			name: "unknown ingredient",
+			setup: func(m *mocks.MockQuerier, id uuid.UUID) {
+				m.EXPECT().DeleteArchivedIngredient(mock.Anything, db.DeleteArchivedIngredientParams{ID: id}).Return(0, nil)
+				m.EXPECT().GetIngredient(mock.Anything, id).Return(db.Ingredient{}, sql.ErrNoRows)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockQ := mocks.NewMockQuerier(t)
+			svc := New(mockQ, nil, 0.8)
+			id := uuid.New()
+			tt.setup(mockQ, id)
+
+			err := svc.DeleteIngredient(context.Background(), id)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestListIngredients_ExcludesArchived(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	salt := newIngredient("salt", nil)
+	old := archived(newIngredient("old salt", nil))
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{old, salt}, nil)
+
+	got, err := svc.ListIngredients(context.Background(), IngredientFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []db.Ingredient{salt}, got)
+
+	got, err = svc.ListIngredients(context.Background(), IngredientFilter{IncludeArchived: true})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+}
+
+func TestArchiveAndRestore_RecordActor(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	salt := newIngredient("salt", nil)
+	ctx := WithReason(WithActor(context.Background(), "curator"), "duplicate")
+	actor := sql.NullString{String: "curator", Valid: true}
+	reason := sql.NullString{String: "duplicate", Valid: true}
+	mockQ.EXPECT().ArchiveIngredient(mock.Anything, db.ArchiveIngredientParams{ID: salt.ID, Actor: actor, Reason: reason}).
+		Return(archived(salt), nil)
+	mockQ.EXPECT().RestoreIngredient(mock.Anything, db.RestoreIngredientParams{ID: salt.ID, Actor: actor, Reason: reason}).
+		Return(salt, nil)
+	mockQ.EXPECT().DeleteArchivedIngredient(mock.Anything, db.DeleteArchivedIngredientParams{ID: salt.ID, Actor: actor, Reason: reason}).
+		Return(1, nil)
+
+	_, err := svc.ArchiveIngredient(ctx, salt.ID)
+	require.NoError(t, err)
+	_, err = svc.RestoreIngredient(ctx, salt.ID)
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteIngredient(ctx, salt.ID))
+}
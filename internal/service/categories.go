@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+var (
+	// ErrInvalidCategory is returned for a category without a usable slug or
+	// display name.
+	ErrInvalidCategory = errors.New("invalid category")
+	// ErrUnknownCategory is returned when a referenced category does not exist.
+	ErrUnknownCategory = errors.New("unknown category")
+	// ErrDuplicateCategory is returned when a slug is already taken.
+	ErrDuplicateCategory = errors.New("category slug already exists")
+	// ErrCategoryCycle is returned when a parent change would make a category
+	// its own ancestor.
+	ErrCategoryCycle = errors.New("category parent would create a cycle")
+	// ErrCategoryInUse is returned when deleting a category that still has
+	// ingredients or subcategories.
+	ErrCategoryInUse = errors.New("category is in use")
+)
+
+// CategoryInput describes a category to create or update. An empty Slug is
+// derived from DisplayName.
+type CategoryInput struct {
+	Slug        string
+	DisplayName string
+	ParentID    uuid.NullUUID
+}
+
+// CategoryID looks up the category for slug, as accepted by the ingredient
+// endpoints. An empty slug yields a null ID; a slug that matches no category
+// returns ErrUnknownCategory.
+func (s *Service) CategoryID(ctx context.Context, slug string) (uuid.NullUUID, error) {
+	if strings.TrimSpace(slug) == "" {
+		return uuid.NullUUID{}, nil
+	}
+	cat, err := s.q.GetCategoryBySlug(ctx, Slugify(slug))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.NullUUID{}, fmt.Errorf("%w: %q", ErrUnknownCategory, slug)
+		}
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: cat.ID, Valid: true}, nil
+}
+
+// CreateCategory validates in and creates the category. It returns
+// ErrInvalidCategory, ErrUnknownCategory for a missing parent or
+// ErrDuplicateCategory for a taken slug.
+func (s *Service) CreateCategory(ctx context.Context, in CategoryInput) (db.Category, error) {
+	in, err := normalizeCategoryInput(in)
+	if err != nil {
+		return db.Category{}, err
+	}
+	if in.ParentID.Valid {
+		if _, err := s.getParentCategory(ctx, in.ParentID.UUID); err != nil {
+			return db.Category{}, err
+		}
+	}
+	cat, err := s.q.CreateCategory(ctx, db.CreateCategoryParams{
+		Slug:        in.Slug,
+		DisplayName: in.DisplayName,
+		ParentID:    in.ParentID,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return db.Category{}, fmt.Errorf("%w: %q", ErrDuplicateCategory, in.Slug)
+		}
+		return db.Category{}, err
+	}
+	return cat, nil
+}
+
+// UpdateCategory replaces a category's slug, display name and parent. It
+// returns sql.ErrNoRows if the category does not exist, ErrCategoryCycle if
+// the parent is the category itself or one of its subcategories, and the
+// errors of CreateCategory otherwise.
+func (s *Service) UpdateCategory(ctx context.Context, id uuid.UUID, in CategoryInput) (db.Category, error) {
+	in, err := normalizeCategoryInput(in)
+	if err != nil {
+		return db.Category{}, err
+	}
+	if _, err := s.q.GetCategory(ctx, id); err != nil {
+		return db.Category{}, err
+	}
+	// Walk up from the new parent; meeting id means id would become its own
+	// ancestor.
+	for parentID, depth := in.ParentID, 0; parentID.Valid; depth++ {
+		if parentID.UUID == id || depth >= maxHierarchyDepth {
+			return db.Category{}, ErrCategoryCycle
+		}
+		parent, err := s.getParentCategory(ctx, parentID.UUID)
+		if err != nil {
+			return db.Category{}, err
+		}
+		parentID = parent.ParentID
+	}
+	cat, err := s.q.UpdateCategory(ctx, db.UpdateCategoryParams{
+		ID:          id,
+		Slug:        in.Slug,
+		DisplayName: in.DisplayName,
+		ParentID:    in.ParentID,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return db.Category{}, fmt.Errorf("%w: %q", ErrDuplicateCategory, in.Slug)
+		}
+		return db.Category{}, err
+	}
+	return cat, nil
+}
+
+// DeleteCategory removes a category. It returns sql.ErrNoRows if the
+// category does not exist and ErrCategoryInUse if ingredients or
+// subcategories still reference it.
+func (s *Service) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	n, err := s.q.DeleteCategory(ctx, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrCategoryInUse
+		}
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (s *Service) getParentCategory(ctx context.Context, id uuid.UUID) (db.Category, error) {
+	cat, err := s.q.GetCategory(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.Category{}, fmt.Errorf("%w: parent %s", ErrUnknownCategory, id)
+		}
+		return db.Category{}, err
+	}
+	return cat, nil
+}
+
+func normalizeCategoryInput(in CategoryInput) (CategoryInput, error) {
+	in.DisplayName = strings.TrimSpace(in.DisplayName)
+	if in.DisplayName == "" {
+		return in, fmt.Errorf("%w: display_name is required", ErrInvalidCategory)
+	}
+	if in.Slug == "" {
+		in.Slug = in.DisplayName
+	}
+	in.Slug = Slugify(in.Slug)
+	if in.Slug == "" {
+		return in, fmt.Errorf("%w: slug must contain letters or digits", ErrInvalidCategory)
+	}
+	return in, nil
+}
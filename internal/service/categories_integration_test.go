@@ -0,0 +1,50 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategories_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	dairy, err := svc.CreateCategory(ctx, CategoryInput{DisplayName: "Dairy"})
+	require.NoError(t, err)
+	cheese, err := svc.CreateCategory(ctx, CategoryInput{
+		DisplayName: "Cheese",
+		ParentID:    uuid.NullUUID{UUID: dairy.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = svc.CreateCategory(ctx, CategoryInput{Slug: "Dairy", DisplayName: "Dairy again"})
+	assert.ErrorIs(t, err, ErrDuplicateCategory)
+
+	_, err = svc.UpdateCategory(ctx, dairy.ID, CategoryInput{
+		DisplayName: "Dairy",
+		ParentID:    uuid.NullUUID{UUID: cheese.ID, Valid: true},
+	})
+	assert.ErrorIs(t, err, ErrCategoryCycle)
+
+	categoryID, err := svc.CategoryID(ctx, "cheese")
+	require.NoError(t, err)
+	_, err = q.CreateIngredient(ctx, db.CreateIngredientParams{
+		Name:       "cheddar",
+		Aliases:    []string{},
+		CategoryID: categoryID,
+	})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, svc.DeleteCategory(ctx, cheese.ID), ErrCategoryInUse)
+	assert.ErrorIs(t, svc.DeleteCategory(ctx, dairy.ID), ErrCategoryInUse)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryID(t *testing.T) {
+	t.Parallel()
+
+	dairy := db.Category{ID: uuid.New(), Slug: "dairy", DisplayName: "Dairy"}
+
+	t.Run("empty slug is null", func(t *testing.T) {
+		t.Parallel()
+		svc := New(mocks.NewMockQuerier(t), nil, 0.8)
+		got, err := svc.CategoryID(context.Background(), "  ")
+		require.NoError(t, err)
+		assert.False(t, got.Valid)
+	})
+
+	t.Run("slugifies before lookup", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		svc := New(mockQ, nil, 0.8)
+		mockQ.EXPECT().GetCategoryBySlug(mock.Anything, "dairy").Return(dairy, nil)
+
+		got, err := svc.CategoryID(context.Background(), "Dairy")
+		require.NoError(t, err)
+		assert.Equal(t, uuid.NullUUID{UUID: dairy.ID, Valid: true}, got)
+	})
+
+	t.Run("unknown slug", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		svc := New(mockQ, nil, 0.8)
+		mockQ.EXPECT().GetCategoryBySlug(mock.Anything, "dairy-eggs").Return(db.Category{}, sql.ErrNoRows)
+
+		_, err := svc.CategoryID(context.Background(), "dairy & eggs")
+		assert.ErrorIs(t, err, ErrUnknownCategory)
+	})
+}
+
+func TestCreateCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("derives slug from display name", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		svc := New(mockQ, nil, 0.8)
+
+		want := db.Category{ID: uuid.New(), Slug: "dairy-eggs", DisplayName: "Dairy & Eggs"}
+		mockQ.EXPECT().CreateCategory(mock.Anything, db.CreateCategoryParams{
+			Slug:        "dairy-eggs",
+			DisplayName: "Dairy & Eggs",
+		}).Return(want, nil)
+
+		got, err := svc.CreateCategory(context.Background(), CategoryInput{DisplayName: " Dairy & Eggs "})
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("requires display name", func(t *testing.T) {
+		t.Parallel()
+		svc := New(mocks.NewMockQuerier(t), nil, 0.8)
+		_, err := svc.CreateCategory(context.Background(), CategoryInput{Slug: "dairy"})
+		assert.ErrorIs(t, err, ErrInvalidCategory)
+	})
+
+	t.Run("unknown parent", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		svc := New(mockQ, nil, 0.8)
+		parentID := uuid.New()
+		mockQ.EXPECT().GetCategory(mock.Anything, parentID).Return(db.Category{}, sql.ErrNoRows)
+
+		_, err := svc.CreateCategory(context.Background(), CategoryInput{
+			DisplayName: "Cheese",
+			ParentID:    uuid.NullUUID{UUID: parentID, Valid: true},
+		})
+		assert.ErrorIs(t, err, ErrUnknownCategory)
+	})
+}
+
+func TestUpdateCategory_Cycle(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	// dairy → cheese; making dairy a child of cheese closes the loop.
+	dairy := db.Category{ID: uuid.New(), Slug: "dairy", DisplayName: "Dairy"}
+	cheese := db.Category{
+		ID: uuid.New(), Slug: "cheese", DisplayName: "Cheese",
+		ParentID: uuid.NullUUID{UUID: dairy.ID, Valid: true},
+	}
+	mockQ.EXPECT().GetCategory(mock.Anything, dairy.ID).Return(dairy, nil)
+	mockQ.EXPECT().GetCategory(mock.Anything, cheese.ID).Return(cheese, nil)
+
+	_, err := svc.UpdateCategory(context.Background(), dairy.ID, CategoryInput{
+		DisplayName: "Dairy",
+		ParentID:    uuid.NullUUID{UUID: cheese.ID, Valid: true},
+	})
+	assert.ErrorIs(t, err, ErrCategoryCycle)
+}
+
+func TestDeleteCategory_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+	id := uuid.New()
+	mockQ.EXPECT().DeleteCategory(mock.Anything, id).Return(0, nil)
+
+	assert.ErrorIs(t, svc.DeleteCategory(context.Background(), id), sql.ErrNoRows)
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+var (
+	// ErrTooFewComponents is returned when a composite substitute has fewer
+	// than two components; single-ingredient swaps belong in
+	// ingredient_substitutes.
+	ErrTooFewComponents = errors.New("a composite substitute needs at least two components")
+	// ErrInvalidComponent is returned for a component that repeats, refers to
+	// the ingredient being substituted, or has a non-positive quantity.
+	ErrInvalidComponent = errors.New("invalid component")
+	// ErrComponentNotFound is returned when a component ingredient does not
+	// exist.
+	ErrComponentNotFound = errors.New("component ingredient not found")
+	// ErrInvalidQuantity is returned when the quantity being replaced is not
+	// positive.
+	ErrInvalidQuantity = errors.New("quantity must be positive")
+)
+
+// CompositeComponent is a component row with its ingredient embedded.
+type CompositeComponent = db.ListCompositeComponentsByIngredientRow
+
+// CompositeSubstitute replaces Quantity Unit of an ingredient with the sum of
+// its components, e.g. 1 cup buttermilk = 1 cup milk + 1 tbsp lemon juice.
+type CompositeSubstitute struct {
+	db.CompositeSubstitute
+	Components []CompositeComponent
+}
+
+// CompositeComponentInput is one component of a new composite substitute.
+type CompositeComponentInput struct {
+	ComponentID uuid.UUID
+	Quantity    float64
+	Unit        string
+}
+
+// CompositeSubstituteInput describes a new composite substitute.
+type CompositeSubstituteInput struct {
+	IngredientID uuid.UUID
+	Quantity     float64
+	Unit         string
+	Notes        string
+	Contexts     []string
+	Reasons      []string
+	Components   []CompositeComponentInput
+}
+
+// ListCompositeSubstitutes returns an ingredient's composite substitutes that
+// pass filter, with their components. It returns ErrInvalidTag for an unknown
+// filter value and sql.ErrNoRows if the ingredient does not exist.
+func (s *Service) ListCompositeSubstitutes(ctx context.Context, ingredientID uuid.UUID, filter SubstituteFilter) ([]CompositeSubstitute, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.q.GetIngredient(ctx, ingredientID); err != nil {
+		return nil, err
+	}
+	composites, err := s.q.ListCompositeSubstitutesByIngredient(ctx, db.ListCompositeSubstitutesByIngredientParams{
+		IngredientID: ingredientID,
+		Context:      filter.Context,
+		Reason:       filter.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	components, err := s.q.ListCompositeComponentsByIngredient(ctx, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+
+	byComposite := make(map[uuid.UUID][]CompositeComponent, len(composites))
+	for _, c := range components {
+		id := c.CompositeSubstituteComponent.CompositeID
+		byComposite[id] = append(byComposite[id], c)
+	}
+	result := make([]CompositeSubstitute, 0, len(composites))
+	for _, cs := range composites {
+		result = append(result, CompositeSubstitute{CompositeSubstitute: cs, Components: byComposite[cs.ID]})
+	}
+	return result, nil
+}
+
+// AddCompositeSubstitute validates and stores a composite substitute and its
+// components in one transaction. It returns sql.ErrNoRows if the ingredient
+// does not exist, ErrComponentNotFound for an unknown component, and
+// ErrInvalidQuantity, ErrTooFewComponents, ErrInvalidComponent or
+// ErrInvalidTag for invalid input.
+func (s *Service) AddCompositeSubstitute(ctx context.Context, in CompositeSubstituteInput) (CompositeSubstitute, error) {
+	if err := validateCompositeInput(in); err != nil {
+		return CompositeSubstitute{}, err
+	}
+	contexts, err := normalizeTags(in.Contexts, SubstituteContexts, "context")
+	if err != nil {
+		return CompositeSubstitute{}, err
+	}
+	reasons, err := normalizeTags(in.Reasons, SubstituteReasons, "reason")
+	if err != nil {
+		return CompositeSubstitute{}, err
+	}
+	if _, err := s.q.GetIngredient(ctx, in.IngredientID); err != nil {
+		return CompositeSubstitute{}, err
+	}
+	ingredients := make(map[uuid.UUID]db.Ingredient, len(in.Components))
+	for _, c := range in.Components {
+		ing, err := s.q.GetIngredient(ctx, c.ComponentID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return CompositeSubstitute{}, fmt.Errorf("%w: %s", ErrComponentNotFound, c.ComponentID)
+			}
+			return CompositeSubstitute{}, err
+		}
+		ingredients[c.ComponentID] = ing
+	}
+
+	tx, err := s.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return CompositeSubstitute{}, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	qtx := db.New(tx)
+
+	cs, err := qtx.CreateCompositeSubstitute(ctx, db.CreateCompositeSubstituteParams{
+		IngredientID: in.IngredientID,
+		Quantity:     in.Quantity,
+		Unit:         nullString(in.Unit),
+		Notes:        nullString(in.Notes),
+		Contexts:     contexts,
+		Reasons:      reasons,
+	})
+	if err != nil {
+		return CompositeSubstitute{}, err
+	}
+	result := CompositeSubstitute{CompositeSubstitute: cs}
+	for _, c := range in.Components {
+		row, err := qtx.CreateCompositeComponent(ctx, db.CreateCompositeComponentParams{
+			CompositeID: cs.ID,
+			ComponentID: c.ComponentID,
+			Quantity:    c.Quantity,
+			Unit:        nullString(c.Unit),
+		})
+		if err != nil {
+			return CompositeSubstitute{}, err
+		}
+		result.Components = append(result.Components, CompositeComponent{
+			CompositeSubstituteComponent: row,
+			Ingredient:                   ingredients[c.ComponentID],
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return CompositeSubstitute{}, err
+	}
+	return result, nil
+}
+
+// RemoveCompositeSubstitute deletes a composite substitute and its
+// components. It returns sql.ErrNoRows if no such composite belongs to the
+// ingredient.
+func (s *Service) RemoveCompositeSubstitute(ctx context.Context, ingredientID, compositeID uuid.UUID) error {
+	n, err := s.q.DeleteCompositeSubstitute(ctx, db.DeleteCompositeSubstituteParams{
+		ID:           compositeID,
+		IngredientID: ingredientID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func validateCompositeInput(in CompositeSubstituteInput) error {
+	if in.Quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if len(in.Components) < 2 {
+		return ErrTooFewComponents
+	}
+	seen := make(map[uuid.UUID]bool, len(in.Components))
+	for _, c := range in.Components {
+		switch {
+		case c.ComponentID == in.IngredientID:
+			return fmt.Errorf("%w: an ingredient cannot be a component of its own substitute", ErrInvalidComponent)
+		case seen[c.ComponentID]:
+			return fmt.Errorf("%w: %s is listed more than once", ErrInvalidComponent, c.ComponentID)
+		case c.Quantity <= 0:
+			return fmt.Errorf("%w: quantity must be positive", ErrInvalidComponent)
+		}
+		seen[c.ComponentID] = true
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositeSubstitutes_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	create := func(name string) db.Ingredient {
+		ing, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: name, Aliases: []string{}})
+		require.NoError(t, err)
+		return ing
+	}
+	buttermilk := create("buttermilk")
+	milk := create("milk")
+	lemon := create("lemon juice")
+	wholeMilk := create("whole milk")
+
+	cs, err := svc.AddCompositeSubstitute(ctx, CompositeSubstituteInput{
+		IngredientID: buttermilk.ID,
+		Quantity:     1,
+		Unit:         "cup",
+		Components: []CompositeComponentInput{
+			{ComponentID: wholeMilk.ID, Quantity: 1, Unit: "cup"},
+			{ComponentID: lemon.ID, Quantity: 1, Unit: "tbsp"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Len(t, cs.Components, 2)
+
+	// Merging a component re-points it rather than dropping it.
+	_, err = svc.Merge(ctx, milk.ID, wholeMilk.ID, MergeOptions{})
+	require.NoError(t, err)
+
+	got, err := svc.ListCompositeSubstitutes(ctx, buttermilk.ID, SubstituteFilter{})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Len(t, got[0].Components, 2)
+	assert.Equal(t, "lemon juice", got[0].Components[0].Ingredient.Name)
+	assert.Equal(t, "milk", got[0].Components[1].Ingredient.Name)
+}
+
+func TestCompositeSubstitutes_MergeFoldsRepeatedComponent(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	create := func(name string) db.Ingredient {
+		ing, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: name, Aliases: []string{}})
+		require.NoError(t, err)
+		return ing
+	}
+	buttermilk := create("buttermilk")
+	milk := create("milk")
+	wholeMilk := create("whole milk")
+	lemon := create("lemon juice")
+
+	_, err := svc.AddCompositeSubstitute(ctx, CompositeSubstituteInput{
+		IngredientID: buttermilk.ID,
+		Quantity:     1,
+		Unit:         "cup",
+		Components: []CompositeComponentInput{
+			{ComponentID: milk.ID, Quantity: 0.5, Unit: "cup"},
+			{ComponentID: wholeMilk.ID, Quantity: 0.5, Unit: "cup"},
+			{ComponentID: lemon.ID, Quantity: 1, Unit: "tbsp"},
+		},
+	})
+	require.NoError(t, err)
+
+	// Both milks are components, so the loser's row folds into the winner's.
+	result, err := svc.Merge(ctx, milk.ID, wholeMilk.ID, MergeOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.DroppedComponents, 1)
+	assert.Equal(t, wholeMilk.ID, result.DroppedComponents[0].ComponentID)
+
+	got, err := svc.ListCompositeSubstitutes(ctx, buttermilk.ID, SubstituteFilter{})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Len(t, got[0].Components, 2)
+	assert.Equal(t, "milk", got[0].Components[1].Ingredient.Name)
+	assert.Equal(t, 1.0, got[0].Components[1].CompositeSubstituteComponent.Quantity)
+
+	// Splitting brings back both rows as they were.
+	_, err = svc.Split(ctx, milk.ID, uuid.NullUUID{UUID: wholeMilk.ID, Valid: true})
+	require.NoError(t, err)
+	got, err = svc.ListCompositeSubstitutes(ctx, buttermilk.ID, SubstituteFilter{})
+	require.NoError(t, err)
+	require.Len(t, got[0].Components, 3)
+	for _, c := range got[0].Components {
+		if c.Ingredient.Name != "lemon juice" {
+			assert.Equal(t, 0.5, c.CompositeSubstituteComponent.Quantity, c.Ingredient.Name)
+		}
+	}
+}
+
+func TestCompositeSubstitutes_MergeDropsUndersizedComposite(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	create := func(name string) db.Ingredient {
+		ing, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: name, Aliases: []string{}})
+		require.NoError(t, err)
+		return ing
+	}
+	buttermilk := create("buttermilk")
+	milk := create("milk")
+	lemon := create("lemon juice")
+
+	cs, err := svc.AddCompositeSubstitute(ctx, CompositeSubstituteInput{
+		IngredientID: buttermilk.ID,
+		Quantity:     1,
+		Unit:         "cup",
+		Components: []CompositeComponentInput{
+			{ComponentID: milk.ID, Quantity: 1, Unit: "cup"},
+			{ComponentID: lemon.ID, Quantity: 1, Unit: "tbsp"},
+		},
+	})
+	require.NoError(t, err)
+
+	// Folding lemon juice into milk leaves buttermilk = milk alone.
+	result, err := svc.Merge(ctx, milk.ID, lemon.ID, MergeOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.DroppedComposites, 1)
+	assert.Equal(t, cs.ID, result.DroppedComposites[0].ID)
+
+	got, err := svc.ListCompositeSubstitutes(ctx, buttermilk.ID, SubstituteFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestCompositeSubstitutes_MergeRejectsUnconvertibleComponents(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	create := func(name string) db.Ingredient {
+		ing, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: name, Aliases: []string{}})
+		require.NoError(t, err)
+		return ing
+	}
+	cake := create("cake")
+	butter := create("butter")
+	margarine := create("margarine")
+	egg := create("egg")
+
+	_, err := svc.AddCompositeSubstitute(ctx, CompositeSubstituteInput{
+		IngredientID: cake.ID,
+		Quantity:     1,
+		Components: []CompositeComponentInput{
+			{ComponentID: butter.ID, Quantity: 1, Unit: "stick"},
+			{ComponentID: margarine.ID, Quantity: 50, Unit: "g"},
+			{ComponentID: egg.ID, Quantity: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	// Neither the catalog nor a conversion links sticks and grams.
+	_, err = svc.Merge(ctx, butter.ID, margarine.ID, MergeOptions{})
+	require.ErrorIs(t, err, ErrComponentUnitMismatch)
+
+	got, err := svc.ListCompositeSubstitutes(ctx, cake.ID, SubstituteFilter{})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Len(t, got[0].Components, 3)
+}
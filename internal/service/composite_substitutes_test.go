@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCompositeInput(t *testing.T) {
+	t.Parallel()
+
+	buttermilk, milk, lemon := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		in      CompositeSubstituteInput
+		wantErr error
+	}{
+		{
+			name: "valid",
+			in: CompositeSubstituteInput{IngredientID: buttermilk, Quantity: 1, Components: []CompositeComponentInput{
+				{ComponentID: milk, Quantity: 1, Unit: "cup"},
+				{ComponentID: lemon, Quantity: 1, Unit: "tbsp"},
+			}},
+		},
+		{
+			name:    "non-positive quantity",
+			in:      CompositeSubstituteInput{IngredientID: buttermilk, Quantity: 0},
+			wantErr: ErrInvalidQuantity,
+		},
+		{
+			name: "single component",
+			in: CompositeSubstituteInput{IngredientID: buttermilk, Quantity: 1, Components: []CompositeComponentInput{
+				{ComponentID: milk, Quantity: 1},
+			}},
+			wantErr: ErrTooFewComponents,
+		},
+		{
+			name: "component is the ingredient itself",
+			in: CompositeSubstituteInput{IngredientID: buttermilk, Quantity: 1, Components: []CompositeComponentInput{
+				{ComponentID: milk, Quantity: 1},
+				{ComponentID: buttermilk, Quantity: 1},
+			}},
+			wantErr: ErrInvalidComponent,
+		},
+		{
+			name: "repeated component",
+			in: CompositeSubstituteInput{IngredientID: buttermilk, Quantity: 1, Components: []CompositeComponentInput{
+				{ComponentID: milk, Quantity: 1},
+				{ComponentID: milk, Quantity: 2},
+			}},
+			wantErr: ErrInvalidComponent,
+		},
+		{
+			name: "non-positive component quantity",
+			in: CompositeSubstituteInput{IngredientID: buttermilk, Quantity: 1, Components: []CompositeComponentInput{
+				{ComponentID: milk, Quantity: 1},
+				{ComponentID: lemon, Quantity: 0},
+			}},
+			wantErr: ErrInvalidComponent,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateCompositeInput(tc.in)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestListCompositeSubstitutes_GroupsComponents(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	buttermilk := newIngredient("buttermilk", []string{})
+	milk := newIngredient("milk", []string{})
+	lemon := newIngredient("lemon juice", []string{})
+	composite := db.CompositeSubstitute{ID: uuid.New(), IngredientID: buttermilk.ID, Quantity: 1}
+
+	mockQ.EXPECT().GetIngredient(mock.Anything, buttermilk.ID).Return(buttermilk, nil)
+	mockQ.EXPECT().ListCompositeSubstitutesByIngredient(mock.Anything, db.ListCompositeSubstitutesByIngredientParams{IngredientID: buttermilk.ID}).
+		Return([]db.CompositeSubstitute{composite}, nil)
+	mockQ.EXPECT().ListCompositeComponentsByIngredient(mock.Anything, buttermilk.ID).
+		Return([]db.ListCompositeComponentsByIngredientRow{
+			{CompositeSubstituteComponent: db.CompositeSubstituteComponent{CompositeID: composite.ID, ComponentID: lemon.ID, Quantity: 1}, Ingredient: lemon},
+			{CompositeSubstituteComponent: db.CompositeSubstituteComponent{CompositeID: composite.ID, ComponentID: milk.ID, Quantity: 1}, Ingredient: milk},
+		}, nil)
+
+	got, err := svc.ListCompositeSubstitutes(context.Background(), buttermilk.ID, SubstituteFilter{})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Len(t, got[0].Components, 2)
+}
@@ -0,0 +1,453 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// conversionTolerance is the relative error allowed before two factors that
+// should agree are reported as contradictory.
+const conversionTolerance = 0.02
+
+// densityOutlierRatio is how far an ingredient's density may stray from the
+// median of its category peers before it is reported as an outlier.
+const densityOutlierRatio = 10.0
+
+// minDensityPeers is the number of other ingredients in a category with a
+// known density required before outliers are reported.
+const minDensityPeers = 2
+
+// ConversionIssueKind classifies a problem found by CheckConversions.
+type ConversionIssueKind string
+
+const (
+	ConversionIssueInvalidFactor ConversionIssueKind = "invalid_factor"
+	ConversionIssueDuplicate     ConversionIssueKind = "duplicate"
+	ConversionIssueInverse       ConversionIssueKind = "inverse_mismatch"
+	ConversionIssueCycle         ConversionIssueKind = "cycle_mismatch"
+	ConversionIssueOutlier       ConversionIssueKind = "outlier"
+)
+
+// ConversionIssue describes one contradiction or outlier in an ingredient's
+// unit conversions. ConversionIDs lists the rows involved; for inverse
+// mismatches the first row is the one kept on repair.
+type ConversionIssue struct {
+	IngredientID  uuid.UUID
+	Kind          ConversionIssueKind
+	ConversionIDs []uuid.UUID
+	Detail        string
+	Repaired      bool
+}
+
+// ConversionReport is returned by CheckConversions.
+type ConversionReport struct {
+	IngredientsChecked int
+	ConversionsChecked int
+	Issues             []ConversionIssue
+}
+
+// CheckConversions walks every ingredient's conversion graph and reports
+// invalid factors, conflicting duplicate rows, inverse pairs whose product
+// isn't 1, cycles whose product isn't 1, and volume/mass densities far from
+// the ingredient's category peers. Catalog units are folded together, so a
+// "cup→tbsp" row that disagrees with the unit catalog is also reported.
+//
+// With repair set, inverse mismatches are fixed in one transaction by
+// rewriting the second row of the pair (the one whose from_unit sorts last)
+// as the reciprocal of the first. A pair either row of which has changed
+// since it was read is left alone and not marked repaired.
+func (s *Service) CheckConversions(ctx context.Context, repair bool) (ConversionReport, error) {
+	ingredients, err := s.q.ListIngredients(ctx)
+	if err != nil {
+		return ConversionReport{}, err
+	}
+	all, err := s.q.ListUnitConversions(ctx)
+	if err != nil {
+		return ConversionReport{}, err
+	}
+	categories, err := s.q.ListCategories(ctx)
+	if err != nil {
+		return ConversionReport{}, err
+	}
+	categorySlugs := make(map[uuid.UUID]string, len(categories))
+	for _, c := range categories {
+		categorySlugs[c.ID] = c.Slug
+	}
+
+	byIngredient := make(map[uuid.UUID][]db.UnitConversion)
+	byID := make(map[uuid.UUID]db.UnitConversion, len(all))
+	for _, c := range all {
+		byIngredient[c.IngredientID] = append(byIngredient[c.IngredientID], c)
+		byID[c.ID] = c
+	}
+
+	report := ConversionReport{ConversionsChecked: len(all)}
+	var densities []ingredientDensity
+	for _, ing := range ingredients {
+		convs, ok := byIngredient[ing.ID]
+		if !ok {
+			continue
+		}
+		report.IngredientsChecked++
+		report.Issues = append(report.Issues, checkConversions(convs)...)
+		if d, ok := conversionDensity(convs); ok {
+			densities = append(densities, ingredientDensity{
+				ingredientID: ing.ID,
+				name:         ing.Name,
+				category:     categorySlugs[ing.CategoryID.UUID],
+				density:      d,
+			})
+		}
+	}
+	report.Issues = append(report.Issues, densityOutliers(densities)...)
+
+	if repair {
+		tx, err := s.sqlDB.BeginTx(ctx, nil)
+		if err != nil {
+			return ConversionReport{}, err
+		}
+		defer tx.Rollback() //nolint:errcheck
+
+		repaired, err := repairInversePairs(ctx, db.New(tx), report.Issues, byID)
+		if err != nil {
+			return ConversionReport{}, err
+		}
+		if err := tx.Commit(); err != nil {
+			return ConversionReport{}, err
+		}
+		for _, i := range repaired {
+			report.Issues[i].Repaired = true
+		}
+	}
+
+	return report, nil
+}
+
+// repairInversePairs rewrites the second row of each inverse mismatch in
+// issues as the reciprocal of the first, as both were read into byID, and
+// returns the indexes of the issues it repaired.
+func repairInversePairs(ctx context.Context, q db.Querier, issues []ConversionIssue, byID map[uuid.UUID]db.UnitConversion) ([]int, error) {
+	var repaired []int
+	for i, issue := range issues {
+		if issue.Kind != ConversionIssueInverse {
+			continue
+		}
+		keep, fix := byID[issue.ConversionIDs[0]], byID[issue.ConversionIDs[1]]
+		n, err := q.RepairUnitConversionFactor(ctx, db.RepairUnitConversionFactorParams{
+			KeepID:     keep.ID,
+			KeepFactor: keep.Factor,
+			ID:         fix.ID,
+			Factor:     fix.Factor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			repaired = append(repaired, i)
+		}
+	}
+	return repaired, nil
+}
+
+// RunConversionChecks runs CheckConversions every interval until ctx is
+// cancelled, logging a summary of each run. It never repairs.
+func (s *Service) RunConversionChecks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := s.CheckConversions(ctx, false)
+			if err != nil {
+				slog.Error("conversion check failed", "error", err)
+				continue
+			}
+			if len(report.Issues) > 0 {
+				slog.Warn("conversion check found issues",
+					"issues", len(report.Issues),
+					"ingredients", report.IngredientsChecked,
+					"conversions", report.ConversionsChecked)
+			} else {
+				slog.Info("conversion check passed",
+					"ingredients", report.IngredientsChecked,
+					"conversions", report.ConversionsChecked)
+			}
+		}
+	}
+}
+
+// factorsAgree reports whether a and b are within conversionTolerance of
+// each other.
+func factorsAgree(a, b float64) bool {
+	return math.Abs(a/b-1) <= conversionTolerance
+}
+
+// checkConversions checks a single ingredient's conversions for invalid
+// factors, conflicting duplicates, inverse mismatches and inconsistent cycles.
+// Rows already reported are left out of the later checks so one bad row does
+// not produce a cascade of issues.
+func checkConversions(convs []db.UnitConversion) []ConversionIssue {
+	var issues []ConversionIssue
+	flagged := make(map[uuid.UUID]bool)
+
+	byPair := make(map[[2]string][]db.UnitConversion)
+	var pairs [][2]string
+	for _, c := range convs {
+		if c.Factor <= 0 || math.IsNaN(c.Factor) || math.IsInf(c.Factor, 0) {
+			issues = append(issues, ConversionIssue{
+				IngredientID:  c.IngredientID,
+				Kind:          ConversionIssueInvalidFactor,
+				ConversionIDs: []uuid.UUID{c.ID},
+				Detail:        fmt.Sprintf("%s->%s has non-positive factor %g", c.FromUnit, c.ToUnit, c.Factor),
+			})
+			flagged[c.ID] = true
+			continue
+		}
+		key := [2]string{Normalize(c.FromUnit), Normalize(c.ToUnit)}
+		if _, ok := byPair[key]; !ok {
+			pairs = append(pairs, key)
+		}
+		byPair[key] = append(byPair[key], c)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+
+	for _, key := range pairs {
+		rows := byPair[key]
+		for _, r := range rows[1:] {
+			if factorsAgree(rows[0].Factor, r.Factor) {
+				continue
+			}
+			issues = append(issues, ConversionIssue{
+				IngredientID:  r.IngredientID,
+				Kind:          ConversionIssueDuplicate,
+				ConversionIDs: []uuid.UUID{rows[0].ID, r.ID},
+				Detail:        fmt.Sprintf("%s->%s is both %g and %g", key[0], key[1], rows[0].Factor, r.Factor),
+			})
+			flagged[r.ID] = true
+		}
+	}
+
+	for _, key := range pairs {
+		if key[0] >= key[1] {
+			continue
+		}
+		rev, ok := byPair[[2]string{key[1], key[0]}]
+		if !ok {
+			continue
+		}
+		fwd, back := byPair[key][0], rev[0]
+		if factorsAgree(fwd.Factor*back.Factor, 1) {
+			continue
+		}
+		issues = append(issues, ConversionIssue{
+			IngredientID:  fwd.IngredientID,
+			Kind:          ConversionIssueInverse,
+			ConversionIDs: []uuid.UUID{fwd.ID, back.ID},
+			Detail: fmt.Sprintf("%s->%s is %g but %s->%s is %g (product %g)",
+				key[0], key[1], fwd.Factor, key[1], key[0], back.Factor, fwd.Factor*back.Factor),
+		})
+		flagged[back.ID] = true
+	}
+
+	var remaining []db.UnitConversion
+	for _, c := range convs {
+		if !flagged[c.ID] {
+			remaining = append(remaining, c)
+		}
+	}
+	graph := solveConversions(remaining)
+	return append(issues, graph.mismatches...)
+}
+
+// conversionGraph is the result of solveConversions. pos holds, for each
+// node, how many of that unit make up one of its component's root unit.
+type conversionGraph struct {
+	pos        map[string]float64
+	component  map[string]int
+	mismatches []ConversionIssue
+}
+
+// conversionEdge is a conversion row expressed between graph nodes.
+type conversionEdge struct {
+	row      db.UnitConversion
+	from, to string
+	factor   float64
+}
+
+// unitNode maps a unit to its graph node. Catalog units of the same dimension
+// collapse onto the dimension's base unit; the returned multiplier converts
+// one of the unit into the node.
+func unitNode(unit string) (string, float64) {
+	if u, ok := LookupUnit(unit); ok {
+		if u.Dimension == DimensionMass {
+			return unitG.Name, u.ToBase
+		}
+		return unitML.Name, u.ToBase
+	}
+	return Normalize(unit), 1
+}
+
+// convertQuantity expresses qty of unit from in unit to, using the unit
+// catalog within a dimension and convs to cross between dimensions or reach
+// units outside the catalog.
+func convertQuantity(qty float64, from, to string, convs []db.UnitConversion) (float64, bool) {
+	if Normalize(from) == Normalize(to) {
+		return qty, true
+	}
+	fromNode, fm := unitNode(from)
+	toNode, tm := unitNode(to)
+	if fromNode == toNode {
+		return qty * fm / tm, true
+	}
+	g := solveConversions(convs)
+	pf, okFrom := g.pos[fromNode]
+	pt, okTo := g.pos[toNode]
+	if !okFrom || !okTo || g.component[fromNode] != g.component[toNode] {
+		return 0, false
+	}
+	return qty * fm * pt / pf / tm, true
+}
+
+// solveConversions assigns every unit a position relative to a root unit by
+// walking a spanning tree of the conversions, then checks every row against
+// those positions. Rows that disagree close a cycle whose product isn't 1.
+func solveConversions(convs []db.UnitConversion) conversionGraph {
+	edges := make([]conversionEdge, 0, len(convs))
+	adj := make(map[string][]int)
+	var nodes []string
+	for _, c := range convs {
+		from, fm := unitNode(c.FromUnit)
+		to, tm := unitNode(c.ToUnit)
+		e := conversionEdge{row: c, from: from, to: to, factor: c.Factor * tm / fm}
+		for _, n := range []string{from, to} {
+			if _, ok := adj[n]; !ok {
+				nodes = append(nodes, n)
+			}
+		}
+		adj[from] = append(adj[from], len(edges))
+		adj[to] = append(adj[to], len(edges))
+		edges = append(edges, e)
+	}
+
+	g := conversionGraph{pos: make(map[string]float64), component: make(map[string]int)}
+	for ci, root := range nodes {
+		if _, seen := g.pos[root]; seen {
+			continue
+		}
+		g.pos[root] = 1
+		g.component[root] = ci
+		queue := []string{root}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			for _, ei := range adj[n] {
+				e := edges[ei]
+				next, p := e.to, g.pos[n]*e.factor
+				if n == e.to {
+					next, p = e.from, g.pos[n]/e.factor
+				}
+				if _, seen := g.pos[next]; !seen {
+					g.pos[next] = p
+					g.component[next] = ci
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+
+	for _, e := range edges {
+		implied := g.pos[e.to] / g.pos[e.from]
+		if factorsAgree(e.factor, implied) {
+			continue
+		}
+		detail := fmt.Sprintf("%s->%s is %g but other conversions imply %g",
+			e.row.FromUnit, e.row.ToUnit, e.row.Factor, e.row.Factor*implied/e.factor)
+		g.mismatches = append(g.mismatches, ConversionIssue{
+			IngredientID:  e.row.IngredientID,
+			Kind:          ConversionIssueCycle,
+			ConversionIDs: []uuid.UUID{e.row.ID},
+			Detail:        detail,
+		})
+	}
+	return g
+}
+
+// conversionDensity returns grams per millilitre implied by an ingredient's
+// conversions, if they link volume and mass.
+func conversionDensity(convs []db.UnitConversion) (float64, bool) {
+	g := solveConversions(convs)
+	ml, okV := g.pos[unitML.Name]
+	gr, okM := g.pos[unitG.Name]
+	if !okV || !okM || g.component[unitML.Name] != g.component[unitG.Name] {
+		return 0, false
+	}
+	return gr / ml, true
+}
+
+type ingredientDensity struct {
+	ingredientID uuid.UUID
+	name         string
+	category     string
+	density      float64
+}
+
+// densityOutliers reports ingredients whose density differs from the median
+// of the other ingredients in their category by more than densityOutlierRatio.
+// Uncategorised ingredients are not compared.
+func densityOutliers(densities []ingredientDensity) []ConversionIssue {
+	byCategory := make(map[string][]ingredientDensity)
+	for _, d := range densities {
+		if d.category != "" {
+			byCategory[d.category] = append(byCategory[d.category], d)
+		}
+	}
+
+	var issues []ConversionIssue
+	for _, d := range densities {
+		var peers []float64
+		for _, p := range byCategory[d.category] {
+			if p.ingredientID != d.ingredientID {
+				peers = append(peers, p.density)
+			}
+		}
+		if len(peers) < minDensityPeers {
+			continue
+		}
+		m := median(peers)
+		ratio := d.density / m
+		if ratio <= densityOutlierRatio && ratio >= 1/densityOutlierRatio {
+			continue
+		}
+		issues = append(issues, ConversionIssue{
+			IngredientID: d.ingredientID,
+			Kind:         ConversionIssueOutlier,
+			Detail: fmt.Sprintf("%s density %.3g g/ml is %.1fx the %q median of %.3g g/ml",
+				d.name, d.density, ratio, d.category, m),
+		})
+	}
+	return issues
+}
+
+func median(vs []float64) float64 {
+	sorted := append([]float64(nil), vs...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
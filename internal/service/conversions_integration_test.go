@@ -0,0 +1,55 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConversions_Repair_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	flour, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "flour", Aliases: []string{}})
+	require.NoError(t, err)
+	fwd, err := q.CreateUnitConversion(ctx, db.CreateUnitConversionParams{
+		IngredientID: flour.ID, FromUnit: "cup", ToUnit: "g", Factor: 125,
+	})
+	require.NoError(t, err)
+	back, err := q.CreateUnitConversion(ctx, db.CreateUnitConversionParams{
+		IngredientID: flour.ID, FromUnit: "g", ToUnit: "cup", Factor: 0.5,
+	})
+	require.NoError(t, err)
+
+	// A row edited after the check read it is left alone.
+	n, err := q.RepairUnitConversionFactor(ctx, db.RepairUnitConversionFactorParams{
+		ID: back.ID, Factor: 0.25, KeepID: fwd.ID, KeepFactor: fwd.Factor,
+	})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	report, err := svc.CheckConversions(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 1)
+	assert.True(t, report.Issues[0].Repaired)
+
+	convs, err := q.ListUnitConversionsByIngredient(ctx, flour.ID)
+	require.NoError(t, err)
+	for _, c := range convs {
+		if c.ID == back.ID {
+			assert.InDelta(t, 1.0/125, c.Factor, 1e-12)
+		}
+	}
+
+	report, err = svc.CheckConversions(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.Issues)
+}
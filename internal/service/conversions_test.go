@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newConversion(ingredientID uuid.UUID, from, to string, factor float64) db.UnitConversion {
+	return db.UnitConversion{ID: uuid.New(), IngredientID: ingredientID, FromUnit: from, ToUnit: to, Factor: factor}
+}
+
+func TestCheckConversions(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+
+	tests := []struct {
+		name      string
+		convs     []db.UnitConversion
+		wantKinds []ConversionIssueKind
+	}{
+		{
+			name: "consistent graph",
+			convs: []db.UnitConversion{
+				newConversion(id, "cup", "g", 120),
+				newConversion(id, "g", "cup", 1.0/120),
+				newConversion(id, "tbsp", "g", 7.5),
+			},
+		},
+		{
+			name: "non-positive factor",
+			convs: []db.UnitConversion{
+				newConversion(id, "cup", "g", 0),
+			},
+			wantKinds: []ConversionIssueKind{ConversionIssueInvalidFactor},
+		},
+		{
+			name: "conflicting duplicates",
+			convs: []db.UnitConversion{
+				newConversion(id, "cup", "g", 120),
+				newConversion(id, "cup", "g", 150),
+			},
+			wantKinds: []ConversionIssueKind{ConversionIssueDuplicate},
+		},
+		{
+			name: "inverse mismatch",
+			convs: []db.UnitConversion{
+				newConversion(id, "cup", "g", 120),
+				newConversion(id, "g", "cup", 0.5),
+			},
+			wantKinds: []ConversionIssueKind{ConversionIssueInverse},
+		},
+		{
+			name: "cycle through non-catalog units",
+			convs: []db.UnitConversion{
+				newConversion(id, "stick", "tbsp", 8),
+				newConversion(id, "stick", "g", 113),
+				newConversion(id, "tbsp", "g", 30),
+			},
+			wantKinds: []ConversionIssueKind{ConversionIssueCycle},
+		},
+		{
+			name: "row contradicting the unit catalog",
+			convs: []db.UnitConversion{
+				newConversion(id, "cup", "tbsp", 20),
+			},
+			wantKinds: []ConversionIssueKind{ConversionIssueCycle},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			issues := checkConversions(tc.convs)
+			var kinds []ConversionIssueKind
+			for _, issue := range issues {
+				assert.Equal(t, id, issue.IngredientID)
+				kinds = append(kinds, issue.Kind)
+			}
+			assert.Equal(t, tc.wantKinds, kinds)
+		})
+	}
+}
+
+func TestConversionDensity(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	d, ok := conversionDensity([]db.UnitConversion{newConversion(id, "cup", "g", 120)})
+	require.True(t, ok)
+	assert.InDelta(t, 120/unitCup.ToBase, d, 1e-9)
+
+	_, ok = conversionDensity([]db.UnitConversion{newConversion(id, "cup", "tbsp", 16)})
+	assert.False(t, ok)
+}
+
+func TestDensityOutliers(t *testing.T) {
+	t.Parallel()
+
+	flour := ingredientDensity{ingredientID: uuid.New(), name: "flour", category: "baking", density: 12.5}
+	densities := []ingredientDensity{
+		flour,
+		{ingredientID: uuid.New(), name: "sugar", category: "baking", density: 0.85},
+		{ingredientID: uuid.New(), name: "cocoa", category: "baking", density: 0.4},
+		{ingredientID: uuid.New(), name: "salt", category: "baking", density: 1.2},
+		// Over 5x the median but within 10x: not reported.
+		{ingredientID: uuid.New(), name: "honey", category: "baking", density: 6},
+		{ingredientID: uuid.New(), name: "lead", category: "", density: 11},
+	}
+
+	issues := densityOutliers(densities)
+	require.Len(t, issues, 1)
+	assert.Equal(t, flour.ingredientID, issues[0].IngredientID)
+	assert.Equal(t, ConversionIssueOutlier, issues[0].Kind)
+}
+
+func TestRepairInversePairs(t *testing.T) {
+	t.Parallel()
+
+	flourID, sugarID := uuid.New(), uuid.New()
+	fwd := newConversion(flourID, "cup", "g", 125)
+	back := newConversion(flourID, "g", "cup", 0.5)
+	sugarFwd := newConversion(sugarID, "cup", "g", 200)
+	sugarBack := newConversion(sugarID, "g", "cup", 0.1)
+	byID := map[uuid.UUID]db.UnitConversion{fwd.ID: fwd, back.ID: back, sugarFwd.ID: sugarFwd, sugarBack.ID: sugarBack}
+	issues := []ConversionIssue{
+		{IngredientID: flourID, Kind: ConversionIssueInverse, ConversionIDs: []uuid.UUID{fwd.ID, back.ID}},
+		{IngredientID: flourID, Kind: ConversionIssueOutlier, ConversionIDs: []uuid.UUID{fwd.ID}},
+		{IngredientID: sugarID, Kind: ConversionIssueInverse, ConversionIDs: []uuid.UUID{sugarFwd.ID, sugarBack.ID}},
+	}
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().RepairUnitConversionFactor(mock.Anything, db.RepairUnitConversionFactorParams{
+		ID: back.ID, Factor: 0.5, KeepID: fwd.ID, KeepFactor: 125,
+	}).Return(1, nil)
+	// Sugar's rows changed since they were read, so nothing is updated.
+	mockQ.EXPECT().RepairUnitConversionFactor(mock.Anything, db.RepairUnitConversionFactorParams{
+		ID: sugarBack.ID, Factor: 0.1, KeepID: sugarFwd.ID, KeepFactor: 200,
+	}).Return(0, nil)
+
+	repaired, err := repairInversePairs(context.Background(), mockQ, issues, byID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, repaired)
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// ErrInvalidAttribute is returned for an allergen or dietary tag outside the
+// known vocabulary.
+var ErrInvalidAttribute = errors.New("invalid dietary attribute")
+
+// Allergens are the allergen flags an ingredient can carry: the fourteen
+// major allergens of EU labelling law, which cover the US major nine.
+var Allergens = []string{
+	"celery", "crustaceans", "eggs", "fish", "gluten", "lupin", "milk",
+	"molluscs", "mustard", "peanuts", "sesame", "soy", "sulphites", "tree_nuts",
+}
+
+// DietaryTags are the diets an ingredient can be marked as suitable for.
+var DietaryTags = []string{
+	"vegan", "vegetarian", "pescatarian", "gluten_free", "dairy_free",
+	"nut_free", "egg_free", "soy_free", "halal", "kosher",
+}
+
+// dietConflicts lists, per dietary tag, the allergens an ingredient suited
+// to that diet cannot carry. Religious diets aren't implied by allergens.
+var dietConflicts = map[string][]string{
+	"vegan":       {"milk", "eggs", "fish", "crustaceans", "molluscs"},
+	"vegetarian":  {"fish", "crustaceans", "molluscs"},
+	"gluten_free": {"gluten"},
+	"dairy_free":  {"milk"},
+	"nut_free":    {"peanuts", "tree_nuts"},
+	"egg_free":    {"eggs"},
+	"soy_free":    {"soy"},
+}
+
+// DietaryAttributes are an ingredient's allergens and dietary tags. FreeOf
+// lists allergens the ingredient is explicitly free of, cancelling the same
+// allergens inherited from its ancestors; it is empty in effective
+// attributes.
+type DietaryAttributes struct {
+	Allergens   []string
+	DietaryTags []string
+	FreeOf      []string
+}
+
+// IngredientFilter narrows ListIngredients using inherited dietary
+// attributes. FreeOf excludes ingredients carrying any of the allergens;
+// Diets keeps only ingredients tagged with every listed diet.
+type IngredientFilter struct {
+	FreeOf []string
+	Diets  []string
+	// IncludeArchived keeps archived ingredients in the result.
+	IncludeArchived bool
+	// AsOf, if set, lists the ingredients as they were at that time.
+	AsOf time.Time
+}
+
+// ListIngredients returns the active ingredients ordered by name that pass
+// filter, and archived ones too if filter.IncludeArchived is set.
+// It returns ErrInvalidAttribute for an unknown allergen or diet.
+func (s *Service) ListIngredients(ctx context.Context, filter IngredientFilter) ([]db.Ingredient, error) {
+	freeOf, err := normalizeAttributes(filter.FreeOf, Allergens, "allergen")
+	if err != nil {
+		return nil, err
+	}
+	diets, err := normalizeAttributes(filter.Diets, DietaryTags, "dietary tag")
+	if err != nil {
+		return nil, err
+	}
+	var all []db.Ingredient
+	if filter.AsOf.IsZero() {
+		all, err = s.q.ListIngredients(ctx)
+	} else {
+		all, err = s.listIngredientsAsOf(ctx, filter.AsOf)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(freeOf) == 0 && len(diets) == 0 && filter.IncludeArchived {
+		return all, nil
+	}
+
+	byID := make(map[uuid.UUID]db.Ingredient, len(all))
+	for _, ing := range all {
+		byID[ing.ID] = ing
+	}
+	var result []db.Ingredient
+	for _, ing := range all {
+		if ing.ArchivedAt.Valid && !filter.IncludeArchived {
+			continue
+		}
+		attrs := effectiveAttributes(ing, ancestorsFromMap(ing, byID))
+		if slices.ContainsFunc(freeOf, func(a string) bool { return slices.Contains(attrs.Allergens, a) }) {
+			continue
+		}
+		if !containsAll(attrs.DietaryTags, diets) {
+			continue
+		}
+		result = append(result, ing)
+	}
+	return result, nil
+}
+
+// DietaryAttributes returns the ingredient's own attributes and its
+// effective attributes after inheritance. It returns sql.ErrNoRows if the
+// ingredient does not exist.
+func (s *Service) DietaryAttributes(ctx context.Context, id uuid.UUID) (own, effective DietaryAttributes, err error) {
+	ing, err := s.q.GetIngredient(ctx, id)
+	if err != nil {
+		return DietaryAttributes{}, DietaryAttributes{}, err
+	}
+	return s.dietaryAttributes(ctx, ing)
+}
+
+// SetDietaryAttributes replaces the ingredient's own allergens, dietary
+// tags and free-of allergens and returns its own and effective attributes.
+// It returns ErrInvalidAttribute for unknown values or an allergen both
+// carried and free of, and sql.ErrNoRows if the ingredient does not exist.
+func (s *Service) SetDietaryAttributes(ctx context.Context, id uuid.UUID, attrs DietaryAttributes) (own, effective DietaryAttributes, err error) {
+	allergens, err := normalizeAttributes(attrs.Allergens, Allergens, "allergen")
+	if err != nil {
+		return DietaryAttributes{}, DietaryAttributes{}, err
+	}
+	tags, err := normalizeAttributes(attrs.DietaryTags, DietaryTags, "dietary tag")
+	if err != nil {
+		return DietaryAttributes{}, DietaryAttributes{}, err
+	}
+	freeOf, err := normalizeAttributes(attrs.FreeOf, Allergens, "allergen")
+	if err != nil {
+		return DietaryAttributes{}, DietaryAttributes{}, err
+	}
+	for _, a := range freeOf {
+		if slices.Contains(allergens, a) {
+			return DietaryAttributes{}, DietaryAttributes{}, fmt.Errorf("%w: %q is both an allergen and free of", ErrInvalidAttribute, a)
+		}
+	}
+	ing, err := s.q.UpdateIngredientDietary(ctx, db.UpdateIngredientDietaryParams{
+		ID:          id,
+		Allergens:   allergens,
+		DietaryTags: tags,
+		FreeOf:      freeOf,
+		Actor:       actorFrom(ctx),
+		Reason:      reasonFrom(ctx),
+	})
+	if err != nil {
+		return DietaryAttributes{}, DietaryAttributes{}, err
+	}
+	return s.dietaryAttributes(ctx, ing)
+}
+
+func (s *Service) dietaryAttributes(ctx context.Context, ing db.Ingredient) (own, effective DietaryAttributes, err error) {
+	own = DietaryAttributes{Allergens: ing.Allergens, DietaryTags: ing.DietaryTags, FreeOf: ing.FreeOf}
+	if !ing.ParentID.Valid {
+		return own, effectiveAttributes(ing, nil), nil
+	}
+	rows, err := s.q.ListIngredientAncestors(ctx, ing.ID)
+	if err != nil {
+		return DietaryAttributes{}, DietaryAttributes{}, err
+	}
+	ancestors := make([]db.Ingredient, 0, len(rows))
+	for _, r := range rows {
+		ancestors = append(ancestors, r.Ingredient)
+	}
+	return own, effectiveAttributes(ing, ancestors), nil
+}
+
+// effectiveAttributes applies inheritance given ancestors ordered nearest
+// first. Allergens accumulate down the hierarchy, since a "sharp cheddar"
+// still contains the "cheese" allergens, unless an ingredient on the way
+// down is free of them: each allergen is decided by the nearest ingredient
+// that either carries it or is free of it, so "oat milk" under "milk" can
+// drop milk. Dietary tags come from the nearest ingredient that has any, so
+// a child can narrow or override its parent's, less any the effective
+// allergens contradict: a child with milk doesn't inherit "vegan".
+func effectiveAttributes(ing db.Ingredient, ancestors []db.Ingredient) DietaryAttributes {
+	attrs := DietaryAttributes{Allergens: []string{}, DietaryTags: ing.DietaryTags}
+	decided := make(map[string]bool)
+	for _, a := range append([]db.Ingredient{ing}, ancestors...) {
+		for _, allergen := range a.Allergens {
+			if !decided[allergen] {
+				decided[allergen] = true
+				attrs.Allergens = append(attrs.Allergens, allergen)
+			}
+		}
+		for _, allergen := range a.FreeOf {
+			decided[allergen] = true
+		}
+		if len(attrs.DietaryTags) == 0 {
+			attrs.DietaryTags = a.DietaryTags
+		}
+	}
+	slices.Sort(attrs.Allergens)
+	attrs.DietaryTags = dropContradictedTags(attrs.DietaryTags, attrs.Allergens)
+	return attrs
+}
+
+// dropContradictedTags returns tags without those that any of allergens
+// rules out.
+func dropContradictedTags(tags, allergens []string) []string {
+	return slices.DeleteFunc(slices.Clone(tags), func(tag string) bool {
+		return slices.ContainsFunc(dietConflicts[tag], func(a string) bool { return slices.Contains(allergens, a) })
+	})
+}
+
+// ancestorsFromMap walks ing's parent chain through byID, nearest first.
+func ancestorsFromMap(ing db.Ingredient, byID map[uuid.UUID]db.Ingredient) []db.Ingredient {
+	var ancestors []db.Ingredient
+	for parentID := ing.ParentID; parentID.Valid && len(ancestors) < maxHierarchyDepth; {
+		parent, ok := byID[parentID.UUID]
+		if !ok {
+			break
+		}
+		ancestors = append(ancestors, parent)
+		parentID = parent.ParentID
+	}
+	return ancestors
+}
+
+// normalizeAttributes lowercases and deduplicates values, returning
+// ErrInvalidAttribute for any outside vocab. The result is never nil so it
+// can be stored in the NOT NULL array columns.
+func normalizeAttributes(values, vocab []string, kind string) ([]string, error) {
+	result := []string{}
+	for _, v := range values {
+		v = Normalize(v)
+		if !slices.Contains(vocab, v) {
+			return nil, fmt.Errorf("%w: unknown %s %q", ErrInvalidAttribute, kind, v)
+		}
+		if !slices.Contains(result, v) {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+func containsAll(have, want []string) bool {
+	for _, w := range want {
+		if !slices.Contains(have, w) {
+			return false
+		}
+	}
+	return true
+}
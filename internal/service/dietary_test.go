@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveAttributes(t *testing.T) {
+	t.Parallel()
+
+	dairy := newIngredient("dairy", []string{})
+	dairy.Allergens = []string{"milk"}
+	dairy.DietaryTags = []string{"vegetarian"}
+	cheese := withParent(newIngredient("cheese", []string{}), dairy)
+	blue := withParent(newIngredient("blue cheese", []string{}), cheese)
+	blue.Allergens = []string{"sulphites"}
+	vegan := withParent(newIngredient("vegan cheese", []string{}), cheese)
+	vegan.DietaryTags = []string{"vegan", "dairy_free"}
+	vegan.FreeOf = []string{"milk"}
+	plant := newIngredient("plant milk", []string{})
+	plant.DietaryTags = []string{"vegan", "gluten_free"}
+	blend := withParent(newIngredient("milk blend", []string{}), plant)
+	blend.Allergens = []string{"milk"}
+	oat := withParent(newIngredient("oat milk", []string{}), dairy)
+	oat.Allergens = []string{"gluten"}
+	oat.FreeOf = []string{"milk"}
+	barista := withParent(newIngredient("barista oat milk", []string{}), oat)
+	custard := withParent(newIngredient("oat custard", []string{}), oat)
+	custard.Allergens = []string{"milk", "eggs"}
+
+	tests := []struct {
+		name      string
+		ing       db.Ingredient
+		ancestors []db.Ingredient
+		want      DietaryAttributes
+	}{
+		{
+			name: "root keeps its own",
+			ing:  dairy,
+			want: DietaryAttributes{Allergens: []string{"milk"}, DietaryTags: []string{"vegetarian"}},
+		},
+		{
+			name:      "allergens accumulate",
+			ing:       blue,
+			ancestors: []db.Ingredient{cheese, dairy},
+			want:      DietaryAttributes{Allergens: []string{"milk", "sulphites"}, DietaryTags: []string{"vegetarian"}},
+		},
+		{
+			name:      "own dietary tags override inherited",
+			ing:       vegan,
+			ancestors: []db.Ingredient{cheese, dairy},
+			want:      DietaryAttributes{Allergens: []string{}, DietaryTags: []string{"vegan", "dairy_free"}},
+		},
+		{
+			name:      "allergens drop the tags they contradict",
+			ing:       blend,
+			ancestors: []db.Ingredient{plant},
+			want:      DietaryAttributes{Allergens: []string{"milk"}, DietaryTags: []string{"gluten_free"}},
+		},
+		{
+			name:      "free of clears an inherited allergen",
+			ing:       oat,
+			ancestors: []db.Ingredient{dairy},
+			want:      DietaryAttributes{Allergens: []string{"gluten"}, DietaryTags: []string{"vegetarian"}},
+		},
+		{
+			name:      "descendants inherit the cleared allergen as cleared",
+			ing:       barista,
+			ancestors: []db.Ingredient{oat, dairy},
+			want:      DietaryAttributes{Allergens: []string{"gluten"}, DietaryTags: []string{"vegetarian"}},
+		},
+		{
+			name:      "a descendant can carry it again",
+			ing:       custard,
+			ancestors: []db.Ingredient{oat, dairy},
+			want:      DietaryAttributes{Allergens: []string{"eggs", "gluten", "milk"}, DietaryTags: []string{"vegetarian"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, effectiveAttributes(tt.ing, tt.ancestors))
+		})
+	}
+}
+
+func TestListIngredients_Filter(t *testing.T) {
+	t.Parallel()
+
+	dairy := newIngredient("dairy", []string{})
+	dairy.Allergens = []string{"milk"}
+	dairy.DietaryTags = []string{"vegetarian"}
+	cheddar := withParent(newIngredient("cheddar", []string{}), dairy)
+	tofu := newIngredient("tofu", []string{})
+	tofu.Allergens = []string{"soy"}
+	tofu.DietaryTags = []string{"vegan", "vegetarian"}
+	salt := newIngredient("salt", []string{})
+	lactoseFree := withParent(newIngredient("lactose-free cheddar", []string{}), cheddar)
+	lactoseFree.FreeOf = []string{"milk"}
+	all := []db.Ingredient{cheddar, dairy, lactoseFree, salt, tofu}
+
+	tests := []struct {
+		name   string
+		filter IngredientFilter
+		want   []string
+	}{
+		{name: "no filter", filter: IngredientFilter{}, want: []string{"cheddar", "dairy", "lactose-free cheddar", "salt", "tofu"}},
+		{name: "free of inherited allergen", filter: IngredientFilter{FreeOf: []string{"Milk"}}, want: []string{"lactose-free cheddar", "salt", "tofu"}},
+		{name: "diet requires a tag", filter: IngredientFilter{Diets: []string{"vegetarian"}}, want: []string{"cheddar", "dairy", "lactose-free cheddar", "tofu"}},
+		{name: "combined", filter: IngredientFilter{FreeOf: []string{"soy"}, Diets: []string{"vegetarian"}}, want: []string{"cheddar", "dairy", "lactose-free cheddar"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockQ := mocks.NewMockQuerier(t)
+			svc := New(mockQ, nil, 0.8)
+			mockQ.EXPECT().ListIngredients(mock.Anything).Return(all, nil)
+
+			got, err := svc.ListIngredients(context.Background(), tt.filter)
+			require.NoError(t, err)
+			names := make([]string, 0, len(got))
+			for _, ing := range got {
+				names = append(names, ing.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestSetDietaryAttributes_Invalid(t *testing.T) {
+	t.Parallel()
+
+	svc := New(mocks.NewMockQuerier(t), nil, 0.8)
+	ing := newIngredient("flour", []string{})
+
+	_, _, err := svc.SetDietaryAttributes(context.Background(), ing.ID, DietaryAttributes{Allergens: []string{"wheat"}})
+	assert.ErrorIs(t, err, ErrInvalidAttribute)
+
+	_, _, err = svc.SetDietaryAttributes(context.Background(), ing.ID, DietaryAttributes{DietaryTags: []string{"carnivore"}})
+	assert.ErrorIs(t, err, ErrInvalidAttribute)
+
+	_, _, err = svc.SetDietaryAttributes(context.Background(), ing.ID, DietaryAttributes{Allergens: []string{"milk"}, FreeOf: []string{"milk"}})
+	assert.ErrorIs(t, err, ErrInvalidAttribute)
+}
+
+func TestSetDietaryAttributes_Normalizes(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+	ing := newIngredient("flour", []string{})
+	updated := ing
+	updated.Allergens = []string{"gluten"}
+	updated.DietaryTags = []string{}
+
+	mockQ.EXPECT().UpdateIngredientDietary(mock.Anything, db.UpdateIngredientDietaryParams{
+		ID:          ing.ID,
+		Allergens:   []string{"gluten"},
+		DietaryTags: []string{},
+		FreeOf:      []string{},
+	}).Return(updated, nil)
+
+	own, effective, err := svc.SetDietaryAttributes(context.Background(), ing.ID, DietaryAttributes{
+		Allergens: []string{"Gluten", "gluten"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gluten"}, own.Allergens)
+	assert.Equal(t, own, effective)
+}
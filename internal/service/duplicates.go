@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// ErrInvalidDuplicateOptions is returned for a MinScore outside (0, 1] or a
+// negative Limit.
+var ErrInvalidDuplicateOptions = errors.New("invalid duplicate options")
+
+const (
+	defaultDuplicateLimit = 50
+	maxDuplicateLimit     = 500
+)
+
+// DuplicateOptions controls FindDuplicates.
+type DuplicateOptions struct {
+	// MinScore is the lowest pair score reported. Zero means the resolve
+	// threshold.
+	MinScore float64
+	// Limit caps the number of clusters. Zero means 50; at most 500.
+	Limit int
+}
+
+// DuplicatePair is two ingredients whose closest name or alias scored Score.
+// ATerm and BTerm are the terms that matched.
+type DuplicatePair struct {
+	A     uuid.UUID
+	B     uuid.UUID
+	ATerm string
+	BTerm string
+	Score float64
+}
+
+// MergeSuggestion is a ready-made MergeMany call for a duplicate cluster.
+type MergeSuggestion struct {
+	WinnerID uuid.UUID
+	LoserIDs []uuid.UUID
+}
+
+// DuplicateCluster is a suggested winner and the ingredients that each scored
+// against it as suspected duplicates. Pairs lists every scored pair within
+// the cluster and Score is the highest of them.
+type DuplicateCluster struct {
+	Ingredients []db.Ingredient
+	Pairs       []DuplicatePair
+	Score       float64
+	Suggestion  MergeSuggestion
+}
+
+// FindDuplicates compares the names and aliases of every active ingredient
+// using the resolver's scoring and returns the suspected duplicate clusters,
+// highest scoring first.
+func (s *Service) FindDuplicates(ctx context.Context, opts DuplicateOptions) ([]DuplicateCluster, error) {
+	if opts.MinScore == 0 {
+		opts.MinScore = s.threshold
+	}
+	if opts.MinScore <= 0 || opts.MinScore > 1 {
+		return nil, fmt.Errorf("%w: min_score must be in (0, 1]", ErrInvalidDuplicateOptions)
+	}
+	if opts.Limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", ErrInvalidDuplicateOptions)
+	}
+	if opts.Limit == 0 {
+		opts.Limit = defaultDuplicateLimit
+	}
+	opts.Limit = min(opts.Limit, maxDuplicateLimit)
+
+	all, err := s.q.ListIngredients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := make([]db.Ingredient, 0, len(all))
+	for _, ing := range all {
+		if !ing.ArchivedAt.Valid {
+			active = append(active, ing)
+		}
+	}
+	clusters := findDuplicates(active, opts.MinScore)
+	if len(clusters) > opts.Limit {
+		clusters = clusters[:opts.Limit]
+	}
+	return clusters, nil
+}
+
+// duplicateTerm is one name or alias of the ingredient at index ing.
+type duplicateTerm struct {
+	ing      int
+	text     string
+	trigrams map[string]struct{}
+}
+
+// findDuplicates scores every pair of ingredients that could reach minScore
+// and clusters the ingredients that do around suggested winners. Comparing
+// all terms pairwise is quadratic, so terms are blocked by shared trigrams
+// first: an edit touches at most three trigrams, so two terms within the
+// edit distance minScore allows must share all but 3×distance of them. Only
+// those candidates are scored. Terms that share no trigram at all are never
+// compared, which can only miss a pair when minScore is 2/3 or lower.
+func findDuplicates(ings []db.Ingredient, minScore float64) []DuplicateCluster {
+	var terms []duplicateTerm
+	index := make(map[string][]int)
+	for i, ing := range ings {
+		seen := make(map[string]bool)
+		for _, text := range append([]string{ing.Name}, ing.Aliases...) {
+			if text == "" || seen[text] {
+				continue
+			}
+			seen[text] = true
+			t := duplicateTerm{ing: i, text: text, trigrams: trigrams(text)}
+			for g := range t.trigrams {
+				index[g] = append(index[g], len(terms))
+			}
+			terms = append(terms, t)
+		}
+	}
+
+	best := make(map[[2]int]DuplicatePair)
+	for i, a := range terms {
+		shared := make(map[int]int)
+		for g := range a.trigrams {
+			for _, j := range index[g] {
+				if j > i && terms[j].ing != a.ing {
+					shared[j]++
+				}
+			}
+		}
+		for j, n := range shared {
+			b := terms[j]
+			maxLen := max(len([]rune(a.text)), len([]rune(b.text)))
+			maxDist := int(math.Floor((1-minScore)*float64(maxLen) + 1e-9))
+			if n < max(len(a.trigrams), len(b.trigrams))-3*maxDist {
+				continue
+			}
+			score := similarity(a.text, b.text)
+			if score < minScore {
+				continue
+			}
+			key := [2]int{min(a.ing, b.ing), max(a.ing, b.ing)}
+			if prev, ok := best[key]; ok && prev.Score >= score {
+				continue
+			}
+			pair := DuplicatePair{A: ings[a.ing].ID, B: ings[b.ing].ID, ATerm: a.text, BTerm: b.text, Score: score}
+			if a.ing > b.ing {
+				pair = DuplicatePair{A: pair.B, B: pair.A, ATerm: pair.BTerm, BTerm: pair.ATerm, Score: score}
+			}
+			best[key] = pair
+		}
+	}
+
+	// Carve clusters around winners. Only ingredients that themselves
+	// scored against the winner join its cluster, so a chain a~b~c never
+	// suggests merging a into c; whatever is left over is clustered again
+	// among itself.
+	links := make(map[int]map[int]float64)
+	for key, pair := range best {
+		for _, end := range []int{0, 1} {
+			i, j := key[end], key[1-end]
+			if links[i] == nil {
+				links[i] = make(map[int]float64)
+			}
+			links[i][j] = pair.Score
+		}
+	}
+	live := make(map[int]bool, len(links))
+	for i := range links {
+		live[i] = true
+	}
+
+	var clusters []DuplicateCluster
+	for {
+		winner, winnerWeight := -1, 0.0
+		for i := range live {
+			var w float64
+			for j, score := range links[i] {
+				if live[j] {
+					w += score
+				}
+			}
+			if w == 0 {
+				continue
+			}
+			if winner == -1 || w > winnerWeight ||
+				w == winnerWeight && (ings[i].CreatedAt.Before(ings[winner].CreatedAt) ||
+					ings[i].CreatedAt.Equal(ings[winner].CreatedAt) && i < winner) {
+				winner, winnerWeight = i, w
+			}
+		}
+		if winner == -1 {
+			break
+		}
+		idx := []int{winner}
+		for j := range links[winner] {
+			if live[j] {
+				idx = append(idx, j)
+			}
+		}
+		sort.Ints(idx)
+
+		c := DuplicateCluster{Suggestion: MergeSuggestion{WinnerID: ings[winner].ID}}
+		for n, i := range idx {
+			delete(live, i)
+			c.Ingredients = append(c.Ingredients, ings[i])
+			if i != winner {
+				c.Suggestion.LoserIDs = append(c.Suggestion.LoserIDs, ings[i].ID)
+			}
+			for _, j := range idx[n+1:] {
+				if pair, ok := best[[2]int{i, j}]; ok {
+					c.Pairs = append(c.Pairs, pair)
+					c.Score = max(c.Score, pair.Score)
+				}
+			}
+		}
+		sort.SliceStable(c.Pairs, func(i, j int) bool { return c.Pairs[i].Score > c.Pairs[j].Score })
+		clusters = append(clusters, c)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].Score != clusters[j].Score {
+			return clusters[i].Score > clusters[j].Score
+		}
+		return len(clusters[i].Ingredients) > len(clusters[j].Ingredients)
+	})
+	return clusters
+}
+
+// trigrams returns the set of three-rune windows of s padded with a space
+// on each side, so short terms still produce some.
+func trigrams(s string) map[string]struct{} {
+	r := []rune(" " + s + " ")
+	out := make(map[string]struct{}, len(r))
+	for i := 0; i+3 <= len(r); i++ {
+		out[string(r[i:i+3])] = struct{}{}
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	t.Parallel()
+
+	chili := newIngredient("chili", nil)
+	chile := newIngredient("chile", nil)
+	chilli := newIngredient("chilli", []string{"chilli pepper"})
+	scallion := newIngredient("scallion", []string{"green onion"})
+	greenOnions := newIngredient("green onions", nil)
+	salt := newIngredient("salt", nil)
+	chili.CreatedAt = time.Now().Add(-time.Hour)
+
+	clusters := findDuplicates([]db.Ingredient{chili, chile, chilli, scallion, greenOnions, salt}, 0.8)
+	require.Len(t, clusters, 2)
+
+	// "green onion" vs "green onions" scores 11/12, above the chili pairs.
+	assert.InDelta(t, 11.0/12, clusters[0].Score, 1e-9)
+	assert.ElementsMatch(t, []uuid.UUID{scallion.ID, greenOnions.ID}, ids(clusters[0].Ingredients))
+	require.Len(t, clusters[0].Pairs, 1)
+	assert.Equal(t, "green onion", termFor(clusters[0].Pairs[0], scallion.ID))
+
+	chilis := clusters[1]
+	assert.ElementsMatch(t, []uuid.UUID{chili.ID, chile.ID, chilli.ID}, ids(chilis.Ingredients))
+	assert.Equal(t, chili.ID, chilis.Suggestion.WinnerID)
+	assert.ElementsMatch(t, []uuid.UUID{chile.ID, chilli.ID}, chilis.Suggestion.LoserIDs)
+}
+
+func TestFindDuplicates_SplitsChains(t *testing.T) {
+	t.Parallel()
+
+	// Each name is one edit from the next and two from the one after, so
+	// only neighbours in the chain score above 0.85.
+	a := newIngredient("abcdefghij", nil)
+	b := newIngredient("xbcdefghij", nil)
+	c := newIngredient("xycdefghij", nil)
+	d := newIngredient("xyzdefghij", nil)
+	e := newIngredient("xyzwefghij", nil)
+	b.CreatedAt = time.Now().Add(-time.Hour)
+
+	clusters := findDuplicates([]db.Ingredient{a, b, c, d, e}, 0.85)
+	require.Len(t, clusters, 2)
+
+	// b wins the a~b~c end of the chain; a and c never scored against each
+	// other, but both scored against b.
+	first := clusters[0]
+	assert.Equal(t, b.ID, first.Suggestion.WinnerID)
+	assert.ElementsMatch(t, []uuid.UUID{a.ID, c.ID}, first.Suggestion.LoserIDs)
+	assert.Len(t, first.Pairs, 2)
+
+	// d scored only against c, which is already taken, so it forms its own
+	// cluster with e instead of being merged into b.
+	assert.ElementsMatch(t, []uuid.UUID{d.ID, e.ID}, ids(clusters[1].Ingredients))
+
+	for _, cluster := range clusters {
+		for _, loser := range cluster.Suggestion.LoserIDs {
+			assert.True(t, slices.ContainsFunc(cluster.Pairs, func(p DuplicatePair) bool {
+				return p.A == cluster.Suggestion.WinnerID && p.B == loser ||
+					p.B == cluster.Suggestion.WinnerID && p.A == loser
+			}), "loser %s did not score against the winner", loser)
+		}
+	}
+}
+
+func TestFindDuplicates_ExactAliasClash(t *testing.T) {
+	t.Parallel()
+
+	a := newIngredient("cilantro", []string{"coriander"})
+	b := newIngredient("coriander", nil)
+
+	clusters := findDuplicates([]db.Ingredient{a, b}, 0.95)
+	require.Len(t, clusters, 1)
+	assert.Equal(t, 1.0, clusters[0].Score)
+}
+
+func TestFindDuplicates_Service(t *testing.T) {
+	t.Parallel()
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	chili := newIngredient("chili", nil)
+	oldChile := archived(newIngredient("chile", nil))
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{chili, oldChile}, nil)
+
+	clusters, err := svc.FindDuplicates(context.Background(), DuplicateOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, clusters)
+}
+
+func TestFindDuplicates_InvalidOptions(t *testing.T) {
+	t.Parallel()
+	svc := New(mocks.NewMockQuerier(t), nil, 0.8)
+
+	for _, opts := range []DuplicateOptions{{MinScore: 1.5}, {MinScore: -0.1}, {Limit: -1}} {
+		_, err := svc.FindDuplicates(context.Background(), opts)
+		assert.ErrorIs(t, err, ErrInvalidDuplicateOptions)
+	}
+}
+
+func ids(ings []db.Ingredient) []uuid.UUID {
+	out := make([]uuid.UUID, len(ings))
+	for i, ing := range ings {
+		out[i] = ing.ID
+	}
+	return out
+}
+
+func termFor(p DuplicatePair, id uuid.UUID) string {
+	if p.A == id {
+		return p.ATerm
+	}
+	return p.BTerm
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// maxHierarchyDepth bounds descendant walks and matches the guard in the
+// recursive hierarchy queries.
+const maxHierarchyDepth = 64
+
+var (
+	// ErrHierarchyCycle is returned when a parent change would make an
+	// ingredient its own ancestor.
+	ErrHierarchyCycle = errors.New("parent would create a cycle")
+	// ErrParentNotFound is returned when the requested parent does not exist.
+	ErrParentNotFound = errors.New("parent ingredient not found")
+)
+
+// RelatedIngredient is an ingredient found by walking the hierarchy. Depth is
+// the number of levels between it and the starting ingredient.
+type RelatedIngredient struct {
+	Ingredient db.Ingredient
+	Depth      int
+}
+
+// SetParent makes parentID the parent of ingredient id, or detaches it when
+// parentID is not valid. It returns sql.ErrNoRows if the ingredient does not
+// exist, ErrParentNotFound for an unknown parent and ErrHierarchyCycle if the
+// parent is the ingredient itself or one of its descendants.
+func (s *Service) SetParent(ctx context.Context, id uuid.UUID, parentID uuid.NullUUID) (db.Ingredient, error) {
+	if parentID.Valid && parentID.UUID == id {
+		return db.Ingredient{}, ErrHierarchyCycle
+	}
+
+	tx, err := s.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return db.Ingredient{}, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	qtx := db.New(tx)
+	if err := setChange(ctx, qtx, ChangeUpdate); err != nil {
+		return db.Ingredient{}, err
+	}
+	if err := qtx.LockIngredientHierarchy(ctx); err != nil {
+		return db.Ingredient{}, err
+	}
+	if _, err := qtx.GetIngredient(ctx, id); err != nil {
+		return db.Ingredient{}, err
+	}
+	if parentID.Valid {
+		if _, err := qtx.GetIngredient(ctx, parentID.UUID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return db.Ingredient{}, ErrParentNotFound
+			}
+			return db.Ingredient{}, err
+		}
+		ancestors, err := qtx.ListIngredientAncestors(ctx, parentID.UUID)
+		if err != nil {
+			return db.Ingredient{}, err
+		}
+		if hasAncestor(ancestors, id) {
+			return db.Ingredient{}, ErrHierarchyCycle
+		}
+	}
+
+	ing, err := qtx.SetIngredientParent(ctx, db.SetIngredientParentParams{ID: id, ParentID: parentID})
+	if err != nil {
+		return db.Ingredient{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return db.Ingredient{}, err
+	}
+	return ing, nil
+}
+
+// Ancestors returns an ingredient's ancestors, nearest first. It returns
+// sql.ErrNoRows if the ingredient does not exist.
+func (s *Service) Ancestors(ctx context.Context, id uuid.UUID) ([]RelatedIngredient, error) {
+	if _, err := s.q.GetIngredient(ctx, id); err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListIngredientAncestors(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]RelatedIngredient, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, RelatedIngredient{Ingredient: r.Ingredient, Depth: int(r.Depth)})
+	}
+	return result, nil
+}
+
+// Descendants returns an ingredient's descendants up to maxDepth levels
+// below it, ordered by depth then name. A maxDepth of 0 walks the whole
+// subtree. It returns sql.ErrNoRows if the ingredient does not exist.
+func (s *Service) Descendants(ctx context.Context, id uuid.UUID, maxDepth int) ([]RelatedIngredient, error) {
+	if maxDepth <= 0 || maxDepth > maxHierarchyDepth {
+		maxDepth = maxHierarchyDepth
+	}
+	if _, err := s.q.GetIngredient(ctx, id); err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListIngredientDescendants(ctx, db.ListIngredientDescendantsParams{
+		ID:       id,
+		MaxDepth: int32(maxDepth),
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]RelatedIngredient, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, RelatedIngredient{Ingredient: r.Ingredient, Depth: int(r.Depth)})
+	}
+	return result, nil
+}
+
+// RollUp returns the ancestor of ing that sits depth levels below the root of
+// its tree, so that with depth 0 every ingredient rolls up to its root
+// ("sharp cheddar" → "cheese"). Ingredients at or above depth are returned
+// unchanged.
+func (s *Service) RollUp(ctx context.Context, ing db.Ingredient, depth int) (db.Ingredient, error) {
+	if !ing.ParentID.Valid {
+		return ing, nil
+	}
+	ancestors, err := s.q.ListIngredientAncestors(ctx, ing.ID)
+	if err != nil {
+		return db.Ingredient{}, err
+	}
+	return rollUp(ing, ancestors, depth), nil
+}
+
+// rollUp picks the roll-up target from ancestors, which are ordered nearest
+// first.
+func rollUp(ing db.Ingredient, ancestors []db.ListIngredientAncestorsRow, depth int) db.Ingredient {
+	// ing sits len(ancestors) levels below the root.
+	levels := len(ancestors) - depth
+	if levels <= 0 {
+		return ing
+	}
+	return ancestors[levels-1].Ingredient
+}
+
+// reparentChildren moves loser's children under winner as part of a merge.
+// Children that are winner itself or one of its ancestors take loser's parent
+// instead, since putting them under winner would create a cycle. It returns
+// the parent changes it made.
+func reparentChildren(ctx context.Context, q db.Querier, winner, loser db.Ingredient) ([]db.SetIngredientParentParams, error) {
+	if err := q.LockIngredientHierarchy(ctx); err != nil {
+		return nil, err
+	}
+	children, err := q.ListIngredientChildren(ctx, loser.ID)
+	if err != nil || len(children) == 0 {
+		return nil, err
+	}
+	ancestors, err := q.ListIngredientAncestors(ctx, winner.ID)
+	if err != nil {
+		return nil, err
+	}
+	moves := make([]db.SetIngredientParentParams, 0, len(children))
+	for _, child := range children {
+		move := db.SetIngredientParentParams{ID: child.ID, ParentID: uuid.NullUUID{UUID: winner.ID, Valid: true}}
+		if child.ID == winner.ID || hasAncestor(ancestors, child.ID) {
+			move.ParentID = loser.ParentID
+		}
+		if _, err := q.SetIngredientParent(ctx, move); err != nil {
+			return nil, err
+		}
+		moves = append(moves, move)
+	}
+	return moves, nil
+}
+
+func hasAncestor(ancestors []db.ListIngredientAncestorsRow, id uuid.UUID) bool {
+	for _, a := range ancestors {
+		if a.Ingredient.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHierarchy_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	create := func(name string) db.Ingredient {
+		ing, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: name, Aliases: []string{}})
+		require.NoError(t, err)
+		return ing
+	}
+	parent := func(ing db.Ingredient) uuid.NullUUID {
+		return uuid.NullUUID{UUID: ing.ID, Valid: true}
+	}
+
+	cheese := create("cheese")
+	cheddar := create("cheddar")
+	sharp := create("sharp cheddar")
+
+	_, err := svc.SetParent(ctx, cheddar.ID, parent(cheese))
+	require.NoError(t, err)
+	sharp, err = svc.SetParent(ctx, sharp.ID, parent(cheddar))
+	require.NoError(t, err)
+
+	ancestors, err := svc.Ancestors(ctx, sharp.ID)
+	require.NoError(t, err)
+	require.Len(t, ancestors, 2)
+	assert.Equal(t, "cheddar", ancestors[0].Ingredient.Name)
+	assert.Equal(t, "cheese", ancestors[1].Ingredient.Name)
+	assert.Equal(t, 2, ancestors[1].Depth)
+
+	descendants, err := svc.Descendants(ctx, cheese.ID, 1)
+	require.NoError(t, err)
+	require.Len(t, descendants, 1)
+	assert.Equal(t, "cheddar", descendants[0].Ingredient.Name)
+
+	rolled, err := svc.RollUp(ctx, sharp, 0)
+	require.NoError(t, err)
+	assert.Equal(t, cheese.ID, rolled.ID)
+
+	// cheese under sharp cheddar would close the loop.
+	_, err = svc.SetParent(ctx, cheese.ID, parent(sharp))
+	assert.ErrorIs(t, err, ErrHierarchyCycle)
+
+	_, err = svc.SetParent(ctx, cheese.ID, uuid.NullUUID{UUID: uuid.New(), Valid: true})
+	assert.ErrorIs(t, err, ErrParentNotFound)
+}
+
+func TestMerge_ReparentsChildren(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	create := func(name string, parentID uuid.NullUUID) db.Ingredient {
+		ing, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: name, Aliases: []string{}})
+		require.NoError(t, err)
+		if parentID.Valid {
+			ing, err = q.SetIngredientParent(ctx, db.SetIngredientParentParams{ID: ing.ID, ParentID: parentID})
+			require.NoError(t, err)
+		}
+		return ing
+	}
+
+	dairy := create("dairy", uuid.NullUUID{})
+	loser := create("cheeses", uuid.NullUUID{UUID: dairy.ID, Valid: true})
+	cheddar := create("cheddar", uuid.NullUUID{UUID: loser.ID, Valid: true})
+	winner := create("cheese", uuid.NullUUID{UUID: loser.ID, Valid: true})
+
+	result, err := svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NullUUID{UUID: dairy.ID, Valid: true}, result.Ingredient.ParentID)
+
+	cheddar, err = q.GetIngredient(ctx, cheddar.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NullUUID{UUID: winner.ID, Valid: true}, cheddar.ParentID)
+}
+
+func TestDietaryInheritance_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	cheese, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "cheese", Aliases: []string{}})
+	require.NoError(t, err)
+	blue, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "blue cheese", Aliases: []string{}})
+	require.NoError(t, err)
+	_, err = svc.SetParent(ctx, blue.ID, uuid.NullUUID{UUID: cheese.ID, Valid: true})
+	require.NoError(t, err)
+
+	_, _, err = svc.SetDietaryAttributes(ctx, cheese.ID, DietaryAttributes{
+		Allergens:   []string{"milk"},
+		DietaryTags: []string{"vegetarian"},
+	})
+	require.NoError(t, err)
+
+	own, effective, err := svc.DietaryAttributes(ctx, blue.ID)
+	require.NoError(t, err)
+	assert.Empty(t, own.Allergens)
+	assert.Equal(t, []string{"milk"}, effective.Allergens)
+	assert.Equal(t, []string{"vegetarian"}, effective.DietaryTags)
+
+	milkFree, err := svc.ListIngredients(ctx, IngredientFilter{FreeOf: []string{"milk"}})
+	require.NoError(t, err)
+	assert.Empty(t, milkFree)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func withParent(ing, parent db.Ingredient) db.Ingredient {
+	ing.ParentID = uuid.NullUUID{UUID: parent.ID, Valid: true}
+	return ing
+}
+
+func TestRollUp(t *testing.T) {
+	t.Parallel()
+
+	cheese := newIngredient("cheese", []string{})
+	cheddar := withParent(newIngredient("cheddar", []string{}), cheese)
+	sharp := withParent(newIngredient("sharp cheddar", []string{}), cheddar)
+	ancestors := []db.ListIngredientAncestorsRow{
+		{Ingredient: cheddar, Depth: 1},
+		{Ingredient: cheese, Depth: 2},
+	}
+
+	tests := []struct {
+		name  string
+		depth int
+		want  string
+	}{
+		{name: "depth 0 rolls up to the root", depth: 0, want: "cheese"},
+		{name: "depth 1 stops one level below the root", depth: 1, want: "cheddar"},
+		{name: "depth at the ingredient keeps it", depth: 2, want: "sharp cheddar"},
+		{name: "depth below the ingredient keeps it", depth: 5, want: "sharp cheddar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, rollUp(sharp, ancestors, tt.depth).Name)
+		})
+	}
+}
+
+func TestRollUp_RootSkipsLookup(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	cheese := newIngredient("cheese", []string{})
+	got, err := svc.RollUp(context.Background(), cheese, 0)
+	require.NoError(t, err)
+	assert.Equal(t, cheese, got)
+}
+
+func TestSetParent_Self(t *testing.T) {
+	t.Parallel()
+
+	svc := New(mocks.NewMockQuerier(t), nil, 0.8)
+	id := uuid.New()
+
+	_, err := svc.SetParent(context.Background(), id, uuid.NullUUID{UUID: id, Valid: true})
+	assert.ErrorIs(t, err, ErrHierarchyCycle)
+}
+
+func TestDescendants(t *testing.T) {
+	t.Parallel()
+
+	cheese := newIngredient("cheese", []string{})
+	cheddar := withParent(newIngredient("cheddar", []string{}), cheese)
+
+	t.Run("defaults to the full subtree", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		svc := New(mockQ, nil, 0.8)
+
+		mockQ.EXPECT().GetIngredient(mock.Anything, cheese.ID).Return(cheese, nil)
+		mockQ.EXPECT().ListIngredientDescendants(mock.Anything, db.ListIngredientDescendantsParams{
+			ID:       cheese.ID,
+			MaxDepth: maxHierarchyDepth,
+		}).Return([]db.ListIngredientDescendantsRow{{Ingredient: cheddar, Depth: 1}}, nil)
+
+		got, err := svc.Descendants(context.Background(), cheese.ID, 0)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "cheddar", got[0].Ingredient.Name)
+		assert.Equal(t, 1, got[0].Depth)
+	})
+
+	t.Run("missing ingredient", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		svc := New(mockQ, nil, 0.8)
+
+		mockQ.EXPECT().GetIngredient(mock.Anything, cheese.ID).Return(db.Ingredient{}, sql.ErrNoRows)
+
+		_, err := svc.Descendants(context.Background(), cheese.ID, 2)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestReparentChildren(t *testing.T) {
+	t.Parallel()
+
+	// dairy → cheese (loser) → {cheddar, fromage (winner)}
+	dairy := newIngredient("dairy", []string{})
+	loser := withParent(newIngredient("cheese", []string{}), dairy)
+	cheddar := withParent(newIngredient("cheddar", []string{}), loser)
+	winner := withParent(newIngredient("fromage", []string{}), loser)
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().LockIngredientHierarchy(mock.Anything).Return(nil)
+	mockQ.EXPECT().ListIngredientChildren(mock.Anything, loser.ID).Return([]db.Ingredient{cheddar, winner}, nil)
+	mockQ.EXPECT().ListIngredientAncestors(mock.Anything, winner.ID).Return([]db.ListIngredientAncestorsRow{
+		{Ingredient: loser, Depth: 1},
+		{Ingredient: dairy, Depth: 2},
+	}, nil)
+	mockQ.EXPECT().SetIngredientParent(mock.Anything, db.SetIngredientParentParams{
+		ID:       cheddar.ID,
+		ParentID: uuid.NullUUID{UUID: winner.ID, Valid: true},
+	}).Return(db.Ingredient{}, nil)
+	// The winner was the loser's child, so it takes the loser's parent.
+	mockQ.EXPECT().SetIngredientParent(mock.Anything, db.SetIngredientParentParams{
+		ID:       winner.ID,
+		ParentID: uuid.NullUUID{UUID: dairy.ID, Valid: true},
+	}).Return(db.Ingredient{}, nil)
+
+	moves, err := reparentChildren(context.Background(), mockQ, winner, loser)
+	require.NoError(t, err)
+	assert.Len(t, moves, 2)
+}
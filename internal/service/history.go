@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// ChangeOperation labels an entry in an ingredient's history.
+type ChangeOperation string
+
+const (
+	ChangeCreate     ChangeOperation = "create"
+	ChangeAutoCreate ChangeOperation = "auto_create"
+	ChangeUpdate     ChangeOperation = "update"
+	ChangeRename     ChangeOperation = "rename"
+	ChangeMerge      ChangeOperation = "merge"
+	ChangeSplit      ChangeOperation = "split"
+	ChangeDelete     ChangeOperation = "delete"
+	// ChangeBaseline is the state of an ingredient when history recording
+	// began.
+	ChangeBaseline ChangeOperation = "baseline"
+)
+
+// HistoryEntry is one version of an ingredient: the row as the change left
+// it or, when Deleted, as it was when removed.
+type HistoryEntry struct {
+	Version    int64
+	Operation  ChangeOperation
+	Actor      sql.NullString
+	Reason     sql.NullString
+	Deleted    bool
+	RecordedAt time.Time
+	Ingredient db.Ingredient
+}
+
+// IngredientHistory returns every recorded version of an ingredient, newest
+// first. It works for merged-away and deleted ingredients too, and returns
+// sql.ErrNoRows if nothing was ever recorded for id.
+func (s *Service) IngredientHistory(ctx context.Context, id uuid.UUID) ([]HistoryEntry, error) {
+	rows, err := s.q.ListIngredientHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	entries := make([]HistoryEntry, 0, len(rows))
+	for _, row := range rows {
+		entry, err := historyEntry(row)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// GetIngredientAsOf returns an ingredient as it was at asOf. It returns
+// sql.ErrNoRows if the ingredient did not exist then.
+func (s *Service) GetIngredientAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (db.Ingredient, error) {
+	row, err := s.q.GetIngredientHistoryAsOf(ctx, db.GetIngredientHistoryAsOfParams{IngredientID: id, AsOf: asOf})
+	if err != nil {
+		return db.Ingredient{}, err
+	}
+	if row.Deleted {
+		return db.Ingredient{}, sql.ErrNoRows
+	}
+	return decodeSnapshot(row.Snapshot)
+}
+
+// listIngredientsAsOf returns every ingredient that existed at asOf, as it
+// was then, ordered by name.
+func (s *Service) listIngredientsAsOf(ctx context.Context, asOf time.Time) ([]db.Ingredient, error) {
+	rows, err := s.q.ListIngredientHistoryAsOf(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+	var all []db.Ingredient
+	for _, row := range rows {
+		if row.Deleted {
+			continue
+		}
+		ing, err := decodeSnapshot(row.Snapshot)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, ing)
+	}
+	slices.SortFunc(all, func(a, b db.Ingredient) int { return strings.Compare(a.Name, b.Name) })
+	return all, nil
+}
+
+// setChange labels the ingredient writes of the transaction q belongs to
+// with op and the actor and reason carried by ctx.
+func setChange(ctx context.Context, q db.Querier, op ChangeOperation) error {
+	return q.SetIngredientChange(ctx, db.SetIngredientChangeParams{
+		Operation: string(op),
+		Actor:     actorFrom(ctx),
+		Reason:    reasonFrom(ctx),
+	})
+}
+
+func historyEntry(row db.IngredientHistory) (HistoryEntry, error) {
+	ing, err := decodeSnapshot(row.Snapshot)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	return HistoryEntry{
+		Version:    row.Version,
+		Operation:  ChangeOperation(row.Operation),
+		Actor:      row.Actor,
+		Reason:     row.Reason,
+		Deleted:    row.Deleted,
+		RecordedAt: row.RecordedAt,
+		Ingredient: ing,
+	}, nil
+}
+
+// ingredientSnapshot is an ingredients row as to_jsonb renders it.
+type ingredientSnapshot struct {
+	ID          uuid.UUID     `json:"id"`
+	Name        string        `json:"name"`
+	Aliases     []string      `json:"aliases"`
+	DefaultUnit *string       `json:"default_unit"`
+	CreatedAt   time.Time     `json:"created_at"`
+	ParentID    uuid.NullUUID `json:"parent_id"`
+	CategoryID  uuid.NullUUID `json:"category_id"`
+	Allergens   []string      `json:"allergens"`
+	DietaryTags []string      `json:"dietary_tags"`
+	ArchivedAt  *time.Time    `json:"archived_at"`
+	Version     int64         `json:"version"`
+	FreeOf      []string      `json:"free_of"`
+}
+
+func decodeSnapshot(raw json.RawMessage) (db.Ingredient, error) {
+	var snap ingredientSnapshot
+	if err := json.Unmarshal(raw, &snap); err != nil {
+		return db.Ingredient{}, fmt.Errorf("decode ingredient snapshot: %w", err)
+	}
+	ing := db.Ingredient{
+		ID:          snap.ID,
+		Name:        snap.Name,
+		Aliases:     snap.Aliases,
+		CreatedAt:   snap.CreatedAt,
+		ParentID:    snap.ParentID,
+		CategoryID:  snap.CategoryID,
+		Allergens:   snap.Allergens,
+		DietaryTags: snap.DietaryTags,
+		Version:     snap.Version,
+		FreeOf:      snap.FreeOf,
+	}
+	if snap.DefaultUnit != nil {
+		ing.DefaultUnit = sql.NullString{String: *snap.DefaultUnit, Valid: true}
+	}
+	if snap.ArchivedAt != nil {
+		ing.ArchivedAt = sql.NullTime{Time: *snap.ArchivedAt, Valid: true}
+	}
+	return ing, nil
+}
@@ -0,0 +1,107 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngredientHistory_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	bg := context.Background()
+
+	ing, err := svc.CreateIngredient(WithReason(WithActor(bg, "curator"), "initial load"), IngredientInput{
+		Name:    "garlic",
+		Aliases: []string{"clove"},
+	})
+	require.NoError(t, err)
+	_, err = svc.UpdateIngredient(WithActor(bg, "editor"), ing.ID, IngredientInput{Aliases: []string{}})
+	require.NoError(t, err)
+	resolved, err := svc.Resolve(WithActor(bg, "recipes"), "garlic bulb", ResolveOptions{})
+	require.NoError(t, err)
+	require.True(t, resolved.Created)
+	_, err = svc.Merge(WithReason(bg, "duplicate"), ing.ID, resolved.Ingredient.ID, MergeOptions{})
+	require.NoError(t, err)
+
+	history, err := svc.IngredientHistory(bg, ing.ID)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(history), 3)
+	assert.Equal(t, ChangeMerge, history[0].Operation)
+	assert.Equal(t, "duplicate", history[0].Reason.String)
+	update, create := history[len(history)-2], history[len(history)-1]
+	assert.Equal(t, ChangeUpdate, update.Operation)
+	assert.Equal(t, "editor", update.Actor.String)
+	assert.False(t, update.Reason.Valid)
+	assert.Equal(t, ChangeCreate, create.Operation)
+	assert.Equal(t, "curator", create.Actor.String)
+	assert.Equal(t, "initial load", create.Reason.String)
+	assert.Equal(t, int64(1), create.Version)
+
+	// The merged-away ingredient keeps its history, ending in its deletion.
+	loserHistory, err := svc.IngredientHistory(bg, resolved.Ingredient.ID)
+	require.NoError(t, err)
+	require.Len(t, loserHistory, 2)
+	assert.Equal(t, ChangeMerge, loserHistory[0].Operation)
+	assert.True(t, loserHistory[0].Deleted)
+	assert.Equal(t, ChangeAutoCreate, loserHistory[1].Operation)
+	assert.Equal(t, "recipes", loserHistory[1].Actor.String)
+
+	// Point-in-time reads see the ingredient as it was.
+	then, err := svc.GetIngredientAsOf(bg, ing.ID, create.RecordedAt)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"clove"}, then.Aliases)
+	_, err = svc.GetIngredientAsOf(bg, resolved.Ingredient.ID, create.RecordedAt)
+	assert.Error(t, err)
+	_, err = svc.GetIngredientAsOf(bg, resolved.Ingredient.ID, loserHistory[0].RecordedAt)
+	assert.Error(t, err)
+
+	all, err := svc.ListIngredients(bg, IngredientFilter{AsOf: loserHistory[1].RecordedAt})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "garlic", all[0].Name)
+	assert.Equal(t, "garlic bulb", all[1].Name)
+}
+
+func TestIngredientHistory_RecordsActorOnEveryWrite_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := WithReason(WithActor(context.Background(), "curator"), "tidy up")
+
+	dairy, err := svc.CreateIngredient(ctx, IngredientInput{Name: "dairy"})
+	require.NoError(t, err)
+	milk, err := svc.CreateIngredient(ctx, IngredientInput{Name: "milk"})
+	require.NoError(t, err)
+
+	_, err = svc.SetParent(ctx, milk.ID, uuid.NullUUID{UUID: dairy.ID, Valid: true})
+	require.NoError(t, err)
+	_, _, err = svc.SetDietaryAttributes(ctx, milk.ID, DietaryAttributes{Allergens: []string{"milk"}})
+	require.NoError(t, err)
+	_, err = svc.ArchiveIngredient(ctx, milk.ID)
+	require.NoError(t, err)
+	_, err = svc.RestoreIngredient(ctx, milk.ID)
+	require.NoError(t, err)
+	_, err = svc.ArchiveIngredient(ctx, milk.ID)
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteIngredient(ctx, milk.ID))
+
+	history, err := svc.IngredientHistory(context.Background(), milk.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 7)
+	assert.True(t, history[0].Deleted)
+	assert.Equal(t, ChangeDelete, history[0].Operation)
+	for _, entry := range history {
+		assert.Equal(t, "curator", entry.Actor.String, "version %d", entry.Version)
+		assert.Equal(t, "tidy up", entry.Reason.String, "version %d", entry.Version)
+	}
+
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// snapshotJSON renders name the way to_jsonb renders an ingredients row.
+func snapshotJSON(id uuid.UUID, name string, version int64) json.RawMessage {
+	return json.RawMessage(`{"id": "` + id.String() + `", "name": "` + name + `", "aliases": ["clove"],
+		"default_unit": null, "created_at": "2024-03-01T10:00:00.123456+00:00", "parent_id": null,
+		"category_id": null, "allergens": [], "dietary_tags": ["vegan"],
+		"archived_at": "2024-04-01T00:00:00+00:00", "version": ` + strconv.FormatInt(version, 10) + `}`)
+}
+
+func TestDecodeSnapshot(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	ing, err := decodeSnapshot(snapshotJSON(id, "garlic", 3))
+	require.NoError(t, err)
+	assert.Equal(t, id, ing.ID)
+	assert.Equal(t, "garlic", ing.Name)
+	assert.Equal(t, []string{"clove"}, ing.Aliases)
+	assert.False(t, ing.DefaultUnit.Valid)
+	assert.False(t, ing.CategoryID.Valid)
+	assert.True(t, ing.ArchivedAt.Valid)
+	assert.Equal(t, int64(3), ing.Version)
+	assert.Equal(t, 2024, ing.CreatedAt.Year())
+
+	_, err = decodeSnapshot(json.RawMessage(`{"id": 7}`))
+	assert.Error(t, err)
+}
+
+func TestIngredientHistory(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+
+	t.Run("entries", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		mockQ.EXPECT().ListIngredientHistory(mock.Anything, id).Return([]db.IngredientHistory{
+			{IngredientID: id, Version: 2, Operation: "update", Actor: nullString("curator"), Snapshot: snapshotJSON(id, "garlic", 2)},
+			{IngredientID: id, Version: 1, Operation: "auto_create", Snapshot: snapshotJSON(id, "garlic", 1)},
+		}, nil)
+
+		entries, err := New(mockQ, nil, 0.8).IngredientHistory(context.Background(), id)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, ChangeUpdate, entries[0].Operation)
+		assert.Equal(t, "curator", entries[0].Actor.String)
+		assert.Equal(t, ChangeAutoCreate, entries[1].Operation)
+		assert.Equal(t, int64(1), entries[1].Ingredient.Version)
+	})
+
+	t.Run("never recorded", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		mockQ.EXPECT().ListIngredientHistory(mock.Anything, id).Return(nil, nil)
+
+		_, err := New(mockQ, nil, 0.8).IngredientHistory(context.Background(), id)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestGetIngredientAsOf_Deleted(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	id, asOf := uuid.New(), time.Now()
+	mockQ.EXPECT().GetIngredientHistoryAsOf(mock.Anything, db.GetIngredientHistoryAsOfParams{IngredientID: id, AsOf: asOf}).
+		Return(db.IngredientHistory{IngredientID: id, Deleted: true, Operation: "merge", Snapshot: snapshotJSON(id, "garlic", 2)}, nil)
+
+	_, err := New(mockQ, nil, 0.8).GetIngredientAsOf(context.Background(), id, asOf)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListIngredients_AsOf(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	asOf := time.Now().Add(-time.Hour)
+	shallot, garlic, gone := uuid.New(), uuid.New(), uuid.New()
+	mockQ.EXPECT().ListIngredientHistoryAsOf(mock.Anything, asOf).Return([]db.IngredientHistory{
+		{IngredientID: shallot, Snapshot: snapshotJSON(shallot, "shallot", 1)},
+		{IngredientID: gone, Deleted: true, Snapshot: snapshotJSON(gone, "aaa", 2)},
+		{IngredientID: garlic, Snapshot: snapshotJSON(garlic, "garlic", 1)},
+	}, nil)
+
+	got, err := New(mockQ, nil, 0.8).ListIngredients(context.Background(), IngredientFilter{AsOf: asOf, IncludeArchived: true})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "garlic", got[0].Name)
+	assert.Equal(t, "shallot", got[1].Name)
+}
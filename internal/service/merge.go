@@ -2,78 +2,788 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
 )
 
+// ConversionPolicy decides what Merge does when winner and loser both define
+// the same from/to conversion with different factors.
+type ConversionPolicy string
+
+const (
+	ConversionPolicyKeepWinner ConversionPolicy = "keep_winner"
+	ConversionPolicyKeepLoser  ConversionPolicy = "keep_loser"
+	ConversionPolicyAverage    ConversionPolicy = "average"
+	ConversionPolicyFail       ConversionPolicy = "fail"
+)
+
+// ErrConversionConflict is returned by Merge under ConversionPolicyFail when
+// winner and loser disagree on a conversion factor.
+var ErrConversionConflict = errors.New("conflicting unit conversions")
+
+// ErrComponentUnitMismatch is returned by Merge when a composite lists both
+// ingredients in units that cannot be converted into each other.
+var ErrComponentUnitMismatch = errors.New("composite component units cannot be converted")
+
+// ErrInvalidConversionPolicy is returned for an unrecognised ConversionPolicy.
+var ErrInvalidConversionPolicy = errors.New("invalid conversion policy")
+
+// ErrInvalidMerge is returned when the losers are missing, repeated, too
+// many or include the winner.
+var ErrInvalidMerge = errors.New("invalid merge")
+
+// maxMergeLosers caps how many ingredients one merge can fold into a winner.
+const maxMergeLosers = 50
+
+// MergeOptions controls how Merge reconciles the two ingredients.
+type MergeOptions struct {
+	// ConversionPolicy defaults to ConversionPolicyKeepWinner.
+	ConversionPolicy ConversionPolicy
+	// Fields holds a rule per attribute ("category", "default_unit"),
+	// deciding whether the winner keeps its value or takes the loser's.
+	// Attributes without a rule use FieldPreferNonNull.
+	Fields map[string]FieldRule
+	// DryRun runs the merge, fills in MergeResult.Preview and rolls back.
+	DryRun bool
+	// WinnerVersion, if set, is the version the winner is expected to be
+	// at; the merge fails with ErrVersionMismatch otherwise.
+	WinnerVersion int64
+	// LoserVersion is the same for the loser, and needs a single loser.
+	LoserVersion int64
+}
+
+// MergeResult is returned by Merge and MergeMany. MergeIDs holds one history
+// record per loser, in LoserIDs order, which Split uses to undo that loser's
+// merge; it is empty for a dry run. Ingredient is the winner as the merge
+// leaves it. DroppedConversions lists the rows deleted while reconciling
+// conversions that both ingredients defined. DroppedComponents lists loser
+// component rows folded into the winner's row in composites that listed
+// both; their quantity was converted into the winner's unit and added to it.
+// DroppedComposites lists composites the fold left with fewer than two
+// components, which the merge deletes.
+type MergeResult struct {
+	LoserIDs           []uuid.UUID
+	MergeIDs           []uuid.UUID
+	Ingredient         db.Ingredient
+	ConversionPolicy   ConversionPolicy
+	DroppedConversions []db.UnitConversion
+	DroppedComponents  []db.CompositeSubstituteComponent
+	DroppedComposites  []db.CompositeSubstitute
+	ConversionIssues   []ConversionIssue
+	DryRun             bool
+	Preview            *MergePreview
+}
+
+// MergePreview describes what a dry-run merge would change. Counts are
+// summed over all losers.
+type MergePreview struct {
+	// Aliases is the winner's alias list after the merge.
+	Aliases []string
+	// Differences lists fields where the loser disagrees with the winner.
+	Differences         []MergeDifference
+	ChildrenMoved       int
+	SubstitutesMoved    int
+	SubstitutesDropped  int
+	CompositesMoved     int
+	CompositesDropped   int
+	ConversionsMoved    int
+	ConversionsUpdated  int
+	ConversionConflicts []ConversionConflict
+	NutritionMoved      bool
+	StorageMoved        int
+	StorageDropped      int
+	RedirectsMoved      int
+}
+
+// MergeDifference is a field on which winner and loser disagree, and the
+// value the merge settled on. An empty value means the field is unset.
+type MergeDifference struct {
+	LoserID uuid.UUID
+	Field   string
+	Winner  string
+	Loser   string
+	Result  string
+}
+
+// ConversionConflict is a from/to pair both ingredients define with
+// different factors.
+type ConversionConflict struct {
+	LoserID      uuid.UUID
+	FromUnit     string
+	ToUnit       string
+	WinnerFactor float64
+	LoserFactor  float64
+}
+
 // Merge combines loser into winner. The loser's name and aliases are appended
-// to winner's aliases (deduplicated). All foreign key references in
-// ingredient_substitutes and unit_conversions are re-pointed to winner, then
-// the loser row is deleted (cascading any remaining FKs).
-func (s *Service) Merge(ctx context.Context, winnerID, loserID uuid.UUID) (db.Ingredient, error) {
+// to winner's aliases (deduplicated), and attributes such as category and
+// default unit are reconciled according to opts.Fields. All foreign key
+// references in ingredient_substitutes, composite substitutes and
+// unit_conversions are re-pointed to winner, loser's children are
+// re-parented under winner and loser's nutrition data moves over if winner
+// has none, then the loser row is deleted (cascading any remaining FKs). A redirect from the loser's ID to
+// winner is recorded, and earlier redirects to loser now point at winner.
+// The merge is recorded in the merge history with a snapshot of everything
+// it changed, so Split can undo it.
+//
+// Before conversions are re-pointed, any from/to pair defined by both
+// ingredients is reconciled according to opts.ConversionPolicy so the winner
+// ends up with a single row per pair. The winner's combined conversions are
+// then checked for remaining contradictions, which are reported in the
+// result but do not block the merge.
+//
+// With opts.DryRun the whole merge runs in its transaction, which is then
+// rolled back; the result describes the outcome and nothing is recorded.
+//
+// An alias held by a third ingredient, which can only be the loser's name,
+// fails the merge with an *AliasConflictError.
+func (s *Service) Merge(ctx context.Context, winnerID, loserID uuid.UUID, opts MergeOptions) (MergeResult, error) {
+	return s.MergeMany(ctx, winnerID, []uuid.UUID{loserID}, opts)
+}
+
+// MergeMany merges each of loserIDs into winner, in order, in a single
+// transaction: either every loser is merged or none is. Each loser is
+// handled as Merge describes and gets its own history record, so Split can
+// undo them one at a time. The conversion check runs once, at the end.
+func (s *Service) MergeMany(ctx context.Context, winnerID uuid.UUID, loserIDs []uuid.UUID, opts MergeOptions) (MergeResult, error) {
+	policy := opts.ConversionPolicy
+	if policy == "" {
+		policy = ConversionPolicyKeepWinner
+	}
+	if !policy.valid() {
+		return MergeResult{}, fmt.Errorf("%w: %q", ErrInvalidConversionPolicy, policy)
+	}
+	if err := validateMergeLosers(winnerID, loserIDs); err != nil {
+		return MergeResult{}, err
+	}
+	if opts.LoserVersion != 0 && len(loserIDs) != 1 {
+		return MergeResult{}, fmt.Errorf("%w: a loser version needs a single loser", ErrInvalidMerge)
+	}
+	fields, err := validateFieldRules(opts.Fields)
+	if err != nil {
+		return MergeResult{}, err
+	}
+
 	tx, err := s.sqlDB.BeginTx(ctx, nil)
 	if err != nil {
-		return db.Ingredient{}, err
+		return MergeResult{}, err
 	}
 	defer tx.Rollback() //nolint:errcheck
 
 	qtx := db.New(tx)
+	if err := setChange(ctx, qtx, ChangeMerge); err != nil {
+		return MergeResult{}, err
+	}
 
-	winner, err := qtx.GetIngredient(ctx, winnerID)
+	// Lock the winner so nothing else changes it while the losers fold in.
+	winner, err := qtx.GetIngredientForUpdate(ctx, winnerID)
 	if err != nil {
-		return db.Ingredient{}, err
+		return MergeResult{}, err
+	}
+	if opts.WinnerVersion != 0 && opts.WinnerVersion != winner.Version {
+		return MergeResult{}, ErrVersionMismatch
 	}
+
+	result := MergeResult{LoserIDs: loserIDs, ConversionPolicy: policy, DryRun: opts.DryRun}
+	var preview MergePreview
+	for _, loserID := range loserIDs {
+		step, err := mergeLoser(ctx, qtx, winner, loserID, policy, fields)
+		if err != nil {
+			return MergeResult{}, err
+		}
+		if opts.LoserVersion != 0 && opts.LoserVersion != step.snap.Loser.Version {
+			return MergeResult{}, ErrVersionMismatch
+		}
+		winner = step.snap.WinnerAfter
+		result.DroppedConversions = append(result.DroppedConversions, step.plan.dropped...)
+		result.DroppedComponents = append(result.DroppedComponents, step.fold.dropped...)
+		result.DroppedComposites = append(result.DroppedComposites, step.droppedComposites...)
+
+		if opts.DryRun {
+			p, err := previewMerge(ctx, qtx, step.snap, step.loserConvs, step.plan)
+			if err != nil {
+				return MergeResult{}, err
+			}
+			p.ConversionConflicts = conversionConflicts(step.winnerConvs, step.loserConvs)
+			preview.add(p)
+			continue
+		}
+
+		record, err := saveMergeSnapshot(ctx, qtx, step.snap)
+		if err != nil {
+			return MergeResult{}, err
+		}
+		result.MergeIDs = append(result.MergeIDs, record.ID)
+	}
+	result.Ingredient = winner
+
+	convs, err := qtx.ListUnitConversionsByIngredient(ctx, winnerID)
+	if err != nil {
+		return MergeResult{}, err
+	}
+	result.ConversionIssues = checkConversions(convs)
+	if len(result.ConversionIssues) > 0 {
+		slog.Warn("merge: winner has conflicting unit conversions", "winner", winnerID, "issues", len(result.ConversionIssues))
+	}
+
+	if opts.DryRun {
+		result.Preview = &preview
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return MergeResult{}, err
+	}
+	slog.Info("merged ingredients", "winner", winnerID, "losers", loserIDs)
+	return result, nil
+}
+
+// validateMergeLosers checks loserIDs is non-empty, within maxMergeLosers,
+// free of repeats and does not include winnerID.
+func validateMergeLosers(winnerID uuid.UUID, loserIDs []uuid.UUID) error {
+	if len(loserIDs) == 0 {
+		return fmt.Errorf("%w: no losers", ErrInvalidMerge)
+	}
+	if len(loserIDs) > maxMergeLosers {
+		return fmt.Errorf("%w: at most %d losers", ErrInvalidMerge, maxMergeLosers)
+	}
+	seen := make(map[uuid.UUID]bool, len(loserIDs))
+	for _, id := range loserIDs {
+		if id == winnerID {
+			return fmt.Errorf("%w: cannot merge %s into itself", ErrInvalidMerge, id)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: %s listed twice", ErrInvalidMerge, id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// mergeStep is what mergeLoser did for one loser.
+type mergeStep struct {
+	snap mergeSnapshot
+	plan conversionMergePlan
+	fold componentFold
+	// droppedComposites are composites the fold left with too few
+	// components.
+	droppedComposites []db.CompositeSubstitute
+	winnerConvs       []db.UnitConversion
+	loserConvs        []db.UnitConversion
+}
+
+// mergeLoser folds one loser into winner inside the caller's transaction.
+// The returned snapshot's WinnerAfter is the updated winner.
+func mergeLoser(ctx context.Context, qtx db.Querier, winner db.Ingredient, loserID uuid.UUID, policy ConversionPolicy, fields map[string]FieldRule) (mergeStep, error) {
+	winnerID := winner.ID
 	loser, err := qtx.GetIngredient(ctx, loserID)
 	if err != nil {
-		return db.Ingredient{}, err
+		return mergeStep{}, err
 	}
 
-	// Merge loser name + aliases into winner aliases, deduplicated.
+	// Record what the merge is about to change so Split can undo it.
+	snap, err := captureMergeSnapshot(ctx, qtx, winner, loser)
+	if err != nil {
+		return mergeStep{}, err
+	}
+
+	// Move loser's children under winner. This runs before the alias update
+	// so the returned winner reflects any change to its own parent.
+	if snap.ChildMoves, err = reparentChildren(ctx, qtx, winner, loser); err != nil {
+		return mergeStep{}, err
+	}
+
+	// Merge loser name + aliases into winner aliases, deduplicated, and
+	// reconcile the attributes field rules cover.
 	merged := mergeAliases(winner.Aliases, loser.Name, loser.Aliases, winner.Name)
+	attrs, err := applyFieldRules(ctx, qtx, winner, loser, fields)
+	if err != nil {
+		return mergeStep{}, err
+	}
+
+	// The loser's name may be another ingredient's alias. Otherwise the
+	// only holders are the two sides, and the loser's aliases move over
+	// with their metadata; its name becomes a merge alias.
+	if err := checkTerms(ctx, qtx, merged, winnerID, loserID); err != nil {
+		return mergeStep{}, err
+	}
+	if err := qtx.MoveIngredientAliases(ctx, db.MoveIngredientAliasesParams{WinnerID: winnerID, LoserID: loserID}); err != nil {
+		return mergeStep{}, err
+	}
 
 	winner, err = qtx.UpdateIngredient(ctx, db.UpdateIngredientParams{
 		ID:          winnerID,
 		Aliases:     merged,
-		Category:    winner.Category,
-		DefaultUnit: winner.DefaultUnit,
+		CategoryID:  attrs.CategoryID,
+		DefaultUnit: attrs.DefaultUnit,
+		AliasSource: nullString(string(AliasSourceMerge)),
+		CreatedBy:   actorFrom(ctx),
 	})
 	if err != nil {
-		return db.Ingredient{}, err
+		return mergeStep{}, err
+	}
+
+	// Keep every allergen either side carried; take the loser's dietary tags
+	// only if the winner has none.
+	if allergens, tags, freeOf := mergeDietary(winner, loser); len(allergens) != len(winner.Allergens) || len(tags) != len(winner.DietaryTags) || len(freeOf) != len(winner.FreeOf) {
+		winner, err = qtx.UpdateIngredientDietary(ctx, db.UpdateIngredientDietaryParams{
+			ID:          winnerID,
+			Allergens:   allergens,
+			DietaryTags: tags,
+			FreeOf:      freeOf,
+		})
+		if err != nil {
+			return mergeStep{}, err
+		}
 	}
 
-	// Re-point substitute references from loser to winner.
+	// Re-point substitute references from loser to winner, first dropping
+	// loser rows that would duplicate a winner row or point at the winner.
+	if err := qtx.DeleteRedundantMergeSubstitutes(ctx, db.DeleteRedundantMergeSubstitutesParams{
+		WinnerID: winnerID,
+		LoserID:  loserID,
+	}); err != nil {
+		return mergeStep{}, err
+	}
 	if err := qtx.ReplaceSubstituteIngredient(ctx, db.ReplaceSubstituteIngredientParams{
 		IngredientID:   winnerID,
 		IngredientID_2: loserID,
 	}); err != nil {
-		return db.Ingredient{}, err
+		return mergeStep{}, err
 	}
 	if err := qtx.ReplaceSubstituteSubId(ctx, db.ReplaceSubstituteSubIdParams{
 		SubstituteID:   winnerID,
 		SubstituteID_2: loserID,
 	}); err != nil {
-		return db.Ingredient{}, err
+		return mergeStep{}, err
+	}
+
+	winnerConvs, err := qtx.ListUnitConversionsByIngredient(ctx, winnerID)
+	if err != nil {
+		return mergeStep{}, err
+	}
+	loserConvs, err := qtx.ListUnitConversionsByIngredient(ctx, loserID)
+	if err != nil {
+		return mergeStep{}, err
+	}
+
+	// Re-point composite substitutes and components, then drop composites
+	// that now list the winner as a component of itself. A composite that
+	// already lists the winner keeps only the winner's component row, and is
+	// dropped if that leaves it with fewer than two components.
+	if err := qtx.ReplaceCompositeSubstituteIngredient(ctx, db.ReplaceCompositeSubstituteIngredientParams{
+		IngredientID:   winnerID,
+		IngredientID_2: loserID,
+	}); err != nil {
+		return mergeStep{}, err
+	}
+	winnerComponents, err := qtx.ListCompositeComponentsByComponent(ctx, winnerID)
+	if err != nil {
+		return mergeStep{}, err
+	}
+	loserComponents, err := qtx.ListCompositeComponentsByComponent(ctx, loserID)
+	if err != nil {
+		return mergeStep{}, err
+	}
+	fold, err := planComponentFold(winnerComponents, loserComponents, append(slices.Clone(winnerConvs), loserConvs...))
+	if err != nil {
+		return mergeStep{}, err
+	}
+	snap.DroppedComponents, snap.ComponentQuantityUpdates = fold.dropped, fold.updates
+	for _, u := range fold.updates {
+		if err := qtx.UpdateCompositeComponentQuantity(ctx, db.UpdateCompositeComponentQuantityParams{ID: u.ID, Quantity: u.After}); err != nil {
+			return mergeStep{}, err
+		}
+	}
+	for _, c := range fold.dropped {
+		if err := qtx.DeleteCompositeComponent(ctx, c.ID); err != nil {
+			return mergeStep{}, err
+		}
+	}
+	if err := qtx.ReplaceCompositeComponentIngredient(ctx, db.ReplaceCompositeComponentIngredientParams{
+		ComponentID:   winnerID,
+		ComponentID_2: loserID,
+	}); err != nil {
+		return mergeStep{}, err
+	}
+	if err := qtx.DeleteSelfReferencingComposites(ctx, winnerID); err != nil {
+		return mergeStep{}, err
+	}
+	var droppedComposites []db.CompositeSubstitute
+	if len(fold.dropped) > 0 {
+		compositeIDs := make([]uuid.UUID, 0, len(fold.dropped))
+		for _, c := range fold.dropped {
+			compositeIDs = append(compositeIDs, c.CompositeID)
+		}
+		droppedComposites, err = qtx.DeleteUndersizedComposites(ctx, compositeIDs)
+		if err != nil {
+			return mergeStep{}, err
+		}
+	}
+
+	// Reconcile conversions both ingredients define, then re-point the rest.
+	plan, err := planConversionMerge(winnerConvs, loserConvs, policy)
+	if err != nil {
+		return mergeStep{}, err
+	}
+	snap.recordConversions(winnerID, winnerConvs, loserConvs, plan)
+	for _, u := range plan.updates {
+		if _, err := qtx.UpdateUnitConversionFactor(ctx, u); err != nil {
+			return mergeStep{}, err
+		}
+	}
+	for _, c := range plan.dropped {
+		if err := qtx.DeleteUnitConversion(ctx, c.ID); err != nil {
+			return mergeStep{}, err
+		}
 	}
 
-	// Re-point unit conversion references.
 	if err := qtx.ReplaceUnitConversionIngredient(ctx, db.ReplaceUnitConversionIngredientParams{
 		IngredientID:   winnerID,
 		IngredientID_2: loserID,
 	}); err != nil {
-		return db.Ingredient{}, err
+		return mergeStep{}, err
+	}
+
+	// Keep the loser's nutrition data only if the winner has none.
+	if err := qtx.MoveNutritionToWinner(ctx, db.MoveNutritionToWinnerParams{
+		WinnerID: winnerID,
+		LoserID:  loserID,
+	}); err != nil {
+		return mergeStep{}, err
+	}
+
+	// Likewise for storage guidance.
+	if err := qtx.MoveStorageGuidelinesToWinner(ctx, db.MoveStorageGuidelinesToWinnerParams{
+		WinnerID: winnerID,
+		LoserID:  loserID,
+	}); err != nil {
+		return mergeStep{}, err
+	}
+
+	// Leave a tombstone so lookups of the loser ID find the winner. Earlier
+	// redirects to the loser must move first, or deleting it cascades them.
+	if err := qtx.RetargetIngredientRedirects(ctx, db.RetargetIngredientRedirectsParams{
+		WinnerID: winnerID,
+		LoserID:  loserID,
+	}); err != nil {
+		return mergeStep{}, err
+	}
+	if err := qtx.CreateIngredientRedirect(ctx, db.CreateIngredientRedirectParams{
+		OldID: loserID,
+		NewID: winnerID,
+	}); err != nil {
+		return mergeStep{}, err
 	}
 
 	// Delete loser — cascades any remaining substitutes/conversions.
 	if err := qtx.DeleteIngredient(ctx, loserID); err != nil {
-		return db.Ingredient{}, err
+		return mergeStep{}, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return db.Ingredient{}, err
+	snap.WinnerAfter = winner
+	return mergeStep{snap: snap, plan: plan, fold: fold, droppedComposites: droppedComposites, winnerConvs: winnerConvs, loserConvs: loserConvs}, nil
+}
+
+// conversionPair identifies a conversion by its normalized units in a fixed
+// order, so a conversion and its inverse share a pair.
+func conversionPair(c db.UnitConversion) [2]string {
+	from, to := Normalize(c.FromUnit), Normalize(c.ToUnit)
+	if from > to {
+		from, to = to, from
 	}
+	return [2]string{from, to}
+}
+
+// conversionsByPair indexes convs by conversionPair, keeping every row for
+// each pair in order.
+func conversionsByPair(convs []db.UnitConversion) map[[2]string][]db.UnitConversion {
+	byPair := make(map[[2]string][]db.UnitConversion, len(convs))
+	for _, c := range convs {
+		key := conversionPair(c)
+		byPair[key] = append(byPair[key], c)
+	}
+	return byPair
+}
 
-	return winner, nil
+// factorAs returns c's factor in the direction of ref, a conversion of the
+// same pair.
+func factorAs(c, ref db.UnitConversion) float64 {
+	if Normalize(c.FromUnit) == Normalize(ref.FromUnit) {
+		return c.Factor
+	}
+	return 1 / c.Factor
+}
+
+// previewMerge compares the loser-owned rows in snap with what the winner
+// holds after merging that loser, inside the merge's transaction. loserConvs
+// are the loser's conversions before the merge. ConversionConflicts is left
+// for the caller.
+func previewMerge(ctx context.Context, q db.Querier, snap mergeSnapshot, loserConvs []db.UnitConversion, plan conversionMergePlan) (MergePreview, error) {
+	winnerID := snap.WinnerAfter.ID
+	p := MergePreview{
+		Aliases:            snap.WinnerAfter.Aliases,
+		Differences:        mergeDifferences(snap.WinnerBefore, snap.Loser, snap.WinnerAfter),
+		ChildrenMoved:      len(snap.ChildMoves),
+		ConversionsUpdated: len(plan.updates),
+		RedirectsMoved:     len(snap.RedirectsTo),
+	}
+
+	subs, err := q.ListSubstitutesTouching(ctx, winnerID)
+	if err != nil {
+		return MergePreview{}, err
+	}
+	subIDs := make([]uuid.UUID, 0, len(subs))
+	for _, sub := range subs {
+		subIDs = append(subIDs, sub.ID)
+	}
+	for _, sub := range snap.Substitutes {
+		if slices.Contains(subIDs, sub.ID) {
+			p.SubstitutesMoved++
+		} else {
+			p.SubstitutesDropped++
+		}
+	}
+
+	composites, err := q.ListCompositeSubstituteIDsByIngredient(ctx, winnerID)
+	if err != nil {
+		return MergePreview{}, err
+	}
+	components, err := q.ListCompositeComponentIDsByComponent(ctx, winnerID)
+	if err != nil {
+		return MergePreview{}, err
+	}
+	for _, id := range snap.CompositeIDs {
+		if slices.Contains(composites, id) {
+			p.CompositesMoved++
+		} else {
+			p.CompositesDropped++
+		}
+	}
+	for _, id := range snap.ComponentIDs {
+		if slices.Contains(components, id) {
+			p.CompositesMoved++
+		} else {
+			p.CompositesDropped++
+		}
+	}
+
+	winnerConvs, err := q.ListUnitConversionsByIngredient(ctx, winnerID)
+	if err != nil {
+		return MergePreview{}, err
+	}
+	for _, c := range loserConvs {
+		if slices.ContainsFunc(winnerConvs, func(w db.UnitConversion) bool { return w.ID == c.ID }) {
+			p.ConversionsMoved++
+		}
+	}
+
+	guidelines, err := q.ListStorageGuidelinesByIngredient(ctx, winnerID)
+	if err != nil {
+		return MergePreview{}, err
+	}
+	for _, g := range snap.StorageGuidelines {
+		if slices.ContainsFunc(guidelines, func(w db.StorageGuideline) bool { return w.ID == g.ID }) {
+			p.StorageMoved++
+		} else {
+			p.StorageDropped++
+		}
+	}
+
+	if snap.Nutrition != nil {
+		n, err := q.GetIngredientNutrition(ctx, winnerID)
+		switch {
+		case err == nil:
+			p.NutritionMoved = n.UpdatedAt.Equal(snap.Nutrition.UpdatedAt) && n.SourceID == snap.Nutrition.SourceID
+		case !errors.Is(err, sql.ErrNoRows):
+			return MergePreview{}, err
+		}
+	}
+	return p, nil
+}
+
+// add folds the preview of one loser's merge into p. Aliases are taken from
+// step, which reflects every loser merged so far.
+func (p *MergePreview) add(step MergePreview) {
+	p.Aliases = step.Aliases
+	p.Differences = append(p.Differences, step.Differences...)
+	p.ChildrenMoved += step.ChildrenMoved
+	p.SubstitutesMoved += step.SubstitutesMoved
+	p.SubstitutesDropped += step.SubstitutesDropped
+	p.CompositesMoved += step.CompositesMoved
+	p.CompositesDropped += step.CompositesDropped
+	p.ConversionsMoved += step.ConversionsMoved
+	p.ConversionsUpdated += step.ConversionsUpdated
+	p.ConversionConflicts = append(p.ConversionConflicts, step.ConversionConflicts...)
+	p.NutritionMoved = p.NutritionMoved || step.NutritionMoved
+	p.StorageMoved += step.StorageMoved
+	p.StorageDropped += step.StorageDropped
+	p.RedirectsMoved += step.RedirectsMoved
+}
+
+// mergeDifferences lists the merge fields and parent on which loser
+// disagrees with winner, with the value after holds.
+func mergeDifferences(winner, loser, after db.Ingredient) []MergeDifference {
+	var diffs []MergeDifference
+	add := func(field string, value func(db.Ingredient) string) {
+		if w, l := value(winner), value(loser); w != l {
+			diffs = append(diffs, MergeDifference{LoserID: loser.ID, Field: field, Winner: w, Loser: l, Result: value(after)})
+		}
+	}
+	for _, f := range mergeFields {
+		add(f.name, f.display)
+	}
+	add("parent_id", func(ing db.Ingredient) string {
+		if !ing.ParentID.Valid {
+			return ""
+		}
+		return ing.ParentID.UUID.String()
+	})
+	return diffs
+}
+
+// conversionConflicts lists the unit pairs winnerConvs and loserConvs both
+// define, in either direction, with different factors. LoserFactor is given
+// in the direction of the winner's row.
+func conversionConflicts(winnerConvs, loserConvs []db.UnitConversion) []ConversionConflict {
+	winnerByPair := conversionsByPair(winnerConvs)
+	var conflicts []ConversionConflict
+	for _, l := range loserConvs {
+		rows, ok := winnerByPair[conversionPair(l)]
+		if !ok {
+			continue
+		}
+		w := rows[0]
+		if lf := factorAs(l, w); !factorsAgree(w.Factor, lf) {
+			conflicts = append(conflicts, ConversionConflict{
+				LoserID:      l.IngredientID,
+				FromUnit:     w.FromUnit,
+				ToUnit:       w.ToUnit,
+				WinnerFactor: w.Factor,
+				LoserFactor:  lf,
+			})
+		}
+	}
+	return conflicts
+}
+
+func (p ConversionPolicy) valid() bool {
+	switch p {
+	case ConversionPolicyKeepWinner, ConversionPolicyKeepLoser, ConversionPolicyAverage, ConversionPolicyFail:
+		return true
+	}
+	return false
+}
+
+// conversionMergePlan is the set of changes needed to reconcile conversions
+// defined by both merge participants.
+type conversionMergePlan struct {
+	updates []db.UpdateUnitConversionFactorParams
+	dropped []db.UnitConversion
+}
+
+// planConversionMerge pairs each loser conversion with the winner's first
+// row for the same units, in either direction, comparing factors in the
+// direction of that row. Agreeing pairs drop the loser row. Conflicting pairs
+// are resolved by policy: keep_winner drops the loser row, keep_loser drops
+// every winner row for the units, average sets the winner's rows to the mean,
+// or its reciprocal for rows in the other direction, and drops the loser row,
+// and fail returns ErrConversionConflict. Once the winner's rows for a pair
+// have been dropped or averaged, further loser rows for the pair are kept
+// under keep_loser and dropped under average.
+func planConversionMerge(winnerConvs, loserConvs []db.UnitConversion, policy ConversionPolicy) (conversionMergePlan, error) {
+	winnerByPair := conversionsByPair(winnerConvs)
+	resolved := make(map[[2]string]bool)
+
+	var plan conversionMergePlan
+	var conflicts []string
+	for _, l := range loserConvs {
+		key := conversionPair(l)
+		rows, ok := winnerByPair[key]
+		if !ok {
+			continue
+		}
+		w := rows[0]
+		if resolved[key] {
+			if policy == ConversionPolicyAverage {
+				plan.dropped = append(plan.dropped, l)
+			}
+			continue
+		}
+		lf := factorAs(l, w)
+		if factorsAgree(w.Factor, lf) {
+			plan.dropped = append(plan.dropped, l)
+			continue
+		}
+		switch policy {
+		case ConversionPolicyKeepWinner:
+			plan.dropped = append(plan.dropped, l)
+		case ConversionPolicyKeepLoser:
+			plan.dropped = append(plan.dropped, rows...)
+			resolved[key] = true
+		case ConversionPolicyAverage:
+			mean := (w.Factor + lf) / 2
+			for _, r := range rows {
+				factor := mean
+				if Normalize(r.FromUnit) != Normalize(w.FromUnit) {
+					factor = 1 / mean
+				}
+				plan.updates = append(plan.updates, db.UpdateUnitConversionFactorParams{ID: r.ID, Factor: factor})
+			}
+			plan.dropped = append(plan.dropped, l)
+			resolved[key] = true
+		case ConversionPolicyFail:
+			conflicts = append(conflicts, fmt.Sprintf("%s->%s winner %g, loser %g", w.FromUnit, w.ToUnit, w.Factor, lf))
+		}
+	}
+	if len(conflicts) > 0 {
+		return conversionMergePlan{}, fmt.Errorf("%w: %s", ErrConversionConflict, strings.Join(conflicts, "; "))
+	}
+	return plan, nil
+}
+
+// componentFold is the set of changes needed for composites that list both
+// merge participants as components.
+type componentFold struct {
+	updates []quantityUpdate
+	dropped []db.CompositeSubstituteComponent
+}
+
+// planComponentFold pairs each loser component row with the winner's row in
+// the same composite. Every paired loser row is dropped and its quantity,
+// converted into the winner row's unit through the unit catalog or convs, is
+// added to the winner's. Rows whose units cannot be converted fail the fold
+// with ErrComponentUnitMismatch rather than lose their quantity.
+func planComponentFold(winnerRows, loserRows []db.CompositeSubstituteComponent, convs []db.UnitConversion) (componentFold, error) {
+	byComposite := make(map[uuid.UUID]db.CompositeSubstituteComponent, len(winnerRows))
+	for _, c := range winnerRows {
+		byComposite[c.CompositeID] = c
+	}
+	var fold componentFold
+	var mismatches []string
+	for _, l := range loserRows {
+		w, ok := byComposite[l.CompositeID]
+		if !ok {
+			continue
+		}
+		qty, ok := convertQuantity(l.Quantity, l.Unit.String, w.Unit.String, convs)
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("composite %s lists %g %q and %g %q", l.CompositeID, w.Quantity, w.Unit.String, l.Quantity, l.Unit.String))
+			continue
+		}
+		fold.dropped = append(fold.dropped, l)
+		fold.updates = append(fold.updates, quantityUpdate{ID: w.ID, Before: w.Quantity, After: w.Quantity + qty})
+	}
+	if len(mismatches) > 0 {
+		return componentFold{}, fmt.Errorf("%w: %s", ErrComponentUnitMismatch, strings.Join(mismatches, "; "))
+	}
+	return fold, nil
 }
 
 // mergeAliases combines existing winner aliases with the loser's name and
@@ -103,3 +813,30 @@ func mergeAliases(winnerAliases []string, loserName string, loserAliases []strin
 	return result
 }
 
+// mergeDietary returns the winner's allergens extended with the loser's, the
+// winner's dietary tags, or the loser's if the winner has none, less any the
+// merged allergens contradict, and the winner's free-of allergens less any
+// the result now carries.
+func mergeDietary(winner, loser db.Ingredient) (allergens, tags, freeOf []string) {
+	allergens = append([]string{}, winner.Allergens...)
+	for _, a := range loser.Allergens {
+		if !slices.Contains(allergens, a) {
+			allergens = append(allergens, a)
+		}
+	}
+	tags = winner.DietaryTags
+	if len(tags) == 0 {
+		tags = loser.DietaryTags
+	}
+	tags = dropContradictedTags(tags, allergens)
+	if tags == nil {
+		tags = []string{}
+	}
+	freeOf = []string{}
+	for _, a := range winner.FreeOf {
+		if !slices.Contains(allergens, a) {
+			freeOf = append(freeOf, a)
+		}
+	}
+	return allergens, tags, freeOf
+}
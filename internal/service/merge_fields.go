@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// FieldStrategy decides which side of a merge supplies an attribute.
+type FieldStrategy string
+
+const (
+	// FieldPreferWinner always keeps the winner's value, even if unset.
+	FieldPreferWinner FieldStrategy = "prefer_winner"
+	// FieldPreferNonNull keeps the winner's value unless it is unset, in
+	// which case the loser's is taken. This is the default.
+	FieldPreferNonNull FieldStrategy = "prefer_non_null"
+	// FieldPreferLoser takes the loser's value unless it is unset.
+	FieldPreferLoser FieldStrategy = "prefer_loser"
+	// FieldValue sets the value given in the rule; an empty value clears it.
+	FieldValue FieldStrategy = "value"
+)
+
+// ErrInvalidFieldRule is returned for a field rule naming an unknown field
+// or strategy.
+var ErrInvalidFieldRule = errors.New("invalid merge field rule")
+
+// FieldRule is the merge strategy for one attribute. Value is only used by
+// FieldValue.
+type FieldRule struct {
+	Strategy FieldStrategy
+	Value    string
+}
+
+// mergeField is an ingredient attribute Merge reconciles field by field.
+// display renders the value for previews; "" means unset. set applies an
+// explicit FieldValue.
+type mergeField struct {
+	name    string
+	display func(db.Ingredient) string
+	take    func(dst *db.Ingredient, src db.Ingredient)
+	set     func(ctx context.Context, q db.Querier, dst *db.Ingredient, value string) error
+}
+
+// mergeFields lists the attributes field rules apply to. A new attribute
+// only needs an entry here to take part in merges, previews and splits.
+var mergeFields = []mergeField{
+	{
+		name: "category",
+		display: func(ing db.Ingredient) string {
+			if !ing.CategoryID.Valid {
+				return ""
+			}
+			return ing.CategoryID.UUID.String()
+		},
+		take: func(dst *db.Ingredient, src db.Ingredient) { dst.CategoryID = src.CategoryID },
+		set: func(ctx context.Context, q db.Querier, dst *db.Ingredient, slug string) error {
+			if slug == "" {
+				dst.CategoryID = uuid.NullUUID{}
+				return nil
+			}
+			cat, err := q.GetCategoryBySlug(ctx, Slugify(slug))
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("%w: %q", ErrUnknownCategory, slug)
+				}
+				return err
+			}
+			dst.CategoryID = uuid.NullUUID{UUID: cat.ID, Valid: true}
+			return nil
+		},
+	},
+	{
+		name:    "default_unit",
+		display: func(ing db.Ingredient) string { return ing.DefaultUnit.String },
+		take:    func(dst *db.Ingredient, src db.Ingredient) { dst.DefaultUnit = src.DefaultUnit },
+		set: func(_ context.Context, _ db.Querier, dst *db.Ingredient, unit string) error {
+			dst.DefaultUnit = sql.NullString{String: unit, Valid: unit != ""}
+			return nil
+		},
+	},
+}
+
+// validateFieldRules checks every rule names a known field and strategy,
+// and that only FieldValue rules carry a value. Values are trimmed.
+func validateFieldRules(rules map[string]FieldRule) (map[string]FieldRule, error) {
+	out := make(map[string]FieldRule, len(rules))
+	for name, rule := range rules {
+		known := false
+		for _, f := range mergeFields {
+			known = known || f.name == name
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidFieldRule, name)
+		}
+		rule.Value = strings.TrimSpace(rule.Value)
+		switch rule.Strategy {
+		case "":
+			rule.Strategy = FieldPreferNonNull
+		case FieldPreferWinner, FieldPreferNonNull, FieldPreferLoser, FieldValue:
+		default:
+			return nil, fmt.Errorf("%w: unknown strategy %q for %s", ErrInvalidFieldRule, rule.Strategy, name)
+		}
+		if rule.Value != "" && rule.Strategy != FieldValue {
+			return nil, fmt.Errorf("%w: %s takes a value only with strategy %q", ErrInvalidFieldRule, name, FieldValue)
+		}
+		out[name] = rule
+	}
+	return out, nil
+}
+
+// applyFieldRules returns winner with each mergeField reconciled against
+// loser. Fields without a rule use FieldPreferNonNull.
+func applyFieldRules(ctx context.Context, q db.Querier, winner, loser db.Ingredient, rules map[string]FieldRule) (db.Ingredient, error) {
+	for _, f := range mergeFields {
+		rule, ok := rules[f.name]
+		if !ok {
+			rule.Strategy = FieldPreferNonNull
+		}
+		switch rule.Strategy {
+		case FieldPreferNonNull:
+			if f.display(winner) == "" {
+				f.take(&winner, loser)
+			}
+		case FieldPreferLoser:
+			if f.display(loser) != "" {
+				f.take(&winner, loser)
+			}
+		case FieldValue:
+			if err := f.set(ctx, q, &winner, rule.Value); err != nil {
+				return db.Ingredient{}, err
+			}
+		}
+	}
+	return winner, nil
+}
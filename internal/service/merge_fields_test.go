@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFieldRules(t *testing.T) {
+	t.Parallel()
+
+	winnerCat := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	loserCat := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	winner := db.Ingredient{ID: uuid.New(), CategoryID: winnerCat}
+	loser := db.Ingredient{ID: uuid.New(), CategoryID: loserCat, DefaultUnit: sql.NullString{String: "g", Valid: true}}
+
+	tests := []struct {
+		name     string
+		rules    map[string]FieldRule
+		wantCat  uuid.NullUUID
+		wantUnit sql.NullString
+	}{
+		{
+			name:     "defaults fill nulls from the loser",
+			wantCat:  winnerCat,
+			wantUnit: loser.DefaultUnit,
+		},
+		{
+			name: "prefer winner keeps nulls",
+			rules: map[string]FieldRule{
+				"default_unit": {Strategy: FieldPreferWinner},
+			},
+			wantCat: winnerCat,
+		},
+		{
+			name: "prefer loser",
+			rules: map[string]FieldRule{
+				"category": {Strategy: FieldPreferLoser},
+			},
+			wantCat:  loserCat,
+			wantUnit: loser.DefaultUnit,
+		},
+		{
+			name: "explicit values",
+			rules: map[string]FieldRule{
+				"category":     {Strategy: FieldValue},
+				"default_unit": {Strategy: FieldValue, Value: "ml"},
+			},
+			wantUnit: sql.NullString{String: "ml", Valid: true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := applyFieldRules(context.Background(), mocks.NewMockQuerier(t), winner, loser, tc.rules)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCat, got.CategoryID)
+			assert.Equal(t, tc.wantUnit, got.DefaultUnit)
+		})
+	}
+}
+
+func TestApplyFieldRules_CategorySlug(t *testing.T) {
+	t.Parallel()
+	mockQ := mocks.NewMockQuerier(t)
+
+	dairy := db.Category{ID: uuid.New(), Slug: "dairy"}
+	mockQ.EXPECT().GetCategoryBySlug(mock.Anything, "dairy").Return(dairy, nil)
+	mockQ.EXPECT().GetCategoryBySlug(mock.Anything, "nope").Return(db.Category{}, sql.ErrNoRows)
+
+	got, err := applyFieldRules(context.Background(), mockQ, db.Ingredient{}, db.Ingredient{},
+		map[string]FieldRule{"category": {Strategy: FieldValue, Value: "Dairy"}})
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NullUUID{UUID: dairy.ID, Valid: true}, got.CategoryID)
+
+	_, err = applyFieldRules(context.Background(), mockQ, db.Ingredient{}, db.Ingredient{},
+		map[string]FieldRule{"category": {Strategy: FieldValue, Value: "nope"}})
+	assert.ErrorIs(t, err, ErrUnknownCategory)
+}
+
+func TestValidateFieldRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rules   map[string]FieldRule
+		wantErr bool
+	}{
+		{name: "empty", rules: nil},
+		{name: "known field and strategy", rules: map[string]FieldRule{"category": {Strategy: FieldPreferLoser}}},
+		{name: "explicit value", rules: map[string]FieldRule{"default_unit": {Strategy: FieldValue, Value: "g"}}},
+		{name: "unknown field", rules: map[string]FieldRule{"colour": {Strategy: FieldPreferLoser}}, wantErr: true},
+		{name: "unknown strategy", rules: map[string]FieldRule{"category": {Strategy: "coin_flip"}}, wantErr: true},
+		{name: "value without value strategy", rules: map[string]FieldRule{"default_unit": {Strategy: FieldPreferWinner, Value: "g"}}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := validateFieldRules(tc.rules)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidFieldRule)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
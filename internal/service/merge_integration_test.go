@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
 	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
 	"github.com/stretchr/testify/assert"
@@ -33,8 +34,9 @@ func TestMerge_Integration(t *testing.T) {
 	require.NoError(t, err)
 
 	// Merge loser into winner.
-	merged, err := svc.Merge(ctx, winner.ID, loser.ID)
+	result, err := svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{})
 	require.NoError(t, err)
+	merged := result.Ingredient
 	assert.Equal(t, winner.ID, merged.ID)
 	assert.Contains(t, merged.Aliases, "minced garlic")
 	assert.Contains(t, merged.Aliases, "garlic paste")
@@ -78,7 +80,7 @@ func TestMerge_MovesSubstitutes(t *testing.T) {
 	require.NoError(t, err)
 
 	// Merge — substitute should now point to winner.
-	_, err = svc.Merge(ctx, winner.ID, loser.ID)
+	_, err = svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{})
 	require.NoError(t, err)
 
 	subs, err := q.ListSubstitutesByIngredient(ctx, winner.ID)
@@ -86,3 +88,244 @@ func TestMerge_MovesSubstitutes(t *testing.T) {
 	assert.Len(t, subs, 1)
 	assert.Equal(t, other.ID, subs[0].SubstituteID)
 }
+
+func TestMerge_ReconcilesConflictingConversions(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	winner, err := q.CreateIngredient(ctx, db.CreateIngredientParams{
+		Name:    "flour",
+		Aliases: []string{},
+	})
+	require.NoError(t, err)
+
+	loser, err := q.CreateIngredient(ctx, db.CreateIngredientParams{
+		Name:    "all purpose flour",
+		Aliases: []string{},
+	})
+	require.NoError(t, err)
+
+	_, err = q.CreateUnitConversion(ctx, db.CreateUnitConversionParams{
+		IngredientID: winner.ID, FromUnit: "cup", ToUnit: "g", Factor: 120,
+	})
+	require.NoError(t, err)
+	_, err = q.CreateUnitConversion(ctx, db.CreateUnitConversionParams{
+		IngredientID: loser.ID, FromUnit: "cup", ToUnit: "g", Factor: 125,
+	})
+	require.NoError(t, err)
+
+	result, err := svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{ConversionPolicy: ConversionPolicyAverage})
+	require.NoError(t, err)
+	assert.Equal(t, ConversionPolicyAverage, result.ConversionPolicy)
+	require.Len(t, result.DroppedConversions, 1)
+	assert.Empty(t, result.ConversionIssues)
+
+	convs, err := q.ListUnitConversionsByIngredient(ctx, winner.ID)
+	require.NoError(t, err)
+	require.Len(t, convs, 1)
+	assert.InDelta(t, 122.5, convs[0].Factor, 1e-9)
+}
+
+func TestMerge_FailsOnConversionConflict(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	winner, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "sugar", Aliases: []string{}})
+	require.NoError(t, err)
+	loser, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "white sugar", Aliases: []string{}})
+	require.NoError(t, err)
+
+	_, err = q.CreateUnitConversion(ctx, db.CreateUnitConversionParams{
+		IngredientID: winner.ID, FromUnit: "cup", ToUnit: "g", Factor: 200,
+	})
+	require.NoError(t, err)
+	_, err = q.CreateUnitConversion(ctx, db.CreateUnitConversionParams{
+		IngredientID: loser.ID, FromUnit: "cup", ToUnit: "g", Factor: 150,
+	})
+	require.NoError(t, err)
+
+	_, err = svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{ConversionPolicy: ConversionPolicyFail})
+	assert.ErrorIs(t, err, ErrConversionConflict)
+
+	// Nothing changed: the loser still exists.
+	_, err = q.GetIngredient(ctx, loser.ID)
+	assert.NoError(t, err)
+}
+
+func TestMerge_DropsRedundantSubstitutes(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	winner, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "butter", Aliases: []string{}})
+	require.NoError(t, err)
+	loser, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "unsalted butter", Aliases: []string{}})
+	require.NoError(t, err)
+	margarine, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "margarine", Aliases: []string{}})
+	require.NoError(t, err)
+
+	// Both ingredients list margarine, and the loser lists the winner.
+	for _, p := range []db.CreateSubstituteParams{
+		{IngredientID: winner.ID, SubstituteID: margarine.ID, Ratio: 1},
+		{IngredientID: loser.ID, SubstituteID: margarine.ID, Ratio: 1},
+		{IngredientID: loser.ID, SubstituteID: winner.ID, Ratio: 1},
+	} {
+		_, err = q.CreateSubstitute(ctx, p)
+		require.NoError(t, err)
+	}
+
+	_, err = svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{})
+	require.NoError(t, err)
+
+	subs, err := q.ListSubstitutesByIngredient(ctx, winner.ID)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.Equal(t, margarine.ID, subs[0].SubstituteID)
+}
+
+func TestMerge_DryRunRollsBack(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	winner, err := q.CreateIngredient(ctx, db.CreateIngredientParams{
+		Name:        "powdered sugar",
+		Aliases:     []string{},
+		DefaultUnit: sql.NullString{String: "cup", Valid: true},
+	})
+	require.NoError(t, err)
+	loser, err := q.CreateIngredient(ctx, db.CreateIngredientParams{
+		Name:        "icing sugar",
+		Aliases:     []string{"confectioners sugar"},
+		DefaultUnit: sql.NullString{String: "g", Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = q.CreateUnitConversion(ctx, db.CreateUnitConversionParams{
+		IngredientID: winner.ID, FromUnit: "cup", ToUnit: "g", Factor: 120,
+	})
+	require.NoError(t, err)
+	_, err = q.CreateUnitConversion(ctx, db.CreateUnitConversionParams{
+		IngredientID: loser.ID, FromUnit: "cup", ToUnit: "g", Factor: 130,
+	})
+	require.NoError(t, err)
+	_, err = q.CreateUnitConversion(ctx, db.CreateUnitConversionParams{
+		IngredientID: loser.ID, FromUnit: "tbsp", ToUnit: "g", Factor: 8,
+	})
+	require.NoError(t, err)
+
+	result, err := svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{DryRun: true})
+	require.NoError(t, err)
+	require.NotNil(t, result.Preview)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, []string{"icing sugar", "confectioners sugar"}, result.Preview.Aliases)
+	assert.Equal(t, 1, result.Preview.ConversionsMoved)
+	require.Len(t, result.Preview.ConversionConflicts, 1)
+	assert.Equal(t, 130.0, result.Preview.ConversionConflicts[0].LoserFactor)
+	assert.Equal(t, []MergeDifference{{LoserID: loser.ID, Field: "default_unit", Winner: "cup", Loser: "g", Result: "cup"}}, result.Preview.Differences)
+
+	// Nothing was written.
+	_, err = q.GetIngredient(ctx, loser.ID)
+	require.NoError(t, err)
+	got, err := q.GetIngredient(ctx, winner.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.Aliases)
+	convs, err := q.ListUnitConversionsByIngredient(ctx, loser.ID)
+	require.NoError(t, err)
+	assert.Len(t, convs, 2)
+	merges, err := svc.ListMerges(ctx, winner.ID)
+	require.NoError(t, err)
+	assert.Empty(t, merges)
+}
+
+func TestMergeMany_Cluster(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	winner, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "garlic", Aliases: []string{}})
+	require.NoError(t, err)
+	var loserIDs []uuid.UUID
+	for _, name := range []string{"garlic clove", "garlic cloves", "fresh garlic", "garlic bulb"} {
+		ing, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: name, Aliases: []string{}})
+		require.NoError(t, err)
+		loserIDs = append(loserIDs, ing.ID)
+	}
+
+	result, err := svc.MergeMany(ctx, winner.ID, loserIDs, MergeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, loserIDs, result.LoserIDs)
+	assert.Len(t, result.MergeIDs, 4)
+	assert.Equal(t, []string{"garlic clove", "garlic cloves", "fresh garlic", "garlic bulb"}, result.Ingredient.Aliases)
+
+	for _, id := range loserIDs {
+		_, err := q.GetIngredient(ctx, id)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		redirect, err := q.GetIngredientRedirect(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, winner.ID, redirect.NewID)
+	}
+}
+
+func TestMergeMany_IsAtomic(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	winner, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "garlic", Aliases: []string{}})
+	require.NoError(t, err)
+	loser, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "garlic clove", Aliases: []string{}})
+	require.NoError(t, err)
+
+	// The second loser does not exist, so the first merge must not stick.
+	_, err = svc.MergeMany(ctx, winner.ID, []uuid.UUID{loser.ID, uuid.New()}, MergeOptions{})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = q.GetIngredient(ctx, loser.ID)
+	assert.NoError(t, err)
+	got, err := q.GetIngredient(ctx, winner.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.Aliases)
+}
+
+func TestMerge_FieldRules(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	dairy, err := svc.CreateCategory(ctx, CategoryInput{DisplayName: "Dairy"})
+	require.NoError(t, err)
+	winner, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "butter", Aliases: []string{}})
+	require.NoError(t, err)
+	loser, err := q.CreateIngredient(ctx, db.CreateIngredientParams{
+		Name:        "unsalted butter",
+		Aliases:     []string{},
+		CategoryID:  uuid.NullUUID{UUID: dairy.ID, Valid: true},
+		DefaultUnit: sql.NullString{String: "g", Valid: true},
+	})
+	require.NoError(t, err)
+
+	// By default the winner's nulls are filled from the loser; an explicit
+	// value overrides both sides.
+	result, err := svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{
+		Fields: map[string]FieldRule{"default_unit": {Strategy: FieldValue, Value: "tbsp"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NullUUID{UUID: dairy.ID, Valid: true}, result.Ingredient.CategoryID)
+	assert.Equal(t, "tbsp", result.Ingredient.DefaultUnit.String)
+
+	// Splitting puts the winner's own values back.
+	split, err := svc.Split(ctx, winner.ID, uuid.NullUUID{})
+	require.NoError(t, err)
+	assert.Empty(t, split.Issues)
+	assert.False(t, split.Winner.CategoryID.Valid)
+	assert.False(t, split.Winner.DefaultUnit.Valid)
+}
@@ -1,9 +1,16 @@
 package service
 
 import (
+	"context"
+	"database/sql"
 	"testing"
 
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMergeAliases(t *testing.T) {
@@ -75,3 +82,336 @@ func TestMergeAliases(t *testing.T) {
 		})
 	}
 }
+
+func TestPlanConversionMerge(t *testing.T) {
+	t.Parallel()
+
+	winnerID, loserID := uuid.New(), uuid.New()
+	wCup := newConversion(winnerID, "cup", "g", 120)
+	wTbsp := newConversion(winnerID, "tbsp", "g", 7.5)
+	lCup := newConversion(loserID, "Cup", "g", 130)
+	lTbsp := newConversion(loserID, "tbsp", "g", 7.51)
+	lOz := newConversion(loserID, "stick", "g", 113)
+	winner := []db.UnitConversion{wCup, wTbsp}
+	loser := []db.UnitConversion{lCup, lTbsp, lOz}
+
+	tests := []struct {
+		name        string
+		policy      ConversionPolicy
+		wantDropped []db.UnitConversion
+		wantUpdates []db.UpdateUnitConversionFactorParams
+		wantErr     error
+	}{
+		{
+			name:        "keep winner drops conflicting and agreeing loser rows",
+			policy:      ConversionPolicyKeepWinner,
+			wantDropped: []db.UnitConversion{lCup, lTbsp},
+		},
+		{
+			name:        "keep loser drops the winner row on conflict",
+			policy:      ConversionPolicyKeepLoser,
+			wantDropped: []db.UnitConversion{wCup, lTbsp},
+		},
+		{
+			name:        "average updates the winner row",
+			policy:      ConversionPolicyAverage,
+			wantDropped: []db.UnitConversion{lCup, lTbsp},
+			wantUpdates: []db.UpdateUnitConversionFactorParams{{ID: wCup.ID, Factor: 125}},
+		},
+		{
+			name:    "fail returns a conflict error",
+			policy:  ConversionPolicyFail,
+			wantErr: ErrConversionConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			plan, err := planConversionMerge(winner, loser, tc.policy)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantDropped, plan.dropped)
+			assert.Equal(t, tc.wantUpdates, plan.updates)
+		})
+	}
+}
+
+func TestPlanConversionMerge_InverseAndDuplicateRows(t *testing.T) {
+	t.Parallel()
+
+	winnerID, loserID := uuid.New(), uuid.New()
+	wCup := newConversion(winnerID, "cup", "g", 120)
+	lInverse := newConversion(loserID, "g", "cup", 1.0/130)
+	lDuplicate := newConversion(loserID, "cup", "g", 130)
+	lAgreeing := newConversion(loserID, "g", "cup", 1.0/120)
+	winner := []db.UnitConversion{wCup}
+
+	t.Run("inverse conflict is resolved", func(t *testing.T) {
+		t.Parallel()
+		plan, err := planConversionMerge(winner, []db.UnitConversion{lInverse}, ConversionPolicyAverage)
+		require.NoError(t, err)
+		require.Len(t, plan.updates, 1)
+		assert.InDelta(t, 125, plan.updates[0].Factor, 1e-9)
+		assert.Equal(t, []db.UnitConversion{lInverse}, plan.dropped)
+	})
+
+	t.Run("inverse that agrees is dropped", func(t *testing.T) {
+		t.Parallel()
+		plan, err := planConversionMerge(winner, []db.UnitConversion{lAgreeing}, ConversionPolicyFail)
+		require.NoError(t, err)
+		assert.Equal(t, []db.UnitConversion{lAgreeing}, plan.dropped)
+	})
+
+	t.Run("keep loser drops the winner row once", func(t *testing.T) {
+		t.Parallel()
+		plan, err := planConversionMerge(winner, []db.UnitConversion{lInverse, lDuplicate}, ConversionPolicyKeepLoser)
+		require.NoError(t, err)
+		assert.Equal(t, []db.UnitConversion{wCup}, plan.dropped)
+	})
+
+	t.Run("average updates the winner row once", func(t *testing.T) {
+		t.Parallel()
+		plan, err := planConversionMerge(winner, []db.UnitConversion{lInverse, lDuplicate}, ConversionPolicyAverage)
+		require.NoError(t, err)
+		assert.Len(t, plan.updates, 1)
+		assert.Equal(t, []db.UnitConversion{lInverse, lDuplicate}, plan.dropped)
+	})
+}
+
+func TestPlanConversionMerge_WinnerHasBothDirections(t *testing.T) {
+	t.Parallel()
+
+	winnerID, loserID := uuid.New(), uuid.New()
+	wFwd := newConversion(winnerID, "cup", "g", 120)
+	wBack := newConversion(winnerID, "g", "cup", 1.0/120)
+	lFwd := newConversion(loserID, "cup", "g", 140)
+
+	// merged applies plan to the two sides, as the merge would.
+	merged := func(plan conversionMergePlan) []db.UnitConversion {
+		dropped := make(map[uuid.UUID]bool)
+		for _, c := range plan.dropped {
+			dropped[c.ID] = true
+		}
+		var out []db.UnitConversion
+		for _, c := range []db.UnitConversion{wFwd, wBack, lFwd} {
+			if dropped[c.ID] {
+				continue
+			}
+			for _, u := range plan.updates {
+				if u.ID == c.ID {
+					c.Factor = u.Factor
+				}
+			}
+			c.IngredientID = winnerID
+			out = append(out, c)
+		}
+		return out
+	}
+
+	for _, policy := range []ConversionPolicy{ConversionPolicyKeepWinner, ConversionPolicyKeepLoser, ConversionPolicyAverage} {
+		t.Run(string(policy), func(t *testing.T) {
+			t.Parallel()
+			plan, err := planConversionMerge([]db.UnitConversion{wFwd, wBack}, []db.UnitConversion{lFwd}, policy)
+			require.NoError(t, err)
+			result := merged(plan)
+			assert.Empty(t, checkConversions(result))
+			switch policy {
+			case ConversionPolicyKeepLoser:
+				assert.Equal(t, []db.UnitConversion{wFwd, wBack}, plan.dropped)
+			case ConversionPolicyAverage:
+				require.Len(t, result, 2)
+				assert.InDelta(t, 130, result[0].Factor, 1e-9)
+				assert.InDelta(t, 1.0/130, result[1].Factor, 1e-12)
+			}
+		})
+	}
+}
+
+func TestPlanComponentFold(t *testing.T) {
+	t.Parallel()
+
+	winnerID, loserID := uuid.New(), uuid.New()
+	sameUnit, loserOnly := uuid.New(), uuid.New()
+	component := func(composite, ingredient uuid.UUID, quantity float64, unit string) db.CompositeSubstituteComponent {
+		return db.CompositeSubstituteComponent{
+			ID:          uuid.New(),
+			CompositeID: composite,
+			ComponentID: ingredient,
+			Quantity:    quantity,
+			Unit:        sql.NullString{String: unit, Valid: unit != ""},
+		}
+	}
+	catalogUnit, convertedUnit := uuid.New(), uuid.New()
+	wSame := component(sameUnit, winnerID, 0.5, "cup")
+	wCatalog := component(catalogUnit, winnerID, 1, "cup")
+	wConverted := component(convertedUnit, winnerID, 1, "cup")
+	lSame := component(sameUnit, loserID, 0.25, "Cup")
+	lCatalog := component(catalogUnit, loserID, 4, "tbsp")
+	lConverted := component(convertedUnit, loserID, 60, "g")
+	lOnly := component(loserOnly, loserID, 1, "")
+	convs := []db.UnitConversion{newConversion(winnerID, "cup", "g", 120)}
+
+	t.Run("converts loser quantities into the winner's unit", func(t *testing.T) {
+		t.Parallel()
+		fold, err := planComponentFold(
+			[]db.CompositeSubstituteComponent{wSame, wCatalog, wConverted},
+			[]db.CompositeSubstituteComponent{lSame, lCatalog, lConverted, lOnly},
+			convs,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []db.CompositeSubstituteComponent{lSame, lCatalog, lConverted}, fold.dropped)
+		require.Len(t, fold.updates, 3)
+		assert.Equal(t, quantityUpdate{ID: wSame.ID, Before: 0.5, After: 0.75}, fold.updates[0])
+		assert.Equal(t, wCatalog.ID, fold.updates[1].ID)
+		assert.InDelta(t, 1.25, fold.updates[1].After, 1e-3)
+		assert.Equal(t, wConverted.ID, fold.updates[2].ID)
+		assert.InDelta(t, 1.5, fold.updates[2].After, 1e-9)
+	})
+
+	t.Run("fails when units cannot be converted", func(t *testing.T) {
+		t.Parallel()
+		_, err := planComponentFold(
+			[]db.CompositeSubstituteComponent{wConverted},
+			[]db.CompositeSubstituteComponent{lConverted},
+			nil,
+		)
+		assert.ErrorIs(t, err, ErrComponentUnitMismatch)
+	})
+}
+
+func TestConversionConflicts(t *testing.T) {
+	t.Parallel()
+
+	winnerID, loserID := uuid.New(), uuid.New()
+	winner := []db.UnitConversion{newConversion(winnerID, "cup", "g", 120), newConversion(winnerID, "tbsp", "g", 7.5)}
+	loser := []db.UnitConversion{
+		newConversion(loserID, "Cup", "g", 130),
+		newConversion(loserID, "tbsp", "g", 7.51),
+		newConversion(loserID, "stick", "g", 113),
+	}
+
+	assert.Equal(t, []ConversionConflict{{LoserID: loserID, FromUnit: "cup", ToUnit: "g", WinnerFactor: 120, LoserFactor: 130}},
+		conversionConflicts(winner, loser))
+
+	inverse := []db.UnitConversion{newConversion(loserID, "g", "cup", 0.01)}
+	assert.Equal(t, []ConversionConflict{{LoserID: loserID, FromUnit: "cup", ToUnit: "g", WinnerFactor: 120, LoserFactor: 100}},
+		conversionConflicts(winner, inverse))
+}
+
+func TestMergeDifferences(t *testing.T) {
+	t.Parallel()
+
+	cat := uuid.New()
+	winner := db.Ingredient{DefaultUnit: sql.NullString{String: "ml", Valid: true}}
+	loser := db.Ingredient{
+		ID:          uuid.New(),
+		DefaultUnit: sql.NullString{String: "g", Valid: true},
+		CategoryID:  uuid.NullUUID{UUID: cat, Valid: true},
+	}
+
+	after := winner
+	after.CategoryID = loser.CategoryID
+
+	assert.Equal(t, []MergeDifference{
+		{LoserID: loser.ID, Field: "category", Winner: "", Loser: cat.String(), Result: cat.String()},
+		{LoserID: loser.ID, Field: "default_unit", Winner: "ml", Loser: "g", Result: "ml"},
+	}, mergeDifferences(winner, loser, after))
+	assert.Empty(t, mergeDifferences(winner, winner, winner))
+}
+
+func TestPreviewMerge(t *testing.T) {
+	t.Parallel()
+	mockQ := mocks.NewMockQuerier(t)
+
+	winner := newIngredient("coconut milk", []string{"coconut cream"})
+	loser := newIngredient("coconut cream", nil)
+	moved := db.IngredientSubstitute{ID: uuid.New(), IngredientID: loser.ID, SubstituteID: uuid.New()}
+	dropped := db.IngredientSubstitute{ID: uuid.New(), IngredientID: loser.ID, SubstituteID: winner.ID}
+	conv := newConversion(loser.ID, "cup", "g", 230)
+	fridge := db.StorageGuideline{ID: uuid.New(), Location: "fridge"}
+	snap := mergeSnapshot{
+		Loser:             loser,
+		WinnerBefore:      winner,
+		WinnerAfter:       winner,
+		Substitutes:       []db.IngredientSubstitute{moved, dropped},
+		CompositeIDs:      []uuid.UUID{uuid.New()},
+		StorageGuidelines: []db.StorageGuideline{fridge},
+		Nutrition:         &db.IngredientNutrition{IngredientID: loser.ID},
+		RedirectsTo:       []uuid.UUID{uuid.New()},
+	}
+
+	mockQ.EXPECT().ListSubstitutesTouching(mock.Anything, winner.ID).Return([]db.IngredientSubstitute{moved}, nil)
+	mockQ.EXPECT().ListCompositeSubstituteIDsByIngredient(mock.Anything, winner.ID).Return(snap.CompositeIDs, nil)
+	mockQ.EXPECT().ListCompositeComponentIDsByComponent(mock.Anything, winner.ID).Return(nil, nil)
+	mockQ.EXPECT().ListStorageGuidelinesByIngredient(mock.Anything, winner.ID).Return(nil, nil)
+	mockQ.EXPECT().GetIngredientNutrition(mock.Anything, winner.ID).Return(db.IngredientNutrition{IngredientID: winner.ID}, nil)
+
+	mockQ.EXPECT().ListUnitConversionsByIngredient(mock.Anything, winner.ID).Return([]db.UnitConversion{conv}, nil)
+
+	p, err := previewMerge(context.Background(), mockQ, snap, []db.UnitConversion{conv}, conversionMergePlan{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"coconut cream"}, p.Aliases)
+	assert.Equal(t, 1, p.SubstitutesMoved)
+	assert.Equal(t, 1, p.SubstitutesDropped)
+	assert.Equal(t, 1, p.CompositesMoved)
+	assert.Equal(t, 1, p.ConversionsMoved)
+	assert.Equal(t, 1, p.StorageDropped)
+	assert.True(t, p.NutritionMoved)
+	assert.Equal(t, 1, p.RedirectsMoved)
+}
+
+func TestMergeDietary(t *testing.T) {
+	t.Parallel()
+
+	winner := db.Ingredient{Allergens: []string{"milk"}, DietaryTags: []string{}}
+	loser := db.Ingredient{Allergens: []string{"milk", "eggs"}, DietaryTags: []string{"vegetarian"}}
+
+	allergens, tags, _ := mergeDietary(winner, loser)
+	assert.Equal(t, []string{"milk", "eggs"}, allergens)
+	assert.Equal(t, []string{"vegetarian"}, tags)
+
+	winner.DietaryTags = []string{"vegan", "gluten_free"}
+	_, tags, _ = mergeDietary(winner, loser)
+	assert.Equal(t, []string{"gluten_free"}, tags, "the loser's milk and eggs rule out vegan")
+
+	// A loser carrying an allergen the winner was free of cancels the override.
+	winner.FreeOf = []string{"eggs", "soy"}
+	_, _, freeOf := mergeDietary(winner, loser)
+	assert.Equal(t, []string{"soy"}, freeOf)
+}
+
+func TestMergeMany_InvalidLosers(t *testing.T) {
+	t.Parallel()
+
+	winnerID, a := uuid.New(), uuid.New()
+	tooMany := make([]uuid.UUID, maxMergeLosers+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New()
+	}
+
+	tests := []struct {
+		name     string
+		loserIDs []uuid.UUID
+		opts     MergeOptions
+	}{
+		{name: "no losers", loserIDs: nil},
+		{name: "winner among losers", loserIDs: []uuid.UUID{a, winnerID}},
+		{name: "repeated loser", loserIDs: []uuid.UUID{a, a}},
+		{name: "too many losers", loserIDs: tooMany},
+		{name: "loser version with several losers", loserIDs: []uuid.UUID{a, uuid.New()}, opts: MergeOptions{LoserVersion: 1}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			svc := New(mocks.NewMockQuerier(t), nil, 0.8)
+
+			_, err := svc.MergeMany(context.Background(), winnerID, tc.loserIDs, tc.opts)
+			assert.ErrorIs(t, err, ErrInvalidMerge)
+		})
+	}
+}
@@ -1,8 +1,21 @@
 package service
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
 
-// Normalize lowercases and trims whitespace from a raw ingredient name.
+// Normalize lowercases and trims whitespace from a raw ingredient name. The
+// normalize_term SQL function applies the same rule in migrations.
 func Normalize(s string) string {
 	return strings.TrimSpace(strings.ToLower(s))
 }
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify lowercases s and joins its alphanumeric runs with hyphens, so
+// "Dairy & Eggs" becomes "dairy-eggs". It matches the mapping applied to
+// free-text categories by the categories migration.
+func Slugify(s string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
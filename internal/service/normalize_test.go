@@ -59,3 +59,26 @@ func TestNormalize(t *testing.T) {
 		})
 	}
 }
+
+func TestSlugify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "lowercases", input: "Dairy", want: "dairy"},
+		{name: "trims whitespace", input: " dairy ", want: "dairy"},
+		{name: "joins words with hyphens", input: "Dairy & Eggs", want: "dairy-eggs"},
+		{name: "strips leading and trailing punctuation", input: "--baking!", want: "baking"},
+		{name: "punctuation only returns empty", input: "&&", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Slugify(tt.input))
+		})
+	}
+}
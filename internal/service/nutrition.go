@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+var (
+	// ErrNutritionNotFound is returned when an ingredient has no nutrition data.
+	ErrNutritionNotFound = errors.New("no nutrition data for ingredient")
+	// ErrInvalidNutritionCSV is returned when an import file has no usable
+	// header.
+	ErrInvalidNutritionCSV = errors.New("invalid nutrition csv")
+)
+
+// nutrientColumn maps a per-100g nutrient onto its CSV headers. Headers are
+// compared after slugifying with underscores, so "Protein (g)" matches
+// "protein_g".
+type nutrientColumn struct {
+	headers []string
+	set     func(*db.UpsertIngredientNutritionParams, sql.NullFloat64)
+}
+
+var nutrientColumns = []nutrientColumn{
+	{[]string{"energy_kcal", "energy", "calories", "kcal"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.EnergyKcal = v }},
+	{[]string{"protein_g", "protein"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.ProteinG = v }},
+	{[]string{"fat_g", "fat", "total_fat", "total_lipid_fat", "total_lipid_fat_g"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.FatG = v }},
+	{[]string{"saturated_fat_g", "saturated_fat", "fatty_acids_total_saturated_g"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.SaturatedFatG = v }},
+	{[]string{"carbohydrate_g", "carbohydrate", "carbohydrates", "carbohydrate_by_difference_g"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.CarbohydrateG = v }},
+	{[]string{"sugars_g", "sugars", "sugar", "sugars_total_g"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.SugarsG = v }},
+	{[]string{"fiber_g", "fiber", "fibre", "fibre_g", "fiber_total_dietary_g"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.FiberG = v }},
+	{[]string{"sodium_mg", "sodium", "sodium_na_mg"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.SodiumMg = v }},
+	{[]string{"potassium_mg", "potassium", "potassium_k_mg"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.PotassiumMg = v }},
+	{[]string{"calcium_mg", "calcium", "calcium_ca_mg"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.CalciumMg = v }},
+	{[]string{"iron_mg", "iron", "iron_fe_mg"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.IronMg = v }},
+	{[]string{"vitamin_c_mg", "vitamin_c", "vitamin_c_total_ascorbic_acid_mg"}, func(p *db.UpsertIngredientNutritionParams, v sql.NullFloat64) { p.VitaminCMg = v }},
+}
+
+var (
+	nameHeaders     = []string{"name", "description", "food_name", "food"}
+	sourceIDHeaders = []string{"id", "source_id", "fdc_id", "food_code", "code"}
+)
+
+// NutritionImportOptions controls ImportNutrition.
+type NutritionImportOptions struct {
+	// Source names the dataset, e.g. "usda-fdc". Required.
+	Source string
+	// DryRun reports the mapping without writing anything.
+	DryRun bool
+}
+
+// NutritionRowStatus is the outcome of one dataset row.
+type NutritionRowStatus string
+
+const (
+	NutritionRowMatched    NutritionRowStatus = "matched"
+	NutritionRowSuperseded NutritionRowStatus = "superseded"
+	NutritionRowInvalid    NutritionRowStatus = "invalid"
+)
+
+// NutritionImportRow describes a dataset row that matched an ingredient or
+// could not be read. Superseded rows matched an ingredient that a row with
+// higher confidence also matched.
+type NutritionImportRow struct {
+	Line       int
+	Name       string
+	SourceID   string
+	Status     NutritionRowStatus
+	Ingredient db.Ingredient
+	Confidence float64
+	Error      string
+}
+
+// NutritionImportReport summarises an import. Rows lists matched, superseded
+// and invalid rows; rows that matched no ingredient are only counted, since a
+// full dataset is mostly foods the dictionary doesn't know.
+type NutritionImportReport struct {
+	Source    string
+	DryRun    bool
+	RowsRead  int
+	Unmatched int
+	Imported  int
+	Rows      []NutritionImportRow
+}
+
+// GetNutrition returns an ingredient's nutrition facts per 100 g. It returns
+// sql.ErrNoRows if the ingredient does not exist and ErrNutritionNotFound if
+// it has no nutrition data.
+func (s *Service) GetNutrition(ctx context.Context, id uuid.UUID) (db.IngredientNutrition, error) {
+	if _, err := s.q.GetIngredient(ctx, id); err != nil {
+		return db.IngredientNutrition{}, err
+	}
+	n, err := s.q.GetIngredientNutrition(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.IngredientNutrition{}, ErrNutritionNotFound
+		}
+		return db.IngredientNutrition{}, err
+	}
+	return n, nil
+}
+
+// ImportNutrition reads a food composition CSV and links its rows to
+// dictionary ingredients with the same matching as Resolve, but never creates
+// ingredients. Rows must match at or above the resolve threshold. When
+// several rows match one ingredient, the most confident wins. Unless
+// opts.DryRun is set, the winning rows are upserted in a single transaction.
+//
+// The CSV needs a header row with a name column ("name" or "description")
+// and any of the nutrient columns; see nutrientColumns for accepted headers.
+func (s *Service) ImportNutrition(ctx context.Context, r io.Reader, opts NutritionImportOptions) (NutritionImportReport, error) {
+	opts.Source = strings.TrimSpace(opts.Source)
+	if opts.Source == "" {
+		return NutritionImportReport{}, fmt.Errorf("%w: source is required", ErrInvalidNutritionCSV)
+	}
+	rows, err := parseNutritionCSV(r)
+	if err != nil {
+		return NutritionImportReport{}, err
+	}
+	ingredients, err := s.q.ListIngredients(ctx)
+	if err != nil {
+		return NutritionImportReport{}, err
+	}
+
+	report := NutritionImportReport{Source: opts.Source, DryRun: opts.DryRun, RowsRead: len(rows)}
+	best := make(map[uuid.UUID]int) // ingredient → index into report.Rows
+	params := make(map[int]db.UpsertIngredientNutritionParams)
+	for _, row := range rows {
+		if row.err != "" {
+			report.Rows = append(report.Rows, NutritionImportRow{
+				Line: row.line, Name: row.name, SourceID: row.sourceID,
+				Status: NutritionRowInvalid, Error: row.err,
+			})
+			continue
+		}
+		ing, score := matchDatasetName(ingredients, row.name)
+		if score < s.threshold {
+			report.Unmatched++
+			continue
+		}
+		entry := NutritionImportRow{
+			Line: row.line, Name: row.name, SourceID: row.sourceID,
+			Status: NutritionRowMatched, Ingredient: ing, Confidence: score,
+		}
+		if prev, ok := best[ing.ID]; ok {
+			if report.Rows[prev].Confidence >= score {
+				entry.Status = NutritionRowSuperseded
+				report.Rows = append(report.Rows, entry)
+				continue
+			}
+			report.Rows[prev].Status = NutritionRowSuperseded
+		}
+		best[ing.ID] = len(report.Rows)
+		report.Rows = append(report.Rows, entry)
+
+		p := row.params
+		p.IngredientID = ing.ID
+		p.Source = opts.Source
+		params[len(report.Rows)-1] = p
+	}
+
+	var toWrite []db.UpsertIngredientNutritionParams
+	for i, row := range report.Rows {
+		if row.Status == NutritionRowMatched {
+			toWrite = append(toWrite, params[i])
+		}
+	}
+	if opts.DryRun || len(toWrite) == 0 {
+		return report, nil
+	}
+
+	tx, err := s.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return NutritionImportReport{}, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	qtx := db.New(tx)
+	for _, p := range toWrite {
+		if _, err := qtx.UpsertIngredientNutrition(ctx, p); err != nil {
+			return NutritionImportReport{}, fmt.Errorf("upsert nutrition for %s: %w", p.IngredientID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return NutritionImportReport{}, err
+	}
+	report.Imported = len(toWrite)
+	slog.Info("nutrition import complete", "source", opts.Source, "rows", report.RowsRead, "imported", report.Imported)
+	return report, nil
+}
+
+// matchDatasetName matches a dataset food name against the dictionary.
+// Composition datasets name foods "Cheese, cheddar", so the comma-reversed
+// form ("cheddar cheese") is tried as well.
+func matchDatasetName(ingredients []db.Ingredient, name string) (db.Ingredient, float64) {
+	normalized := Normalize(name)
+	ing, score := bestMatch(ingredients, normalized)
+	parts := strings.Split(normalized, ",")
+	if len(parts) < 2 {
+		return ing, score
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	slices.Reverse(parts)
+	if alt, altScore := bestMatch(ingredients, strings.Join(parts, " ")); altScore > score {
+		return alt, altScore
+	}
+	return ing, score
+}
+
+type nutritionCSVRow struct {
+	line     int
+	name     string
+	sourceID string
+	params   db.UpsertIngredientNutritionParams
+	err      string
+}
+
+// parseNutritionCSV reads the header and every data row. Row-level problems
+// are recorded on the row; only an unusable header is an error.
+func parseNutritionCSV(r io.Reader) ([]nutritionCSVRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: empty file", ErrInvalidNutritionCSV)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNutritionCSV, err)
+	}
+	type indexedColumn struct {
+		index int
+		nutrientColumn
+	}
+	nameCol, idCol := -1, -1
+	var nutrientCols []indexedColumn
+	for i, h := range header {
+		key := strings.ReplaceAll(Slugify(h), "-", "_")
+		switch {
+		case nameCol < 0 && slices.Contains(nameHeaders, key):
+			nameCol = i
+		case idCol < 0 && slices.Contains(sourceIDHeaders, key):
+			idCol = i
+		default:
+			for _, c := range nutrientColumns {
+				if slices.Contains(c.headers, key) {
+					nutrientCols = append(nutrientCols, indexedColumn{index: i, nutrientColumn: c})
+					break
+				}
+			}
+		}
+	}
+	if nameCol < 0 {
+		return nil, fmt.Errorf("%w: no name column", ErrInvalidNutritionCSV)
+	}
+	if len(nutrientCols) == 0 {
+		return nil, fmt.Errorf("%w: no nutrient columns", ErrInvalidNutritionCSV)
+	}
+
+	var rows []nutritionCSVRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			rows = append(rows, nutritionCSVRow{line: csvErrorLine(err), err: err.Error()})
+			continue
+		}
+		line, _ := cr.FieldPos(0)
+		row := nutritionCSVRow{line: line}
+		if nameCol < len(record) {
+			row.name = strings.TrimSpace(record[nameCol])
+		}
+		if idCol >= 0 && idCol < len(record) {
+			row.sourceID = strings.TrimSpace(record[idCol])
+		}
+		row.params.SourceName = row.name
+		row.params.SourceID = nullString(row.sourceID)
+		if row.name == "" {
+			row.err = "missing name"
+			rows = append(rows, row)
+			continue
+		}
+		for _, c := range nutrientCols {
+			if c.index >= len(record) {
+				continue
+			}
+			raw := strings.TrimSpace(record[c.index])
+			if raw == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil || v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+				row.err = fmt.Sprintf("invalid value %q in column %q", raw, header[c.index])
+				break
+			}
+			c.set(&row.params, sql.NullFloat64{Float64: v, Valid: true})
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// csvErrorLine returns the line a CSV read error starts on, or 0 if the
+// error does not say.
+func csvErrorLine(err error) int {
+	var pe *csv.ParseError
+	if errors.As(err, &pe) {
+		return pe.StartLine
+	}
+	return 0
+}
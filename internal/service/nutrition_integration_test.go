@@ -0,0 +1,45 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportNutrition_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	butter, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "butter", Aliases: []string{}})
+	require.NoError(t, err)
+	margarine, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "margarine", Aliases: []string{}})
+	require.NoError(t, err)
+
+	input := "fdc_id,description,energy_kcal,fat_g\n173410,\"Butter\",717,81.1\n"
+	report, err := svc.ImportNutrition(ctx, strings.NewReader(input), NutritionImportOptions{Source: "usda-fdc"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Imported)
+
+	n, err := svc.GetNutrition(ctx, butter.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "usda-fdc", n.Source)
+	assert.Equal(t, "173410", n.SourceID.String)
+	assert.InDelta(t, 717, n.EnergyKcal.Float64, 1e-9)
+
+	// Merging butter into margarine carries the data over, since margarine
+	// has none of its own.
+	_, err = svc.Merge(ctx, margarine.ID, butter.ID, MergeOptions{})
+	require.NoError(t, err)
+	n, err = svc.GetNutrition(ctx, margarine.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Butter", n.SourceName)
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNutritionCSV(t *testing.T) {
+	t.Parallel()
+
+	input := `FDC ID,Description,Energy (kcal),Protein (g),Total lipid (fat) (g),Unknown column
+1001,"Cheese, cheddar",403,24.9,33.1,x
+1002,Butter,717,,81.1,y
+1003,Bad row,abc,1,1,z
+1004,,100,1,1,z
+`
+	rows, err := parseNutritionCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, "Cheese, cheddar", rows[0].name)
+	assert.Equal(t, "1001", rows[0].sourceID)
+	assert.Equal(t, 2, rows[0].line)
+	assert.Equal(t, sql.NullFloat64{Float64: 403, Valid: true}, rows[0].params.EnergyKcal)
+	assert.Equal(t, sql.NullFloat64{Float64: 33.1, Valid: true}, rows[0].params.FatG)
+
+	assert.False(t, rows[1].params.ProteinG.Valid, "empty cells are NULL, not zero")
+	assert.Contains(t, rows[2].err, "invalid value")
+	assert.Equal(t, "missing name", rows[3].err)
+}
+
+func TestParseNutritionCSV_MalformedRow(t *testing.T) {
+	t.Parallel()
+
+	input := "Description,Energy (kcal),Protein (g)\n" +
+		"Butter,717,0.9\n" +
+		"\"Cheese, cheddar,403,24.9\n"
+	rows, err := parseNutritionCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "Butter", rows[0].name)
+	assert.Equal(t, 3, rows[1].line)
+	assert.NotEmpty(t, rows[1].err)
+}
+
+func TestParseNutritionCSV_ReportsFirstBadColumn(t *testing.T) {
+	t.Parallel()
+
+	input := "Description,Energy (kcal),Protein (g),Total lipid (fat) (g)\nButter,abc,x,y\n"
+	for range 20 {
+		rows, err := parseNutritionCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, `invalid value "abc" in column "Energy (kcal)"`, rows[0].err)
+	}
+}
+
+func TestParseNutritionCSV_RejectsNonFinite(t *testing.T) {
+	t.Parallel()
+
+	for _, raw := range []string{"NaN", "nan", "Inf", "+Inf", "-Inf", "infinity", "1e400"} {
+		input := "Description,Energy (kcal)\nButter," + raw + "\n"
+		rows, err := parseNutritionCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Contains(t, rows[0].err, "invalid value", raw)
+	}
+}
+
+func TestParseNutritionCSV_BadHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "no name column", input: "id,protein_g\n1,2\n"},
+		{name: "no nutrient columns", input: "name,colour\nbutter,yellow\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseNutritionCSV(strings.NewReader(tt.input))
+			assert.ErrorIs(t, err, ErrInvalidNutritionCSV)
+		})
+	}
+}
+
+func TestMatchDatasetName_ReversesCommaNames(t *testing.T) {
+	t.Parallel()
+
+	cheddar := newIngredient("cheddar cheese", []string{})
+	butter := newIngredient("butter", []string{})
+
+	ing, score := matchDatasetName([]db.Ingredient{butter, cheddar}, "Cheese, Cheddar")
+	assert.Equal(t, cheddar.ID, ing.ID)
+	assert.Equal(t, 1.0, score)
+}
+
+func TestImportNutrition_DryRun(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	butter := newIngredient("butter", []string{})
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{butter}, nil)
+
+	input := `name,energy_kcal
+Buttery spread,500
+Butter,717
+Kale,49
+`
+	report, err := svc.ImportNutrition(context.Background(), strings.NewReader(input), NutritionImportOptions{
+		Source: "usda-fdc",
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.RowsRead)
+	assert.Equal(t, 2, report.Unmatched)
+	assert.Equal(t, 0, report.Imported)
+	require.Len(t, report.Rows, 1)
+	assert.Equal(t, NutritionRowMatched, report.Rows[0].Status)
+	assert.Equal(t, butter.ID, report.Rows[0].Ingredient.ID)
+}
+
+func TestImportNutrition_KeepsMostConfidentRow(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	butter := newIngredient("butter", []string{})
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{butter}, nil)
+
+	input := `name,energy_kcal
+Butters,700
+Butter,717
+Butter,720
+`
+	report, err := svc.ImportNutrition(context.Background(), strings.NewReader(input), NutritionImportOptions{
+		Source: "usda-fdc",
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Rows, 3)
+	statuses := []NutritionRowStatus{report.Rows[0].Status, report.Rows[1].Status, report.Rows[2].Status}
+	assert.Equal(t, []NutritionRowStatus{NutritionRowSuperseded, NutritionRowMatched, NutritionRowSuperseded}, statuses)
+}
+
+func TestImportNutrition_RequiresSource(t *testing.T) {
+	t.Parallel()
+
+	svc := New(mocks.NewMockQuerier(t), nil, 0.8)
+	_, err := svc.ImportNutrition(context.Background(), strings.NewReader("name,energy_kcal\n"), NutritionImportOptions{})
+	assert.ErrorIs(t, err, ErrInvalidNutritionCSV)
+}
+
+func TestGetNutrition_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	butter := newIngredient("butter", []string{})
+	mockQ.EXPECT().GetIngredient(mock.Anything, butter.ID).Return(butter, nil)
+	mockQ.EXPECT().GetIngredientNutrition(mock.Anything, butter.ID).Return(db.IngredientNutrition{}, sql.ErrNoRows)
+
+	_, err := svc.GetNutrition(context.Background(), butter.ID)
+	assert.ErrorIs(t, err, ErrNutritionNotFound)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// ErrInvalidName is returned for an empty ingredient name.
+var ErrInvalidName = errors.New("name is required")
+
+// IngredientPatch is a partial update of an ingredient. Nil fields are left
+// as they are; a non-nil field replaces the current value, so a pointer to
+// an empty slice or a null value clears it. Version, if set, is the version
+// the ingredient is expected to be at.
+type IngredientPatch struct {
+	Name        *string
+	Aliases     *[]string
+	CategoryID  *uuid.NullUUID
+	DefaultUnit *sql.NullString
+	Version     int64
+}
+
+// PatchIngredient applies p to an ingredient. Renaming keeps the old name as
+// an alias, drops the new name from the aliases if it was one and is
+// recorded in history as ChangeRename. It returns sql.ErrNoRows if the
+// ingredient does not exist, ErrInvalidName for an empty name,
+// ErrInvalidAlias if p.Aliases lists the ingredient's name and an
+// *AliasConflictError if the new name or an alias belongs to another
+// ingredient.
+//
+// The patch applies to the ingredient as it is read; if another write lands
+// first, or p.Version is set and not current, it fails with
+// ErrVersionMismatch.
+func (s *Service) PatchIngredient(ctx context.Context, id uuid.UUID, p IngredientPatch) (db.Ingredient, error) {
+	ing, err := s.q.GetIngredient(ctx, id)
+	if err != nil {
+		return db.Ingredient{}, err
+	}
+	if p.Version != 0 && p.Version != ing.Version {
+		return db.Ingredient{}, ErrVersionMismatch
+	}
+
+	params := db.UpdateIngredientParams{
+		ID:              id,
+		Aliases:         ing.Aliases,
+		CategoryID:      ing.CategoryID,
+		DefaultUnit:     ing.DefaultUnit,
+		ExpectedVersion: sql.NullInt64{Int64: ing.Version, Valid: true},
+		CreatedBy:       actorFrom(ctx),
+		ChangeReason:    reasonFrom(ctx),
+	}
+	if p.Aliases != nil {
+		params.Aliases = normalizeAliases(*p.Aliases)
+	}
+	if p.CategoryID != nil {
+		params.CategoryID = *p.CategoryID
+	}
+	if p.DefaultUnit != nil {
+		params.DefaultUnit = *p.DefaultUnit
+	}
+
+	terms := params.Aliases
+	name := ing.Name
+	if p.Name != nil {
+		name = Normalize(*p.Name)
+		if name == "" {
+			return db.Ingredient{}, ErrInvalidName
+		}
+	}
+	if p.Aliases != nil && slices.Contains(params.Aliases, name) {
+		return db.Ingredient{}, nameAsAlias(name)
+	}
+	if name != ing.Name {
+		params.Name = nullString(name)
+		params.ChangeOperation = nullString(string(ChangeRename))
+		params.Aliases = renamedAliases(params.Aliases, ing.Name, name)
+		terms = append([]string{name}, params.Aliases...)
+	}
+
+	if err := checkTerms(ctx, s.q, terms, id); err != nil {
+		return db.Ingredient{}, err
+	}
+	updated, err := s.q.UpdateIngredient(ctx, params)
+	if err != nil {
+		err = versionMissed(ctx, s.q, id, err)
+		return db.Ingredient{}, termsTaken(ctx, s.q, err, terms, id)
+	}
+	return updated, nil
+}
+
+// renamedAliases returns aliases for an ingredient renamed from oldName to
+// newName: without newName, and with oldName appended if missing.
+func renamedAliases(aliases []string, oldName, newName string) []string {
+	out := make([]string, 0, len(aliases)+1)
+	for _, a := range aliases {
+		if a != newName {
+			out = append(out, a)
+		}
+	}
+	if !slices.Contains(out, oldName) {
+		out = append(out, oldName)
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchIngredient_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	ing, err := svc.CreateIngredient(ctx, IngredientInput{
+		Name:        "garlic clove",
+		Aliases:     []string{"garlic"},
+		DefaultUnit: sql.NullString{String: "clove", Valid: true},
+	})
+	require.NoError(t, err)
+
+	// Clearing the unit leaves the aliases alone.
+	ing, err = svc.PatchIngredient(ctx, ing.ID, IngredientPatch{DefaultUnit: &sql.NullString{}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"garlic"}, ing.Aliases)
+	assert.False(t, ing.DefaultUnit.Valid)
+
+	// Renaming to an alias swaps the two.
+	name := "Garlic"
+	ing, err = svc.PatchIngredient(ctx, ing.ID, IngredientPatch{Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, "garlic", ing.Name)
+	assert.Equal(t, []string{"garlic clove"}, ing.Aliases)
+
+	result, err := svc.Resolve(ctx, "garlic clove", ResolveOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, ing.ID, result.Ingredient.ID)
+
+	// Another ingredient's name is not available.
+	shallot, err := svc.CreateIngredient(ctx, IngredientInput{Name: "shallot"})
+	require.NoError(t, err)
+	_, err = svc.PatchIngredient(ctx, shallot.ID, IngredientPatch{Name: &name})
+	var conflict *AliasConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, ing.ID, conflict.OwnerID)
+
+	_, err = svc.PatchIngredient(ctx, uuid.New(), IngredientPatch{})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
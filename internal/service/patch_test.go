@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchIngredient(t *testing.T) {
+	t.Parallel()
+
+	category := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	current := newIngredient("garlic clove", []string{"clove", "garlic"})
+	current.CategoryID = category
+	current.DefaultUnit = sql.NullString{String: "clove", Valid: true}
+	current.Version = 3
+
+	ptr := func(s string) *string { return &s }
+	aliases := func(a ...string) *[]string { return &a }
+
+	tests := []struct {
+		name      string
+		patch     IngredientPatch
+		wantTerms []string
+		want      db.UpdateIngredientParams
+	}{
+		{
+			name:      "empty patch keeps everything",
+			wantTerms: []string{"clove", "garlic"},
+			want: db.UpdateIngredientParams{
+				Aliases:     []string{"clove", "garlic"},
+				CategoryID:  category,
+				DefaultUnit: current.DefaultUnit,
+			},
+		},
+		{
+			name:      "only category cleared",
+			patch:     IngredientPatch{CategoryID: &uuid.NullUUID{}},
+			wantTerms: []string{"clove", "garlic"},
+			want: db.UpdateIngredientParams{
+				Aliases:     []string{"clove", "garlic"},
+				DefaultUnit: current.DefaultUnit,
+			},
+		},
+		{
+			name:      "aliases replaced, unit cleared",
+			patch:     IngredientPatch{Aliases: aliases(" Clove "), DefaultUnit: &sql.NullString{}},
+			wantTerms: []string{"clove"},
+			want: db.UpdateIngredientParams{
+				Aliases:    []string{"clove"},
+				CategoryID: category,
+			},
+		},
+		{
+			name:      "rename to an own alias keeps the old name",
+			patch:     IngredientPatch{Name: ptr("Garlic")},
+			wantTerms: []string{"garlic", "clove", "garlic clove"},
+			want: db.UpdateIngredientParams{
+				Name:            sql.NullString{String: "garlic", Valid: true},
+				Aliases:         []string{"clove", "garlic clove"},
+				CategoryID:      category,
+				DefaultUnit:     current.DefaultUnit,
+				ChangeOperation: sql.NullString{String: "rename", Valid: true},
+			},
+		},
+		{
+			name:      "rename to the same name",
+			patch:     IngredientPatch{Name: ptr(" GARLIC CLOVE ")},
+			wantTerms: []string{"clove", "garlic"},
+			want: db.UpdateIngredientParams{
+				Aliases:     []string{"clove", "garlic"},
+				CategoryID:  category,
+				DefaultUnit: current.DefaultUnit,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mockQ := mocks.NewMockQuerier(t)
+			svc := New(mockQ, nil, 0.8)
+
+			tc.want.ID = current.ID
+			tc.want.ExpectedVersion = sql.NullInt64{Int64: 3, Valid: true}
+			mockQ.EXPECT().GetIngredient(mock.Anything, current.ID).Return(current, nil)
+			mockQ.EXPECT().ListTermOwners(mock.Anything, tc.wantTerms).Return(nil, nil)
+			mockQ.EXPECT().UpdateIngredient(mock.Anything, tc.want).Return(current, nil)
+
+			_, err := svc.PatchIngredient(context.Background(), current.ID, tc.patch)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPatchIngredient_Rejected(t *testing.T) {
+	t.Parallel()
+
+	current := newIngredient("garlic clove", []string{})
+	blank, taken := " ", "shallot"
+	other := uuid.New()
+
+	t.Run("empty name", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		mockQ.EXPECT().GetIngredient(mock.Anything, current.ID).Return(current, nil)
+
+		_, err := New(mockQ, nil, 0.8).PatchIngredient(context.Background(), current.ID, IngredientPatch{Name: &blank})
+		assert.ErrorIs(t, err, ErrInvalidName)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		mockQ.EXPECT().GetIngredient(mock.Anything, current.ID).Return(current, nil)
+		mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"shallot", "garlic clove"}).Return([]db.ListTermOwnersRow{
+			{ID: other, Name: "shallot", Term: "shallot", IsName: true},
+		}, nil)
+
+		_, err := New(mockQ, nil, 0.8).PatchIngredient(context.Background(), current.ID, IngredientPatch{Name: &taken})
+		var conflict *AliasConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, other, conflict.OwnerID)
+	})
+
+	t.Run("name as alias", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		mockQ.EXPECT().GetIngredient(mock.Anything, current.ID).Return(current, nil)
+
+		aliases := []string{"clove", "Garlic Clove"}
+		_, err := New(mockQ, nil, 0.8).PatchIngredient(context.Background(), current.ID, IngredientPatch{Aliases: &aliases})
+		assert.ErrorIs(t, err, ErrInvalidAlias)
+
+		renamed, aliases := "clove", []string{"clove"}
+		_, err = New(mockQ, nil, 0.8).PatchIngredient(context.Background(), current.ID, IngredientPatch{Name: &renamed, Aliases: &aliases})
+		assert.ErrorIs(t, err, ErrInvalidAlias)
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		mockQ.EXPECT().GetIngredient(mock.Anything, current.ID).Return(db.Ingredient{ID: current.ID, Version: 2}, nil)
+
+		_, err := New(mockQ, nil, 0.8).PatchIngredient(context.Background(), current.ID, IngredientPatch{Version: 1})
+		assert.ErrorIs(t, err, ErrVersionMismatch)
+	})
+
+	t.Run("changed since read", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		mockQ.EXPECT().GetIngredient(mock.Anything, current.ID).Return(current, nil)
+		mockQ.EXPECT().UpdateIngredient(mock.Anything, mock.Anything).Return(db.Ingredient{}, sql.ErrNoRows)
+
+		_, err := New(mockQ, nil, 0.8).PatchIngredient(context.Background(), current.ID, IngredientPatch{})
+		assert.ErrorIs(t, err, ErrVersionMismatch)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		mockQ.EXPECT().GetIngredient(mock.Anything, current.ID).Return(db.Ingredient{}, sql.ErrNoRows)
+
+		_, err := New(mockQ, nil, 0.8).PatchIngredient(context.Background(), current.ID, IngredientPatch{})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
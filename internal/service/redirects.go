@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// maxTranslateIDs bounds a single TranslateIDs call.
+const maxTranslateIDs = 1000
+
+// ErrTooManyIDs is returned when TranslateIDs is given more than
+// maxTranslateIDs IDs.
+var ErrTooManyIDs = fmt.Errorf("at most %d ids per request", maxTranslateIDs)
+
+// MergedError is returned by GetIngredient for an ID that was merged into
+// another ingredient.
+type MergedError struct {
+	ID         uuid.UUID
+	MergedInto uuid.UUID
+}
+
+func (e *MergedError) Error() string {
+	return fmt.Sprintf("ingredient %s was merged into %s", e.ID, e.MergedInto)
+}
+
+// IDStatus says what TranslateIDs found for an ID.
+type IDStatus string
+
+const (
+	IDCurrent IDStatus = "current"
+	IDMerged  IDStatus = "merged"
+	IDUnknown IDStatus = "unknown"
+)
+
+// IDTranslation maps an ID to the ingredient that now holds it. CurrentID is
+// the zero UUID for unknown IDs.
+type IDTranslation struct {
+	ID        uuid.UUID
+	CurrentID uuid.UUID
+	Status    IDStatus
+}
+
+// GetIngredient fetches an ingredient by ID. For an ID that was merged away
+// it returns a *MergedError naming the winner; otherwise a missing ingredient
+// is sql.ErrNoRows.
+func (s *Service) GetIngredient(ctx context.Context, id uuid.UUID) (db.Ingredient, error) {
+	ing, err := s.q.GetIngredient(ctx, id)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return ing, err
+	}
+	redirect, rerr := s.q.GetIngredientRedirect(ctx, id)
+	if rerr != nil {
+		if errors.Is(rerr, sql.ErrNoRows) {
+			return db.Ingredient{}, err
+		}
+		return db.Ingredient{}, rerr
+	}
+	return db.Ingredient{}, &MergedError{ID: id, MergedInto: redirect.NewID}
+}
+
+// TranslateIDs maps each ID to its current ingredient, following merge
+// redirects, so other services can repair the references they hold. Results
+// are in input order; duplicates are translated once each time they appear.
+func (s *Service) TranslateIDs(ctx context.Context, ids []uuid.UUID) ([]IDTranslation, error) {
+	if len(ids) > maxTranslateIDs {
+		return nil, ErrTooManyIDs
+	}
+	if len(ids) == 0 {
+		return []IDTranslation{}, nil
+	}
+	live, err := s.q.ListIngredientIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	redirects, err := s.q.ListIngredientRedirects(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return translateIDs(ids, live, redirects), nil
+}
+
+func translateIDs(ids, live []uuid.UUID, redirects []db.IngredientRedirect) []IDTranslation {
+	isLive := make(map[uuid.UUID]bool, len(live))
+	for _, id := range live {
+		isLive[id] = true
+	}
+	mergedInto := make(map[uuid.UUID]uuid.UUID, len(redirects))
+	for _, r := range redirects {
+		mergedInto[r.OldID] = r.NewID
+	}
+
+	out := make([]IDTranslation, 0, len(ids))
+	for _, id := range ids {
+		t := IDTranslation{ID: id, Status: IDUnknown}
+		if isLive[id] {
+			t.CurrentID, t.Status = id, IDCurrent
+		} else if to, ok := mergedInto[id]; ok {
+			t.CurrentID, t.Status = to, IDMerged
+		}
+		out = append(out, t)
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeRedirects_FlattenChains(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	create := func(name string) db.Ingredient {
+		ing, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: name, Aliases: []string{}})
+		require.NoError(t, err)
+		return ing
+	}
+	a, b, c := create("scallion"), create("green onion"), create("spring onion")
+
+	// a → b, then b → c: both old IDs must lead straight to c.
+	_, err := svc.Merge(ctx, b.ID, a.ID, MergeOptions{})
+	require.NoError(t, err)
+	_, err = svc.Merge(ctx, c.ID, b.ID, MergeOptions{})
+	require.NoError(t, err)
+
+	for _, old := range []uuid.UUID{a.ID, b.ID} {
+		_, err := svc.GetIngredient(ctx, old)
+		var merged *MergedError
+		require.True(t, errors.As(err, &merged), "lookup of %s", old)
+		assert.Equal(t, c.ID, merged.MergedInto)
+	}
+
+	got, err := svc.TranslateIDs(ctx, []uuid.UUID{a.ID, c.ID, uuid.New()})
+	require.NoError(t, err)
+	assert.Equal(t, IDMerged, got[0].Status)
+	assert.Equal(t, c.ID, got[0].CurrentID)
+	assert.Equal(t, IDCurrent, got[1].Status)
+	assert.Equal(t, IDUnknown, got[2].Status)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIngredient_FollowsRedirect(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	loserID, winnerID := uuid.New(), uuid.New()
+	mockQ.EXPECT().GetIngredient(mock.Anything, loserID).Return(db.Ingredient{}, sql.ErrNoRows)
+	mockQ.EXPECT().GetIngredientRedirect(mock.Anything, loserID).
+		Return(db.IngredientRedirect{OldID: loserID, NewID: winnerID}, nil)
+
+	_, err := svc.GetIngredient(context.Background(), loserID)
+	var merged *MergedError
+	require.True(t, errors.As(err, &merged))
+	assert.Equal(t, winnerID, merged.MergedInto)
+}
+
+func TestGetIngredient_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	id := uuid.New()
+	mockQ.EXPECT().GetIngredient(mock.Anything, id).Return(db.Ingredient{}, sql.ErrNoRows)
+	mockQ.EXPECT().GetIngredientRedirect(mock.Anything, id).Return(db.IngredientRedirect{}, sql.ErrNoRows)
+
+	_, err := svc.GetIngredient(context.Background(), id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestTranslateIDs(t *testing.T) {
+	t.Parallel()
+
+	live, merged, unknown, winner := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	got := translateIDs(
+		[]uuid.UUID{merged, live, unknown, merged},
+		[]uuid.UUID{live},
+		[]db.IngredientRedirect{{OldID: merged, NewID: winner}},
+	)
+
+	assert.Equal(t, []IDTranslation{
+		{ID: merged, CurrentID: winner, Status: IDMerged},
+		{ID: live, CurrentID: live, Status: IDCurrent},
+		{ID: unknown, Status: IDUnknown},
+		{ID: merged, CurrentID: winner, Status: IDMerged},
+	}, got)
+}
+
+func TestTranslateIDs_TooMany(t *testing.T) {
+	t.Parallel()
+
+	svc := New(mocks.NewMockQuerier(t), nil, 0.8)
+	_, err := svc.TranslateIDs(context.Background(), make([]uuid.UUID, maxTranslateIDs+1))
+	assert.ErrorIs(t, err, ErrTooManyIDs)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// RenameConflictPolicy decides what RenameIngredient does when the new name
+// already belongs to another ingredient.
+type RenameConflictPolicy string
+
+const (
+	// RenameConflictFail returns the *AliasConflictError. This is the
+	// default.
+	RenameConflictFail RenameConflictPolicy = "fail"
+	// RenameConflictMerge merges the renamed ingredient into the one that
+	// holds the name.
+	RenameConflictMerge RenameConflictPolicy = "merge"
+)
+
+// ErrInvalidRenamePolicy is returned for an unrecognised RenameConflictPolicy.
+var ErrInvalidRenamePolicy = errors.New("invalid rename conflict policy")
+
+// RenameOptions controls RenameIngredient.
+type RenameOptions struct {
+	OnConflict RenameConflictPolicy
+	// Version, if set, is the version the ingredient is expected to be at.
+	Version int64
+}
+
+// RenameResult is returned by RenameIngredient. When the rename became a
+// merge, Ingredient is the ingredient that held the name and MergeID the
+// merge Split can undo.
+type RenameResult struct {
+	Ingredient db.Ingredient
+	MergedInto uuid.NullUUID
+	MergeID    uuid.NullUUID
+}
+
+// RenameIngredient gives an ingredient a new canonical name, normalized,
+// keeping the old name as an alias. If another ingredient holds the name,
+// as its name or an alias, it fails with an *AliasConflictError or, under
+// RenameConflictMerge, merges this ingredient into that one, which keeps its
+// own name. Otherwise it returns the errors of PatchIngredient and Merge.
+func (s *Service) RenameIngredient(ctx context.Context, id uuid.UUID, name string, opts RenameOptions) (RenameResult, error) {
+	policy := opts.OnConflict
+	if policy == "" {
+		policy = RenameConflictFail
+	}
+	if policy != RenameConflictFail && policy != RenameConflictMerge {
+		return RenameResult{}, fmt.Errorf("%w: %q", ErrInvalidRenamePolicy, policy)
+	}
+
+	ing, err := s.PatchIngredient(ctx, id, IngredientPatch{Name: &name, Version: opts.Version})
+	var conflict *AliasConflictError
+	if policy == RenameConflictFail || !errors.As(err, &conflict) || conflict.Term != Normalize(name) {
+		if err != nil {
+			return RenameResult{}, err
+		}
+		return RenameResult{Ingredient: ing}, nil
+	}
+
+	merged, err := s.Merge(ctx, conflict.OwnerID, id, MergeOptions{LoserVersion: opts.Version})
+	if err != nil {
+		return RenameResult{}, err
+	}
+	return RenameResult{
+		Ingredient: merged.Ingredient,
+		MergedInto: uuid.NullUUID{UUID: conflict.OwnerID, Valid: true},
+		MergeID:    uuid.NullUUID{UUID: merged.MergeIDs[0], Valid: true},
+	}, nil
+}
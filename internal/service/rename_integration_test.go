@@ -0,0 +1,57 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameIngredient_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	clove, err := svc.CreateIngredient(ctx, IngredientInput{Name: "garlic clove"})
+	require.NoError(t, err)
+	bulb, err := svc.CreateIngredient(ctx, IngredientInput{Name: "garlic bulb", Aliases: []string{"whole garlic"}})
+	require.NoError(t, err)
+
+	// A plain rename keeps the old name as an alias and is recorded as such.
+	renamed, err := svc.RenameIngredient(ctx, clove.ID, " Garlic ", RenameOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "garlic", renamed.Ingredient.Name)
+	assert.Equal(t, []string{"garlic clove"}, renamed.Ingredient.Aliases)
+	assert.False(t, renamed.MergedInto.Valid)
+	history, err := svc.IngredientHistory(ctx, clove.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ChangeRename, history[0].Operation)
+
+	// A name held by another ingredient fails by default.
+	_, err = svc.RenameIngredient(ctx, bulb.ID, "Garlic", RenameOptions{})
+	var conflict *AliasConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, clove.ID, conflict.OwnerID)
+
+	// The merge checks the renamed ingredient's version too.
+	_, err = svc.Merge(ctx, clove.ID, bulb.ID, MergeOptions{LoserVersion: bulb.Version + 1})
+	require.ErrorIs(t, err, ErrVersionMismatch)
+
+	// Under the merge policy it merges into the holder, which keeps its name.
+	merged, err := svc.RenameIngredient(ctx, bulb.ID, "Garlic", RenameOptions{OnConflict: RenameConflictMerge, Version: bulb.Version})
+	require.NoError(t, err)
+	assert.Equal(t, clove.ID, merged.MergedInto.UUID)
+	assert.True(t, merged.MergeID.Valid)
+	assert.Equal(t, "garlic", merged.Ingredient.Name)
+	assert.ElementsMatch(t, []string{"garlic clove", "garlic bulb", "whole garlic"}, merged.Ingredient.Aliases)
+	_, err = svc.GetIngredient(ctx, bulb.ID)
+	var gone *MergedError
+	require.ErrorAs(t, err, &gone)
+	assert.Equal(t, clove.ID, gone.MergedInto)
+}
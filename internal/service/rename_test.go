@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameIngredient(t *testing.T) {
+	t.Parallel()
+
+	current := newIngredient("garlic clove", []string{})
+	renamed := newIngredient("garlic", []string{"garlic clove"})
+	renamed.ID = current.ID
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().GetIngredient(mock.Anything, current.ID).Return(current, nil)
+	mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"garlic", "garlic clove"}).Return(nil, nil)
+	mockQ.EXPECT().UpdateIngredient(mock.Anything, mock.MatchedBy(func(p db.UpdateIngredientParams) bool {
+		return p.Name.String == "garlic" && p.ChangeOperation.String == "rename"
+	})).Return(renamed, nil)
+
+	result, err := New(mockQ, nil, 0.8).RenameIngredient(context.Background(), current.ID, " Garlic ", RenameOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, renamed, result.Ingredient)
+	assert.False(t, result.MergedInto.Valid)
+}
+
+func TestRenameIngredient_Rejected(t *testing.T) {
+	t.Parallel()
+
+	current := newIngredient("garlic clove", []string{})
+	owner := uuid.New()
+
+	t.Run("unknown policy", func(t *testing.T) {
+		t.Parallel()
+		_, err := New(mocks.NewMockQuerier(t), nil, 0.8).RenameIngredient(context.Background(), current.ID, "garlic", RenameOptions{OnConflict: "overwrite"})
+		assert.ErrorIs(t, err, ErrInvalidRenamePolicy)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		mockQ.EXPECT().GetIngredient(mock.Anything, current.ID).Return(current, nil)
+		mockQ.EXPECT().ListTermOwners(mock.Anything, []string{"garlic", "garlic clove"}).Return([]db.ListTermOwnersRow{
+			{ID: owner, Name: "garlic", Term: "garlic", IsName: true},
+		}, nil)
+
+		_, err := New(mockQ, nil, 0.8).RenameIngredient(context.Background(), current.ID, "Garlic", RenameOptions{OnConflict: RenameConflictFail})
+		var conflict *AliasConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, owner, conflict.OwnerID)
+	})
+}
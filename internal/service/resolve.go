@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/agnivade/levenshtein"
+	"github.com/google/uuid"
 	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
 )
 
@@ -17,6 +20,25 @@ type ResolveResult struct {
 	Created    bool
 }
 
+// ResolveOptions controls Resolve.
+type ResolveOptions struct {
+	// RestoreArchived restores and returns an archived ingredient whose name
+	// or alias is the resolved name, instead of failing with an
+	// *ArchivedError.
+	RestoreArchived bool
+}
+
+// ArchivedError is returned by Resolve when the name belongs to an archived
+// ingredient, which Resolve neither matches nor duplicates.
+type ArchivedError struct {
+	ID   uuid.UUID
+	Name string
+}
+
+func (e *ArchivedError) Error() string {
+	return fmt.Sprintf("name belongs to archived ingredient %q (%s)", e.Name, e.ID)
+}
+
 // similarity returns a 0.0–1.0 confidence score between two strings using
 // Levenshtein distance: 1.0 - distance/max(len(a), len(b)).
 func similarity(a, b string) float64 {
@@ -38,7 +60,11 @@ func similarity(a, b string) float64 {
 // match is above the configured threshold, it is returned directly. Otherwise a
 // new ingredient is auto-created (write-through). Concurrent callers are safe:
 // the upsert uses ON CONFLICT DO NOTHING and falls back to a SELECT on conflict.
-func (s *Service) Resolve(ctx context.Context, rawName string) (ResolveResult, error) {
+// Archived ingredients are never matched. If one holds the name being
+// created, as its name or an alias, Resolve fails with an *ArchivedError, or
+// with opts.RestoreArchived restores and returns it. If another ingredient
+// takes the name as an alias meanwhile, it fails with an *AliasConflictError.
+func (s *Service) Resolve(ctx context.Context, rawName string, opts ResolveOptions) (ResolveResult, error) {
 	normalized := Normalize(rawName)
 
 	all, err := s.q.ListIngredients(ctx)
@@ -46,22 +72,87 @@ func (s *Service) Resolve(ctx context.Context, rawName string) (ResolveResult, e
 		return ResolveResult{}, err
 	}
 
+	bestIngredient, bestScore := bestMatch(all, normalized)
+	if bestScore >= s.threshold {
+		if bestScore < 1 {
+			slog.Debug("resolve: fuzzy match", "raw", rawName, "matched", bestIngredient.Name, "score", bestScore)
+		}
+		return ResolveResult{Ingredient: bestIngredient, Confidence: bestScore, Created: false}, nil
+	}
+
+	// An archived ingredient holding the name as an alias keeps it, so a
+	// second ingredient cannot be created under that name.
+	for _, ing := range all {
+		if ing.ArchivedAt.Valid && slices.Contains(ing.Aliases, normalized) {
+			return s.resolveArchived(ctx, ing, opts)
+		}
+	}
+
+	// No match above threshold — auto-create.
+	slog.Info("resolve: auto-creating ingredient", "name", normalized, "best_score", bestScore)
+	ing, err := s.q.UpsertIngredient(ctx, db.UpsertIngredientParams{
+		Name:        normalized,
+		CategoryID:  uuid.NullUUID{},
+		DefaultUnit: sql.NullString{},
+		Actor:       actorFrom(ctx),
+		Reason:      reasonFrom(ctx),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Concurrent insert won the race, or the name belongs to an
+			// archived ingredient; fetch the existing row.
+			ing, err = s.q.GetIngredientByName(ctx, normalized)
+			if err != nil {
+				return ResolveResult{}, err
+			}
+			if ing.ArchivedAt.Valid {
+				return s.resolveArchived(ctx, ing, opts)
+			}
+			return ResolveResult{Ingredient: ing, Confidence: 1.0, Created: false}, nil
+		}
+		// An alias added since the ingredients were listed.
+		return ResolveResult{}, termsTaken(ctx, s.q, err, []string{normalized})
+	}
+
+	return ResolveResult{Ingredient: ing, Confidence: 1.0, Created: true}, nil
+}
+
+// resolveArchived handles a resolved name held by the archived ing.
+func (s *Service) resolveArchived(ctx context.Context, ing db.Ingredient, opts ResolveOptions) (ResolveResult, error) {
+	if !opts.RestoreArchived {
+		return ResolveResult{}, &ArchivedError{ID: ing.ID, Name: ing.Name}
+	}
+	ing, err := s.restoreIfArchived(ctx, ing)
+	if err != nil {
+		return ResolveResult{}, err
+	}
+	return ResolveResult{Ingredient: ing, Confidence: 1.0, Created: false}, nil
+}
+
+// bestMatch scores normalized against every ingredient name and alias and
+// returns the best candidate. Exact name or alias matches short-circuit with
+// a score of 1.0. Archived ingredients are skipped. With no candidates the
+// score is -1.
+func bestMatch(all []db.Ingredient, normalized string) (db.Ingredient, float64) {
 	var bestIngredient db.Ingredient
 	bestScore := -1.0
 
 	for _, ing := range all {
+		if ing.ArchivedAt.Valid {
+			continue
+		}
 		// Check exact name match first.
 		if ing.Name == normalized {
-			slog.Debug("resolve: exact name match", "raw", rawName, "matched", ing.Name)
-			return ResolveResult{Ingredient: ing, Confidence: 1.0, Created: false}, nil
+			slog.Debug("resolve: exact name match", "raw", normalized, "matched", ing.Name)
+			return ing, 1.0
 		}
 		score := similarity(normalized, ing.Name)
 
 		// Check aliases — exact alias match is an immediate hit.
 		for _, alias := range ing.Aliases {
 			if alias == normalized {
-				slog.Debug("resolve: exact alias match", "raw", rawName, "alias", alias, "ingredient", ing.Name)
-				return ResolveResult{Ingredient: ing, Confidence: 1.0, Created: false}, nil
+				slog.Debug("resolve: exact alias match", "raw", normalized, "alias", alias, "ingredient", ing.Name)
+				return ing, 1.0
 			}
 			if s := similarity(normalized, alias); s > score {
 				score = s
@@ -73,31 +164,5 @@ func (s *Service) Resolve(ctx context.Context, rawName string) (ResolveResult, e
 			bestIngredient = ing
 		}
 	}
-
-	if bestScore >= s.threshold {
-		slog.Debug("resolve: fuzzy match", "raw", rawName, "matched", bestIngredient.Name, "score", bestScore)
-		return ResolveResult{Ingredient: bestIngredient, Confidence: bestScore, Created: false}, nil
-	}
-
-	// No match above threshold — auto-create.
-	slog.Info("resolve: auto-creating ingredient", "name", normalized, "best_score", bestScore)
-	ing, err := s.q.UpsertIngredient(ctx, db.UpsertIngredientParams{
-		Name:        normalized,
-		Aliases:     []string{},
-		Category:    sql.NullString{},
-		DefaultUnit: sql.NullString{},
-	})
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			// Concurrent insert won the race; fetch the existing row.
-			ing, err = s.q.GetIngredientByName(ctx, normalized)
-			if err != nil {
-				return ResolveResult{}, err
-			}
-			return ResolveResult{Ingredient: ing, Confidence: 1.0, Created: false}, nil
-		}
-		return ResolveResult{}, err
-	}
-
-	return ResolveResult{Ingredient: ing, Confidence: 1.0, Created: true}, nil
+	return bestIngredient, bestScore
 }
@@ -18,7 +18,7 @@ func TestIntegrationResolve_AutoCreate(t *testing.T) {
 	q := db.New(sqlDB)
 	svc := New(q, sqlDB, 0.8)
 
-	result, err := svc.Resolve(context.Background(), "Flour")
+	result, err := svc.Resolve(context.Background(), "Flour", ResolveOptions{})
 	require.NoError(t, err)
 	assert.True(t, result.Created)
 	assert.Equal(t, "flour", result.Ingredient.Name)
@@ -30,10 +30,10 @@ func TestIntegrationResolve_ExactMatch(t *testing.T) {
 	q := db.New(sqlDB)
 	svc := New(q, sqlDB, 0.8)
 
-	_, err := svc.Resolve(context.Background(), "flour")
+	_, err := svc.Resolve(context.Background(), "flour", ResolveOptions{})
 	require.NoError(t, err)
 
-	result, err := svc.Resolve(context.Background(), "flour")
+	result, err := svc.Resolve(context.Background(), "flour", ResolveOptions{})
 	require.NoError(t, err)
 	assert.False(t, result.Created)
 	assert.Equal(t, 1.0, result.Confidence)
@@ -45,10 +45,10 @@ func TestIntegrationResolve_FuzzyMatch(t *testing.T) {
 	q := db.New(sqlDB)
 	svc := New(q, sqlDB, 0.7)
 
-	_, err := svc.Resolve(context.Background(), "chicken breast")
+	_, err := svc.Resolve(context.Background(), "chicken breast", ResolveOptions{})
 	require.NoError(t, err)
 
-	result, err := svc.Resolve(context.Background(), "chicken breasts")
+	result, err := svc.Resolve(context.Background(), "chicken breasts", ResolveOptions{})
 	require.NoError(t, err)
 	assert.False(t, result.Created)
 	assert.Equal(t, "chicken breast", result.Ingredient.Name)
@@ -60,10 +60,10 @@ func TestIntegrationResolve_BelowThreshold(t *testing.T) {
 	q := db.New(sqlDB)
 	svc := New(q, sqlDB, 0.8)
 
-	_, err := svc.Resolve(context.Background(), "flour")
+	_, err := svc.Resolve(context.Background(), "flour", ResolveOptions{})
 	require.NoError(t, err)
 
-	result, err := svc.Resolve(context.Background(), "garlic")
+	result, err := svc.Resolve(context.Background(), "garlic", ResolveOptions{})
 	require.NoError(t, err)
 	assert.True(t, result.Created)
 	assert.Equal(t, "garlic", result.Ingredient.Name)
@@ -82,7 +82,7 @@ func TestIntegrationResolve_ConcurrentRace(t *testing.T) {
 		wg.Add(1)
 		go func(idx int) {
 			defer wg.Done()
-			results[idx], errs[idx] = svc.Resolve(context.Background(), "concurrent ingredient")
+			results[idx], errs[idx] = svc.Resolve(context.Background(), "concurrent ingredient", ResolveOptions{})
 		}(i)
 	}
 	wg.Wait()
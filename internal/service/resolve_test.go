@@ -90,7 +90,7 @@ func newIngredient(name string, aliases []string) db.Ingredient {
 		ID:          uuid.New(),
 		Name:        name,
 		Aliases:     aliases,
-		Category:    sql.NullString{},
+		CategoryID:  uuid.NullUUID{},
 		DefaultUnit: sql.NullString{},
 		CreatedAt:   time.Now(),
 	}
@@ -105,7 +105,7 @@ func TestResolve_ExactNameMatch(t *testing.T) {
 	garlic := newIngredient("garlic", []string{})
 	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{garlic}, nil)
 
-	result, err := svc.Resolve(context.Background(), "garlic")
+	result, err := svc.Resolve(context.Background(), "garlic", ResolveOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, garlic.ID, result.Ingredient.ID)
 	assert.Equal(t, 1.0, result.Confidence)
@@ -121,7 +121,7 @@ func TestResolve_ExactAliasMatch(t *testing.T) {
 	garlic := newIngredient("garlic", []string{"garlic clove"})
 	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{garlic}, nil)
 
-	result, err := svc.Resolve(context.Background(), "garlic clove")
+	result, err := svc.Resolve(context.Background(), "garlic clove", ResolveOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, garlic.ID, result.Ingredient.ID)
 	assert.Equal(t, 1.0, result.Confidence)
@@ -138,7 +138,7 @@ func TestResolve_FuzzyAboveThreshold(t *testing.T) {
 	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{garlic}, nil)
 
 	// "garlc" is 1 edit away from "garlic" (6 chars) => similarity ~0.833
-	result, err := svc.Resolve(context.Background(), "garlc")
+	result, err := svc.Resolve(context.Background(), "garlc", ResolveOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, garlic.ID, result.Ingredient.ID)
 	assert.GreaterOrEqual(t, result.Confidence, 0.8)
@@ -159,7 +159,7 @@ func TestResolve_BelowThreshold_AutoCreate(t *testing.T) {
 		return p.Name == "butter"
 	})).Return(created, nil)
 
-	result, err := svc.Resolve(context.Background(), "Butter")
+	result, err := svc.Resolve(context.Background(), "Butter", ResolveOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, created.ID, result.Ingredient.ID)
 	assert.Equal(t, 1.0, result.Confidence)
@@ -183,7 +183,7 @@ func TestResolve_ConcurrentConflictFallback(t *testing.T) {
 	mockQ.EXPECT().GetIngredientByName(mock.Anything, "butter").
 		Return(existing, nil)
 
-	result, err := svc.Resolve(context.Background(), "Butter")
+	result, err := svc.Resolve(context.Background(), "Butter", ResolveOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, existing.ID, result.Ingredient.ID)
 	assert.Equal(t, 1.0, result.Confidence)
@@ -203,8 +203,112 @@ func TestResolve_EmptyDB_AutoCreate(t *testing.T) {
 		return p.Name == "salt"
 	})).Return(created, nil)
 
-	result, err := svc.Resolve(context.Background(), "Salt")
+	result, err := svc.Resolve(context.Background(), "Salt", ResolveOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, created.ID, result.Ingredient.ID)
 	assert.True(t, result.Created)
 }
+
+func TestBestMatch(t *testing.T) {
+	t.Parallel()
+
+	garlic := newIngredient("garlic", []string{"garlic clove"})
+
+	ing, score := bestMatch([]db.Ingredient{garlic}, Normalize("Garlic Clove"))
+	assert.Equal(t, garlic.ID, ing.ID)
+	assert.Equal(t, 1.0, score)
+
+	_, score = bestMatch([]db.Ingredient{garlic}, "butter")
+	assert.Less(t, score, 0.8)
+}
+
+func TestBestMatch_SkipsArchived(t *testing.T) {
+	t.Parallel()
+
+	// "garlic" is archived, so the fuzzy match on "garlc" must not find it.
+	garlic := newIngredient("garlic", []string{})
+	garlic.ArchivedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	_, score := bestMatch([]db.Ingredient{garlic}, "garlc")
+	assert.Equal(t, -1.0, score)
+}
+
+func TestResolve_ArchivedNameConflict(t *testing.T) {
+	t.Parallel()
+
+	butter := newIngredient("butter", []string{})
+	butter.ArchivedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	restored := butter
+	restored.ArchivedAt = sql.NullTime{}
+
+	t.Run("fails by default", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		svc := New(mockQ, nil, 0.8)
+
+		mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{butter}, nil)
+		mockQ.EXPECT().UpsertIngredient(mock.Anything, mock.Anything).Return(db.Ingredient{}, sql.ErrNoRows)
+		mockQ.EXPECT().GetIngredientByName(mock.Anything, "butter").Return(butter, nil)
+
+		_, err := svc.Resolve(context.Background(), "Butter", ResolveOptions{})
+		var archived *ArchivedError
+		require.ErrorAs(t, err, &archived)
+		assert.Equal(t, butter.ID, archived.ID)
+	})
+
+	t.Run("restores when asked", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		svc := New(mockQ, nil, 0.8)
+
+		mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{butter}, nil)
+		mockQ.EXPECT().UpsertIngredient(mock.Anything, mock.Anything).Return(db.Ingredient{}, sql.ErrNoRows)
+		mockQ.EXPECT().GetIngredientByName(mock.Anything, "butter").Return(butter, nil)
+		mockQ.EXPECT().RestoreIngredient(mock.Anything, db.RestoreIngredientParams{ID: butter.ID}).Return(restored, nil)
+
+		result, err := svc.Resolve(context.Background(), "Butter", ResolveOptions{RestoreArchived: true})
+		require.NoError(t, err)
+		assert.Equal(t, butter.ID, result.Ingredient.ID)
+		assert.False(t, result.Ingredient.ArchivedAt.Valid)
+		assert.False(t, result.Created)
+	})
+}
+
+func TestResolve_ArchivedAliasOwner(t *testing.T) {
+	t.Parallel()
+
+	// "scallion" is an alias of archived "green onion"; creating a new
+	// ingredient under that name would break alias uniqueness.
+	onion := newIngredient("green onion", []string{"scallion"})
+	onion.ArchivedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	restored := onion
+	restored.ArchivedAt = sql.NullTime{}
+
+	t.Run("fails by default", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		svc := New(mockQ, nil, 0.8)
+
+		mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{onion}, nil)
+
+		_, err := svc.Resolve(context.Background(), "Scallion", ResolveOptions{})
+		var archived *ArchivedError
+		require.ErrorAs(t, err, &archived)
+		assert.Equal(t, onion.ID, archived.ID)
+	})
+
+	t.Run("restores when asked", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+		svc := New(mockQ, nil, 0.8)
+
+		mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{onion}, nil)
+		mockQ.EXPECT().RestoreIngredient(mock.Anything, db.RestoreIngredientParams{ID: onion.ID}).Return(restored, nil)
+
+		result, err := svc.Resolve(context.Background(), "Scallion", ResolveOptions{RestoreArchived: true})
+		require.NoError(t, err)
+		assert.Equal(t, onion.ID, result.Ingredient.ID)
+		assert.False(t, result.Ingredient.ArchivedAt.Valid)
+		assert.False(t, result.Created)
+	})
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// ScaleItem is a single recipe line to scale.
+type ScaleItem struct {
+	IngredientID uuid.UUID
+	Quantity     float64
+	Unit         string
+}
+
+// ScaledItem is a scaled recipe line. Quantity and Unit are the rounded values
+// shown in Display.
+type ScaledItem struct {
+	IngredientID uuid.UUID
+	Quantity     float64
+	Unit         string
+	Display      string
+}
+
+// Scale multiplies every item by factor and expresses it in system. Units
+// missing from the catalog (e.g. "clove", "stick") are first mapped onto a
+// catalog unit via the ingredient's unit conversions. When the ingredient's
+// default unit belongs to system and a conversion links the two dimensions,
+// the result is given in the default unit's dimension, so "1 cup flour" with
+// default unit "g" becomes grams in metric. An empty system keeps each item
+// in the system of its own unit. Items whose unit cannot be placed in the
+// catalog are scaled but left in their original unit.
+func (s *Service) Scale(ctx context.Context, items []ScaleItem, factor float64, system UnitSystem) ([]ScaledItem, error) {
+	ingredients := make(map[uuid.UUID]db.Ingredient)
+	conversions := make(map[uuid.UUID][]db.UnitConversion)
+
+	result := make([]ScaledItem, 0, len(items))
+	for _, item := range items {
+		if _, ok := ingredients[item.IngredientID]; !ok {
+			ing, err := s.q.GetIngredient(ctx, item.IngredientID)
+			if err != nil {
+				return nil, fmt.Errorf("get ingredient %s: %w", item.IngredientID, err)
+			}
+			convs, err := s.q.ListUnitConversionsByIngredient(ctx, item.IngredientID)
+			if err != nil {
+				return nil, fmt.Errorf("list conversions for %s: %w", item.IngredientID, err)
+			}
+			ingredients[item.IngredientID] = ing
+			conversions[item.IngredientID] = convs
+		}
+		scaled := scaleItem(item, factor, system, ingredients[item.IngredientID], conversions[item.IngredientID])
+		result = append(result, scaled)
+	}
+	return result, nil
+}
+
+// scaleItem scales and converts one item. See Scale for the rules applied.
+func scaleItem(item ScaleItem, factor float64, system UnitSystem, ing db.Ingredient, convs []db.UnitConversion) ScaledItem {
+	qty := item.Quantity * factor
+
+	src, ok := LookupUnit(item.Unit)
+	if !ok {
+		src, qty, ok = toCatalogUnit(item.Unit, qty, convs)
+	}
+	if !ok {
+		rounded, display := humanize(qty, Normalize(item.Unit), "", system)
+		return ScaledItem{IngredientID: item.IngredientID, Quantity: rounded, Unit: Normalize(item.Unit), Display: display}
+	}
+
+	target := system
+	if target == "" {
+		target = src.System
+	}
+
+	base := qty * src.ToBase
+	dim := src.Dimension
+	if def, ok := LookupUnit(ing.DefaultUnit.String); ing.DefaultUnit.Valid && ok &&
+		def.System == target && def.Dimension != src.Dimension {
+		if m, ok := crossDimensionFactor(convs, src.Dimension, def.Dimension); ok {
+			base *= m
+			dim = def.Dimension
+		}
+	}
+
+	unit := chooseDisplayUnit(base, dim, target)
+	rounded, display := humanize(base/unit.ToBase, unit.Name, unit.Plural, target)
+	return ScaledItem{IngredientID: item.IngredientID, Quantity: rounded, Unit: unit.Name, Display: display}
+}
+
+// toCatalogUnit converts qty of a non-catalog unit into a catalog unit using
+// an ingredient conversion that links the two, in either direction.
+func toCatalogUnit(unit string, qty float64, convs []db.UnitConversion) (Unit, float64, bool) {
+	unit = Normalize(unit)
+	for _, c := range convs {
+		if Normalize(c.FromUnit) == unit {
+			if to, ok := LookupUnit(c.ToUnit); ok {
+				return to, qty * c.Factor, true
+			}
+		}
+		if Normalize(c.ToUnit) == unit && c.Factor != 0 {
+			if from, ok := LookupUnit(c.FromUnit); ok {
+				return from, qty / c.Factor, true
+			}
+		}
+	}
+	return Unit{}, qty, false
+}
+
+// crossDimensionFactor finds a conversion linking dimensions from and to and
+// returns the multiplier that turns base units of from into base units of to
+// (e.g. millilitres of flour into grams).
+func crossDimensionFactor(convs []db.UnitConversion, from, to Dimension) (float64, bool) {
+	for _, c := range convs {
+		cf, okFrom := LookupUnit(c.FromUnit)
+		ct, okTo := LookupUnit(c.ToUnit)
+		if !okFrom || !okTo || c.Factor == 0 {
+			continue
+		}
+		// 1 cf = Factor ct.
+		if cf.Dimension == from && ct.Dimension == to {
+			return c.Factor * ct.ToBase / cf.ToBase, true
+		}
+		if cf.Dimension == to && ct.Dimension == from {
+			return cf.ToBase / (c.Factor * ct.ToBase), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaleItem(t *testing.T) {
+	t.Parallel()
+
+	flour := newIngredient("flour", []string{})
+	flour.DefaultUnit = sql.NullString{String: "g", Valid: true}
+	flourConvs := []db.UnitConversion{{IngredientID: flour.ID, FromUnit: "cup", ToUnit: "g", Factor: 120}}
+
+	garlic := newIngredient("garlic", []string{})
+	garlicConvs := []db.UnitConversion{{IngredientID: garlic.ID, FromUnit: "clove", ToUnit: "g", Factor: 5}}
+
+	tests := []struct {
+		name        string
+		item        ScaleItem
+		factor      float64
+		system      UnitSystem
+		ing         db.Ingredient
+		convs       []db.UnitConversion
+		wantDisplay string
+	}{
+		{
+			name:        "scales within the source system",
+			item:        ScaleItem{IngredientID: flour.ID, Quantity: 0.5, Unit: "tsp"},
+			factor:      3,
+			ing:         newIngredient("salt", []string{}),
+			wantDisplay: "1 1/2 tsp",
+		},
+		{
+			name:        "promotes to a larger unit",
+			item:        ScaleItem{Quantity: 2, Unit: "tbsp"},
+			factor:      2.5,
+			ing:         newIngredient("butter", []string{}),
+			wantDisplay: "1/3 cup",
+		},
+		{
+			name:        "converts volume to metric volume",
+			item:        ScaleItem{Quantity: 1, Unit: "cup"},
+			factor:      1,
+			system:      UnitSystemMetric,
+			ing:         newIngredient("milk", []string{}),
+			wantDisplay: "235 ml",
+		},
+		{
+			name:        "uses default unit dimension when converting",
+			item:        ScaleItem{Quantity: 1, Unit: "cups"},
+			factor:      2.5,
+			system:      UnitSystemMetric,
+			ing:         flour,
+			convs:       flourConvs,
+			wantDisplay: "300 g",
+		},
+		{
+			name:        "maps non-catalog units through conversions",
+			item:        ScaleItem{Quantity: 3, Unit: "cloves"},
+			factor:      2,
+			system:      UnitSystemMetric,
+			ing:         garlic,
+			convs:       []db.UnitConversion{{FromUnit: "cloves", ToUnit: "g", Factor: 5}},
+			wantDisplay: "30 g",
+		},
+		{
+			name:        "leaves unknown units in place",
+			item:        ScaleItem{Quantity: 1, Unit: "pinch"},
+			factor:      2,
+			system:      UnitSystemUS,
+			ing:         garlic,
+			convs:       garlicConvs,
+			wantDisplay: "2 pinch",
+		},
+		{
+			name:        "counted items",
+			item:        ScaleItem{Quantity: 3, Unit: ""},
+			factor:      1.5,
+			system:      UnitSystemMetric,
+			ing:         newIngredient("egg", []string{}),
+			wantDisplay: "4.5",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := scaleItem(tc.item, tc.factor, tc.system, tc.ing, tc.convs)
+			assert.Equal(t, tc.wantDisplay, got.Display)
+		})
+	}
+}
+
+func TestScale_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	id := uuid.New()
+	mockQ.EXPECT().GetIngredient(mock.Anything, id).Return(db.Ingredient{}, sql.ErrNoRows)
+
+	_, err := svc.Scale(context.Background(), []ScaleItem{{IngredientID: id, Quantity: 1, Unit: "cup"}}, 2, UnitSystemMetric)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestScale_LoadsEachIngredientOnce(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	salt := newIngredient("salt", []string{})
+	mockQ.EXPECT().GetIngredient(mock.Anything, salt.ID).Return(salt, nil).Once()
+	mockQ.EXPECT().ListUnitConversionsByIngredient(mock.Anything, salt.ID).Return(nil, nil).Once()
+
+	got, err := svc.Scale(context.Background(), []ScaleItem{
+		{IngredientID: salt.ID, Quantity: 1, Unit: "tsp"},
+		{IngredientID: salt.ID, Quantity: 2, Unit: "tsp"},
+	}, 2, UnitSystemUS)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "2 tsp", got[0].Display)
+	assert.Equal(t, "1 1/3 tbsp", got[1].Display)
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"database/sql"
+	"errors"
 
+	"github.com/lib/pq"
 	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
 )
 
@@ -23,3 +25,19 @@ func New(q db.Querier, sqlDB *sql.DB, threshold float64) *Service {
 func (s *Service) Queries() db.Querier {
 	return s.q
 }
+
+// isUniqueViolation reports whether err is a Postgres unique_violation.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// isForeignKeyViolation reports whether err is a Postgres foreign_key_violation.
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,641 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+var (
+	// ErrMergeNotFound is returned when there is no unsplit merge to undo.
+	ErrMergeNotFound = errors.New("merge not found")
+	// ErrSplitConflict is returned when the loser cannot be recreated, e.g.
+	// because another ingredient has taken its name since the merge.
+	ErrSplitConflict = errors.New("cannot split merge")
+)
+
+// mergeSnapshot is stored with each merge. It captures the loser and the
+// rows it owned, plus what the merge did to the winner, so Split can put
+// things back. Rows keep their IDs through a merge, which is how Split finds
+// them again.
+type mergeSnapshot struct {
+	Loser        db.Ingredient
+	WinnerBefore db.Ingredient
+	WinnerAfter  db.Ingredient
+	// Aliases are the loser's alias rows. Merges recorded before aliases
+	// carried metadata have none.
+	Aliases []db.IngredientAlias
+	// ChildMoves are the parent changes made to loser's children.
+	ChildMoves []db.SetIngredientParentParams
+	// Substitutes are rows with loser on either side.
+	Substitutes  []db.IngredientSubstitute
+	CompositeIDs []uuid.UUID
+	ComponentIDs []uuid.UUID
+	// DroppedComponents are loser component rows deleted because their
+	// composite already listed the winner, whose quantity the merge may
+	// have raised.
+	DroppedComponents        []db.CompositeSubstituteComponent
+	ComponentQuantityUpdates []quantityUpdate
+	Conversions              []db.UnitConversion
+	// DroppedWinnerConversions are winner rows deleted under keep_loser.
+	DroppedWinnerConversions []db.UnitConversion
+	FactorUpdates            []factorUpdate
+	Nutrition                *db.IngredientNutrition
+	StorageGuidelines        []db.StorageGuideline
+	// RedirectsTo are IDs that redirected to loser before the merge.
+	RedirectsTo []uuid.UUID
+}
+
+// factorUpdate is a winner conversion whose factor the merge changed.
+type factorUpdate struct {
+	ID     uuid.UUID
+	Before float64
+	After  float64
+}
+
+// quantityUpdate is a winner component row whose quantity the merge changed.
+type quantityUpdate struct {
+	ID     uuid.UUID
+	Before float64
+	After  float64
+}
+
+// SplitIssue describes something Split could not cleanly reverse. Kind names
+// the kind of row and ID the row concerned.
+type SplitIssue struct {
+	Kind   string
+	ID     uuid.UUID
+	Detail string
+}
+
+// SplitResult is returned by Split.
+type SplitResult struct {
+	MergeID  uuid.UUID
+	Winner   db.Ingredient
+	Restored db.Ingredient
+	Issues   []SplitIssue
+}
+
+// captureMergeSnapshot loads the loser-owned rows a merge is about to move.
+func captureMergeSnapshot(ctx context.Context, q db.Querier, winner, loser db.Ingredient) (mergeSnapshot, error) {
+	snap := mergeSnapshot{Loser: loser, WinnerBefore: winner}
+	var err error
+	if snap.Aliases, err = q.ListIngredientAliases(ctx, loser.ID); err != nil {
+		return snap, err
+	}
+	if snap.Substitutes, err = q.ListSubstitutesTouching(ctx, loser.ID); err != nil {
+		return snap, err
+	}
+	if snap.CompositeIDs, err = q.ListCompositeSubstituteIDsByIngredient(ctx, loser.ID); err != nil {
+		return snap, err
+	}
+	if snap.ComponentIDs, err = q.ListCompositeComponentIDsByComponent(ctx, loser.ID); err != nil {
+		return snap, err
+	}
+	if snap.StorageGuidelines, err = q.ListStorageGuidelinesByIngredient(ctx, loser.ID); err != nil {
+		return snap, err
+	}
+	if snap.RedirectsTo, err = q.ListRedirectsTo(ctx, loser.ID); err != nil {
+		return snap, err
+	}
+	n, err := q.GetIngredientNutrition(ctx, loser.ID)
+	switch {
+	case err == nil:
+		snap.Nutrition = &n
+	case !errors.Is(err, sql.ErrNoRows):
+		return snap, err
+	}
+	return snap, nil
+}
+
+// recordConversions notes the loser's conversions and the winner rows the
+// conversion plan drops or rewrites.
+func (snap *mergeSnapshot) recordConversions(winnerID uuid.UUID, winnerConvs, loserConvs []db.UnitConversion, plan conversionMergePlan) {
+	snap.Conversions = loserConvs
+	for _, c := range plan.dropped {
+		if c.IngredientID == winnerID {
+			snap.DroppedWinnerConversions = append(snap.DroppedWinnerConversions, c)
+		}
+	}
+	for _, u := range plan.updates {
+		i := slices.IndexFunc(winnerConvs, func(c db.UnitConversion) bool { return c.ID == u.ID })
+		if i >= 0 {
+			snap.FactorUpdates = append(snap.FactorUpdates, factorUpdate{ID: u.ID, Before: winnerConvs[i].Factor, After: u.Factor})
+		}
+	}
+}
+
+func saveMergeSnapshot(ctx context.Context, q db.Querier, snap mergeSnapshot) (db.IngredientMerge, error) {
+	raw, err := json.Marshal(snap)
+	if err != nil {
+		return db.IngredientMerge{}, err
+	}
+	return q.CreateIngredientMerge(ctx, db.CreateIngredientMergeParams{
+		WinnerID:  snap.WinnerAfter.ID,
+		LoserID:   snap.Loser.ID,
+		LoserName: snap.Loser.Name,
+		Snapshot:  raw,
+	})
+}
+
+// ListMerges returns the merges into an ingredient, newest first.
+func (s *Service) ListMerges(ctx context.Context, winnerID uuid.UUID) ([]db.IngredientMerge, error) {
+	return s.q.ListIngredientMerges(ctx, winnerID)
+}
+
+// Split undoes the merge of loserID into winnerID, or the most recent
+// unsplit merge into winnerID when loserID is not valid. The loser is
+// recreated with its original UUID, name and attributes; the aliases, child
+// ingredients, substitutes, composite substitutes, conversions, nutrition,
+// storage guidance and redirects it had before the merge are handed back.
+//
+// Split reverses what it can. Rows that have changed or disappeared since
+// the merge are left alone and reported as issues. It returns sql.ErrNoRows
+// if the winner does not exist, ErrMergeNotFound if there is no merge to
+// undo and ErrSplitConflict if the loser's name is taken.
+func (s *Service) Split(ctx context.Context, winnerID uuid.UUID, loserID uuid.NullUUID) (SplitResult, error) {
+	tx, err := s.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return SplitResult{}, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	qtx := db.New(tx)
+	if err := setChange(ctx, qtx, ChangeSplit); err != nil {
+		return SplitResult{}, err
+	}
+	if err := qtx.LockIngredientHierarchy(ctx); err != nil {
+		return SplitResult{}, err
+	}
+	if _, err := qtx.GetIngredient(ctx, winnerID); err != nil {
+		return SplitResult{}, err
+	}
+	var record db.IngredientMerge
+	if loserID.Valid {
+		record, err = qtx.GetOpenIngredientMerge(ctx, db.GetOpenIngredientMergeParams{WinnerID: winnerID, LoserID: loserID.UUID})
+	} else {
+		record, err = qtx.GetLatestOpenIngredientMerge(ctx, winnerID)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return SplitResult{}, ErrMergeNotFound
+		}
+		return SplitResult{}, err
+	}
+	var snap mergeSnapshot
+	if err := json.Unmarshal(record.Snapshot, &snap); err != nil {
+		return SplitResult{}, fmt.Errorf("decode merge %s: %w", record.ID, err)
+	}
+
+	result, err := splitMerge(ctx, qtx, snap)
+	if err != nil {
+		return SplitResult{}, err
+	}
+	if err := qtx.MarkIngredientMergeSplit(ctx, record.ID); err != nil {
+		return SplitResult{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return SplitResult{}, err
+	}
+	result.MergeID = record.ID
+	slog.Info("split merge", "merge", record.ID, "winner", winnerID, "restored", snap.Loser.ID, "issues", len(result.Issues))
+	return result, nil
+}
+
+// splitMerge reverses snap inside the caller's transaction.
+func splitMerge(ctx context.Context, q db.Querier, snap mergeSnapshot) (SplitResult, error) {
+	loser, winnerID := snap.Loser, snap.WinnerAfter.ID
+	var issues []SplitIssue
+	report := func(kind string, id uuid.UUID, format string, args ...any) {
+		issues = append(issues, SplitIssue{Kind: kind, ID: id, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	if _, err := q.GetIngredient(ctx, loser.ID); err == nil {
+		return SplitResult{}, fmt.Errorf("%w: ingredient %s already exists", ErrSplitConflict, loser.ID)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return SplitResult{}, err
+	}
+	if other, err := q.GetIngredientByName(ctx, loser.Name); err == nil {
+		return SplitResult{}, fmt.Errorf("%w: name %q now belongs to %s", ErrSplitConflict, loser.Name, other.ID)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return SplitResult{}, err
+	}
+
+	// Recreate the loser, dropping references to rows that have gone.
+	if loser.ParentID.Valid {
+		live, err := q.ListIngredientIDs(ctx, []uuid.UUID{loser.ParentID.UUID})
+		if err != nil {
+			return SplitResult{}, err
+		}
+		if len(live) == 0 {
+			report("ingredient", loser.ID, "parent %s no longer exists; restored without a parent", loser.ParentID.UUID)
+			loser.ParentID = uuid.NullUUID{}
+		}
+	}
+	if loser.CategoryID.Valid {
+		if _, err := q.GetCategory(ctx, loser.CategoryID.UUID); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return SplitResult{}, err
+			}
+			report("ingredient", loser.ID, "category %s no longer exists; restored without a category", loser.CategoryID.UUID)
+			loser.CategoryID = uuid.NullUUID{}
+		}
+	}
+
+	// Take the merged aliases off the winner first so the loser can have
+	// its own back. Aliases another ingredient has taken since stay with it.
+	winner, err := restoreWinner(ctx, q, snap, report)
+	if err != nil {
+		return SplitResult{}, err
+	}
+	owners, err := termOwners(ctx, q, append([]string{loser.Name}, loser.Aliases...), loser.ID)
+	if err != nil {
+		return SplitResult{}, err
+	}
+	if owner, ok := owners[loser.Name]; ok {
+		return SplitResult{}, fmt.Errorf("%w: name %q is now an alias of %s", ErrSplitConflict, loser.Name, owner.ID)
+	}
+	aliases := make([]string, 0, len(loser.Aliases))
+	for _, a := range loser.Aliases {
+		if owner, ok := owners[a]; ok {
+			report("ingredient", loser.ID, "alias %q now belongs to %s; not restored", a, owner.ID)
+			continue
+		}
+		aliases = append(aliases, a)
+	}
+
+	restored, err := q.InsertIngredientSnapshot(ctx, db.InsertIngredientSnapshotParams{
+		ID:          loser.ID,
+		Name:        loser.Name,
+		Aliases:     aliases,
+		DefaultUnit: loser.DefaultUnit,
+		CreatedAt:   loser.CreatedAt,
+		ParentID:    loser.ParentID,
+		CategoryID:  loser.CategoryID,
+		Allergens:   nonNil(loser.Allergens),
+		DietaryTags: nonNil(loser.DietaryTags),
+		FreeOf:      nonNil(loser.FreeOf),
+		ArchivedAt:  loser.ArchivedAt,
+		// Past the version the merge's deletion was recorded at. Snapshots
+		// taken before versioning carry 0.
+		Version: loser.Version + 2,
+	})
+	if err != nil {
+		return SplitResult{}, err
+	}
+	if restored, err = restoreAliases(ctx, q, snap, aliases, report); err != nil {
+		return SplitResult{}, err
+	}
+
+	for _, move := range snap.ChildMoves {
+		n, err := q.RestoreIngredientParent(ctx, db.RestoreIngredientParentParams{
+			RestoredID:       loser.ID,
+			ID:               move.ID,
+			ExpectedParentID: move.ParentID,
+		})
+		if err != nil {
+			return SplitResult{}, err
+		}
+		if n == 0 {
+			report("child", move.ID, "moved or deleted since the merge; left in place")
+		}
+	}
+	if slices.ContainsFunc(snap.ChildMoves, func(m db.SetIngredientParentParams) bool { return m.ID == winnerID }) {
+		// The winner was one of the loser's children; re-read its parent.
+		if winner, err = q.GetIngredient(ctx, winnerID); err != nil {
+			return SplitResult{}, err
+		}
+	}
+
+	if err := restoreSubstitutes(ctx, q, snap, report); err != nil {
+		return SplitResult{}, err
+	}
+	if err := restoreConversions(ctx, q, snap, report); err != nil {
+		return SplitResult{}, err
+	}
+	if err := restoreOwnedData(ctx, q, snap, report); err != nil {
+		return SplitResult{}, err
+	}
+
+	return SplitResult{Winner: winner, Restored: restored, Issues: issues}, nil
+}
+
+// restoreWinner removes the aliases the merge added to the winner and puts
+// back the merge fields and dietary attributes the merge changed, if nobody
+// has changed them since.
+func restoreWinner(ctx context.Context, q db.Querier, snap mergeSnapshot, report func(string, uuid.UUID, string, ...any)) (db.Ingredient, error) {
+	winner, err := q.GetIngredient(ctx, snap.WinnerAfter.ID)
+	if err != nil {
+		return db.Ingredient{}, err
+	}
+	added := make(map[string]bool)
+	for _, a := range snap.WinnerAfter.Aliases {
+		if !slices.Contains(snap.WinnerBefore.Aliases, a) {
+			added[a] = true
+		}
+	}
+	aliases := make([]string, 0, len(winner.Aliases))
+	for _, a := range winner.Aliases {
+		if !added[a] {
+			aliases = append(aliases, a)
+		}
+	}
+	changed := len(aliases) != len(winner.Aliases)
+	for _, f := range mergeFields {
+		if f.display(snap.WinnerBefore) == f.display(snap.WinnerAfter) {
+			continue
+		}
+		if f.display(winner) != f.display(snap.WinnerAfter) {
+			report("ingredient", winner.ID, "%s changed since the merge; left as it is", f.name)
+			continue
+		}
+		f.take(&winner, snap.WinnerBefore)
+		changed = true
+	}
+	if changed {
+		winner, err = q.UpdateIngredient(ctx, db.UpdateIngredientParams{
+			ID:          winner.ID,
+			Aliases:     aliases,
+			CategoryID:  winner.CategoryID,
+			DefaultUnit: winner.DefaultUnit,
+		})
+		if err != nil {
+			return db.Ingredient{}, err
+		}
+	}
+
+	mergeChanged := !slices.Equal(snap.WinnerBefore.Allergens, snap.WinnerAfter.Allergens) ||
+		!slices.Equal(snap.WinnerBefore.DietaryTags, snap.WinnerAfter.DietaryTags) ||
+		!slices.Equal(snap.WinnerBefore.FreeOf, snap.WinnerAfter.FreeOf)
+	if !mergeChanged {
+		return winner, nil
+	}
+	if !slices.Equal(winner.Allergens, snap.WinnerAfter.Allergens) || !slices.Equal(winner.DietaryTags, snap.WinnerAfter.DietaryTags) ||
+		!slices.Equal(winner.FreeOf, snap.WinnerAfter.FreeOf) {
+		report("ingredient", winner.ID, "dietary attributes changed since the merge; left as they are")
+		return winner, nil
+	}
+	return q.UpdateIngredientDietary(ctx, db.UpdateIngredientDietaryParams{
+		ID:          winner.ID,
+		Allergens:   nonNil(snap.WinnerBefore.Allergens),
+		DietaryTags: nonNil(snap.WinnerBefore.DietaryTags),
+		FreeOf:      nonNil(snap.WinnerBefore.FreeOf),
+	})
+}
+
+// restoreAliases recreates the loser's alias rows for the aliases in keep,
+// with the metadata they had, and refreshes its aliases column.
+func restoreAliases(ctx context.Context, q db.Querier, snap mergeSnapshot, keep []string, report func(string, uuid.UUID, string, ...any)) (db.Ingredient, error) {
+	loser := snap.Loser
+	rows := snap.Aliases
+	if rows == nil {
+		// Merged before alias metadata was recorded.
+		for _, a := range loser.Aliases {
+			rows = append(rows, db.IngredientAlias{
+				ID: uuid.New(), IngredientID: loser.ID, Alias: a,
+				Source: string(AliasSourceManual), CreatedAt: loser.CreatedAt,
+			})
+		}
+	}
+	for _, a := range rows {
+		if !slices.Contains(keep, a.Alias) {
+			continue
+		}
+		n, err := q.InsertIngredientAliasSnapshot(ctx, db.InsertIngredientAliasSnapshotParams{
+			ID:           a.ID,
+			IngredientID: loser.ID,
+			Alias:        a.Alias,
+			Source:       a.Source,
+			CreatedBy:    a.CreatedBy,
+			Locale:       a.Locale,
+			CreatedAt:    a.CreatedAt,
+		})
+		if err != nil {
+			return db.Ingredient{}, err
+		}
+		if n == 0 {
+			report("alias", a.ID, "alias %q changed since the merge; not restored", a.Alias)
+		}
+	}
+	return q.SyncIngredientAliases(ctx, loser.ID)
+}
+
+// restoreSubstitutes points the loser's substitute rows back at it. Rows the
+// merge dropped as redundant are recreated from the snapshot.
+func restoreSubstitutes(ctx context.Context, q db.Querier, snap mergeSnapshot, report func(string, uuid.UUID, string, ...any)) error {
+	loserID, winnerID := snap.Loser.ID, snap.WinnerAfter.ID
+	for _, sub := range snap.Substitutes {
+		var n int64
+		var err error
+		other := sub.SubstituteID
+		if sub.IngredientID == loserID {
+			n, err = q.RestoreSubstituteIngredient(ctx, db.RestoreSubstituteIngredientParams{LoserID: loserID, ID: sub.ID, WinnerID: winnerID})
+		} else {
+			other = sub.IngredientID
+			n, err = q.RestoreSubstituteSubId(ctx, db.RestoreSubstituteSubIdParams{LoserID: loserID, ID: sub.ID, WinnerID: winnerID})
+		}
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			continue
+		}
+		live, err := q.ListIngredientIDs(ctx, []uuid.UUID{other})
+		if err != nil {
+			return err
+		}
+		if len(live) == 0 {
+			report("substitute", sub.ID, "ingredient %s no longer exists; substitute not restored", other)
+			continue
+		}
+		n, err = q.InsertSubstituteSnapshot(ctx, db.InsertSubstituteSnapshotParams{
+			ID:           sub.ID,
+			IngredientID: sub.IngredientID,
+			SubstituteID: sub.SubstituteID,
+			Ratio:        sub.Ratio,
+			Notes:        sub.Notes,
+			Contexts:     nonNil(sub.Contexts),
+			Reasons:      nonNil(sub.Reasons),
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			report("substitute", sub.ID, "changed since the merge; not restored")
+		}
+	}
+
+	for _, id := range snap.CompositeIDs {
+		n, err := q.RestoreCompositeSubstituteIngredient(ctx, db.RestoreCompositeSubstituteIngredientParams{LoserID: loserID, ID: id, WinnerID: winnerID})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			report("composite_substitute", id, "removed or changed since the merge; not restored")
+		}
+	}
+	for _, u := range snap.ComponentQuantityUpdates {
+		n, err := q.RestoreCompositeComponentQuantity(ctx, db.RestoreCompositeComponentQuantityParams{Before: u.Before, ID: u.ID, After: u.After})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			report("composite_component", u.ID, "quantity changed since the merge; kept at its current value")
+		}
+	}
+	for _, c := range snap.DroppedComponents {
+		n, err := q.InsertCompositeComponentSnapshot(ctx, db.InsertCompositeComponentSnapshotParams{
+			ID:          c.ID,
+			CompositeID: c.CompositeID,
+			ComponentID: c.ComponentID,
+			Quantity:    c.Quantity,
+			Unit:        c.Unit,
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			report("composite_component", c.ID, "composite removed or changed since the merge; not restored")
+		}
+	}
+	for _, id := range snap.ComponentIDs {
+		if slices.ContainsFunc(snap.DroppedComponents, func(c db.CompositeSubstituteComponent) bool { return c.ID == id }) {
+			continue
+		}
+		n, err := q.RestoreCompositeComponent(ctx, db.RestoreCompositeComponentParams{LoserID: loserID, ID: id, WinnerID: winnerID})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			report("composite_component", id, "removed or changed since the merge; not restored")
+		}
+	}
+	return nil
+}
+
+// restoreConversions hands the loser's conversions back, recreates rows the
+// conversion policy dropped and resets factors it rewrote.
+func restoreConversions(ctx context.Context, q db.Querier, snap mergeSnapshot, report func(string, uuid.UUID, string, ...any)) error {
+	loserID, winnerID := snap.Loser.ID, snap.WinnerAfter.ID
+	for _, c := range snap.Conversions {
+		n, err := q.RestoreUnitConversion(ctx, db.RestoreUnitConversionParams{LoserID: loserID, ID: c.ID, WinnerID: winnerID})
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			continue
+		}
+		if err := insertConversionSnapshot(ctx, q, c, report); err != nil {
+			return err
+		}
+	}
+	for _, c := range snap.DroppedWinnerConversions {
+		if err := insertConversionSnapshot(ctx, q, c, report); err != nil {
+			return err
+		}
+	}
+	for _, u := range snap.FactorUpdates {
+		n, err := q.RestoreUnitConversionFactor(ctx, db.RestoreUnitConversionFactorParams{Before: u.Before, ID: u.ID, After: u.After})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			report("conversion", u.ID, "factor changed since the merge; kept at its current value")
+		}
+	}
+	return nil
+}
+
+func insertConversionSnapshot(ctx context.Context, q db.Querier, c db.UnitConversion, report func(string, uuid.UUID, string, ...any)) error {
+	n, err := q.InsertUnitConversionSnapshot(ctx, db.InsertUnitConversionSnapshotParams{
+		ID:           c.ID,
+		IngredientID: c.IngredientID,
+		FromUnit:     c.FromUnit,
+		ToUnit:       c.ToUnit,
+		Factor:       c.Factor,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		report("conversion", c.ID, "changed since the merge; not restored")
+	}
+	return nil
+}
+
+// restoreOwnedData hands back nutrition, storage guidance and redirects.
+func restoreOwnedData(ctx context.Context, q db.Querier, snap mergeSnapshot, report func(string, uuid.UUID, string, ...any)) error {
+	loserID, winnerID := snap.Loser.ID, snap.WinnerAfter.ID
+	if n := snap.Nutrition; n != nil {
+		moved, err := q.RestoreIngredientNutrition(ctx, db.RestoreIngredientNutritionParams{
+			LoserID: loserID, WinnerID: winnerID, UpdatedAt: n.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if moved == 0 {
+			p := db.UpsertIngredientNutritionParams{
+				IngredientID: loserID, Source: n.Source, SourceID: n.SourceID, SourceName: n.SourceName,
+				EnergyKcal: n.EnergyKcal, ProteinG: n.ProteinG, FatG: n.FatG, SaturatedFatG: n.SaturatedFatG,
+				CarbohydrateG: n.CarbohydrateG, SugarsG: n.SugarsG, FiberG: n.FiberG, SodiumMg: n.SodiumMg,
+				PotassiumMg: n.PotassiumMg, CalciumMg: n.CalciumMg, IronMg: n.IronMg, VitaminCMg: n.VitaminCMg,
+			}
+			if _, err := q.UpsertIngredientNutrition(ctx, p); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, g := range snap.StorageGuidelines {
+		n, err := q.RestoreStorageGuideline(ctx, db.RestoreStorageGuidelineParams{LoserID: loserID, ID: g.ID, WinnerID: winnerID})
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			continue
+		}
+		n, err = q.InsertStorageGuidelineSnapshot(ctx, db.InsertStorageGuidelineSnapshotParams{
+			ID:           g.ID,
+			IngredientID: g.IngredientID,
+			Location:     g.Location,
+			UnopenedDays: g.UnopenedDays,
+			OpenedDays:   g.OpenedDays,
+			Notes:        g.Notes,
+			UpdatedAt:    g.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			report("storage_guideline", g.ID, "changed since the merge; not restored")
+		}
+	}
+
+	if err := q.DeleteIngredientRedirect(ctx, loserID); err != nil {
+		return err
+	}
+	for _, old := range snap.RedirectsTo {
+		n, err := q.RestoreIngredientRedirect(ctx, db.RestoreIngredientRedirectParams{LoserID: loserID, OldID: old, WinnerID: winnerID})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			report("redirect", old, "no longer points at the winner; left as it is")
+		}
+	}
+	return nil
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,148 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	winner, err := q.CreateIngredient(ctx, db.CreateIngredientParams{
+		Name:    "coconut milk",
+		Aliases: []string{"coco milk"},
+	})
+	require.NoError(t, err)
+	loser, err := q.CreateIngredient(ctx, db.CreateIngredientParams{
+		Name:    "coconut cream",
+		Aliases: []string{"cream of coconut"},
+	})
+	require.NoError(t, err)
+	other, err := q.CreateIngredient(ctx, db.CreateIngredientParams{
+		Name:    "heavy cream",
+		Aliases: []string{},
+	})
+	require.NoError(t, err)
+
+	sub, err := q.CreateSubstitute(ctx, db.CreateSubstituteParams{
+		IngredientID: loser.ID,
+		SubstituteID: other.ID,
+		Ratio:        1.0,
+	})
+	require.NoError(t, err)
+	conv, err := q.CreateUnitConversion(ctx, db.CreateUnitConversionParams{
+		IngredientID: loser.ID,
+		FromUnit:     "cup",
+		ToUnit:       "g",
+		Factor:       230,
+	})
+	require.NoError(t, err)
+
+	merged, err := svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{})
+	require.NoError(t, err)
+	require.Len(t, merged.MergeIDs, 1)
+
+	result, err := svc.Split(ctx, winner.ID, uuid.NullUUID{})
+	require.NoError(t, err)
+	assert.Equal(t, merged.MergeIDs[0], result.MergeID)
+	assert.Empty(t, result.Issues)
+
+	// The loser is back under its original ID with its own aliases.
+	assert.Equal(t, loser.ID, result.Restored.ID)
+	assert.Equal(t, "coconut cream", result.Restored.Name)
+	assert.Equal(t, []string{"cream of coconut"}, result.Restored.Aliases)
+	assert.Equal(t, []string{"coco milk"}, result.Winner.Aliases)
+
+	subs, err := q.ListSubstitutesByIngredient(ctx, loser.ID)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.Equal(t, sub.ID, subs[0].ID)
+
+	convs, err := q.ListUnitConversionsByIngredient(ctx, loser.ID)
+	require.NoError(t, err)
+	require.Len(t, convs, 1)
+	assert.Equal(t, conv.ID, convs[0].ID)
+
+	// The redirect is gone and the merge is marked as split.
+	ing, err := svc.GetIngredient(ctx, loser.ID)
+	require.NoError(t, err)
+	assert.Equal(t, loser.ID, ing.ID)
+
+	merges, err := svc.ListMerges(ctx, winner.ID)
+	require.NoError(t, err)
+	require.Len(t, merges, 1)
+	assert.True(t, merges[0].SplitAt.Valid)
+
+	_, err = svc.Split(ctx, winner.ID, uuid.NullUUID{})
+	assert.ErrorIs(t, err, ErrMergeNotFound)
+}
+
+func TestSplit_ReportsIrreversibleChanges(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	winner, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "scallion", Aliases: []string{}})
+	require.NoError(t, err)
+	loser, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "green onion", Aliases: []string{}})
+	require.NoError(t, err)
+	other, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "chives", Aliases: []string{}})
+	require.NoError(t, err)
+	sub, err := q.CreateSubstitute(ctx, db.CreateSubstituteParams{
+		IngredientID: loser.ID,
+		SubstituteID: other.ID,
+		Ratio:        1.0,
+	})
+	require.NoError(t, err)
+
+	_, err = svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{})
+	require.NoError(t, err)
+
+	// Deleting the substitute's target after the merge takes the row with it.
+	require.NoError(t, q.DeleteIngredient(ctx, other.ID))
+
+	result, err := svc.Split(ctx, winner.ID, uuid.NullUUID{UUID: loser.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, "substitute", result.Issues[0].Kind)
+	assert.Equal(t, sub.ID, result.Issues[0].ID)
+
+	_, err = q.GetIngredient(ctx, loser.ID)
+	assert.NoError(t, err)
+}
+
+func TestSplit_NameConflict(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	winner, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "cilantro", Aliases: []string{}})
+	require.NoError(t, err)
+	loser, err := q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "coriander leaves", Aliases: []string{}})
+	require.NoError(t, err)
+
+	_, err = svc.Merge(ctx, winner.ID, loser.ID, MergeOptions{})
+	require.NoError(t, err)
+	_, err = q.CreateIngredient(ctx, db.CreateIngredientParams{Name: "coriander leaves", Aliases: []string{}})
+	require.NoError(t, err)
+
+	_, err = svc.Split(ctx, winner.ID, uuid.NullUUID{})
+	assert.ErrorIs(t, err, ErrSplitConflict)
+
+	_, err = q.GetIngredient(ctx, loser.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordConversions(t *testing.T) {
+	t.Parallel()
+
+	winnerID, loserID := uuid.New(), uuid.New()
+	winnerCup := db.UnitConversion{ID: uuid.New(), IngredientID: winnerID, FromUnit: "cup", ToUnit: "g", Factor: 240}
+	winnerTbsp := db.UnitConversion{ID: uuid.New(), IngredientID: winnerID, FromUnit: "tbsp", ToUnit: "g", Factor: 15}
+	loserCup := db.UnitConversion{ID: uuid.New(), IngredientID: loserID, FromUnit: "cup", ToUnit: "g", Factor: 250}
+	loserTbsp := db.UnitConversion{ID: uuid.New(), IngredientID: loserID, FromUnit: "tbsp", ToUnit: "g", Factor: 16}
+
+	plan := conversionMergePlan{
+		updates: []db.UpdateUnitConversionFactorParams{{ID: winnerTbsp.ID, Factor: 15.5}},
+		dropped: []db.UnitConversion{winnerCup, loserTbsp},
+	}
+
+	var snap mergeSnapshot
+	snap.recordConversions(winnerID,
+		[]db.UnitConversion{winnerCup, winnerTbsp},
+		[]db.UnitConversion{loserCup, loserTbsp},
+		plan,
+	)
+
+	assert.Equal(t, []db.UnitConversion{loserCup, loserTbsp}, snap.Conversions)
+	assert.Equal(t, []db.UnitConversion{winnerCup}, snap.DroppedWinnerConversions)
+	assert.Equal(t, []factorUpdate{{ID: winnerTbsp.ID, Before: 15, After: 15.5}}, snap.FactorUpdates)
+}
+
+func TestSplitMerge_Conflict(t *testing.T) {
+	t.Parallel()
+
+	loser := newIngredient("coconut cream", nil)
+	winner := newIngredient("coconut milk", []string{"coconut cream"})
+
+	tests := []struct {
+		name  string
+		setup func(*mocks.MockQuerier)
+	}{
+		{
+			name: "loser ID in use",
+			setup: func(m *mocks.MockQuerier) {
+				m.EXPECT().GetIngredient(mock.Anything, loser.ID).Return(loser, nil)
+			},
+		},
+		{
+			name: "loser name taken",
+			setup: func(m *mocks.MockQuerier) {
+				m.EXPECT().GetIngredient(mock.Anything, loser.ID).Return(db.Ingredient{}, sql.ErrNoRows)
+				m.EXPECT().GetIngredientByName(mock.Anything, loser.Name).Return(newIngredient(loser.Name, nil), nil)
+			},
+		},
+		{
+			name: "loser name now another alias",
+			setup: func(m *mocks.MockQuerier) {
+				m.EXPECT().GetIngredient(mock.Anything, loser.ID).Return(db.Ingredient{}, sql.ErrNoRows)
+				m.EXPECT().GetIngredientByName(mock.Anything, loser.Name).Return(db.Ingredient{}, sql.ErrNoRows)
+				m.EXPECT().GetIngredient(mock.Anything, winner.ID).Return(winner, nil)
+				m.EXPECT().UpdateIngredient(mock.Anything, mock.Anything).Return(winner, nil)
+				m.EXPECT().ListTermOwners(mock.Anything, []string{loser.Name}).Return([]db.ListTermOwnersRow{
+					{ID: uuid.New(), Name: "cream", Term: loser.Name},
+				}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockQ := mocks.NewMockQuerier(t)
+			tt.setup(mockQ)
+
+			_, err := splitMerge(context.Background(), mockQ, mergeSnapshot{Loser: loser, WinnerAfter: winner})
+			assert.ErrorIs(t, err, ErrSplitConflict)
+		})
+	}
+}
+
+func TestRestoreWinner(t *testing.T) {
+	t.Parallel()
+
+	before := newIngredient("coconut milk", []string{"coco milk"})
+	after := before
+	after.Aliases = []string{"coco milk", "coconut cream", "cream of coconut"}
+	after.Allergens = []string{"tree_nuts"}
+
+	t.Run("removes added aliases and restores attributes", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+
+		current := after
+		current.Aliases = []string{"coco milk", "coconut cream", "cream of coconut", "coconut drink"}
+		mockQ.EXPECT().GetIngredient(mock.Anything, before.ID).Return(current, nil)
+		mockQ.EXPECT().UpdateIngredient(mock.Anything, db.UpdateIngredientParams{
+			ID:      before.ID,
+			Aliases: []string{"coco milk", "coconut drink"},
+		}).Return(current, nil)
+		mockQ.EXPECT().UpdateIngredientDietary(mock.Anything, db.UpdateIngredientDietaryParams{
+			ID:          before.ID,
+			Allergens:   []string{},
+			DietaryTags: []string{},
+			FreeOf:      []string{},
+		}).Return(before, nil)
+
+		var issues int
+		_, err := restoreWinner(context.Background(), mockQ,
+			mergeSnapshot{WinnerBefore: before, WinnerAfter: after},
+			func(string, uuid.UUID, string, ...any) { issues++ })
+		require.NoError(t, err)
+		assert.Zero(t, issues)
+	})
+
+	t.Run("reports attributes changed since the merge", func(t *testing.T) {
+		t.Parallel()
+		mockQ := mocks.NewMockQuerier(t)
+
+		current := before
+		current.Allergens = []string{"tree_nuts", "sesame"}
+		mockQ.EXPECT().GetIngredient(mock.Anything, before.ID).Return(current, nil)
+
+		var kinds []string
+		got, err := restoreWinner(context.Background(), mockQ,
+			mergeSnapshot{WinnerBefore: before, WinnerAfter: after},
+			func(kind string, _ uuid.UUID, _ string, _ ...any) { kinds = append(kinds, kind) })
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ingredient"}, kinds)
+		assert.Equal(t, current.Allergens, got.Allergens)
+	})
+}
@@ -0,0 +1,572 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// StorageLocations are the places an ingredient can be stored.
+var StorageLocations = []string{"pantry", "fridge", "freezer"}
+
+var (
+	// ErrInvalidStorage is returned for a storage guideline or expiry request
+	// that fails validation.
+	ErrInvalidStorage = errors.New("invalid storage guideline")
+	// ErrNoStorageGuidance is returned when neither an ingredient nor its
+	// categories have guidance for the requested location.
+	ErrNoStorageGuidance = errors.New("no storage guidance")
+	// ErrInvalidStorageCSV is returned when an import file has no usable
+	// header.
+	ErrInvalidStorageCSV = errors.New("invalid storage csv")
+)
+
+// maxShelfLifeDays caps a shelf life at ten years.
+const maxShelfLifeDays = 3650
+
+// StorageGuideline is the shelf life of an ingredient kept at Location, in
+// days. At least one of UnopenedDays and OpenedDays must be set, and each
+// is at most maxShelfLifeDays.
+type StorageGuideline struct {
+	Location     string
+	UnopenedDays *int
+	OpenedDays   *int
+	Notes        string
+}
+
+// StorageSource says where an ingredient's effective guidance comes from.
+type StorageSource string
+
+const (
+	StorageFromIngredient StorageSource = "ingredient"
+	StorageFromCategory   StorageSource = "category"
+	StorageNone           StorageSource = "none"
+)
+
+// StorageGuidance is the guidance that applies to an ingredient. An
+// ingredient with no guidelines of its own inherits those of its category,
+// or of the nearest parent category that has any; CategoryID then names the
+// category they came from.
+type StorageGuidance struct {
+	Source     StorageSource
+	CategoryID uuid.NullUUID
+	Guidelines []db.StorageGuideline
+}
+
+// ExpiryRequest describes an item bought on PurchasedOn. A zero OpenedOn
+// means the item is still sealed; an empty Location estimates every location
+// with guidance.
+type ExpiryRequest struct {
+	PurchasedOn time.Time
+	OpenedOn    time.Time
+	Location    string
+}
+
+// ExpiryEstimate is the estimated expiry at one location. Basis is "opened"
+// when the opened shelf life decided the date and "unopened" otherwise.
+type ExpiryEstimate struct {
+	Location  string
+	ExpiresOn time.Time
+	Days      int
+	Basis     string
+}
+
+// StorageGuidance returns the guidance that applies to an ingredient. It
+// returns sql.ErrNoRows if the ingredient does not exist.
+func (s *Service) StorageGuidance(ctx context.Context, id uuid.UUID) (StorageGuidance, error) {
+	ing, err := s.q.GetIngredient(ctx, id)
+	if err != nil {
+		return StorageGuidance{}, err
+	}
+	own, err := s.q.ListStorageGuidelinesByIngredient(ctx, id)
+	if err != nil {
+		return StorageGuidance{}, err
+	}
+	if len(own) > 0 {
+		return StorageGuidance{Source: StorageFromIngredient, Guidelines: own}, nil
+	}
+
+	catID := ing.CategoryID
+	for depth := 0; catID.Valid && depth < maxHierarchyDepth; depth++ {
+		inherited, err := s.q.ListStorageGuidelinesByCategory(ctx, catID.UUID)
+		if err != nil {
+			return StorageGuidance{}, err
+		}
+		if len(inherited) > 0 {
+			return StorageGuidance{Source: StorageFromCategory, CategoryID: catID, Guidelines: inherited}, nil
+		}
+		cat, err := s.q.GetCategory(ctx, catID.UUID)
+		if err != nil {
+			return StorageGuidance{}, err
+		}
+		catID = cat.ParentID
+	}
+	return StorageGuidance{Source: StorageNone, Guidelines: []db.StorageGuideline{}}, nil
+}
+
+// SetStorageGuidelines replaces an ingredient's own guidelines. An empty list
+// clears them, so the ingredient inherits from its category again. It
+// returns sql.ErrNoRows if the ingredient does not exist and
+// ErrInvalidStorage for bad input.
+func (s *Service) SetStorageGuidelines(ctx context.Context, id uuid.UUID, guidelines []StorageGuideline) (StorageGuidance, error) {
+	guidelines, err := normalizeStorageGuidelines(guidelines)
+	if err != nil {
+		return StorageGuidance{}, err
+	}
+
+	tx, err := s.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return StorageGuidance{}, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	qtx := db.New(tx)
+	if _, err := qtx.GetIngredient(ctx, id); err != nil {
+		return StorageGuidance{}, err
+	}
+	if err := qtx.DeleteIngredientStorageGuidelines(ctx, id); err != nil {
+		return StorageGuidance{}, err
+	}
+	for _, g := range guidelines {
+		if _, err := qtx.UpsertIngredientStorageGuideline(ctx, db.UpsertIngredientStorageGuidelineParams{
+			IngredientID: uuid.NullUUID{UUID: id, Valid: true},
+			Location:     g.Location,
+			UnopenedDays: nullDays(g.UnopenedDays),
+			OpenedDays:   nullDays(g.OpenedDays),
+			Notes:        nullString(g.Notes),
+		}); err != nil {
+			return StorageGuidance{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return StorageGuidance{}, err
+	}
+	return s.StorageGuidance(ctx, id)
+}
+
+// CategoryStorageGuidelines returns a category's own guidelines. It returns
+// sql.ErrNoRows if the category does not exist.
+func (s *Service) CategoryStorageGuidelines(ctx context.Context, id uuid.UUID) ([]db.StorageGuideline, error) {
+	if _, err := s.q.GetCategory(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.q.ListStorageGuidelinesByCategory(ctx, id)
+}
+
+// SetCategoryStorageGuidelines replaces a category's guidelines, which its
+// ingredients and subcategories inherit. It returns sql.ErrNoRows if the
+// category does not exist and ErrInvalidStorage for bad input.
+func (s *Service) SetCategoryStorageGuidelines(ctx context.Context, id uuid.UUID, guidelines []StorageGuideline) ([]db.StorageGuideline, error) {
+	guidelines, err := normalizeStorageGuidelines(guidelines)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	qtx := db.New(tx)
+	if _, err := qtx.GetCategory(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := qtx.DeleteCategoryStorageGuidelines(ctx, id); err != nil {
+		return nil, err
+	}
+	saved := make([]db.StorageGuideline, 0, len(guidelines))
+	for _, g := range guidelines {
+		row, err := qtx.UpsertCategoryStorageGuideline(ctx, db.UpsertCategoryStorageGuidelineParams{
+			CategoryID:   uuid.NullUUID{UUID: id, Valid: true},
+			Location:     g.Location,
+			UnopenedDays: nullDays(g.UnopenedDays),
+			OpenedDays:   nullDays(g.OpenedDays),
+			Notes:        nullString(g.Notes),
+		})
+		if err != nil {
+			return nil, err
+		}
+		saved = append(saved, row)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// EstimateExpiry estimates when an item of ingredient id expires, using its
+// effective storage guidance. It returns sql.ErrNoRows if the ingredient
+// does not exist, ErrInvalidStorage for a bad request and
+// ErrNoStorageGuidance if there is no guidance for the location.
+func (s *Service) EstimateExpiry(ctx context.Context, id uuid.UUID, req ExpiryRequest) ([]ExpiryEstimate, StorageGuidance, error) {
+	if req.PurchasedOn.IsZero() {
+		return nil, StorageGuidance{}, fmt.Errorf("%w: purchase date is required", ErrInvalidStorage)
+	}
+	if !req.OpenedOn.IsZero() && req.OpenedOn.Before(req.PurchasedOn) {
+		return nil, StorageGuidance{}, fmt.Errorf("%w: opened before purchase", ErrInvalidStorage)
+	}
+	req.Location = strings.ToLower(strings.TrimSpace(req.Location))
+	if req.Location != "" && !slices.Contains(StorageLocations, req.Location) {
+		return nil, StorageGuidance{}, fmt.Errorf("%w: unknown location %q", ErrInvalidStorage, req.Location)
+	}
+
+	guidance, err := s.StorageGuidance(ctx, id)
+	if err != nil {
+		return nil, StorageGuidance{}, err
+	}
+	var estimates []ExpiryEstimate
+	for _, g := range guidance.Guidelines {
+		if req.Location != "" && g.Location != req.Location {
+			continue
+		}
+		estimates = append(estimates, estimateExpiry(g, req.PurchasedOn, req.OpenedOn))
+	}
+	if len(estimates) == 0 {
+		return nil, guidance, ErrNoStorageGuidance
+	}
+	return estimates, guidance, nil
+}
+
+// estimateExpiry applies one guideline. A sealed item keeps for the unopened
+// shelf life from purchase. Once opened it keeps for the opened shelf life,
+// but never past the sealed date. When only one shelf life is known it is
+// used for both.
+func estimateExpiry(g db.StorageGuideline, purchased, opened time.Time) ExpiryEstimate {
+	est := ExpiryEstimate{Location: g.Location, Basis: "unopened"}
+	var sealed time.Time
+	if g.UnopenedDays.Valid {
+		sealed = purchased.AddDate(0, 0, int(g.UnopenedDays.Int32))
+	}
+	switch {
+	case !opened.IsZero() && g.OpenedDays.Valid:
+		est.ExpiresOn = opened.AddDate(0, 0, int(g.OpenedDays.Int32))
+		est.Basis = "opened"
+		if !sealed.IsZero() && sealed.Before(est.ExpiresOn) {
+			est.ExpiresOn = sealed
+			est.Basis = "unopened"
+		}
+	case !sealed.IsZero():
+		est.ExpiresOn = sealed
+	default:
+		est.ExpiresOn = purchased.AddDate(0, 0, int(g.OpenedDays.Int32))
+		est.Basis = "opened"
+	}
+	est.Days = int(est.ExpiresOn.Sub(purchased).Hours() / 24)
+	return est
+}
+
+// StorageImportOptions controls ImportStorage.
+type StorageImportOptions struct {
+	// DryRun reports the mapping without writing anything.
+	DryRun bool
+}
+
+// StorageRowStatus is the outcome of one import row.
+type StorageRowStatus string
+
+const (
+	StorageRowImported   StorageRowStatus = "imported"
+	StorageRowSuperseded StorageRowStatus = "superseded"
+	StorageRowUnmatched  StorageRowStatus = "unmatched"
+	StorageRowInvalid    StorageRowStatus = "invalid"
+)
+
+// StorageImportRow describes one import row. Exactly one of Ingredient and
+// Category is set for a row that was imported or superseded. Superseded rows
+// target the same ingredient or category and location as a row with higher
+// confidence, or as an earlier row with the same confidence.
+type StorageImportRow struct {
+	Line       int
+	Target     string
+	Location   string
+	Status     StorageRowStatus
+	Ingredient *db.Ingredient
+	Category   *db.Category
+	Confidence float64
+	Error      string
+}
+
+// StorageImportReport summarises an import.
+type StorageImportReport struct {
+	DryRun   bool
+	RowsRead int
+	Imported int
+	Rows     []StorageImportRow
+}
+
+// ImportStorage reads storage guidelines from a CSV and upserts them in a
+// single transaction unless opts.DryRun is set. Each row names either an
+// ingredient, matched like Resolve but never created, or a category slug.
+// Rows add to or replace guidelines for their location; other locations are
+// left alone.
+//
+// The CSV needs a header row with "location", one of "ingredient" and
+// "category", and "unopened_days" and/or "opened_days"; "notes" is optional.
+func (s *Service) ImportStorage(ctx context.Context, r io.Reader, opts StorageImportOptions) (StorageImportReport, error) {
+	rows, err := parseStorageCSV(r)
+	if err != nil {
+		return StorageImportReport{}, err
+	}
+	ingredients, err := s.q.ListIngredients(ctx)
+	if err != nil {
+		return StorageImportReport{}, err
+	}
+	categories, err := s.q.ListCategories(ctx)
+	if err != nil {
+		return StorageImportReport{}, err
+	}
+	bySlug := make(map[string]db.Category, len(categories))
+	for _, c := range categories {
+		bySlug[c.Slug] = c
+	}
+
+	type target struct {
+		id       uuid.UUID
+		category bool
+		location string
+	}
+	report := StorageImportReport{DryRun: opts.DryRun, RowsRead: len(rows)}
+	best := make(map[target]int) // target → index into report.Rows
+	for _, row := range rows {
+		entry := StorageImportRow{Line: row.line, Target: row.target, Location: row.guideline.Location}
+		switch {
+		case row.err != "":
+			entry.Status, entry.Error = StorageRowInvalid, row.err
+		case row.category:
+			if c, ok := bySlug[Slugify(row.target)]; ok {
+				entry.Status, entry.Category, entry.Confidence = StorageRowImported, &c, 1
+			} else {
+				entry.Status = StorageRowUnmatched
+			}
+		default:
+			ing, score := bestMatch(ingredients, Normalize(row.target))
+			if score >= s.threshold {
+				entry.Status, entry.Ingredient, entry.Confidence = StorageRowImported, &ing, score
+			} else {
+				entry.Status = StorageRowUnmatched
+			}
+		}
+		if entry.Status == StorageRowImported {
+			key := target{category: row.category, location: entry.Location}
+			if entry.Category != nil {
+				key.id = entry.Category.ID
+			} else {
+				key.id = entry.Ingredient.ID
+			}
+			if prev, ok := best[key]; ok {
+				if report.Rows[prev].Confidence >= entry.Confidence {
+					entry.Status = StorageRowSuperseded
+					report.Rows = append(report.Rows, entry)
+					continue
+				}
+				report.Rows[prev].Status = StorageRowSuperseded
+			}
+			best[key] = len(report.Rows)
+		}
+		report.Rows = append(report.Rows, entry)
+	}
+
+	var toWrite []int
+	for i, row := range report.Rows {
+		if row.Status == StorageRowImported {
+			toWrite = append(toWrite, i)
+		}
+	}
+	if opts.DryRun || len(toWrite) == 0 {
+		return report, nil
+	}
+
+	tx, err := s.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return StorageImportReport{}, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	qtx := db.New(tx)
+	for _, i := range toWrite {
+		row, g := report.Rows[i], rows[i].guideline
+		if row.Ingredient != nil {
+			_, err = qtx.UpsertIngredientStorageGuideline(ctx, db.UpsertIngredientStorageGuidelineParams{
+				IngredientID: uuid.NullUUID{UUID: row.Ingredient.ID, Valid: true},
+				Location:     g.Location,
+				UnopenedDays: nullDays(g.UnopenedDays),
+				OpenedDays:   nullDays(g.OpenedDays),
+				Notes:        nullString(g.Notes),
+			})
+		} else {
+			_, err = qtx.UpsertCategoryStorageGuideline(ctx, db.UpsertCategoryStorageGuidelineParams{
+				CategoryID:   uuid.NullUUID{UUID: row.Category.ID, Valid: true},
+				Location:     g.Location,
+				UnopenedDays: nullDays(g.UnopenedDays),
+				OpenedDays:   nullDays(g.OpenedDays),
+				Notes:        nullString(g.Notes),
+			})
+		}
+		if err != nil {
+			return StorageImportReport{}, fmt.Errorf("upsert storage guideline on line %d: %w", row.Line, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return StorageImportReport{}, err
+	}
+	report.Imported = len(toWrite)
+	slog.Info("storage import complete", "rows", report.RowsRead, "imported", report.Imported)
+	return report, nil
+}
+
+type storageCSVRow struct {
+	line      int
+	target    string
+	category  bool
+	guideline StorageGuideline
+	err       string
+}
+
+// parseStorageCSV reads the header and every data row. Row-level problems
+// are recorded on the row; only an unusable header is an error.
+func parseStorageCSV(r io.Reader) ([]storageCSVRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: empty file", ErrInvalidStorageCSV)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStorageCSV, err)
+	}
+	cols := map[string]int{}
+	for i, h := range header {
+		key := strings.ReplaceAll(Slugify(h), "-", "_")
+		if _, seen := cols[key]; !seen {
+			cols[key] = i
+		}
+	}
+	col := func(names ...string) int {
+		for _, n := range names {
+			if i, ok := cols[n]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+	ingCol, catCol := col("ingredient", "name"), col("category")
+	locCol := col("location")
+	unopenedCol, openedCol := col("unopened_days"), col("opened_days")
+	notesCol := col("notes")
+	switch {
+	case ingCol < 0 && catCol < 0:
+		return nil, fmt.Errorf("%w: no ingredient or category column", ErrInvalidStorageCSV)
+	case locCol < 0:
+		return nil, fmt.Errorf("%w: no location column", ErrInvalidStorageCSV)
+	case unopenedCol < 0 && openedCol < 0:
+		return nil, fmt.Errorf("%w: no shelf life columns", ErrInvalidStorageCSV)
+	}
+
+	var rows []storageCSVRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			rows = append(rows, storageCSVRow{line: csvErrorLine(err), err: err.Error()})
+			continue
+		}
+		line, _ := cr.FieldPos(0)
+		field := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		row := storageCSVRow{line: line}
+		ingName, catSlug := field(ingCol), field(catCol)
+		switch {
+		case ingName != "" && catSlug != "":
+			row.err = "set either ingredient or category, not both"
+		case ingName != "":
+			row.target = ingName
+		case catSlug != "":
+			row.target, row.category = catSlug, true
+		default:
+			row.err = "missing ingredient or category"
+		}
+		row.guideline = StorageGuideline{Location: field(locCol), Notes: field(notesCol)}
+		for _, d := range []struct {
+			col int
+			dst **int
+		}{{unopenedCol, &row.guideline.UnopenedDays}, {openedCol, &row.guideline.OpenedDays}} {
+			raw := field(d.col)
+			if raw == "" || row.err != "" {
+				continue
+			}
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				row.err = fmt.Sprintf("invalid value %q in column %q", raw, header[d.col])
+				continue
+			}
+			*d.dst = &n
+		}
+		if row.err == "" {
+			g, err := normalizeStorageGuidelines([]StorageGuideline{row.guideline})
+			if err != nil {
+				row.err = strings.TrimPrefix(err.Error(), ErrInvalidStorage.Error()+": ")
+			} else {
+				row.guideline = g[0]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// normalizeStorageGuidelines lowercases locations and checks that each is
+// known, appears once and has a shelf life between one day and
+// maxShelfLifeDays.
+func normalizeStorageGuidelines(in []StorageGuideline) ([]StorageGuideline, error) {
+	out := make([]StorageGuideline, 0, len(in))
+	seen := make(map[string]bool, len(in))
+	for _, g := range in {
+		g.Location = strings.ToLower(strings.TrimSpace(g.Location))
+		g.Notes = strings.TrimSpace(g.Notes)
+		if !slices.Contains(StorageLocations, g.Location) {
+			return nil, fmt.Errorf("%w: unknown location %q", ErrInvalidStorage, g.Location)
+		}
+		if seen[g.Location] {
+			return nil, fmt.Errorf("%w: duplicate location %q", ErrInvalidStorage, g.Location)
+		}
+		seen[g.Location] = true
+		if g.UnopenedDays == nil && g.OpenedDays == nil {
+			return nil, fmt.Errorf("%w: %s needs unopened_days or opened_days", ErrInvalidStorage, g.Location)
+		}
+		for _, d := range []*int{g.UnopenedDays, g.OpenedDays} {
+			if d != nil && (*d <= 0 || *d > maxShelfLifeDays) {
+				return nil, fmt.Errorf("%w: %s shelf life must be between 1 and %d days", ErrInvalidStorage, g.Location, maxShelfLifeDays)
+			}
+		}
+		out = append(out, g)
+	}
+	return out, nil
+}
+
+func nullDays(d *int) sql.NullInt32 {
+	if d == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(*d), Valid: true}
+}
@@ -0,0 +1,65 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageGuidance_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	dairy, err := svc.CreateCategory(ctx, CategoryInput{DisplayName: "Dairy"})
+	require.NoError(t, err)
+	milk, err := q.CreateIngredient(ctx, db.CreateIngredientParams{
+		Name: "milk", Aliases: []string{}, CategoryID: uuid.NullUUID{UUID: dairy.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// Bulk import category guidance; milk inherits it.
+	input := "category,location,unopened_days,opened_days\ndairy,fridge,14,5\ndairy,freezer,90,\n"
+	report, err := svc.ImportStorage(ctx, strings.NewReader(input), StorageImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Imported)
+
+	g, err := svc.StorageGuidance(ctx, milk.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StorageFromCategory, g.Source)
+	require.Len(t, g.Guidelines, 2)
+	assert.Equal(t, "fridge", g.Guidelines[0].Location)
+
+	// Its own guidance takes over completely.
+	g, err = svc.SetStorageGuidelines(ctx, milk.ID, []StorageGuideline{
+		{Location: "fridge", UnopenedDays: days(10), OpenedDays: days(4)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StorageFromIngredient, g.Source)
+	require.Len(t, g.Guidelines, 1)
+
+	purchased := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	estimates, _, err := svc.EstimateExpiry(ctx, milk.ID, ExpiryRequest{
+		PurchasedOn: purchased,
+		OpenedOn:    purchased.AddDate(0, 0, 1),
+		Location:    "fridge",
+	})
+	require.NoError(t, err)
+	require.Len(t, estimates, 1)
+	assert.Equal(t, "2026-01-15", estimates[0].ExpiresOn.Format("2006-01-02"))
+
+	// Clearing it falls back to the category again.
+	g, err = svc.SetStorageGuidelines(ctx, milk.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, StorageFromCategory, g.Source)
+}
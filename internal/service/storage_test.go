@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func days(n int) *int { return &n }
+
+func guideline(location string, unopened, opened int32) db.StorageGuideline {
+	return db.StorageGuideline{
+		ID:           uuid.New(),
+		Location:     location,
+		UnopenedDays: sql.NullInt32{Int32: unopened, Valid: unopened > 0},
+		OpenedDays:   sql.NullInt32{Int32: opened, Valid: opened > 0},
+	}
+}
+
+func TestNormalizeStorageGuidelines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      []StorageGuideline
+		wantErr bool
+	}{
+		{name: "valid", in: []StorageGuideline{{Location: " Fridge ", UnopenedDays: days(14), OpenedDays: days(5)}}},
+		{name: "opened only", in: []StorageGuideline{{Location: "pantry", OpenedDays: days(30)}}},
+		{name: "empty list", in: nil},
+		{name: "unknown location", in: []StorageGuideline{{Location: "cellar", UnopenedDays: days(1)}}, wantErr: true},
+		{name: "no shelf life", in: []StorageGuideline{{Location: "fridge"}}, wantErr: true},
+		{name: "zero days", in: []StorageGuideline{{Location: "fridge", UnopenedDays: days(0)}}, wantErr: true},
+		{name: "ten years", in: []StorageGuideline{{Location: "freezer", UnopenedDays: days(3650)}}},
+		{name: "over ten years", in: []StorageGuideline{{Location: "freezer", UnopenedDays: days(3651)}}, wantErr: true},
+		{name: "past int32", in: []StorageGuideline{{Location: "pantry", OpenedDays: days(3000000000)}}, wantErr: true},
+		{
+			name: "duplicate location",
+			in: []StorageGuideline{
+				{Location: "fridge", UnopenedDays: days(1)},
+				{Location: "FRIDGE", UnopenedDays: days(2)},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			out, err := normalizeStorageGuidelines(tt.in)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidStorage)
+				return
+			}
+			require.NoError(t, err)
+			for _, g := range out {
+				assert.Contains(t, StorageLocations, g.Location)
+			}
+		})
+	}
+}
+
+func TestEstimateExpiry(t *testing.T) {
+	t.Parallel()
+
+	purchased := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		g         db.StorageGuideline
+		opened    time.Time
+		wantDate  string
+		wantBasis string
+	}{
+		{name: "sealed", g: guideline("fridge", 21, 7), wantDate: "2026-03-22", wantBasis: "unopened"},
+		{name: "opened", g: guideline("fridge", 21, 7), opened: purchased.AddDate(0, 0, 2), wantDate: "2026-03-10", wantBasis: "opened"},
+		{name: "opened late is capped by sealed date", g: guideline("fridge", 21, 7), opened: purchased.AddDate(0, 0, 18), wantDate: "2026-03-22", wantBasis: "unopened"},
+		{name: "opened without opened shelf life", g: guideline("freezer", 180, 0), opened: purchased, wantDate: "2026-08-28", wantBasis: "unopened"},
+		{name: "sealed with only opened shelf life", g: guideline("pantry", 0, 5), wantDate: "2026-03-06", wantBasis: "opened"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			est := estimateExpiry(tt.g, purchased, tt.opened)
+			assert.Equal(t, tt.wantDate, est.ExpiresOn.Format("2006-01-02"))
+			assert.Equal(t, tt.wantBasis, est.Basis)
+			assert.Equal(t, int(est.ExpiresOn.Sub(purchased).Hours()/24), est.Days)
+		})
+	}
+}
+
+func TestStorageGuidance_OwnGuidelines(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	ing := newIngredient("milk", nil)
+	ing.CategoryID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	own := []db.StorageGuideline{guideline("fridge", 10, 5)}
+	mockQ.EXPECT().GetIngredient(mock.Anything, ing.ID).Return(ing, nil)
+	mockQ.EXPECT().ListStorageGuidelinesByIngredient(mock.Anything, ing.ID).Return(own, nil)
+
+	g, err := svc.StorageGuidance(context.Background(), ing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StorageFromIngredient, g.Source)
+	assert.False(t, g.CategoryID.Valid)
+	assert.Equal(t, own, g.Guidelines)
+}
+
+func TestStorageGuidance_InheritsFromParentCategory(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	dairy := db.Category{ID: uuid.New(), Slug: "dairy"}
+	cheese := db.Category{ID: uuid.New(), Slug: "cheese", ParentID: uuid.NullUUID{UUID: dairy.ID, Valid: true}}
+	ing := newIngredient("cheddar", nil)
+	ing.CategoryID = uuid.NullUUID{UUID: cheese.ID, Valid: true}
+	inherited := []db.StorageGuideline{guideline("fridge", 14, 7)}
+
+	mockQ.EXPECT().GetIngredient(mock.Anything, ing.ID).Return(ing, nil)
+	mockQ.EXPECT().ListStorageGuidelinesByIngredient(mock.Anything, ing.ID).Return(nil, nil)
+	mockQ.EXPECT().ListStorageGuidelinesByCategory(mock.Anything, cheese.ID).Return(nil, nil)
+	mockQ.EXPECT().GetCategory(mock.Anything, cheese.ID).Return(cheese, nil)
+	mockQ.EXPECT().ListStorageGuidelinesByCategory(mock.Anything, dairy.ID).Return(inherited, nil)
+
+	g, err := svc.StorageGuidance(context.Background(), ing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StorageFromCategory, g.Source)
+	assert.Equal(t, uuid.NullUUID{UUID: dairy.ID, Valid: true}, g.CategoryID)
+	assert.Equal(t, inherited, g.Guidelines)
+}
+
+func TestEstimateExpiry_NoGuidanceForLocation(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	ing := newIngredient("milk", nil)
+	mockQ.EXPECT().GetIngredient(mock.Anything, ing.ID).Return(ing, nil)
+	mockQ.EXPECT().ListStorageGuidelinesByIngredient(mock.Anything, ing.ID).
+		Return([]db.StorageGuideline{guideline("fridge", 10, 5)}, nil)
+
+	_, _, err := svc.EstimateExpiry(context.Background(), ing.ID, ExpiryRequest{
+		PurchasedOn: time.Now(),
+		Location:    "freezer",
+	})
+	assert.ErrorIs(t, err, ErrNoStorageGuidance)
+}
+
+func TestEstimateExpiry_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tests := []struct {
+		name string
+		req  ExpiryRequest
+	}{
+		{name: "missing purchase date", req: ExpiryRequest{}},
+		{name: "opened before purchase", req: ExpiryRequest{PurchasedOn: now, OpenedOn: now.AddDate(0, 0, -1)}},
+		{name: "unknown location", req: ExpiryRequest{PurchasedOn: now, Location: "cellar"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc := New(mocks.NewMockQuerier(t), nil, 0.8)
+			_, _, err := svc.EstimateExpiry(context.Background(), uuid.New(), tt.req)
+			assert.ErrorIs(t, err, ErrInvalidStorage)
+		})
+	}
+}
+
+func TestParseStorageCSV(t *testing.T) {
+	t.Parallel()
+
+	input := `ingredient,category,location,unopened_days,opened_days,notes
+milk,,fridge,10,5,keep at the back
+,dairy,Fridge,14,,
+butter,dairy,fridge,30,,
+rice,,cellar,365,,
+flour,,pantry,abc,,
+`
+	rows, err := parseStorageCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	assert.Equal(t, "milk", rows[0].target)
+	assert.False(t, rows[0].category)
+	assert.Equal(t, 10, *rows[0].guideline.UnopenedDays)
+	assert.Equal(t, "keep at the back", rows[0].guideline.Notes)
+
+	assert.True(t, rows[1].category)
+	assert.Equal(t, "fridge", rows[1].guideline.Location)
+	assert.Nil(t, rows[1].guideline.OpenedDays)
+
+	assert.Contains(t, rows[2].err, "not both")
+	assert.Contains(t, rows[3].err, "unknown location")
+	assert.Contains(t, rows[4].err, "invalid value")
+}
+
+func TestParseStorageCSV_MalformedRow(t *testing.T) {
+	t.Parallel()
+
+	input := "ingredient,location,unopened_days\n" +
+		"milk,fridge,10\n" +
+		"\"butter,fridge,30\n"
+	rows, err := parseStorageCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "milk", rows[0].target)
+	assert.Equal(t, 3, rows[1].line)
+	assert.NotEmpty(t, rows[1].err)
+}
+
+func TestParseStorageCSV_BadHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "no target column", input: "location,unopened_days\nfridge,1\n"},
+		{name: "no location column", input: "ingredient,unopened_days\nmilk,1\n"},
+		{name: "no shelf life columns", input: "ingredient,location\nmilk,fridge\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseStorageCSV(strings.NewReader(tt.input))
+			assert.ErrorIs(t, err, ErrInvalidStorageCSV)
+		})
+	}
+}
+
+func TestImportStorage_DryRun(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	milk := newIngredient("milk", nil)
+	dairy := db.Category{ID: uuid.New(), Slug: "dairy"}
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{milk}, nil)
+	mockQ.EXPECT().ListCategories(mock.Anything).Return([]db.Category{dairy}, nil)
+
+	input := "ingredient,category,location,unopened_days\nMilk,,fridge,10\n,Dairy,fridge,14\nsaffron,,pantry,700\n,spices,pantry,365\n"
+	report, err := svc.ImportStorage(context.Background(), strings.NewReader(input), StorageImportOptions{DryRun: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, report.RowsRead)
+	assert.Zero(t, report.Imported)
+	require.Len(t, report.Rows, 4)
+	assert.Equal(t, StorageRowImported, report.Rows[0].Status)
+	assert.Equal(t, milk.ID, report.Rows[0].Ingredient.ID)
+	assert.Equal(t, StorageRowImported, report.Rows[1].Status)
+	assert.Equal(t, dairy.ID, report.Rows[1].Category.ID)
+	assert.Equal(t, StorageRowUnmatched, report.Rows[2].Status)
+	assert.Equal(t, StorageRowUnmatched, report.Rows[3].Status)
+}
+
+func TestImportStorage_Superseded(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	milk := newIngredient("milk", nil)
+	dairy := db.Category{ID: uuid.New(), Slug: "dairy"}
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{milk}, nil)
+	mockQ.EXPECT().ListCategories(mock.Anything).Return([]db.Category{dairy}, nil)
+
+	input := "ingredient,category,location,unopened_days\n" +
+		"milks,,fridge,7\n" +
+		"Milk,,fridge,10\n" +
+		"milk,,Fridge,12\n" +
+		"milk,,freezer,90\n" +
+		",dairy,fridge,14\n" +
+		",Dairy,fridge,21\n"
+	report, err := svc.ImportStorage(context.Background(), strings.NewReader(input), StorageImportOptions{DryRun: true})
+	require.NoError(t, err)
+
+	statuses := make([]StorageRowStatus, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		statuses = append(statuses, row.Status)
+	}
+	assert.Equal(t, []StorageRowStatus{
+		StorageRowSuperseded, // a weaker match for milk in the fridge
+		StorageRowImported,
+		StorageRowSuperseded, // an exact match, but after the first
+		StorageRowImported,   // another location
+		StorageRowImported,
+		StorageRowSuperseded,
+	}, statuses)
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+const (
+	// DefaultSubstituteDepth is the search depth used when none is given.
+	DefaultSubstituteDepth = 3
+	// MaxSubstituteDepth caps how far SearchSubstitutes will traverse.
+	MaxSubstituteDepth = 5
+)
+
+// SubstituteSearch configures SearchSubstitutes.
+type SubstituteSearch struct {
+	// MaxDepth is the longest chain followed. Zero means
+	// DefaultSubstituteDepth; values above MaxSubstituteDepth are capped.
+	MaxDepth int
+	// Available restricts results to these ingredient IDs (e.g. the user's
+	// pantry). Intermediate hops need not be available. Empty means no
+	// restriction.
+	Available []uuid.UUID
+	// Filter limits which substitute rows may be followed; every hop must
+	// pass it.
+	Filter SubstituteFilter
+}
+
+// SubstituteStep is one hop of a substitute chain.
+type SubstituteStep struct {
+	Ingredient db.Ingredient
+	Ratio      float64
+}
+
+// SubstitutePath is a chain of substitutes ending at a usable ingredient.
+// Ratio is the product of the hop ratios: the amount of the final
+// ingredient per unit of the original. Confidence is how close Ratio is to
+// a like-for-like swap: the smaller of Ratio and 1/Ratio, so 1.0 for 1:1 and
+// 0.5 for a chain that doubles or halves the amount.
+type SubstitutePath struct {
+	Steps      []SubstituteStep
+	Ratio      float64
+	Confidence float64
+}
+
+// Substitute returns the ingredient at the end of the chain.
+func (p SubstitutePath) Substitute() db.Ingredient {
+	return p.Steps[len(p.Steps)-1].Ingredient
+}
+
+// SearchSubstitutes finds direct and transitive substitutes for an
+// ingredient by walking ingredient_substitutes breadth-first. Each reachable
+// ingredient is reported once, via the most confident of its shortest
+// chains. Results are ranked by chain length, then confidence, then name.
+// It returns ErrInvalidTag for an unknown filter value and sql.ErrNoRows if
+// the ingredient does not exist.
+func (s *Service) SearchSubstitutes(ctx context.Context, ingredientID uuid.UUID, opts SubstituteSearch) ([]SubstitutePath, error) {
+	if err := opts.Filter.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.q.GetIngredient(ctx, ingredientID); err != nil {
+		return nil, err
+	}
+	edges, err := s.q.ListAllSubstitutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ingredients, err := s.q.ListIngredients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return searchSubstitutes(ingredientID, edges, ingredients, opts), nil
+}
+
+// searchSubstitutes is the graph walk behind SearchSubstitutes.
+func searchSubstitutes(start uuid.UUID, edges []db.IngredientSubstitute, ingredients []db.Ingredient, opts SubstituteSearch) []SubstitutePath {
+	depth := opts.MaxDepth
+	if depth <= 0 {
+		depth = DefaultSubstituteDepth
+	}
+	if depth > MaxSubstituteDepth {
+		depth = MaxSubstituteDepth
+	}
+
+	byID := make(map[uuid.UUID]db.Ingredient, len(ingredients))
+	for _, ing := range ingredients {
+		byID[ing.ID] = ing
+	}
+	adj := make(map[uuid.UUID][]db.IngredientSubstitute)
+	for _, e := range edges {
+		if opts.Filter.matches(e) {
+			adj[e.IngredientID] = append(adj[e.IngredientID], e)
+		}
+	}
+	var available map[uuid.UUID]bool
+	if len(opts.Available) > 0 {
+		available = make(map[uuid.UUID]bool, len(opts.Available))
+		for _, id := range opts.Available {
+			available[id] = true
+		}
+	}
+
+	// Ingredients are visited once their level is done, so every chain of
+	// the same length competes and the most confident one is kept.
+	visited := map[uuid.UUID]bool{start: true}
+	frontier := []SubstitutePath{{Ratio: 1, Confidence: 1}}
+	var results []SubstitutePath
+	for level := 1; level <= depth && len(frontier) > 0; level++ {
+		best := make(map[uuid.UUID]int)
+		var next []SubstitutePath
+		for _, p := range frontier {
+			from := start
+			if len(p.Steps) > 0 {
+				from = p.Substitute().ID
+			}
+			for _, e := range adj[from] {
+				if visited[e.SubstituteID] {
+					continue
+				}
+				ing, ok := byID[e.SubstituteID]
+				if !ok {
+					continue
+				}
+				steps := make([]SubstituteStep, len(p.Steps), len(p.Steps)+1)
+				copy(steps, p.Steps)
+				ratio := p.Ratio * e.Ratio
+				extended := SubstitutePath{
+					Steps:      append(steps, SubstituteStep{Ingredient: ing, Ratio: e.Ratio}),
+					Ratio:      ratio,
+					Confidence: ratioConfidence(ratio),
+				}
+				if i, ok := best[e.SubstituteID]; ok {
+					if extended.Confidence > next[i].Confidence {
+						next[i] = extended
+					}
+					continue
+				}
+				best[e.SubstituteID] = len(next)
+				next = append(next, extended)
+			}
+		}
+		for _, p := range next {
+			visited[p.Substitute().ID] = true
+			if available == nil || available[p.Substitute().ID] {
+				results = append(results, p)
+			}
+		}
+		frontier = next
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if len(a.Steps) != len(b.Steps) {
+			return len(a.Steps) < len(b.Steps)
+		}
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		return a.Substitute().Name < b.Substitute().Name
+	})
+	return results
+}
+
+// ratioConfidence scores an overall substitution ratio by its distance from
+// 1:1.
+func ratioConfidence(ratio float64) float64 {
+	if ratio <= 0 {
+		return 0
+	}
+	return math.Min(ratio, 1/ratio)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newSubstitute(from, to db.Ingredient, ratio float64) db.IngredientSubstitute {
+	return db.IngredientSubstitute{ID: uuid.New(), IngredientID: from.ID, SubstituteID: to.ID, Ratio: ratio}
+}
+
+func substituteNames(paths []SubstitutePath) []string {
+	names := make([]string, 0, len(paths))
+	for _, p := range paths {
+		names = append(names, p.Substitute().Name)
+	}
+	return names
+}
+
+func TestSearchSubstitutes(t *testing.T) {
+	t.Parallel()
+
+	buttermilk := newIngredient("buttermilk", []string{})
+	yogurt := newIngredient("yogurt", []string{})
+	milk := newIngredient("milk", []string{})
+	soyMilk := newIngredient("soy milk", []string{})
+	oatMilk := newIngredient("oat milk", []string{})
+	ingredients := []db.Ingredient{buttermilk, yogurt, milk, soyMilk, oatMilk}
+	edges := []db.IngredientSubstitute{
+		newSubstitute(buttermilk, yogurt, 0.75),
+		newSubstitute(buttermilk, milk, 1),
+		newSubstitute(milk, soyMilk, 1),
+		newSubstitute(soyMilk, oatMilk, 2),
+		newSubstitute(milk, buttermilk, 1), // cycle back to the start
+	}
+
+	t.Run("ranks by depth then name", func(t *testing.T) {
+		t.Parallel()
+		got := searchSubstitutes(buttermilk.ID, edges, ingredients, SubstituteSearch{})
+		assert.Equal(t, []string{"milk", "yogurt", "soy milk", "oat milk"}, substituteNames(got))
+		assert.Equal(t, 1.0, got[0].Confidence)
+		assert.InDelta(t, 0.75, got[1].Confidence, 1e-9)
+		assert.Equal(t, 1.0, got[2].Confidence)
+		assert.InDelta(t, 0.5, got[3].Confidence, 1e-9)
+		assert.InDelta(t, 2.0, got[3].Ratio, 1e-9)
+		assert.Len(t, got[3].Steps, 3)
+	})
+
+	t.Run("respects max depth", func(t *testing.T) {
+		t.Parallel()
+		got := searchSubstitutes(buttermilk.ID, edges, ingredients, SubstituteSearch{MaxDepth: 2})
+		assert.Equal(t, []string{"milk", "yogurt", "soy milk"}, substituteNames(got))
+	})
+
+	t.Run("filters to available ingredients but walks through others", func(t *testing.T) {
+		t.Parallel()
+		got := searchSubstitutes(buttermilk.ID, edges, ingredients, SubstituteSearch{
+			Available: []uuid.UUID{oatMilk.ID, yogurt.ID},
+		})
+		assert.Equal(t, []string{"yogurt", "oat milk"}, substituteNames(got))
+	})
+}
+
+func TestSearchSubstitutes_PrefersConfidentChain(t *testing.T) {
+	t.Parallel()
+
+	a := newIngredient("a", []string{})
+	b := newIngredient("b", []string{})
+	c := newIngredient("c", []string{})
+	x := newIngredient("x", []string{})
+	ingredients := []db.Ingredient{a, b, c, x}
+	viaB := newSubstitute(b, x, 3)
+	viaC := newSubstitute(c, x, 1)
+
+	for name, edges := range map[string][]db.IngredientSubstitute{
+		"weak chain first":      {newSubstitute(a, b, 1), newSubstitute(a, c, 1), viaB, viaC},
+		"confident chain first": {newSubstitute(a, c, 1), newSubstitute(a, b, 1), viaC, viaB},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := searchSubstitutes(a.ID, edges, ingredients, SubstituteSearch{})
+			require.Len(t, got, 3)
+			assert.Equal(t, "x", got[2].Substitute().Name)
+			assert.Equal(t, "c", got[2].Steps[0].Ingredient.Name)
+			assert.Equal(t, 1.0, got[2].Confidence)
+		})
+	}
+}
+
+func TestSearchSubstitutes_LoadsGraph(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	egg := newIngredient("egg", []string{})
+	flax := newIngredient("flax egg", []string{})
+	mockQ.EXPECT().GetIngredient(mock.Anything, egg.ID).Return(egg, nil)
+	mockQ.EXPECT().ListAllSubstitutes(mock.Anything).Return([]db.IngredientSubstitute{newSubstitute(egg, flax, 1)}, nil)
+	mockQ.EXPECT().ListIngredients(mock.Anything).Return([]db.Ingredient{egg, flax}, nil)
+
+	got, err := svc.SearchSubstitutes(context.Background(), egg.ID, SubstituteSearch{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"flax egg"}, substituteNames(got))
+}
+
+func TestSearchSubstitutes_Filter(t *testing.T) {
+	t.Parallel()
+
+	egg := newIngredient("egg", []string{})
+	applesauce := newIngredient("applesauce", []string{})
+	flaxEgg := newIngredient("flax egg", []string{})
+	tofu := newIngredient("tofu", []string{})
+	ingredients := []db.Ingredient{egg, applesauce, flaxEgg, tofu}
+
+	toApplesauce := newSubstitute(egg, applesauce, 1)
+	toApplesauce.Contexts = []string{"baking"}
+	toApplesauce.Reasons = []string{"vegan"}
+	toFlax := newSubstitute(egg, flaxEgg, 1)
+	toFlax.Reasons = []string{"vegan", "allergy"}
+	toTofu := newSubstitute(egg, tofu, 1)
+	toTofu.Contexts = []string{"cooking"}
+	edges := []db.IngredientSubstitute{toApplesauce, toFlax, toTofu}
+
+	got := searchSubstitutes(egg.ID, edges, ingredients, SubstituteSearch{
+		Filter: SubstituteFilter{Context: "baking", Reason: "vegan"},
+	})
+	assert.Equal(t, []string{"applesauce", "flax egg"}, substituteNames(got))
+}
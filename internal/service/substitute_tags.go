@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// ErrInvalidTag is returned for a substitute context or reason outside the
+// known vocabulary.
+var ErrInvalidTag = errors.New("invalid substitute tag")
+
+// SubstituteContexts are the usage contexts a substitute can be limited to.
+// A substitute with no contexts applies in all of them.
+var SubstituteContexts = []string{"baking", "cooking", "frying", "raw", "beverage"}
+
+// SubstituteReasons are the reasons a substitute may be chosen for.
+var SubstituteReasons = []string{
+	"vegan", "vegetarian", "dairy_free", "gluten_free", "allergy", "availability", "health", "cost",
+}
+
+// SubstituteFilter narrows substitute listings and searches. Empty fields
+// match everything. Substitutes without any context match every Context;
+// Reason only matches substitutes tagged with it.
+type SubstituteFilter struct {
+	Context string
+	Reason  string
+}
+
+// Validate normalizes the filter and checks it against the vocabulary.
+func (f *SubstituteFilter) Validate() error {
+	f.Context = Normalize(f.Context)
+	f.Reason = Normalize(f.Reason)
+	if f.Context != "" && !slices.Contains(SubstituteContexts, f.Context) {
+		return fmt.Errorf("%w: unknown context %q", ErrInvalidTag, f.Context)
+	}
+	if f.Reason != "" && !slices.Contains(SubstituteReasons, f.Reason) {
+		return fmt.Errorf("%w: unknown reason %q", ErrInvalidTag, f.Reason)
+	}
+	return nil
+}
+
+// matches reports whether a substitute row passes the filter, mirroring the
+// SQL used by ListSubstitutesWithIngredient.
+func (f SubstituteFilter) matches(sub db.IngredientSubstitute) bool {
+	if f.Context != "" && len(sub.Contexts) > 0 && !slices.Contains(sub.Contexts, f.Context) {
+		return false
+	}
+	if f.Reason != "" && !slices.Contains(sub.Reasons, f.Reason) {
+		return false
+	}
+	return true
+}
+
+// normalizeTags lowercases and deduplicates tags, returning ErrInvalidTag for
+// any outside vocab. kind names the tag type in the error. An empty input
+// yields nil, which the insert queries store as an empty array.
+func normalizeTags(tags []string, vocab []string, kind string) ([]string, error) {
+	var result []string
+	for _, t := range tags {
+		t = Normalize(t)
+		if !slices.Contains(vocab, t) {
+			return nil, fmt.Errorf("%w: unknown %s %q", ErrInvalidTag, kind, t)
+		}
+		if !slices.Contains(result, t) {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubstituteFilter_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filter  SubstituteFilter
+		want    SubstituteFilter
+		wantErr bool
+	}{
+		{name: "empty", filter: SubstituteFilter{}, want: SubstituteFilter{}},
+		{name: "normalizes", filter: SubstituteFilter{Context: " Baking ", Reason: "VEGAN"}, want: SubstituteFilter{Context: "baking", Reason: "vegan"}},
+		{name: "unknown context", filter: SubstituteFilter{Context: "grilling"}, wantErr: true},
+		{name: "unknown reason", filter: SubstituteFilter{Reason: "taste"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f := tt.filter
+			err := f.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidTag)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, f)
+		})
+	}
+}
+
+func TestSubstituteFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	untagged := db.IngredientSubstitute{}
+	baking := db.IngredientSubstitute{Contexts: []string{"baking"}, Reasons: []string{"vegan"}}
+
+	assert.True(t, SubstituteFilter{}.matches(baking))
+	assert.True(t, SubstituteFilter{Context: "frying"}.matches(untagged))
+	assert.True(t, SubstituteFilter{Context: "baking", Reason: "vegan"}.matches(baking))
+	assert.False(t, SubstituteFilter{Context: "frying"}.matches(baking))
+	assert.False(t, SubstituteFilter{Reason: "vegan"}.matches(untagged))
+}
+
+func TestAddSubstitute_NormalizesTags(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	egg := newIngredient("egg", []string{})
+	applesauce := newIngredient("applesauce", []string{})
+
+	mockQ.EXPECT().GetIngredient(mock.Anything, egg.ID).Return(egg, nil)
+	mockQ.EXPECT().GetIngredient(mock.Anything, applesauce.ID).Return(applesauce, nil)
+	mockQ.EXPECT().GetSubstitute(mock.Anything, mock.Anything).Return(db.IngredientSubstitute{}, sql.ErrNoRows)
+	mockQ.EXPECT().CreateSubstitute(mock.Anything, db.CreateSubstituteParams{
+		IngredientID: egg.ID,
+		SubstituteID: applesauce.ID,
+		Ratio:        1,
+		Contexts:     []string{"baking"},
+		Reasons:      []string{"vegan", "allergy"},
+	}).Return(db.IngredientSubstitute{IngredientID: egg.ID, SubstituteID: applesauce.ID}, nil)
+
+	_, err := svc.AddSubstitute(context.Background(), db.CreateSubstituteParams{
+		IngredientID: egg.ID,
+		SubstituteID: applesauce.ID,
+		Ratio:        1,
+		Contexts:     []string{"Baking", "baking"},
+		Reasons:      []string{"vegan", "Allergy"},
+	})
+	require.NoError(t, err)
+}
+
+func TestAddSubstitute_RejectsUnknownTag(t *testing.T) {
+	t.Parallel()
+
+	svc := New(mocks.NewMockQuerier(t), nil, 0.8)
+	egg := newIngredient("egg", []string{})
+	applesauce := newIngredient("applesauce", []string{})
+
+	_, err := svc.AddSubstitute(context.Background(), db.CreateSubstituteParams{
+		IngredientID: egg.ID,
+		SubstituteID: applesauce.ID,
+		Ratio:        1,
+		Contexts:     []string{"grilling"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidTag)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+var (
+	// ErrSelfSubstitute is returned when an ingredient is added as its own
+	// substitute.
+	ErrSelfSubstitute = errors.New("an ingredient cannot substitute for itself")
+	// ErrDuplicateSubstitute is returned when the substitute pair already exists.
+	ErrDuplicateSubstitute = errors.New("substitute already exists")
+	// ErrSubstituteNotFound is returned when the substitute ingredient does not
+	// exist.
+	ErrSubstituteNotFound = errors.New("substitute ingredient not found")
+	// ErrInvalidRatio is returned for a non-positive substitution ratio.
+	ErrInvalidRatio = errors.New("ratio must be positive")
+)
+
+// Substitute is a substitute row with the substitute ingredient embedded.
+type Substitute = db.ListSubstitutesWithIngredientRow
+
+// ListSubstitutes returns the substitutes for an ingredient that pass
+// filter, ordered by the substitute's name. It returns ErrInvalidTag for an
+// unknown filter value and sql.ErrNoRows if the ingredient does not exist.
+func (s *Service) ListSubstitutes(ctx context.Context, ingredientID uuid.UUID, filter SubstituteFilter) ([]Substitute, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.q.GetIngredient(ctx, ingredientID); err != nil {
+		return nil, err
+	}
+	return s.q.ListSubstitutesWithIngredient(ctx, db.ListSubstitutesWithIngredientParams{
+		IngredientID: ingredientID,
+		Context:      filter.Context,
+		Reason:       filter.Reason,
+	})
+}
+
+// AddSubstitute records that arg.SubstituteID can stand in for
+// arg.IngredientID at arg.Ratio. It returns sql.ErrNoRows if the ingredient
+// does not exist, ErrSubstituteNotFound if the substitute does not, and
+// ErrSelfSubstitute, ErrInvalidRatio, ErrInvalidTag or ErrDuplicateSubstitute
+// for invalid pairs. Contexts and reasons are normalized before storing.
+func (s *Service) AddSubstitute(ctx context.Context, arg db.CreateSubstituteParams) (Substitute, error) {
+	if arg.IngredientID == arg.SubstituteID {
+		return Substitute{}, ErrSelfSubstitute
+	}
+	if arg.Ratio <= 0 {
+		return Substitute{}, ErrInvalidRatio
+	}
+	var err error
+	if arg.Contexts, err = normalizeTags(arg.Contexts, SubstituteContexts, "context"); err != nil {
+		return Substitute{}, err
+	}
+	if arg.Reasons, err = normalizeTags(arg.Reasons, SubstituteReasons, "reason"); err != nil {
+		return Substitute{}, err
+	}
+	if _, err := s.q.GetIngredient(ctx, arg.IngredientID); err != nil {
+		return Substitute{}, err
+	}
+	sub, err := s.q.GetIngredient(ctx, arg.SubstituteID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Substitute{}, ErrSubstituteNotFound
+		}
+		return Substitute{}, err
+	}
+	_, err = s.q.GetSubstitute(ctx, db.GetSubstituteParams{
+		IngredientID: arg.IngredientID,
+		SubstituteID: arg.SubstituteID,
+	})
+	switch {
+	case err == nil:
+		return Substitute{}, ErrDuplicateSubstitute
+	case !errors.Is(err, sql.ErrNoRows):
+		return Substitute{}, err
+	}
+
+	row, err := s.q.CreateSubstitute(ctx, arg)
+	if err != nil {
+		if isUniqueViolation(err) {
+			// Lost a race with a concurrent insert of the same pair.
+			return Substitute{}, ErrDuplicateSubstitute
+		}
+		return Substitute{}, err
+	}
+	return Substitute{IngredientSubstitute: row, Ingredient: sub}, nil
+}
+
+// RemoveSubstitute deletes the substitute pair. It returns sql.ErrNoRows if
+// the pair does not exist.
+func (s *Service) RemoveSubstitute(ctx context.Context, ingredientID, substituteID uuid.UUID) error {
+	n, err := s.q.DeleteSubstitute(ctx, db.DeleteSubstituteParams{
+		IngredientID: ingredientID,
+		SubstituteID: substituteID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSubstitute_Success(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	butter := newIngredient("butter", []string{})
+	margarine := newIngredient("margarine", []string{})
+	params := db.CreateSubstituteParams{IngredientID: butter.ID, SubstituteID: margarine.ID, Ratio: 1}
+
+	mockQ.EXPECT().GetIngredient(mock.Anything, butter.ID).Return(butter, nil)
+	mockQ.EXPECT().GetIngredient(mock.Anything, margarine.ID).Return(margarine, nil)
+	mockQ.EXPECT().GetSubstitute(mock.Anything, db.GetSubstituteParams{
+		IngredientID: butter.ID,
+		SubstituteID: margarine.ID,
+	}).Return(db.IngredientSubstitute{}, sql.ErrNoRows)
+	row := db.IngredientSubstitute{ID: uuid.New(), IngredientID: butter.ID, SubstituteID: margarine.ID, Ratio: 1}
+	mockQ.EXPECT().CreateSubstitute(mock.Anything, params).Return(row, nil)
+
+	got, err := svc.AddSubstitute(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, row, got.IngredientSubstitute)
+	assert.Equal(t, margarine.ID, got.Ingredient.ID)
+}
+
+func TestAddSubstitute_Validation(t *testing.T) {
+	t.Parallel()
+
+	butter := newIngredient("butter", []string{})
+	margarine := newIngredient("margarine", []string{})
+
+	tests := []struct {
+		name    string
+		params  db.CreateSubstituteParams
+		setup   func(m *mocks.MockQuerier)
+		wantErr error
+	}{
+		{
+			name:    "self substitution",
+			params:  db.CreateSubstituteParams{IngredientID: butter.ID, SubstituteID: butter.ID, Ratio: 1},
+			wantErr: ErrSelfSubstitute,
+		},
+		{
+			name:    "non-positive ratio",
+			params:  db.CreateSubstituteParams{IngredientID: butter.ID, SubstituteID: margarine.ID, Ratio: 0},
+			wantErr: ErrInvalidRatio,
+		},
+		{
+			name:   "missing ingredient",
+			params: db.CreateSubstituteParams{IngredientID: butter.ID, SubstituteID: margarine.ID, Ratio: 1},
+			setup: func(m *mocks.MockQuerier) {
+				m.EXPECT().GetIngredient(mock.Anything, butter.ID).Return(db.Ingredient{}, sql.ErrNoRows)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name:   "missing substitute",
+			params: db.CreateSubstituteParams{IngredientID: butter.ID, SubstituteID: margarine.ID, Ratio: 1},
+			setup: func(m *mocks.MockQuerier) {
+				m.EXPECT().GetIngredient(mock.Anything, butter.ID).Return(butter, nil)
+				m.EXPECT().GetIngredient(mock.Anything, margarine.ID).Return(db.Ingredient{}, sql.ErrNoRows)
+			},
+			wantErr: ErrSubstituteNotFound,
+		},
+		{
+			name:   "duplicate pair",
+			params: db.CreateSubstituteParams{IngredientID: butter.ID, SubstituteID: margarine.ID, Ratio: 1},
+			setup: func(m *mocks.MockQuerier) {
+				m.EXPECT().GetIngredient(mock.Anything, butter.ID).Return(butter, nil)
+				m.EXPECT().GetIngredient(mock.Anything, margarine.ID).Return(margarine, nil)
+				m.EXPECT().GetSubstitute(mock.Anything, mock.Anything).Return(db.IngredientSubstitute{}, nil)
+			},
+			wantErr: ErrDuplicateSubstitute,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mockQ := mocks.NewMockQuerier(t)
+			svc := New(mockQ, nil, 0.8)
+			if tc.setup != nil {
+				tc.setup(mockQ)
+			}
+			_, err := svc.AddSubstitute(context.Background(), tc.params)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestRemoveSubstitute_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := New(mockQ, nil, 0.8)
+
+	mockQ.EXPECT().DeleteSubstitute(mock.Anything, mock.Anything).Return(0, nil)
+
+	err := svc.RemoveSubstitute(context.Background(), uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// UnitSystem is the measurement system quantities are displayed in.
+type UnitSystem string
+
+const (
+	UnitSystemMetric UnitSystem = "metric"
+	UnitSystemUS     UnitSystem = "us"
+)
+
+// Dimension groups units that can be converted into each other without
+// ingredient-specific density data.
+type Dimension string
+
+const (
+	DimensionVolume Dimension = "volume"
+	DimensionMass   Dimension = "mass"
+)
+
+// Unit is an entry in the unit catalog. ToBase converts one of this unit into
+// the dimension's base unit (millilitres for volume, grams for mass).
+type Unit struct {
+	Name      string
+	Plural    string
+	Dimension Dimension
+	System    UnitSystem
+	ToBase    float64
+}
+
+var (
+	unitML     = Unit{Name: "ml", Plural: "ml", Dimension: DimensionVolume, System: UnitSystemMetric, ToBase: 1}
+	unitL      = Unit{Name: "l", Plural: "l", Dimension: DimensionVolume, System: UnitSystemMetric, ToBase: 1000}
+	unitG      = Unit{Name: "g", Plural: "g", Dimension: DimensionMass, System: UnitSystemMetric, ToBase: 1}
+	unitKG     = Unit{Name: "kg", Plural: "kg", Dimension: DimensionMass, System: UnitSystemMetric, ToBase: 1000}
+	unitTsp    = Unit{Name: "tsp", Plural: "tsp", Dimension: DimensionVolume, System: UnitSystemUS, ToBase: 4.92892}
+	unitTbsp   = Unit{Name: "tbsp", Plural: "tbsp", Dimension: DimensionVolume, System: UnitSystemUS, ToBase: 14.7868}
+	unitFlOz   = Unit{Name: "fl oz", Plural: "fl oz", Dimension: DimensionVolume, System: UnitSystemUS, ToBase: 29.5735}
+	unitCup    = Unit{Name: "cup", Plural: "cups", Dimension: DimensionVolume, System: UnitSystemUS, ToBase: 236.588}
+	unitPint   = Unit{Name: "pint", Plural: "pints", Dimension: DimensionVolume, System: UnitSystemUS, ToBase: 473.176}
+	unitQuart  = Unit{Name: "quart", Plural: "quarts", Dimension: DimensionVolume, System: UnitSystemUS, ToBase: 946.353}
+	unitGallon = Unit{Name: "gallon", Plural: "gallons", Dimension: DimensionVolume, System: UnitSystemUS, ToBase: 3785.41}
+	unitOz     = Unit{Name: "oz", Plural: "oz", Dimension: DimensionMass, System: UnitSystemUS, ToBase: 28.3495}
+	unitLb     = Unit{Name: "lb", Plural: "lb", Dimension: DimensionMass, System: UnitSystemUS, ToBase: 453.592}
+)
+
+// unitCatalog maps every accepted spelling of a unit to its catalog entry.
+var unitCatalog = map[string]Unit{
+	"ml": unitML, "milliliter": unitML, "milliliters": unitML, "millilitre": unitML, "millilitres": unitML,
+	"l": unitL, "liter": unitL, "liters": unitL, "litre": unitL, "litres": unitL,
+	"g": unitG, "gram": unitG, "grams": unitG,
+	"kg": unitKG, "kilogram": unitKG, "kilograms": unitKG,
+	"tsp": unitTsp, "tsps": unitTsp, "teaspoon": unitTsp, "teaspoons": unitTsp,
+	"tbsp": unitTbsp, "tbsps": unitTbsp, "tbs": unitTbsp, "tablespoon": unitTbsp, "tablespoons": unitTbsp,
+	"fl oz": unitFlOz, "fluid ounce": unitFlOz, "fluid ounces": unitFlOz,
+	"cup": unitCup, "cups": unitCup, "c": unitCup,
+	"pint": unitPint, "pints": unitPint, "pt": unitPint,
+	"quart": unitQuart, "quarts": unitQuart, "qt": unitQuart,
+	"gallon": unitGallon, "gallons": unitGallon, "gal": unitGallon,
+	"oz": unitOz, "ounce": unitOz, "ounces": unitOz,
+	"lb": unitLb, "lbs": unitLb, "pound": unitLb, "pounds": unitLb,
+}
+
+// displayUnit is a candidate unit for humanized output. A unit is chosen when
+// the quantity expressed in it is at least min.
+type displayUnit struct {
+	unit Unit
+	min  float64
+}
+
+// displayUnits lists the units used for output per system and dimension,
+// largest first. Less common units (pints, quarts, fl oz) are accepted as
+// input but never produced.
+var displayUnits = map[UnitSystem]map[Dimension][]displayUnit{
+	UnitSystemMetric: {
+		DimensionVolume: {{unitL, 1}, {unitML, 0}},
+		DimensionMass:   {{unitKG, 1}, {unitG, 0}},
+	},
+	UnitSystemUS: {
+		DimensionVolume: {{unitCup, 0.25}, {unitTbsp, 1}, {unitTsp, 0}},
+		DimensionMass:   {{unitLb, 1}, {unitOz, 0}},
+	},
+}
+
+// LookupUnit returns the catalog entry for a unit spelling, ignoring case and
+// surrounding whitespace.
+func LookupUnit(name string) (Unit, bool) {
+	u, ok := unitCatalog[Normalize(name)]
+	return u, ok
+}
+
+// chooseDisplayUnit picks the largest display unit in system for which the
+// base quantity is at least that unit's minimum.
+func chooseDisplayUnit(base float64, dim Dimension, system UnitSystem) Unit {
+	candidates := displayUnits[system][dim]
+	for _, c := range candidates {
+		if base/c.unit.ToBase >= c.min {
+			return c.unit
+		}
+	}
+	return candidates[len(candidates)-1].unit
+}
+
+// usFractions are the fractional parts kitchen measures are rounded to.
+var usFractions = []struct {
+	value float64
+	label string
+}{
+	{0, ""}, {0.125, "1/8"}, {0.25, "1/4"}, {1.0 / 3, "1/3"}, {0.375, "3/8"},
+	{0.5, "1/2"}, {0.625, "5/8"}, {2.0 / 3, "2/3"}, {0.75, "3/4"}, {0.875, "7/8"}, {1, ""},
+}
+
+// roundUS rounds v to the nearest common kitchen fraction and returns the
+// rounded value with its label, e.g. 1.52 → (1.5, "1 1/2"). Non-zero inputs
+// never round below 1/8.
+func roundUS(v float64) (float64, string) {
+	whole := math.Floor(v)
+	frac := v - whole
+	best := usFractions[0]
+	for _, f := range usFractions[1:] {
+		if math.Abs(frac-f.value) < math.Abs(frac-best.value) {
+			best = f
+		}
+	}
+	if best.value == 1 {
+		whole++
+		best = usFractions[0]
+	}
+	if whole == 0 && best.value == 0 && v > 0 {
+		best = usFractions[1]
+	}
+	rounded := whole + best.value
+	switch {
+	case whole == 0 && best.label == "":
+		return 0, "0"
+	case whole == 0:
+		return rounded, best.label
+	case best.label == "":
+		return rounded, strconv.FormatFloat(whole, 'f', -1, 64)
+	default:
+		return rounded, fmt.Sprintf("%s %s", strconv.FormatFloat(whole, 'f', -1, 64), best.label)
+	}
+}
+
+// roundMetric rounds v to a precision that suits its magnitude: one decimal
+// below 10, whole numbers below 100, then steps of 5 and 10.
+func roundMetric(v float64) (float64, string) {
+	var rounded float64
+	switch {
+	case v < 10:
+		rounded = math.Round(v*10) / 10
+	case v < 100:
+		rounded = math.Round(v)
+	case v < 1000:
+		rounded = math.Round(v/5) * 5
+	default:
+		rounded = math.Round(v/10) * 10
+	}
+	return rounded, strconv.FormatFloat(rounded, 'f', -1, 64)
+}
+
+// humanize rounds quantity for display in system and formats it with unit.
+// An empty unit (counted items) is formatted as a bare number.
+func humanize(quantity float64, unit string, plural string, system UnitSystem) (float64, string) {
+	var rounded float64
+	var label string
+	if system == UnitSystemUS {
+		rounded, label = roundUS(quantity)
+	} else {
+		rounded, label = roundMetric(quantity)
+	}
+	if unit == "" {
+		return rounded, label
+	}
+	if plural != "" && rounded > 1 {
+		unit = plural
+	}
+	return rounded, strings.TrimSpace(label + " " + unit)
+}
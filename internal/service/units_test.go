@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupUnit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{input: "cups", want: "cup", wantOK: true},
+		{input: " Tablespoon ", want: "tbsp", wantOK: true},
+		{input: "litres", want: "l", wantOK: true},
+		{input: "clove", wantOK: false},
+		{input: "", wantOK: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+			u, ok := LookupUnit(tc.input)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.want, u.Name)
+		})
+	}
+}
+
+func TestRoundUS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     float64
+		wantValue float64
+		wantLabel string
+	}{
+		{name: "whole number", input: 2, wantValue: 2, wantLabel: "2"},
+		{name: "half", input: 1.52, wantValue: 1.5, wantLabel: "1 1/2"},
+		{name: "third", input: 0.34, wantValue: 1.0 / 3, wantLabel: "1/3"},
+		{name: "rounds up to next whole", input: 2.96, wantValue: 3, wantLabel: "3"},
+		{name: "tiny amounts never round to zero", input: 0.01, wantValue: 0.125, wantLabel: "1/8"},
+		{name: "zero", input: 0, wantValue: 0, wantLabel: "0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			v, label := roundUS(tc.input)
+			assert.InDelta(t, tc.wantValue, v, 1e-9)
+			assert.Equal(t, tc.wantLabel, label)
+		})
+	}
+}
+
+func TestRoundMetric(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input float64
+		want  string
+	}{
+		{input: 7.39, want: "7.4"},
+		{input: 59.1, want: "59"},
+		{input: 296.4, want: "295"},
+		{input: 1234, want: "1230"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			t.Parallel()
+			_, label := roundMetric(tc.input)
+			assert.Equal(t, tc.want, label)
+		})
+	}
+}
+
+func TestChooseDisplayUnit(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "tsp", chooseDisplayUnit(unitTsp.ToBase*2, DimensionVolume, UnitSystemUS).Name)
+	assert.Equal(t, "tbsp", chooseDisplayUnit(unitTbsp.ToBase*2, DimensionVolume, UnitSystemUS).Name)
+	assert.Equal(t, "cup", chooseDisplayUnit(unitCup.ToBase/4, DimensionVolume, UnitSystemUS).Name)
+	assert.Equal(t, "ml", chooseDisplayUnit(500, DimensionVolume, UnitSystemMetric).Name)
+	assert.Equal(t, "kg", chooseDisplayUnit(1500, DimensionMass, UnitSystemMetric).Name)
+}
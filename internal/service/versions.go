@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+)
+
+// ErrVersionMismatch is returned when a write names the version it expects
+// an ingredient to be at and the ingredient has since changed.
+var ErrVersionMismatch = errors.New("ingredient has been modified")
+
+// expectVersion returns the expected_version parameter for version; 0 means
+// any version.
+func expectVersion(version int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: version, Valid: version != 0}
+}
+
+// versionMissed explains sql.ErrNoRows from a write made at an expected
+// version: ErrVersionMismatch if the ingredient still exists, sql.ErrNoRows
+// if it does not. Other errors are returned as they are.
+func versionMissed(ctx context.Context, q db.Querier, id uuid.UUID, err error) error {
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if _, err := q.GetIngredient(ctx, id); err != nil {
+		return err
+	}
+	return ErrVersionMismatch
+}
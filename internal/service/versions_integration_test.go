@@ -0,0 +1,66 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/db"
+	"github.com/mwhite7112/woodpantry-ingredients/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngredientVersion_Integration(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := New(q, sqlDB, 0.8)
+	ctx := context.Background()
+
+	ing, err := svc.CreateIngredient(ctx, IngredientInput{Name: "garlic"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), ing.Version)
+
+	// Every write bumps the version, whichever query makes it.
+	ing, err = svc.UpdateIngredient(ctx, ing.ID, IngredientInput{Aliases: []string{"clove"}, Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), ing.Version)
+	_, _, err = svc.AddAlias(ctx, ing.ID, AliasInput{Alias: "ajo"})
+	require.NoError(t, err)
+	ing, err = q.GetIngredient(ctx, ing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), ing.Version)
+
+	// A write that changes nothing leaves it alone.
+	_, err = q.ArchiveIngredient(ctx, db.ArchiveIngredientParams{ID: ing.ID})
+	require.NoError(t, err)
+	archived, err := q.ArchiveIngredient(ctx, db.ArchiveIngredientParams{ID: ing.ID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), archived.Version)
+	_, err = q.RestoreIngredient(ctx, db.RestoreIngredientParams{ID: ing.ID})
+	require.NoError(t, err)
+
+	// A stale version is refused and the aliases stay as they were.
+	_, err = svc.UpdateIngredient(ctx, ing.ID, IngredientInput{Aliases: []string{}, Version: 2})
+	assert.ErrorIs(t, err, ErrVersionMismatch)
+	_, err = svc.PatchIngredient(ctx, ing.ID, IngredientPatch{Aliases: &[]string{}, Version: 2})
+	assert.ErrorIs(t, err, ErrVersionMismatch)
+	aliases, err := q.ListIngredientAliases(ctx, ing.ID)
+	require.NoError(t, err)
+	assert.Len(t, aliases, 2)
+
+	loser, err := svc.CreateIngredient(ctx, IngredientInput{Name: "garlic bulb"})
+	require.NoError(t, err)
+	_, err = svc.Merge(ctx, ing.ID, loser.ID, MergeOptions{WinnerVersion: 2})
+	assert.ErrorIs(t, err, ErrVersionMismatch)
+	result, err := svc.Merge(ctx, ing.ID, loser.ID, MergeOptions{WinnerVersion: 5})
+	require.NoError(t, err)
+	assert.Greater(t, result.Ingredient.Version, int64(5))
+
+	// A split brings the loser back past the version its deletion took.
+	split, err := svc.Split(ctx, ing.ID, uuid.NullUUID{UUID: loser.ID, Valid: true})
+	require.NoError(t, err)
+	assert.Equal(t, loser.Version+2, split.Restored.Version)
+}